@@ -0,0 +1,33 @@
+//kage:unit pixels
+
+//go:build ignore
+
+package main
+
+// The array sizes should match the graphics.MaxPaletteColors value.
+var NumColors int
+var From [32]vec4
+var To [32]vec4
+
+func Fragment(_ vec4, pos vec2, color vec4) vec4 {
+	c := imageSrc0At(pos)
+	if c.a == 0 {
+		return vec4(0)
+	}
+	// The source colors are premultiplied, but the palette colors are not.
+	rgb := c.rgb / c.a
+	result := rgb
+	// Only the first match is used, so the replaced color
+	// is never matched by the later From entries.
+	found := false
+	for i := 0; i < 32; i++ {
+		if !found && i < NumColors {
+			d := rgb - From[i].rgb
+			if dot(d, d) < 0.0001 {
+				result = To[i].rgb
+				found = true
+			}
+		}
+	}
+	return vec4(result*c.a, c.a) * color
+}
@@ -0,0 +1,16 @@
+//kage:unit pixels
+
+//go:build ignore
+
+package main
+
+var TeamColor vec4
+
+func Fragment(_ vec4, pos vec2, color vec4) vec4 {
+	c := imageSrc0At(pos)
+	// The mask is a grayscale image: white pixels are fully recolored,
+	// black (or transparent) pixels keep their original colors.
+	m := imageSrc1At(pos).r * TeamColor.a
+	recolored := c.rgb * TeamColor.rgb
+	return vec4(mix(c.rgb, recolored, m), c.a) * color
+}
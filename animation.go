@@ -0,0 +1,226 @@
+package graphics
+
+import (
+	"image"
+)
+
+// AnimationMode describes what happens when the animation reaches its last frame.
+type AnimationMode uint8
+
+const (
+	// AnimationLoop restarts the animation from the first frame.
+	AnimationLoop AnimationMode = iota
+
+	// AnimationOnce stops the animation at the last frame.
+	AnimationOnce
+
+	// AnimationPingPong plays the animation backwards, then forward again, and so on.
+	AnimationPingPong
+)
+
+// Animation drives the sprite's frame rect to play a frame animation.
+//
+// The frames are taken from the sprite's image that is treated as a grid
+// of equally-sized frames. The frame size is the sprite's frame size
+// and the grid origin is the sprite's frame offset at the moment
+// of the NewAnimation call. The frames are enumerated left-to-right,
+// top-to-bottom; a single row strip is just a grid with one row.
+//
+// Animation implements gscene Object-like Update/IsDisposed protocol,
+// so it can be added to the scene as a regular object:
+// this way graphics and animation state advance together.
+// An animation is disposed together with its sprite.
+type Animation struct {
+	// OnFinished is called when the animation reaches its end.
+	// For AnimationOnce, it's called after the last frame was displayed for its time.
+	// For the looping modes, it's called after every full cycle.
+	// It's optional.
+	OnFinished func()
+
+	sprite *Sprite
+
+	originX     int
+	originY     int
+	frameWidth  int
+	frameHeight int
+	columns     int
+
+	firstFrame int
+	numFrames  int
+
+	frame    int
+	backward bool
+
+	fps      float64
+	speed    float64
+	progress float64
+
+	mode     AnimationMode
+	playing  bool
+	disposed bool
+}
+
+// NewAnimation creates an animation for the sprite.
+//
+// The sprite should have an image and a frame size assigned.
+// By default, all grid frames are used, the FPS is 10
+// and the mode is AnimationLoop.
+// The animation starts in a playing state.
+func NewAnimation(s *Sprite) *Animation {
+	a := &Animation{
+		sprite:      s,
+		originX:     s.GetFrameOffsetX(),
+		originY:     s.GetFrameOffsetY(),
+		frameWidth:  s.GetFrameWidth(),
+		frameHeight: s.GetFrameHeight(),
+		fps:         10,
+		speed:       1,
+		playing:     true,
+	}
+	if a.frameWidth == 0 || a.frameHeight == 0 {
+		panic("can't create an animation for a sprite with zero frame size")
+	}
+	a.columns = max(1, (s.ImageWidth()-a.originX)/a.frameWidth)
+	rows := max(1, (s.ImageHeight()-a.originY)/a.frameHeight)
+	a.numFrames = a.columns * rows
+	a.applyFrame()
+	return a
+}
+
+// SetFrameRange limits the animation to the [first, first+n) frames of the grid.
+// It rewinds the animation.
+func (a *Animation) SetFrameRange(first, n int) {
+	a.firstFrame = first
+	a.numFrames = max(1, n)
+	a.Rewind()
+}
+
+// GetNumFrames returns the number of the animation frames.
+func (a *Animation) GetNumFrames() int { return a.numFrames }
+
+// GetFPS returns the current animation frames per second rate.
+// Use SetFPS to change it.
+func (a *Animation) GetFPS() float64 { return a.fps }
+
+// SetFPS sets the animation frames per second rate.
+func (a *Animation) SetFPS(fps float64) { a.fps = fps }
+
+// GetSpeed returns the current playback speed multiplier.
+// Use SetSpeed to change it.
+func (a *Animation) GetSpeed() float64 { return a.speed }
+
+// SetSpeed sets the playback speed multiplier.
+// 1 is a normal speed, 2 is twice as fast, 0.5 is half the speed.
+func (a *Animation) SetSpeed(speed float64) { a.speed = speed }
+
+// GetMode returns the current animation mode.
+// Use SetMode to change it.
+func (a *Animation) GetMode() AnimationMode { return a.mode }
+
+// SetMode changes the animation mode.
+func (a *Animation) SetMode(mode AnimationMode) { a.mode = mode }
+
+// GetFrame returns the current frame index (relative to the frame range start).
+func (a *Animation) GetFrame() int { return a.frame }
+
+// SetFrame jumps to the specified frame (relative to the frame range start).
+func (a *Animation) SetFrame(frame int) {
+	a.frame = min(max(frame, 0), a.numFrames-1)
+	a.progress = 0
+	a.applyFrame()
+}
+
+// IsPlaying reports whether the animation is being played.
+// The AnimationOnce animations stop playing after their last frame.
+func (a *Animation) IsPlaying() bool { return a.playing }
+
+// Play resumes the animation playback.
+func (a *Animation) Play() { a.playing = true }
+
+// Pause stops the animation playback at the current frame.
+func (a *Animation) Pause() { a.playing = false }
+
+// Rewind resets the animation to its first frame.
+// It doesn't change the playing state.
+func (a *Animation) Rewind() {
+	a.backward = false
+	a.SetFrame(0)
+}
+
+// Dispose marks the animation as disposed: it stops the updates.
+// It doesn't dispose the sprite.
+func (a *Animation) Dispose() { a.disposed = true }
+
+// IsDisposed reports whether the animation or its sprite are disposed.
+func (a *Animation) IsDisposed() bool {
+	return a.disposed || a.sprite.IsDisposed()
+}
+
+// Update advances the animation.
+// delta is a time passed since the last Update call, in seconds.
+func (a *Animation) Update(delta float64) {
+	if !a.playing || a.IsDisposed() || a.fps <= 0 {
+		return
+	}
+
+	a.progress += delta * a.fps * a.speed
+	changed := false
+	for a.progress >= 1 && a.playing {
+		a.progress--
+		changed = true
+		a.nextFrame()
+	}
+	if changed {
+		a.applyFrame()
+	}
+}
+
+func (a *Animation) nextFrame() {
+	lastFrame := a.numFrames - 1
+
+	if a.mode == AnimationPingPong && a.numFrames > 1 {
+		if a.backward {
+			a.frame--
+			if a.frame == 0 {
+				a.backward = false
+				a.finished()
+			}
+		} else {
+			a.frame++
+			if a.frame == lastFrame {
+				a.backward = true
+			}
+		}
+		return
+	}
+
+	if a.frame < lastFrame {
+		a.frame++
+		return
+	}
+
+	// The last frame was displayed for its time.
+	if a.mode == AnimationOnce {
+		a.playing = false
+		a.progress = 0
+		a.finished()
+		return
+	}
+	a.frame = 0
+	a.finished()
+}
+
+func (a *Animation) finished() {
+	if a.OnFinished != nil {
+		a.OnFinished()
+	}
+}
+
+func (a *Animation) applyFrame() {
+	index := a.firstFrame + a.frame
+	col := index % a.columns
+	row := index / a.columns
+	x := a.originX + col*a.frameWidth
+	y := a.originY + row*a.frameHeight
+	a.sprite.SetFrameRect(image.Rect(x, y, x+a.frameWidth, y+a.frameHeight))
+}
@@ -0,0 +1,64 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestAnimationOnceFinished(t *testing.T) {
+	tests := []struct {
+		numFrames int
+		steps     int
+	}{
+		{numFrames: 1, steps: 1},
+		{numFrames: 2, steps: 2},
+		{numFrames: 4, steps: 4},
+	}
+	for _, test := range tests {
+		s := NewSprite()
+		s.SetImage(ebiten.NewImage(8*test.numFrames, 8))
+		s.SetFrameWidth(8)
+		a := NewAnimation(s)
+		a.SetMode(AnimationOnce)
+		finished := 0
+		a.OnFinished = func() { finished++ }
+
+		// Every Update call advances the animation by one frame.
+		for i := 1; i < test.steps; i++ {
+			a.Update(0.1)
+			if finished != 0 || !a.IsPlaying() {
+				t.Fatalf("frames=%d: finished after %d steps", test.numFrames, i)
+			}
+		}
+		if a.GetFrame() != test.numFrames-1 {
+			t.Fatalf("frames=%d: the last frame is not displayed: frame=%d", test.numFrames, a.GetFrame())
+		}
+		a.Update(0.1)
+		if finished != 1 || a.IsPlaying() {
+			t.Fatalf("frames=%d: the animation is not finished after %d steps", test.numFrames, test.steps)
+		}
+		a.Update(1)
+		if finished != 1 || a.GetFrame() != test.numFrames-1 {
+			t.Fatalf("frames=%d: OnFinished is called more than once", test.numFrames)
+		}
+	}
+}
+
+func TestAnimationLoopFinished(t *testing.T) {
+	s := NewSprite()
+	s.SetImage(ebiten.NewImage(24, 8))
+	s.SetFrameWidth(8)
+	a := NewAnimation(s)
+	finished := 0
+	a.OnFinished = func() { finished++ }
+
+	a.Update(0.25)
+	if finished != 0 || a.GetFrame() != 2 {
+		t.Fatalf("after 2 frames: finished=%d frame=%d", finished, a.GetFrame())
+	}
+	a.Update(0.1)
+	if finished != 1 || a.GetFrame() != 0 {
+		t.Fatalf("after a full cycle: finished=%d frame=%d", finished, a.GetFrame())
+	}
+}
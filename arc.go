@@ -0,0 +1,272 @@
+package graphics
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+	"github.com/quasilyte/gmath"
+)
+
+// Arc is a filled circle sector (pie) or a ring segment primitive.
+// It's a classic radial cooldown overlay and a pie chart building block.
+//
+// The angles use the same convention as the other rotations:
+// 0 is pointing right and the angle grows clockwise.
+// Use SetFraction to fill the arc clockwise from the top.
+//
+// Arc uses the same cached unit circle mesh as [Ellipse],
+// only the two partial segments at the arc ends are calculated
+// during the rendering, so it's cheap to change the angles every frame.
+//
+// The arc is always centered around its Pos.
+type Arc struct {
+	Pos gmath.Pos
+
+	radius      float64
+	innerRadius float64
+
+	startAngle gmath.Rad
+	sweep      gmath.Rad
+
+	colorScale       ColorScale
+	ebitenColorScale ebiten.ColorScale
+
+	visible  bool
+	disposed bool
+	blendID  uint8
+}
+
+// NewArc returns a full circle arc of the specified radius.
+//
+// By default, an arc has these properties:
+// * Visible=true
+// * The ColorScale is {1, 1, 1, 1}
+// * InnerRadius is 0 (a pie sector)
+// * The start angle is -Pi/2 (the top) and the sweep is 2*Pi
+func NewArc(r float64) *Arc {
+	return &Arc{
+		radius:           r,
+		startAngle:       -math.Pi / 2,
+		sweep:            2 * math.Pi,
+		colorScale:       defaultColorScale,
+		ebitenColorScale: defaultColorScale.ToEbitenColorScale(),
+		visible:          true,
+	}
+}
+
+// BoundsRect returns the full circle containing rectangle.
+//
+// This is useful when trying to calculate whether this object is contained
+// inside some area or not (like a camera view area).
+func (a *Arc) BoundsRect() gmath.Rect {
+	pos := a.Pos.Resolve()
+	offset := gmath.Vec{X: a.radius, Y: a.radius}
+	return gmath.Rect{
+		Min: pos.Sub(offset),
+		Max: pos.Add(offset),
+	}
+}
+
+// Dispose marks this arc for deletion.
+// After calling this method, IsDisposed will report true.
+func (a *Arc) Dispose() {
+	a.disposed = true
+}
+
+// IsDisposed reports whether this arc is marked for deletion.
+// IsDisposed returns true only after Disposed was called on this arc.
+func (a *Arc) IsDisposed() bool {
+	return a.disposed
+}
+
+// IsVisible reports whether this arc is visible.
+// Use SetVisibility to change this flag value.
+//
+// When arc is invisible (visible=false), it will not be rendered at all.
+// This is an efficient way to temporarily hide an arc.
+func (a *Arc) IsVisible() bool { return a.visible }
+
+// SetVisibility changes the Visible flag value.
+// It can be used to show or hide the arc.
+// Use IsVisible to get the current flag value.
+func (a *Arc) SetVisibility(visible bool) { a.visible = visible }
+
+// GetRadius reports the current arc radius.
+// Use SetRadius to change it.
+func (a *Arc) GetRadius() float64 {
+	return a.radius
+}
+
+// SetRadius changes the arc radius.
+func (a *Arc) SetRadius(r float64) {
+	a.radius = r
+}
+
+// GetInnerRadius reports the current arc inner radius.
+// Use SetInnerRadius to change it.
+func (a *Arc) GetInnerRadius() float64 {
+	return a.innerRadius
+}
+
+// SetInnerRadius changes the arc inner radius.
+// A zero inner radius makes it a pie sector,
+// a positive value makes it a ring segment.
+func (a *Arc) SetInnerRadius(r float64) {
+	a.innerRadius = r
+}
+
+// GetAngles reports the current start and end angles.
+// Use SetAngles to change them.
+func (a *Arc) GetAngles() (start, end gmath.Rad) {
+	return a.startAngle, a.startAngle + a.sweep
+}
+
+// SetAngles changes the arc start and end angles.
+// The arc goes clockwise from start to end.
+// If end is less than start, the angles are swapped.
+// The arc never covers more than a full circle.
+func (a *Arc) SetAngles(start, end gmath.Rad) {
+	if end < start {
+		start, end = end, start
+	}
+	a.startAngle = start
+	a.sweep = min(end-start, 2*math.Pi)
+}
+
+// GetFraction reports the arc sweep as a full circle fraction.
+func (a *Arc) GetFraction() float64 {
+	return float64(a.sweep / (2 * math.Pi))
+}
+
+// SetFraction makes the arc start at the top and
+// cover the specified full circle fraction clockwise.
+// The value is clamped to [0, 1].
+//
+// This is a shorthand for SetAngles(-Pi/2, -Pi/2 + 2*Pi*fraction).
+func (a *Arc) SetFraction(fraction float64) {
+	a.startAngle = -math.Pi / 2
+	a.sweep = gmath.Rad(2 * math.Pi * gmath.Clamp(fraction, 0, 1))
+}
+
+// GetColorScale is used to retrieve the current color scale value of the arc.
+// Use SetColorScale to change it.
+func (a *Arc) GetColorScale() ColorScale {
+	return a.colorScale
+}
+
+// SetColorScale assigns a new ColorScale to this arc.
+// Use GetColorScale to retrieve the current color scale.
+func (a *Arc) SetColorScale(cs ColorScale) {
+	if a.colorScale == cs {
+		return
+	}
+	a.colorScale = cs
+	a.ebitenColorScale = cs.ToEbitenColorScale()
+}
+
+// Draw renders the arc onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (a *Arc) Draw(dst *ebiten.Image) {
+	a.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the arc onto the provided dst image
+// while also using the extra provided offset and other options.
+func (a *Arc) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !a.visible || a.colorScale.A == 0 || a.sweep <= 0 || a.radius <= 0 {
+		return
+	}
+
+	opts.Blend = resolveBlend(a.blendID, opts.Blend)
+
+	vertices := cache.Global.ScratchVertices[:0]
+	indices := cache.Global.ScratchIndices[:0]
+	defer func() {
+		cache.Global.ScratchVertices = vertices[:0]
+		cache.Global.ScratchIndices = indices[:0]
+	}()
+
+	center := a.Pos.Resolve().Add(opts.Offset)
+	cs := a.ebitenColorScale
+	vertex := func(p gmath.Vec, r float64) ebiten.Vertex {
+		return ebiten.Vertex{
+			DstX:   float32(center.X + p.X*r),
+			DstY:   float32(center.Y + p.Y*r),
+			SrcX:   1.5,
+			SrcY:   1.5,
+			ColorR: cs.R(),
+			ColorG: cs.G(),
+			ColorB: cs.B(),
+			ColorA: cs.A(),
+		}
+	}
+
+	ring := a.innerRadius > 0
+	if !ring {
+		vertices = append(vertices, vertex(gmath.Vec{}, 0))
+	}
+	forEachArcPoint(a.startAngle, a.sweep, func(p gmath.Vec) {
+		idx := uint16(len(vertices))
+		if ring {
+			vertices = append(vertices, vertex(p, a.radius), vertex(p, a.innerRadius))
+			if idx != 0 {
+				indices = append(indices,
+					idx-2, idx-1, idx,
+					idx-1, idx, idx+1,
+				)
+			}
+			return
+		}
+		vertices = append(vertices, vertex(p, a.radius))
+		if idx != 1 {
+			indices = append(indices, 0, idx-1, idx)
+		}
+	})
+
+	var drawOptions ebiten.DrawTrianglesOptions
+	if opts.Blend != nil {
+		drawOptions.Blend = *opts.Blend
+	}
+	drawVertexColorTriangles(dst, vertices, indices, emptyImage, &drawOptions)
+}
+
+// forEachArcPoint calls f for every unit circle point of the arc outline.
+//
+// The first and the last points are exactly at the arc ends,
+// all points in between are taken from the cached unit circle mesh.
+func forEachArcPoint(start, sweep gmath.Rad, f func(p gmath.Vec)) {
+	const step = 2 * math.Pi / unitCircleNumSegments
+
+	from := float64(start.Normalized())
+	to := from + float64(sweep)
+
+	f(gmath.RadToVec(gmath.Rad(from)))
+	for i := int(math.Floor(from/step)) + 1; float64(i)*step < to; i++ {
+		f(unitCircleMesh[i%unitCircleNumSegments])
+	}
+	f(gmath.RadToVec(gmath.Rad(to)))
+}
+
+// GetBlend returns the blend mode assigned by SetBlend.
+// The second result value is false if there is no blend override.
+func (a *Arc) GetBlend() (ebiten.Blend, bool) {
+	return getBlend(a.blendID)
+}
+
+// SetBlend assigns a blend mode that is used to render this arc.
+// It takes priority over the DrawOptions.Blend value.
+// Use ResetBlend to remove the override.
+func (a *Arc) SetBlend(b ebiten.Blend) {
+	a.blendID = internBlend(b)
+}
+
+// ResetBlend removes the blend mode override.
+// The DrawOptions.Blend value (if any) will be used again.
+func (a *Arc) ResetBlend() {
+	a.blendID = 0
+}
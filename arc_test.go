@@ -0,0 +1,65 @@
+package graphics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/quasilyte/gmath"
+)
+
+func TestArcPoints(t *testing.T) {
+	// A full unit circle mesh area.
+	const step = 2 * math.Pi / unitCircleNumSegments
+	fullArea := 0.5 * unitCircleNumSegments * math.Sin(step)
+
+	tests := []struct {
+		start gmath.Rad
+		sweep gmath.Rad
+		want  float64
+	}{
+		{0, 2 * math.Pi, fullArea},
+		{-math.Pi / 2, 2 * math.Pi, fullArea},
+		{-math.Pi / 2, math.Pi, fullArea / 2},
+		{math.Pi, math.Pi / 2, fullArea / 4},
+		{3 * math.Pi / 2, math.Pi, fullArea / 2},
+	}
+
+	for _, test := range tests {
+		var points []gmath.Vec
+		forEachArcPoint(test.start, test.sweep, func(p gmath.Vec) {
+			points = append(points, p)
+		})
+
+		first := gmath.RadToVec(test.start)
+		last := gmath.RadToVec(test.start + test.sweep)
+		if !points[0].EqualApprox(first) || !points[len(points)-1].EqualApprox(last) {
+			t.Fatalf("start=%v sweep=%v: arc ends mismatch", test.start, test.sweep)
+		}
+
+		area := 0.0
+		for i := 1; i < len(points); i++ {
+			a := points[i-1]
+			b := points[i]
+			area += 0.5 * (a.X*b.Y - b.X*a.Y)
+		}
+		if math.Abs(area-test.want) > 0.0001 {
+			t.Fatalf("start=%v sweep=%v area:\nhave: %v\nwant: %v", test.start, test.sweep, area, test.want)
+		}
+	}
+
+	// A partial sweep: the area is between the mesh-based
+	// approximation and a perfect circle sector.
+	var points []gmath.Vec
+	forEachArcPoint(0.1, 1, func(p gmath.Vec) {
+		points = append(points, p)
+	})
+	area := 0.0
+	for i := 1; i < len(points); i++ {
+		a := points[i-1]
+		b := points[i]
+		area += 0.5 * (a.X*b.Y - b.X*a.Y)
+	}
+	if area > 0.5 || area < 0.5*fullArea/math.Pi {
+		t.Fatalf("partial arc area %v is out of bounds", area)
+	}
+}
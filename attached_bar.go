@@ -0,0 +1,261 @@
+package graphics
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+	"github.com/quasilyte/gmath"
+)
+
+// AttachedBarsConfig describes the [AttachedBars] appearance.
+type AttachedBarsConfig struct {
+	// Width and Height are the bar sizes.
+	// Zero values mean 24 and 3.
+	Width  float64
+	Height float64
+
+	// Gap is a distance between the target's bounds top and the bar.
+	// A zero value means 3.
+	Gap float64
+
+	// FillColorScale is a color of the bar's filled part.
+	// A zero value means a green color.
+	FillColorScale ColorScale
+
+	// BackgroundColorScale is a color of the bar's empty part.
+	// A zero value means a half-transparent black color.
+	BackgroundColorScale ColorScale
+
+	// ShowWhenFull makes the full bars visible.
+	// By default, the bars are hidden when their value is 1.
+	ShowWhenFull bool
+
+	// Camera is an optional camera used for the distance-based fading.
+	// If it's nil, the bars never fade.
+	Camera *Camera
+
+	// FadeStart and FadeEnd describe the fading distances
+	// (between the bar and the camera center).
+	// The bar is fully visible up to FadeStart and it's
+	// completely faded at FadeEnd.
+	FadeStart float64
+	FadeEnd   float64
+}
+
+// AttachedBars renders the small bars (like health bars) above the tracked objects.
+//
+// All bars are rendered in a single draw call and the bar objects
+// are pooled, so it's suitable for hundreds of units.
+// AttachedBars should be added to a [Layer] as the bars
+// are positioned in world coordinates.
+type AttachedBars struct {
+	config AttachedBarsConfig
+
+	fillColor       ebiten.ColorScale
+	backgroundColor ebiten.ColorScale
+
+	bars []*AttachedBar
+	free []*AttachedBar
+
+	visible  bool
+	disposed bool
+}
+
+// AttachedBar is a single bar created by [AttachedBars.Attach].
+type AttachedBar struct {
+	target   BoundedObject
+	value    float64
+	disposed bool
+}
+
+// NewAttachedBars creates a bars set with the specified config.
+func NewAttachedBars(config AttachedBarsConfig) *AttachedBars {
+	if config.Width == 0 {
+		config.Width = 24
+	}
+	if config.Height == 0 {
+		config.Height = 3
+	}
+	if config.Gap == 0 {
+		config.Gap = 3
+	}
+	if config.FillColorScale == (ColorScale{}) {
+		config.FillColorScale = ColorScale{R: 0.3, G: 0.9, B: 0.3, A: 1}
+	}
+	if config.BackgroundColorScale == (ColorScale{}) {
+		config.BackgroundColorScale = ColorScale{A: 0.6}
+	}
+	return &AttachedBars{
+		config:          config,
+		fillColor:       config.FillColorScale.ToEbitenColorScale(),
+		backgroundColor: config.BackgroundColorScale.ToEbitenColorScale(),
+		visible:         true,
+	}
+}
+
+// Attach creates a new bar for the target.
+// The initial bar value is 1.
+//
+// The bar is removed when it's disposed or when its target
+// is disposed (if the target implements IsDisposed method).
+// Only the explicitly disposed bars are re-used by the next Attach calls,
+// so the bar of a disposed target can still be safely accessed
+// (its changes have no effect).
+func (b *AttachedBars) Attach(target BoundedObject) *AttachedBar {
+	var bar *AttachedBar
+	if n := len(b.free); n != 0 {
+		bar = b.free[n-1]
+		b.free = b.free[:n-1]
+	} else {
+		bar = &AttachedBar{}
+	}
+	*bar = AttachedBar{target: target, value: 1}
+	b.bars = append(b.bars, bar)
+	return bar
+}
+
+// GetValue returns the current bar value.
+// Use SetValue to change it.
+func (bar *AttachedBar) GetValue() float64 { return bar.value }
+
+// SetValue assigns the bar fill value.
+// The value is clamped to [0, 1].
+func (bar *AttachedBar) SetValue(v float64) { bar.value = gmath.Clamp(v, 0, 1) }
+
+// Dispose removes the bar.
+// The bar object will be re-used by the next Attach call,
+// so it should not be accessed after the Dispose call.
+func (bar *AttachedBar) Dispose() { bar.disposed = true }
+
+func (bar *AttachedBar) isDisposed() bool {
+	if bar.disposed {
+		return true
+	}
+	d, ok := bar.target.(gsceneGraphics)
+	return ok && d.IsDisposed()
+}
+
+func (b *AttachedBars) IsDisposed() bool { return b.disposed }
+
+func (b *AttachedBars) Dispose() { b.disposed = true }
+
+// IsVisible reports whether the bars are visible.
+// Use SetVisibility to change this flag value.
+func (b *AttachedBars) IsVisible() bool { return b.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (b *AttachedBars) SetVisibility(visible bool) { b.visible = visible }
+
+func (b *AttachedBars) Draw(dst *ebiten.Image) {
+	b.DrawWithOptions(dst, DrawOptions{})
+}
+
+func (b *AttachedBars) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !b.visible {
+		return
+	}
+
+	vertices := cache.Global.ScratchVertices[:0]
+	indices := cache.Global.ScratchIndices[:0]
+	defer func() {
+		cache.Global.ScratchVertices = vertices[:0]
+		cache.Global.ScratchIndices = indices[:0]
+	}()
+
+	var cameraCenter gmath.Vec
+	fade := b.config.Camera != nil && b.config.FadeEnd > b.config.FadeStart
+	if fade {
+		cameraCenter = b.config.Camera.GetWorldRect().Center()
+	}
+
+	flush := func() {
+		if len(indices) == 0 {
+			return
+		}
+		var drawOptions ebiten.DrawTrianglesOptions
+		if opts.Blend != nil {
+			drawOptions.Blend = *opts.Blend
+		}
+		drawVertexColorTriangles(dst, vertices, indices, emptyImage, &drawOptions)
+		vertices = vertices[:0]
+		indices = indices[:0]
+	}
+
+	liveBars := b.bars[:0]
+	for _, bar := range b.bars {
+		if bar.disposed {
+			bar.target = nil
+			b.free = append(b.free, bar)
+			continue
+		}
+		if bar.isDisposed() {
+			// The bar owner may still hold this handle,
+			// so it can't be pooled.
+			continue
+		}
+		liveBars = append(liveBars, bar)
+
+		if bar.value >= 1 && !b.config.ShowWhenFull {
+			continue
+		}
+		if v, ok := bar.target.(visibleObject); ok && !v.IsVisible() {
+			continue
+		}
+
+		bounds := bar.target.BoundsRect()
+		alpha := float32(1)
+		if fade {
+			dist := bounds.Center().DistanceTo(cameraCenter)
+			if dist >= b.config.FadeEnd {
+				continue
+			}
+			if dist > b.config.FadeStart {
+				alpha = float32(1 - (dist-b.config.FadeStart)/(b.config.FadeEnd-b.config.FadeStart))
+			}
+		}
+
+		pos := gmath.Vec{
+			X: bounds.Center().X - b.config.Width*0.5,
+			Y: bounds.Min.Y - b.config.Gap - b.config.Height,
+		}
+		pos = pos.Add(opts.Offset).Rounded()
+		fillWidth := b.config.Width * bar.value
+
+		if len(vertices)+8 > 0xffff {
+			flush()
+		}
+		vertices, indices = appendRectQuad(vertices, indices, pos.X+fillWidth, pos.Y, b.config.Width-fillWidth, b.config.Height, b.backgroundColor, alpha)
+		vertices, indices = appendRectQuad(vertices, indices, pos.X, pos.Y, fillWidth, b.config.Height, b.fillColor, alpha)
+	}
+	b.bars = liveBars
+
+	flush()
+}
+
+// appendRectQuad appends a solid color rectangle to the triangles batch.
+// The batch is expected to be rendered with emptyImage source.
+func appendRectQuad(vertices []ebiten.Vertex, indices []uint16, x, y, w, h float64, cs ebiten.ColorScale, alpha float32) ([]ebiten.Vertex, []uint16) {
+	if w <= 0 || h <= 0 {
+		return vertices, indices
+	}
+	r := cs.R() * alpha
+	g := cs.G() * alpha
+	b := cs.B() * alpha
+	a := cs.A() * alpha
+	x1 := float32(x)
+	y1 := float32(y)
+	x2 := float32(x + w)
+	y2 := float32(y + h)
+	idx := uint16(len(vertices))
+	vertices = append(vertices,
+		ebiten.Vertex{DstX: x1, DstY: y1, SrcX: 1.5, SrcY: 1.5, ColorR: r, ColorG: g, ColorB: b, ColorA: a},
+		ebiten.Vertex{DstX: x2, DstY: y1, SrcX: 1.5, SrcY: 1.5, ColorR: r, ColorG: g, ColorB: b, ColorA: a},
+		ebiten.Vertex{DstX: x1, DstY: y2, SrcX: 1.5, SrcY: 1.5, ColorR: r, ColorG: g, ColorB: b, ColorA: a},
+		ebiten.Vertex{DstX: x2, DstY: y2, SrcX: 1.5, SrcY: 1.5, ColorR: r, ColorG: g, ColorB: b, ColorA: a},
+	)
+	indices = append(indices,
+		idx+0, idx+1, idx+2,
+		idx+1, idx+2, idx+3,
+	)
+	return vertices, indices
+}
@@ -0,0 +1,40 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestAttachedBarsReuse(t *testing.T) {
+	dst := ebiten.NewImage(64, 64)
+	bars := NewAttachedBars(AttachedBarsConfig{})
+
+	// A bar of the disposed target is removed, but not re-used:
+	// its owner can still access it.
+	target1 := NewRect(8, 8)
+	bar1 := bars.Attach(target1)
+	target1.Dispose()
+	bars.Draw(dst)
+	target2 := NewRect(8, 8)
+	bar2 := bars.Attach(target2)
+	if bar2 == bar1 {
+		t.Fatal("a bar of the disposed target is re-used")
+	}
+	bar1.SetValue(0.5)
+	bar1.Dispose()
+	bars.Draw(dst)
+	if len(bars.bars) != 1 || bars.bars[0] != bar2 || bar2.GetValue() != 1 {
+		t.Fatal("a stale bar handle affected another bar")
+	}
+
+	// The explicitly disposed bars are re-used.
+	bar2.Dispose()
+	bars.Draw(dst)
+	if bar3 := bars.Attach(NewRect(8, 8)); bar3 != bar2 {
+		t.Fatal("a disposed bar is not re-used")
+	}
+	if len(bars.free) != 0 {
+		t.Fatalf("unexpected free bars: %d", len(bars.free))
+	}
+}
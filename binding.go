@@ -0,0 +1,90 @@
+package graphics
+
+// Binding connects a data source to a graphics property.
+//
+// The source is polled during the Binding.Update call and
+// the property is updated only when the source value changes,
+// so a bound label doesn't re-layout its text every frame.
+// The first Update call always applies the value.
+//
+// A binding created for a target object (like [BindLabelText])
+// disposes itself when the target is disposed.
+//
+// The bindings are updated explicitly, they're not graphics objects.
+// A typical usage is to keep all UI bindings in a slice and
+// update them once per frame.
+type Binding[T comparable] struct {
+	source func() T
+	apply  func(v T)
+
+	target interface{ IsDisposed() bool }
+
+	value    T
+	applied  bool
+	disposed bool
+}
+
+// Bind creates a binding that passes the source values to the apply function.
+func Bind[T comparable](source func() T, apply func(v T)) *Binding[T] {
+	return &Binding[T]{source: source, apply: apply}
+}
+
+// BindPtr is like [Bind], but the source value is read from the pointer.
+func BindPtr[T comparable](ptr *T, apply func(v T)) *Binding[T] {
+	return Bind(func() T { return *ptr }, apply)
+}
+
+// BindLabelText binds the label text to the formatted source value.
+func BindLabelText[T comparable](l *Label, source func() T, format func(v T) string) *Binding[T] {
+	b := Bind(source, func(v T) {
+		l.SetText(format(v))
+	})
+	b.target = l
+	return b
+}
+
+// BindGaugeValue binds the gauge value to the source.
+func BindGaugeValue(g *Gauge, source func() float64) *Binding[float64] {
+	b := Bind(source, g.SetValue)
+	b.target = g
+	return b
+}
+
+// BindAnimationFrame binds the animation frame index to the source.
+// It's useful for the state-driven sprites (like a battery charge level icon).
+func BindAnimationFrame(a *Animation, source func() int) *Binding[int] {
+	b := Bind(source, a.SetFrame)
+	b.target = a
+	return b
+}
+
+// Dispose stops the binding.
+func (b *Binding[T]) Dispose() { b.disposed = true }
+
+// IsDisposed reports whether this binding is stopped.
+func (b *Binding[T]) IsDisposed() bool { return b.disposed }
+
+// GetValue returns the last applied value.
+func (b *Binding[T]) GetValue() T { return b.value }
+
+// Invalidate forces the value to be re-applied during the next Update.
+func (b *Binding[T]) Invalidate() { b.applied = false }
+
+// Update reads the source value and applies it if it was changed.
+func (b *Binding[T]) Update(_ float64) {
+	if b.disposed {
+		return
+	}
+	if b.target != nil && b.target.IsDisposed() {
+		b.disposed = true
+		return
+	}
+
+	v := b.source()
+	if b.applied && v == b.value {
+		return
+	}
+	b.value = v
+	b.applied = true
+	b.apply(v)
+}
@@ -0,0 +1,60 @@
+package graphics
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestBinding(t *testing.T) {
+	value := 10
+	var applied []int
+	b := BindPtr(&value, func(v int) {
+		applied = append(applied, v)
+	})
+
+	b.Update(0)
+	b.Update(0)
+	value = 20
+	b.Update(0)
+	b.Update(0)
+	b.Invalidate()
+	b.Update(0)
+	b.Dispose()
+	value = 30
+	b.Update(0)
+
+	want := []int{10, 20, 20}
+	if len(applied) != len(want) {
+		t.Fatalf("applied:\nhave: %v\nwant: %v", applied, want)
+	}
+	for i := range want {
+		if applied[i] != want[i] {
+			t.Fatalf("applied[%d]:\nhave: %d\nwant: %d", i, applied[i], want[i])
+		}
+	}
+	if have := b.GetValue(); have != 20 {
+		t.Fatalf("value:\nhave: %d\nwant: 20", have)
+	}
+}
+
+func TestBindLabelText(t *testing.T) {
+	l := NewLabel(nil)
+	gold := 100
+	b := BindLabelText(l, func() int { return gold }, strconv.Itoa)
+
+	b.Update(0)
+	if have := l.text; have != "100" {
+		t.Fatalf("text:\nhave: %q\nwant: %q", have, "100")
+	}
+	gold = 250
+	b.Update(0)
+	if have := l.text; have != "250" {
+		t.Fatalf("text:\nhave: %q\nwant: %q", have, "250")
+	}
+
+	l.Dispose()
+	b.Update(0)
+	if !b.IsDisposed() {
+		t.Fatal("the binding is not disposed with its target")
+	}
+}
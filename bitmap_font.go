@@ -0,0 +1,374 @@
+package graphics
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// BitmapFont is a font face that is rendered from a prebaked glyph atlas.
+//
+// It can be loaded from an AngelCode BMFont descriptor (see [ParseBitmapFont])
+// or from a fixed-size cells atlas (see [NewGridBitmapFont]).
+// The glyph alpha channel is used as a mask, so the glyphs are
+// rendered white; use the label color scale to change their color.
+//
+// To use it with a [Label], pass the Face method result to [NewLabel].
+//
+// BitmapFont implements golang.org/x/image/font.Face interface.
+// Every glyph is copied from the atlas only once:
+// the text/v2 glyph cache is used for the subsequent draw calls.
+type BitmapFont struct {
+	glyphs  map[rune]bitmapGlyph
+	kerning map[bitmapKerningPair]int
+
+	lineHeight int
+	base       int
+
+	pages []image.Image
+
+	face *text.GoXFace
+}
+
+type bitmapGlyph struct {
+	// rect is a glyph location inside its page.
+	rect image.Rectangle
+
+	// offset is a glyph top-left corner position relative to the line top.
+	offset image.Point
+
+	advance int
+
+	page int
+}
+
+type bitmapKerningPair struct {
+	first  rune
+	second rune
+}
+
+// BitmapFontGridConfig describes the [NewGridBitmapFont] atlas layout.
+type BitmapFontGridConfig struct {
+	// CellWidth and CellHeight are the atlas cell sizes.
+	// They're required.
+	CellWidth  int
+	CellHeight int
+
+	// Chars lists the atlas glyphs in the row-major order.
+	// A zero value means the printable ASCII characters (from ' ' to '~').
+	Chars string
+
+	// Advance is a horizontal distance between the glyph origins.
+	// A zero value means CellWidth.
+	Advance int
+
+	// Baseline is a distance from the cell top to the baseline.
+	// A zero value means CellHeight.
+	Baseline int
+
+	// LineHeight is a distance between the consecutive baselines.
+	// A zero value means CellHeight.
+	LineHeight int
+}
+
+// NewGridBitmapFont creates a bitmap font out of the atlas that
+// has all its glyphs arranged in the equally-sized cells.
+// The cells are read left-to-right, top-to-bottom;
+// the number of columns is derived from the atlas width.
+//
+// The atlas should be a decoded image (like the png.Decode result),
+// not an [ebiten.Image]: the glyph pixels are read on the CPU side.
+//
+// It panics if the cell sizes are not positive or
+// if the atlas is too small to fit all config Chars.
+func NewGridBitmapFont(atlas image.Image, config BitmapFontGridConfig) *BitmapFont {
+	if config.CellWidth <= 0 || config.CellHeight <= 0 {
+		panic("invalid bitmap font cell size")
+	}
+	if config.Chars == "" {
+		var chars strings.Builder
+		for ch := ' '; ch <= '~'; ch++ {
+			chars.WriteRune(ch)
+		}
+		config.Chars = chars.String()
+	}
+	if config.Advance == 0 {
+		config.Advance = config.CellWidth
+	}
+	if config.Baseline == 0 {
+		config.Baseline = config.CellHeight
+	}
+	if config.LineHeight == 0 {
+		config.LineHeight = config.CellHeight
+	}
+
+	bounds := atlas.Bounds()
+	numColumns := bounds.Dx() / config.CellWidth
+	numRows := bounds.Dy() / config.CellHeight
+	f := &BitmapFont{
+		glyphs:     make(map[rune]bitmapGlyph, len(config.Chars)),
+		lineHeight: config.LineHeight,
+		base:       config.Baseline,
+		pages:      []image.Image{atlas},
+	}
+	i := 0
+	for _, ch := range config.Chars {
+		if i >= numColumns*numRows {
+			panic("bitmap font atlas is too small for its chars")
+		}
+		col := i % numColumns
+		row := i / numColumns
+		i++
+		f.glyphs[ch] = bitmapGlyph{
+			rect:    image.Rect(0, 0, config.CellWidth, config.CellHeight).Add(image.Pt(col*config.CellWidth, row*config.CellHeight)),
+			advance: config.Advance,
+		}
+	}
+	f.face = text.NewGoXFace(f)
+	return f
+}
+
+// ParseBitmapFont creates a bitmap font out of the BMFont descriptor (.fnt file).
+// Only the text descriptor format is supported.
+//
+// The pages slice should contain the decoded atlas images
+// in the order of the descriptor page ids;
+// a typical single-page font needs only one image.
+// See [NewGridBitmapFont] for the images requirements.
+//
+// The "info" line is ignored (the glyph padding, spacing and
+// outline values are expected to be already applied to the char offsets).
+func ParseBitmapFont(data []byte, pages []image.Image) (*BitmapFont, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("<")) {
+		return nil, errors.New("the XML BMFont format is not supported")
+	}
+	if bytes.HasPrefix(data, []byte("BMF")) {
+		return nil, errors.New("the binary BMFont format is not supported")
+	}
+
+	f := &BitmapFont{
+		glyphs:  make(map[rune]bitmapGlyph),
+		kerning: make(map[bitmapKerningPair]int),
+		pages:   pages,
+	}
+	hasCommon := false
+	lineNum := 0
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		lineNum++
+		tag, attrs, err := parseBitmapFontLine(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+
+		switch tag {
+		case "common":
+			hasCommon = true
+			f.lineHeight, err = attrs.getInt("lineHeight")
+			if err == nil {
+				f.base, err = attrs.getInt("base")
+			}
+
+		case "char":
+			var id int
+			var g bitmapGlyph
+			id, err = attrs.getInt("id")
+			if err == nil {
+				g, err = parseBitmapGlyph(attrs)
+			}
+			if err == nil && (g.page < 0 || g.page >= len(pages)) {
+				err = fmt.Errorf("char %d refers to a missing page %d", id, g.page)
+			}
+			if err == nil && !g.rect.In(pages[g.page].Bounds().Sub(pages[g.page].Bounds().Min)) {
+				err = fmt.Errorf("char %d is out of its page bounds", id)
+			}
+			f.glyphs[rune(id)] = g
+
+		case "kerning":
+			var first, second, amount int
+			first, err = attrs.getInt("first")
+			if err == nil {
+				second, err = attrs.getInt("second")
+			}
+			if err == nil {
+				amount, err = attrs.getInt("amount")
+			}
+			f.kerning[bitmapKerningPair{first: rune(first), second: rune(second)}] = amount
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s: %w", lineNum, tag, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !hasCommon {
+		return nil, errors.New("missing common line")
+	}
+
+	f.face = text.NewGoXFace(f)
+	return f, nil
+}
+
+func parseBitmapGlyph(attrs bitmapFontAttrs) (bitmapGlyph, error) {
+	var values [8]int
+	keys := [...]string{"x", "y", "width", "height", "xoffset", "yoffset", "xadvance", "page"}
+	for i, key := range keys {
+		v, err := attrs.getInt(key)
+		if err != nil {
+			// The page attribute is optional for the single-page fonts.
+			if key == "page" && !attrs.has(key) {
+				continue
+			}
+			return bitmapGlyph{}, err
+		}
+		values[i] = v
+	}
+	x, y, w, h := values[0], values[1], values[2], values[3]
+	if w < 0 || h < 0 {
+		return bitmapGlyph{}, errors.New("negative glyph size")
+	}
+	return bitmapGlyph{
+		rect:    image.Rect(x, y, x+w, y+h),
+		offset:  image.Pt(values[4], values[5]),
+		advance: values[6],
+		page:    values[7],
+	}, nil
+}
+
+// bitmapFontAttrs is a list of key=value pairs of a BMFont descriptor line.
+type bitmapFontAttrs []string
+
+func (attrs bitmapFontAttrs) has(key string) bool {
+	for i := 0; i < len(attrs); i += 2 {
+		if attrs[i] == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (attrs bitmapFontAttrs) getInt(key string) (int, error) {
+	for i := 0; i < len(attrs); i += 2 {
+		if attrs[i] == key {
+			return strconv.Atoi(attrs[i+1])
+		}
+	}
+	return 0, fmt.Errorf("missing %s attribute", key)
+}
+
+// parseBitmapFontLine splits a descriptor line like
+// `char id=65 x=0 y=0` into its tag and attributes.
+// The quoted values may contain spaces (e.g. `face="Arial Black"`).
+func parseBitmapFontLine(line string) (string, bitmapFontAttrs, error) {
+	tag, rest, _ := strings.Cut(strings.TrimSpace(line), " ")
+	var attrs bitmapFontAttrs
+	for {
+		rest = strings.TrimLeft(rest, " \t")
+		if rest == "" {
+			break
+		}
+		key, value, ok := strings.Cut(rest, "=")
+		if !ok || strings.ContainsAny(key, " \t") {
+			return "", nil, fmt.Errorf("%s: malformed attribute", tag)
+		}
+		if strings.HasPrefix(value, `"`) {
+			end := strings.IndexByte(value[1:], '"')
+			if end == -1 {
+				return "", nil, fmt.Errorf("%s: unterminated %s value", tag, key)
+			}
+			rest = value[end+2:]
+			value = value[1 : end+1]
+		} else {
+			end := strings.IndexAny(value, " \t")
+			if end == -1 {
+				end = len(value)
+			}
+			rest = value[end:]
+			value = value[:end]
+		}
+		attrs = append(attrs, key, value)
+	}
+	return tag, attrs, nil
+}
+
+// Face returns a text/v2 face that is suitable for [NewLabel].
+// It always returns the same face object,
+// so all labels share the glyph cache.
+func (f *BitmapFont) Face() text.Face { return f.face }
+
+// HasGlyph reports whether the font has a glyph for the rune.
+func (f *BitmapFont) HasGlyph(r rune) bool {
+	_, ok := f.glyphs[r]
+	return ok
+}
+
+// Close implements font.Face interface.
+// It's a no-op.
+func (f *BitmapFont) Close() error { return nil }
+
+// Glyph implements font.Face interface.
+func (f *BitmapFont) Glyph(dot fixed.Point26_6, r rune) (dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+	g, ok := f.glyphs[r]
+	if !ok {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+	origin := image.Pt(dot.X.Round(), dot.Y.Round()-f.base).Add(g.offset)
+	page := f.pages[g.page]
+	dr = image.Rectangle{Min: origin, Max: origin.Add(g.rect.Size())}
+	return dr, page, g.rect.Min.Add(page.Bounds().Min), fixed.I(g.advance), true
+}
+
+// GlyphBounds implements font.Face interface.
+func (f *BitmapFont) GlyphBounds(r rune) (bounds fixed.Rectangle26_6, advance fixed.Int26_6, ok bool) {
+	g, ok := f.glyphs[r]
+	if !ok {
+		return fixed.Rectangle26_6{}, 0, false
+	}
+	minX := g.offset.X
+	minY := g.offset.Y - f.base
+	bounds = fixed.R(minX, minY, minX+g.rect.Dx(), minY+g.rect.Dy())
+	return bounds, fixed.I(g.advance), true
+}
+
+// GlyphAdvance implements font.Face interface.
+func (f *BitmapFont) GlyphAdvance(r rune) (advance fixed.Int26_6, ok bool) {
+	g, ok := f.glyphs[r]
+	if !ok {
+		return 0, false
+	}
+	return fixed.I(g.advance), true
+}
+
+// Kern implements font.Face interface.
+func (f *BitmapFont) Kern(r0, r1 rune) fixed.Int26_6 {
+	return fixed.I(f.kerning[bitmapKerningPair{first: r0, second: r1}])
+}
+
+// Metrics implements font.Face interface.
+//
+// The cap height and x-height are taken from
+// the 'H' and 'x' glyphs (if they're present).
+func (f *BitmapFont) Metrics() font.Metrics {
+	m := font.Metrics{
+		Height:  fixed.I(f.lineHeight),
+		Ascent:  fixed.I(f.base),
+		Descent: fixed.I(max(0, f.lineHeight-f.base)),
+	}
+	if g, ok := f.glyphs['H']; ok {
+		m.CapHeight = fixed.I(f.base - g.offset.Y)
+	}
+	if g, ok := f.glyphs['x']; ok {
+		m.XHeight = fixed.I(f.base - g.offset.Y)
+	}
+	return m
+}
@@ -0,0 +1,111 @@
+package graphics
+
+import (
+	"image"
+	"strings"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+const testBitmapFontDescriptor = `info face="Pixel Sans" size=8 bold=0 italic=0 padding=0,0,0,0 spacing=1,1
+common lineHeight=10 base=8 scaleW=64 scaleH=16 pages=1 packed=0
+page id=0 file="pixel_sans_0.png"
+chars count=3
+char id=32   x=0  y=0 width=0 height=0 xoffset=0 yoffset=0 xadvance=3 page=0 chnl=15
+char id=65   x=0  y=0 width=5 height=7 xoffset=0 yoffset=1 xadvance=6 page=0 chnl=15
+char id=86   x=6  y=0 width=5 height=7 xoffset=0 yoffset=1 xadvance=6 page=0 chnl=15
+char id=120  x=12 y=0 width=4 height=4 xoffset=1 yoffset=4 xadvance=5 page=0 chnl=15
+kernings count=1
+kerning first=65 second=86 amount=-1
+`
+
+func TestParseBitmapFont(t *testing.T) {
+	page := image.NewAlpha(image.Rect(0, 0, 64, 16))
+	f, err := ParseBitmapFont([]byte(testBitmapFontDescriptor), []image.Image{page})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if have := font.MeasureString(f, "AV A"); have != fixed.I(6+6-1+3+6) {
+		t.Fatalf("AV A width: have %v, want %v", have, fixed.I(20))
+	}
+	if f.HasGlyph('B') {
+		t.Fatal("B glyph should not be present")
+	}
+
+	m := f.Metrics()
+	if m.Height != fixed.I(10) || m.Ascent != fixed.I(8) || m.Descent != fixed.I(2) {
+		t.Fatalf("unexpected metrics: %+v", m)
+	}
+	if m.XHeight != fixed.I(4) {
+		t.Fatalf("x-height: have %v, want 4", m.XHeight)
+	}
+
+	dr, mask, maskp, advance, ok := f.Glyph(fixed.P(10, 20), 'x')
+	if !ok {
+		t.Fatal("x glyph is not found")
+	}
+	if want := image.Rect(11, 16, 15, 20); dr != want {
+		t.Fatalf("x glyph dst rect: have %v, want %v", dr, want)
+	}
+	if mask != page || maskp != image.Pt(12, 0) || advance != fixed.I(5) {
+		t.Fatalf("x glyph: unexpected mask location %v or advance %v", maskp, advance)
+	}
+	bounds, _, _ := f.GlyphBounds('x')
+	if want := fixed.R(1, -4, 5, 0); bounds != want {
+		t.Fatalf("x glyph bounds: have %v, want %v", bounds, want)
+	}
+}
+
+func TestParseBitmapFontErrors(t *testing.T) {
+	page := image.NewAlpha(image.Rect(0, 0, 8, 8))
+	tests := []struct {
+		data string
+		err  string
+	}{
+		{`<?xml version="1.0"?>`, "XML BMFont format is not supported"},
+		{`char id=65 x=0 y=0 width=4 height=4 xoffset=0 yoffset=0 xadvance=4`, "missing common line"},
+		{`common lineHeight=8`, "line 1: common: missing base attribute"},
+		{`info face="Pixel`, "line 1: info: unterminated face value"},
+		{"common lineHeight=8 base=7\nchar id=65 x=0 y=0 width=4 height=4 xoffset=0 yoffset=0", "line 2: char: missing xadvance attribute"},
+		{"common lineHeight=8 base=7\nchar id=65 x=6 y=0 width=4 height=4 xoffset=0 yoffset=0 xadvance=4", "line 2: char: char 65 is out of its page bounds"},
+		{"common lineHeight=8 base=7\nchar id=65 x=0 y=0 width=4 height=4 xoffset=0 yoffset=0 xadvance=4 page=1", "line 2: char: char 65 refers to a missing page 1"},
+	}
+	for _, test := range tests {
+		_, err := ParseBitmapFont([]byte(test.data), []image.Image{page})
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Fatalf("parse %q:\nhave error: %v\nwant error: %s", test.data, err, test.err)
+		}
+	}
+}
+
+func TestGridBitmapFont(t *testing.T) {
+	atlas := image.NewAlpha(image.Rect(0, 0, 40, 16))
+	f := NewGridBitmapFont(atlas, BitmapFontGridConfig{
+		CellWidth:  8,
+		CellHeight: 8,
+		Chars:      "0123456789",
+		Advance:    7,
+		Baseline:   7,
+	})
+
+	_, _, maskp, advance, ok := f.Glyph(fixed.Point26_6{}, '7')
+	if !ok || maskp != image.Pt(16, 8) || advance != fixed.I(7) {
+		t.Fatalf("7 glyph: have %v at %v (%v), want 7 at (16,8)", advance, maskp, ok)
+	}
+	if f.HasGlyph('A') {
+		t.Fatal("A glyph should not be present")
+	}
+	if have := f.Metrics().Descent; have != fixed.I(1) {
+		t.Fatalf("descent: have %v, want 1", have)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected a panic for a too small atlas")
+		}
+	}()
+	NewGridBitmapFont(atlas, BitmapFontGridConfig{CellWidth: 8, CellHeight: 8})
+}
@@ -0,0 +1,43 @@
+package graphics
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+)
+
+// BlendMultiply multiplies the destination colors by the source colors.
+// It's useful for the shadows and the darkening effects.
+//
+// Ebitengine has no predefined multiply blend, hence this variable.
+var BlendMultiply = ebiten.Blend{
+	BlendFactorSourceRGB:        ebiten.BlendFactorDestinationColor,
+	BlendFactorSourceAlpha:      ebiten.BlendFactorDestinationAlpha,
+	BlendFactorDestinationRGB:   ebiten.BlendFactorOneMinusSourceAlpha,
+	BlendFactorDestinationAlpha: ebiten.BlendFactorOneMinusSourceAlpha,
+	BlendOperationRGB:           ebiten.BlendOperationAdd,
+	BlendOperationAlpha:         ebiten.BlendOperationAdd,
+}
+
+// The objects store their blend modes as a 1-byte index
+// inside the global blends table, so the blend override
+// doesn't make the objects bigger (see the Sprite size test).
+
+func internBlend(b ebiten.Blend) uint8 {
+	return cache.Global.InternBlend(b)
+}
+
+func getBlend(id uint8) (ebiten.Blend, bool) {
+	if id == 0 {
+		return ebiten.Blend{}, false
+	}
+	return *cache.Global.GetBlend(id), true
+}
+
+// resolveBlend returns the object's own blend mode if it's set.
+// Otherwise the blend from the draw options is returned.
+func resolveBlend(id uint8, blend *ebiten.Blend) *ebiten.Blend {
+	if id == 0 {
+		return blend
+	}
+	return cache.Global.GetBlend(id)
+}
@@ -0,0 +1,106 @@
+package graphics
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+	"github.com/quasilyte/gmath"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// BootLineHeight is a line height of the [DrawBootText] font.
+const BootLineHeight = 13
+
+// MeasureBootText returns the [DrawBootText] rendered text size.
+// The lines are separated by '\n'.
+func MeasureBootText(s string) gmath.Vec {
+	face := basicfont.Face7x13
+	lineWidth := 0
+	maxWidth := 0
+	numLines := 1
+	for _, ch := range s {
+		if ch == '\n' {
+			numLines++
+			lineWidth = 0
+			continue
+		}
+		lineWidth += face.Advance
+		maxWidth = max(maxWidth, lineWidth)
+	}
+	return gmath.Vec{X: float64(maxWidth), Y: float64(numLines * BootLineHeight)}
+}
+
+// DrawBootText renders the text using a built-in bitmap font.
+// The pos is a top-left corner of the text.
+// Only the ASCII and Latin-1 characters are supported.
+//
+// This function, together with [DrawBootBar], is a minimal rendering path
+// that is usable during the very first frames of the game:
+// before the fonts and textures are loaded and even if
+// the shaders failed to compile (see [TryCompileShaders]).
+//
+// No shaders, font faces or glyph caches are involved:
+// the font glyphs sheet is uploaded once during the first call.
+// This is enough to show a loading screen or an error message.
+func DrawBootText(dst *ebiten.Image, pos gmath.Vec, s string, cs ColorScale) {
+	face := basicfont.Face7x13
+	sheet := getBootFontImage()
+
+	var drawOptions ebiten.DrawImageOptions
+	drawOptions.ColorScale = cs.ToEbitenColorScale()
+	x := int(pos.X)
+	y := int(pos.Y)
+	for _, ch := range s {
+		if ch == '\n' {
+			x = int(pos.X)
+			y += BootLineHeight
+			continue
+		}
+		if ch != ' ' {
+			// For unsupported runes, the face provides a fallback glyph.
+			_, _, maskp, _, _ := face.Glyph(fixed.Point26_6{}, ch)
+			glyph := subImage(sheet, image.Rect(maskp.X, maskp.Y, maskp.X+face.Width, maskp.Y+face.Ascent+face.Descent))
+			drawOptions.GeoM.Reset()
+			drawOptions.GeoM.Translate(float64(x+face.Left), float64(y))
+			drawImage(dst, glyph, &drawOptions)
+		}
+		x += face.Advance
+	}
+}
+
+// DrawBootBar renders a simple progress bar inside the rect.
+// The value is clamped to [0, 1]; the bar is filled from left to right.
+//
+// It's a part of the boot rendering path, see [DrawBootText].
+func DrawBootBar(dst *ebiten.Image, rect gmath.Rect, value float64, fill, background ColorScale) {
+	var drawOptions ebiten.DrawImageOptions
+
+	if background.A != 0 {
+		drawOptions.ColorScale = background.ToEbitenColorScale()
+		drawOptions.GeoM.Scale(rect.Width(), rect.Height())
+		drawOptions.GeoM.Translate(rect.Min.X, rect.Min.Y)
+		drawImage(dst, whitePixel, &drawOptions)
+	}
+
+	value = gmath.Clamp(value, 0, 1)
+	if value == 0 || fill.A == 0 {
+		return
+	}
+	drawOptions.ColorScale = fill.ToEbitenColorScale()
+	drawOptions.GeoM.Reset()
+	drawOptions.GeoM.Scale(rect.Width()*value, rect.Height())
+	drawOptions.GeoM.Translate(rect.Min.X, rect.Min.Y)
+	drawImage(dst, whitePixel, &drawOptions)
+}
+
+func getBootFontImage() *ebiten.Image {
+	if cache.Global.BootFontImage != nil {
+		return cache.Global.BootFontImage
+	}
+	// The mask is an alpha image, so the result is a white glyphs sheet.
+	img := ebiten.NewImageFromImage(basicfont.Face7x13.Mask)
+	cache.Global.BootFontImage = img
+	return img
+}
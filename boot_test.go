@@ -0,0 +1,24 @@
+package graphics_test
+
+import (
+	"testing"
+
+	graphics "github.com/quasilyte/ebitengine-graphics"
+	"github.com/quasilyte/gmath"
+)
+
+func TestMeasureBootText(t *testing.T) {
+	tests := []struct {
+		s    string
+		want gmath.Vec
+	}{
+		{"", gmath.Vec{X: 0, Y: 13}},
+		{"Loading", gmath.Vec{X: 7 * 7, Y: 13}},
+		{"ab\nabcd\n", gmath.Vec{X: 4 * 7, Y: 3 * 13}},
+	}
+	for _, test := range tests {
+		if have := graphics.MeasureBootText(test.s); have != test.want {
+			t.Fatalf("MeasureBootText(%q):\nhave: %v\nwant: %v", test.s, have, test.want)
+		}
+	}
+}
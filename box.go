@@ -0,0 +1,294 @@
+package graphics
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// BoxAlign controls the child placement along the box cross axis.
+// For the [NewHBox] it's a vertical alignment, for the [NewVBox] it's a horizontal one.
+type BoxAlign uint8
+
+const (
+	BoxAlignStart BoxAlign = iota
+	BoxAlignCenter
+	BoxAlignEnd
+)
+
+// BoxConfig describes the [BoxContainer] layout parameters.
+type BoxConfig struct {
+	// Spacing is a distance between the adjacent children.
+	// A zero value means no spacing.
+	Spacing float64
+
+	// Padding is an extra space around the box contents.
+	// A zero value means no padding.
+	Padding float64
+
+	// Align is a cross axis alignment.
+	// A zero value means BoxAlignStart.
+	Align BoxAlign
+}
+
+// BoxContainer arranges its children in a row (HBox) or a column (VBox).
+//
+// The children are placed using their BoundsRect values,
+// so it works with any [BoundedObject] without knowing its anchor:
+// the child bounds top-left corner is moved to the computed location.
+// The objects that don't implement [BoundedObject] are treated
+// as zero-sized objects.
+// The invisible children don't take any space.
+//
+// The layout is cached: it's re-computed only after the box is marked dirty.
+// It happens automatically when the box config is changed, when a child
+// is added or disposed, and when a [DirtySource] child emits its signal
+// (like a nested box that was changed).
+// Call MarkDirty after changing a child that can't notify the box,
+// like a label with a new text or a rect that was resized.
+//
+// The Pos is a box top-left corner.
+// Since the box returns its own BoundsRect,
+// the boxes can be nested to build the menus and panels.
+//
+// BoxContainer implements gscene Graphics interface.
+type BoxContainer struct {
+	Pos gmath.Pos
+
+	config BoxConfig
+
+	objects []DisposableObject
+
+	// offsets is a parallel slice for objects that contains
+	// the draw offsets computed by the last layout call.
+	offsets []gmath.Vec
+	size    gmath.Vec
+
+	// bounds is a layout scratch buffer, so every child
+	// is measured only once per layout.
+	bounds []boxChildBounds
+
+	changed DirtySignal
+
+	vertical bool
+	visible  bool
+	disposed bool
+	dirty    bool
+}
+
+type boxChildBounds struct {
+	rect gmath.Rect
+	ok   bool
+}
+
+// NewHBox creates a box container that arranges its children
+// from the left to the right.
+func NewHBox(config BoxConfig) *BoxContainer {
+	return newBox(config, false)
+}
+
+// NewVBox creates a box container that arranges its children
+// from the top to the bottom.
+func NewVBox(config BoxConfig) *BoxContainer {
+	return newBox(config, true)
+}
+
+func newBox(config BoxConfig, vertical bool) *BoxContainer {
+	return &BoxContainer{
+		config:   config,
+		objects:  make([]DisposableObject, 0, 4),
+		vertical: vertical,
+		visible:  true,
+		dirty:    true,
+	}
+}
+
+// AddChild adds an object to the end of the box.
+func (b *BoxContainer) AddChild(o DisposableObject) {
+	b.objects = append(b.objects, o)
+	connectDirtySource(o, b)
+	b.MarkDirty()
+}
+
+// MarkDirty makes the box re-compute its layout
+// and notifies the box dependents about a change.
+func (b *BoxContainer) MarkDirty() {
+	b.dirty = true
+	b.changed.Emit()
+}
+
+// DirtySignal returns a signal that is emitted when the box
+// or one of its [DirtySource] children is changed.
+// It implements the [DirtySource] interface.
+func (b *BoxContainer) DirtySignal() *DirtySignal {
+	return &b.changed
+}
+
+// GetSpacing returns the current spacing value.
+func (b *BoxContainer) GetSpacing() float64 { return b.config.Spacing }
+
+// SetSpacing changes the distance between the adjacent children.
+func (b *BoxContainer) SetSpacing(spacing float64) {
+	b.config.Spacing = spacing
+	b.MarkDirty()
+}
+
+// GetPadding returns the current padding value.
+func (b *BoxContainer) GetPadding() float64 { return b.config.Padding }
+
+// SetPadding changes the extra space around the box contents.
+func (b *BoxContainer) SetPadding(padding float64) {
+	b.config.Padding = padding
+	b.MarkDirty()
+}
+
+// GetAlign returns the current cross axis alignment.
+func (b *BoxContainer) GetAlign() BoxAlign { return b.config.Align }
+
+// SetAlign changes the cross axis alignment.
+func (b *BoxContainer) SetAlign(align BoxAlign) {
+	b.config.Align = align
+	b.MarkDirty()
+}
+
+// IsDisposed reports whether this box is marked for deletion.
+func (b *BoxContainer) IsDisposed() bool { return b.disposed }
+
+// Dispose marks this box and all of its children for deletion.
+func (b *BoxContainer) Dispose() {
+	for _, o := range b.objects {
+		o.Dispose()
+	}
+	b.disposed = true
+}
+
+// IsVisible reports whether this box is visible.
+// Use SetVisibility to change this flag value.
+func (b *BoxContainer) IsVisible() bool { return b.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+// The box parents are notified about the change, as a hidden box doesn't take any space.
+func (b *BoxContainer) SetVisibility(visible bool) {
+	if b.visible == visible {
+		return
+	}
+	b.visible = visible
+	b.changed.Emit()
+}
+
+// BoundsRect returns the box area, including its padding.
+func (b *BoxContainer) BoundsRect() gmath.Rect {
+	b.layout()
+	pos := b.Pos.Resolve()
+	return gmath.Rect{Min: pos, Max: pos.Add(b.size)}
+}
+
+// Draw renders the box children onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (b *BoxContainer) Draw(dst *ebiten.Image) {
+	b.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the box children onto the provided dst image
+// while also using the extra provided offset.
+func (b *BoxContainer) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !b.visible {
+		return
+	}
+
+	b.layout()
+
+	offset := opts.Offset.Add(b.Pos.Resolve())
+	for i, o := range b.objects {
+		if v, ok := o.(visibleObject); ok && !v.IsVisible() {
+			continue
+		}
+		opts.Offset = offset.Add(b.offsets[i])
+		drawObject(dst, o, opts)
+	}
+}
+
+// layout removes the disposed children and
+// re-computes the children offsets and the box size if necessary.
+func (b *BoxContainer) layout() {
+	liveObjects := b.objects[:0]
+	for _, o := range b.objects {
+		if o.IsDisposed() {
+			continue
+		}
+		liveObjects = append(liveObjects, o)
+	}
+	if len(liveObjects) != len(b.objects) {
+		clear(b.objects[len(liveObjects):])
+		b.objects = liveObjects
+		b.dirty = true
+	}
+
+	if !b.dirty {
+		return
+	}
+	b.dirty = false
+
+	b.offsets = b.offsets[:0]
+	b.bounds = b.bounds[:0]
+
+	// The layout is computed as if it was an HBox;
+	// the axes are swapped for the VBox.
+	axes := func(v gmath.Vec) gmath.Vec {
+		if b.vertical {
+			return gmath.Vec{X: v.Y, Y: v.X}
+		}
+		return v
+	}
+
+	crossSize := 0.0
+	for _, o := range b.objects {
+		rect, ok := layoutChildBounds(o)
+		b.bounds = append(b.bounds, boxChildBounds{rect: rect, ok: ok})
+		if ok {
+			crossSize = max(crossSize, axes(rect.Size()).Y)
+		}
+	}
+
+	pad := b.config.Padding
+	cursor := pad
+	numPlaced := 0
+	for _, bounds := range b.bounds {
+		if !bounds.ok {
+			b.offsets = append(b.offsets, gmath.Vec{})
+			continue
+		}
+		size := axes(bounds.rect.Size())
+		cross := pad
+		switch b.config.Align {
+		case BoxAlignCenter:
+			cross += (crossSize - size.Y) * 0.5
+		case BoxAlignEnd:
+			cross += crossSize - size.Y
+		}
+		b.offsets = append(b.offsets, axes(gmath.Vec{X: cursor, Y: cross}).Sub(bounds.rect.Min))
+		cursor += size.X + b.config.Spacing
+		numPlaced++
+	}
+	if numPlaced != 0 {
+		cursor -= b.config.Spacing
+	}
+
+	b.size = axes(gmath.Vec{X: cursor + pad, Y: crossSize + 2*pad})
+}
+
+// layoutChildBounds returns the layout container child bounds rect.
+// The second result is false for the children that don't take any space.
+func layoutChildBounds(o DisposableObject) (gmath.Rect, bool) {
+	if v, ok := o.(visibleObject); ok && !v.IsVisible() {
+		return gmath.Rect{}, false
+	}
+	if bounded, ok := o.(BoundedObject); ok {
+		return bounded.BoundsRect(), true
+	}
+	return gmath.Rect{}, true
+}
@@ -0,0 +1,134 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+func TestBoxLayout(t *testing.T) {
+	newRect := func(w, h float64) *Rect {
+		// A centered rect has its bounds origin at (-w/2, -h/2),
+		// the box should compensate for that.
+		r := NewRect(w, h)
+		r.Pos.Offset = gmath.Vec{X: 100, Y: 100}
+		return r
+	}
+
+	tests := []struct {
+		box     *BoxContainer
+		offsets []gmath.Vec
+		size    gmath.Vec
+	}{
+		{
+			box:     NewHBox(BoxConfig{Spacing: 2, Padding: 1}),
+			offsets: []gmath.Vec{{X: -94, Y: -93}, {X: -82, Y: -95}},
+			size:    gmath.Vec{X: 24, Y: 14},
+		},
+		{
+			box:     NewHBox(BoxConfig{Align: BoxAlignCenter}),
+			offsets: []gmath.Vec{{X: -95, Y: -94}, {X: -85, Y: -94}},
+			size:    gmath.Vec{X: 20, Y: 12},
+		},
+		{
+			box:     NewVBox(BoxConfig{Spacing: 4, Align: BoxAlignEnd}),
+			offsets: []gmath.Vec{{X: -95, Y: -94}, {X: -95, Y: -80}},
+			size:    gmath.Vec{X: 10, Y: 24},
+		},
+	}
+
+	for i, test := range tests {
+		test.box.AddChild(newRect(10, 12))
+		test.box.AddChild(newRect(10, 8))
+		hidden := newRect(50, 50)
+		hidden.SetVisibility(false)
+		test.box.AddChild(hidden)
+		disposed := newRect(50, 50)
+		disposed.Dispose()
+		test.box.AddChild(disposed)
+
+		bounds := test.box.BoundsRect()
+		if bounds.Size() != test.size {
+			t.Fatalf("test%d: size:\nhave: %v\nwant: %v", i, bounds.Size(), test.size)
+		}
+		if len(test.box.objects) != 3 {
+			t.Fatalf("test%d: expected the disposed child to be removed", i)
+		}
+		for j, want := range test.offsets {
+			if have := test.box.offsets[j]; have != want {
+				t.Fatalf("test%d: offsets[%d]:\nhave: %v\nwant: %v", i, j, have, want)
+			}
+		}
+	}
+}
+
+func TestBoxResize(t *testing.T) {
+	box := NewHBox(BoxConfig{Spacing: 1})
+	first := NewRect(10, 10)
+	first.SetCentered(false)
+	second := NewRect(10, 10)
+	second.SetCentered(false)
+	box.AddChild(first)
+	box.AddChild(second)
+
+	if have := box.BoundsRect().Width(); have != 21 {
+		t.Fatalf("width: have %v, want 21", have)
+	}
+
+	// The rect can't notify the box about its size change.
+	first.SetWidth(20)
+	if have := box.BoundsRect().Width(); have != 21 {
+		t.Fatalf("width before MarkDirty: have %v, want 21", have)
+	}
+	box.MarkDirty()
+	if have := box.BoundsRect().Width(); have != 31 {
+		t.Fatalf("width after resize: have %v, want 31", have)
+	}
+	if have := box.offsets[1]; have != (gmath.Vec{X: 21}) {
+		t.Fatalf("offsets[1] after resize: have %v, want {21, 0}", have)
+	}
+}
+
+type boxCountingChild struct {
+	rect     gmath.Rect
+	measured int
+}
+
+func (c *boxCountingChild) BoundsRect() gmath.Rect {
+	c.measured++
+	return c.rect
+}
+
+func (c *boxCountingChild) IsDisposed() bool { return false }
+
+func (c *boxCountingChild) Dispose() {}
+
+func (c *boxCountingChild) Draw(dst *ebiten.Image) {}
+
+func (c *boxCountingChild) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {}
+
+func TestBoxLayoutCache(t *testing.T) {
+	outer := NewVBox(BoxConfig{})
+	inner := NewHBox(BoxConfig{})
+	child := &boxCountingChild{rect: gmath.Rect{Max: gmath.Vec{X: 10, Y: 10}}}
+	inner.AddChild(child)
+	outer.AddChild(inner)
+	outer.AddChild(NewHBox(BoxConfig{}))
+
+	for i := 0; i < 3; i++ {
+		outer.BoundsRect()
+	}
+	if child.measured != 1 {
+		t.Fatalf("measured: have %d, want 1", child.measured)
+	}
+
+	// A nested box change invalidates its parent layout.
+	inner.SetPadding(2)
+	if have := outer.BoundsRect().Size(); have != (gmath.Vec{X: 14, Y: 14}) {
+		t.Fatalf("size after the nested box change: have %v, want {14, 14}", have)
+	}
+	if child.measured != 2 {
+		t.Fatalf("measured after the change: have %d, want 2", child.measured)
+	}
+}
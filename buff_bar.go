@@ -0,0 +1,357 @@
+package graphics
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+	"github.com/quasilyte/gmath"
+)
+
+// BuffBarConfig describes the [BuffBar] appearance.
+type BuffBarConfig struct {
+	// IconSize is a size of a single (square) icon.
+	// The icon images are scaled to fit it.
+	// A zero value means 16.
+	IconSize float64
+
+	// Spacing is a distance between the icons.
+	// A zero value means 2.
+	Spacing float64
+
+	// Gap is a distance between the target's bounds top and the icons row.
+	// It's only used when the bar is attached to a target.
+	// A zero value means 3.
+	Gap float64
+
+	// Face is used to render the stack counts.
+	// A nil face disables the stack count labels.
+	Face text.Face
+
+	// TextColorScale is a stack count label color.
+	// A zero value means {1, 1, 1, 1}.
+	TextColorScale ColorScale
+
+	// WipeColorScale is a color of the radial wipe that
+	// covers the elapsed part of the status effect duration.
+	// A zero value means a half-transparent black color.
+	WipeColorScale ColorScale
+}
+
+// BuffBar renders a horizontal row of the status effect icons.
+//
+// Every icon can display a stack count label (for the values above 1)
+// and a radial wipe duration indicator that grows clockwise
+// from the 12 o'clock position as the effect time runs out.
+//
+// The bar can be attached to a target (like a unit sprite), so it's rendered
+// above it, centered horizontally; in this case, it should be added to a [Layer].
+// Unattached bars use their Pos field as the row's top-left corner,
+// which makes them usable as a HUD element.
+//
+// The icon objects are pooled, so adding and removing the effects
+// frequently doesn't generate garbage.
+//
+// BuffBar implements gscene Graphics interface.
+type BuffBar struct {
+	// Pos is a row top-left corner location binder.
+	// It's ignored when the bar is attached to a target.
+	// See Pos documentation to learn how it works.
+	Pos gmath.Pos
+
+	config BuffBarConfig
+
+	textColor ebiten.ColorScale
+	wipeColor ebiten.ColorScale
+
+	target BoundedObject
+
+	icons []*BuffIcon
+	free  []*BuffIcon
+
+	visible  bool
+	disposed bool
+}
+
+// BuffIcon is a single status effect icon created by [BuffBar.AddIcon].
+type BuffIcon struct {
+	image     *ebiten.Image
+	stacks    int
+	remaining float64
+	disposed  bool
+}
+
+// NewBuffBar creates an empty unattached bar with the specified config.
+func NewBuffBar(config BuffBarConfig) *BuffBar {
+	if config.IconSize == 0 {
+		config.IconSize = 16
+	}
+	if config.Spacing == 0 {
+		config.Spacing = 2
+	}
+	if config.Gap == 0 {
+		config.Gap = 3
+	}
+	if config.TextColorScale == (ColorScale{}) {
+		config.TextColorScale = defaultColorScale
+	}
+	if config.WipeColorScale == (ColorScale{}) {
+		config.WipeColorScale = ColorScale{A: 0.6}
+	}
+	return &BuffBar{
+		config:    config,
+		textColor: config.TextColorScale.ToEbitenColorScale(),
+		wipeColor: config.WipeColorScale.ToEbitenColorScale(),
+		visible:   true,
+	}
+}
+
+// AttachTo binds the bar to the target, so the icons are rendered above it.
+// A nil target detaches the bar; the Pos field is used after that.
+//
+// The bar is considered to be disposed when its target
+// is disposed (if the target implements IsDisposed method).
+func (b *BuffBar) AttachTo(target BoundedObject) { b.target = target }
+
+// AddIcon appends a new status effect icon to the row.
+// The initial stack count is 1 and the remaining duration is 1.
+func (b *BuffBar) AddIcon(img *ebiten.Image) *BuffIcon {
+	var icon *BuffIcon
+	if n := len(b.free); n != 0 {
+		icon = b.free[n-1]
+		b.free = b.free[:n-1]
+	} else {
+		icon = &BuffIcon{}
+	}
+	*icon = BuffIcon{image: img, stacks: 1, remaining: 1}
+	b.icons = append(b.icons, icon)
+	return icon
+}
+
+// GetStacks returns the current stack count.
+// Use SetStacks to change it.
+func (icon *BuffIcon) GetStacks() int { return icon.stacks }
+
+// SetStacks assigns the stack count.
+// The label is only displayed for the values above 1.
+func (icon *BuffIcon) SetStacks(n int) { icon.stacks = n }
+
+// GetRemaining returns the remaining duration fraction.
+// Use SetRemaining to change it.
+func (icon *BuffIcon) GetRemaining() float64 { return icon.remaining }
+
+// SetRemaining assigns the remaining duration fraction.
+// The value is clamped to [0, 1], where 1 means "no wipe".
+func (icon *BuffIcon) SetRemaining(v float64) { icon.remaining = gmath.Clamp(v, 0, 1) }
+
+// Dispose removes the icon from the bar.
+// The icon object will be re-used by the next AddIcon call,
+// so it should not be accessed after the Dispose call.
+func (icon *BuffIcon) Dispose() { icon.disposed = true }
+
+// BoundsRect returns the icons row rectangle.
+func (b *BuffBar) BoundsRect() gmath.Rect {
+	n := 0
+	for _, icon := range b.icons {
+		if !icon.disposed {
+			n++
+		}
+	}
+	width := float64(n)*b.config.IconSize + float64(max(0, n-1))*b.config.Spacing
+	pos := b.rowPos(width)
+	return gmath.Rect{
+		Min: pos,
+		Max: pos.Add(gmath.Vec{X: width, Y: b.config.IconSize}),
+	}
+}
+
+func (b *BuffBar) rowPos(width float64) gmath.Vec {
+	if b.target == nil {
+		return b.Pos.Resolve()
+	}
+	bounds := b.target.BoundsRect()
+	return gmath.Vec{
+		X: bounds.Center().X - width*0.5,
+		Y: bounds.Min.Y - b.config.Gap - b.config.IconSize,
+	}
+}
+
+// Dispose marks this bar for deletion.
+// After calling this method, IsDisposed will report true.
+//
+// The target object is not disposed.
+func (b *BuffBar) Dispose() { b.disposed = true }
+
+// IsDisposed reports whether this bar is marked for deletion.
+//
+// The bar is also considered to be disposed if its target is disposed.
+func (b *BuffBar) IsDisposed() bool {
+	if b.disposed {
+		return true
+	}
+	d, ok := b.target.(gsceneGraphics)
+	return ok && d.IsDisposed()
+}
+
+// IsVisible reports whether this bar is visible.
+// Use SetVisibility to change this flag value.
+//
+// An attached bar is also hidden while its target is invisible.
+func (b *BuffBar) IsVisible() bool { return b.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (b *BuffBar) SetVisibility(visible bool) { b.visible = visible }
+
+// Draw renders the bar onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (b *BuffBar) Draw(dst *ebiten.Image) {
+	b.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the bar onto the provided dst image
+// while also using the extra provided offset.
+func (b *BuffBar) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	liveIcons := b.icons[:0]
+	for _, icon := range b.icons {
+		if icon.disposed {
+			icon.image = nil
+			b.free = append(b.free, icon)
+			continue
+		}
+		liveIcons = append(liveIcons, icon)
+	}
+	clear(b.icons[len(liveIcons):])
+	b.icons = liveIcons
+
+	if !b.visible || len(b.icons) == 0 {
+		return
+	}
+	if v, ok := b.target.(visibleObject); ok && !v.IsVisible() {
+		return
+	}
+
+	size := b.config.IconSize
+	step := size + b.config.Spacing
+	pos := b.BoundsRect().Min.Add(opts.Offset).Rounded()
+
+	var drawOptions ebiten.DrawImageOptions
+	if opts.Blend != nil {
+		drawOptions.Blend = *opts.Blend
+	}
+	drawOptions.Filter = ebiten.FilterLinear
+	for i, icon := range b.icons {
+		if icon.image == nil {
+			continue
+		}
+		bounds := icon.image.Bounds()
+		drawOptions.GeoM.Reset()
+		drawOptions.GeoM.Scale(size/float64(bounds.Dx()), size/float64(bounds.Dy()))
+		drawOptions.GeoM.Translate(pos.X+float64(i)*step, pos.Y)
+		drawImage(dst, icon.image, &drawOptions)
+	}
+
+	b.drawWipes(dst, opts.Blend, pos)
+
+	if b.config.Face == nil {
+		return
+	}
+	var textOptions text.DrawOptions
+	if opts.Blend != nil {
+		textOptions.Blend = *opts.Blend
+	}
+	textOptions.ColorScale = b.textColor
+	textOptions.PrimaryAlign = text.AlignEnd
+	textOptions.SecondaryAlign = text.AlignEnd
+	for i, icon := range b.icons {
+		if icon.stacks <= 1 {
+			continue
+		}
+		textOptions.GeoM.Reset()
+		textOptions.GeoM.Translate(pos.X+float64(i)*step+size, pos.Y+size)
+		drawText(dst, strconv.Itoa(icon.stacks), b.config.Face, &textOptions)
+	}
+}
+
+// drawWipes renders all radial wipes in a single draw call.
+func (b *BuffBar) drawWipes(dst *ebiten.Image, blend *ebiten.Blend, pos gmath.Vec) {
+	vertices := cache.Global.ScratchVertices[:0]
+	indices := cache.Global.ScratchIndices[:0]
+	defer func() {
+		cache.Global.ScratchVertices = vertices[:0]
+		cache.Global.ScratchIndices = indices[:0]
+	}()
+
+	size := b.config.IconSize
+	step := size + b.config.Spacing
+	for i, icon := range b.icons {
+		if icon.remaining >= 1 {
+			continue
+		}
+		if len(vertices)+64 > 0xffff {
+			break
+		}
+		center := gmath.Vec{X: pos.X + float64(i)*step + size*0.5, Y: pos.Y + size*0.5}
+		vertices, indices = appendSquareWipe(vertices, indices, center, size*0.5, 1-icon.remaining, b.wipeColor)
+	}
+	if len(indices) == 0 {
+		return
+	}
+
+	var drawOptions ebiten.DrawTrianglesOptions
+	if blend != nil {
+		drawOptions.Blend = *blend
+	}
+	drawVertexColorTriangles(dst, vertices, indices, emptyImage, &drawOptions)
+}
+
+// appendSquareWipe appends a square pie sector that starts at 12 o'clock
+// and covers the fraction of the square clockwise.
+func appendSquareWipe(vertices []ebiten.Vertex, indices []uint16, center gmath.Vec, half, fraction float64, cs ebiten.ColorScale) ([]ebiten.Vertex, []uint16) {
+	// The angle step is chosen so the square corners are always
+	// sampled exactly, the sector edges stay straight this way.
+	const numSteps = 32
+	const angleStep = 2 * math.Pi / numSteps
+
+	vertex := func(pos gmath.Vec) ebiten.Vertex {
+		return ebiten.Vertex{
+			DstX:   float32(pos.X),
+			DstY:   float32(pos.Y),
+			SrcX:   1.5,
+			SrcY:   1.5,
+			ColorR: cs.R(),
+			ColorG: cs.G(),
+			ColorB: cs.B(),
+			ColorA: cs.A(),
+		}
+	}
+	// squarePoint projects the angle direction onto the square perimeter.
+	squarePoint := func(angle float64) gmath.Vec {
+		sin, cos := math.Sincos(angle - math.Pi/2)
+		k := half / max(math.Abs(cos), math.Abs(sin))
+		return center.Add(gmath.Vec{X: cos * k, Y: sin * k})
+	}
+
+	endAngle := 2 * math.Pi * fraction
+	centerIndex := uint16(len(vertices))
+	vertices = append(vertices, vertex(center), vertex(squarePoint(0)))
+	for angle := angleStep; ; angle += angleStep {
+		last := angle >= endAngle
+		if last {
+			angle = endAngle
+		}
+		idx := uint16(len(vertices))
+		vertices = append(vertices, vertex(squarePoint(angle)))
+		indices = append(indices, centerIndex, idx-1, idx)
+		if last {
+			break
+		}
+	}
+	return vertices, indices
+}
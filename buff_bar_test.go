@@ -0,0 +1,36 @@
+package graphics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/quasilyte/gmath"
+)
+
+func TestBuffBarWipeArea(t *testing.T) {
+	tests := []float64{0.125, 0.25, 0.3, 0.5, 0.9, 1}
+
+	for _, fraction := range tests {
+		vertices, indices := appendSquareWipe(nil, nil, gmath.Vec{X: 8, Y: 8}, 8, fraction, defaultColorScale.ToEbitenColorScale())
+		area := 0.0
+		for i := 0; i < len(indices); i += 3 {
+			a := vertices[indices[i+0]]
+			b := vertices[indices[i+1]]
+			c := vertices[indices[i+2]]
+			area += math.Abs(float64((b.DstX-a.DstX)*(c.DstY-a.DstY)-(c.DstX-a.DstX)*(b.DstY-a.DstY))) * 0.5
+		}
+		// This formula is only valid for the fractions that
+		// are multiples of 1/8 (the square corners and edge midpoints).
+		// The other fractions are checked to be in the expected bounds.
+		want := 256 * fraction
+		if math.Mod(fraction, 0.125) == 0 {
+			if math.Abs(area-want) > 0.01 {
+				t.Fatalf("fraction=%v area:\nhave: %v\nwant: %v", fraction, area, want)
+			}
+			continue
+		}
+		if area <= 256*math.Floor(fraction*8)/8 || area >= 256*math.Ceil(fraction*8)/8 {
+			t.Fatalf("fraction=%v area %v is out of bounds", fraction, area)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package graphics
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// CacheGroup is a container that renders its children
+// into an offscreen image and then re-uses that image.
+//
+// It's useful for the complex static clusters of objects,
+// like a decorated UI panel with many labels and sprites:
+// the entire group is drawn with a single image draw call.
+//
+// The group can't detect most of the children changes automatically.
+// Call MarkDirty after changing any of the children,
+// so the group is re-rendered during the next Draw call.
+// Adding a child or disposing one of them marks the group as dirty too.
+// The children that implement [DirtySource] (like [TileMap] or
+// a nested [Container]) mark the group as dirty on their own.
+//
+// The group is a [DirtySource] too: marking it dirty
+// propagates to its dependents (like an outer cache group).
+//
+// The children positions are relative to the group's Pos.
+// Everything outside of the group's size is clipped.
+type CacheGroup struct {
+	Pos gmath.Pos
+
+	objects []DisposableObject
+
+	img *ebiten.Image
+
+	width  int
+	height int
+
+	changed DirtySignal
+
+	visible      bool
+	disposed     bool
+	dirty        bool
+	debugOverlay bool
+}
+
+// NewCacheGroup creates a group with the specified offscreen image size.
+func NewCacheGroup(width, height int) *CacheGroup {
+	return &CacheGroup{
+		objects: make([]DisposableObject, 0, 4),
+		width:   width,
+		height:  height,
+		visible: true,
+		dirty:   true,
+	}
+}
+
+func (g *CacheGroup) IsDisposed() bool {
+	return g.disposed
+}
+
+// Dispose disposes all children and releases the offscreen image.
+func (g *CacheGroup) Dispose() {
+	for _, o := range g.objects {
+		o.Dispose()
+	}
+	if g.img != nil {
+		g.img.Deallocate()
+		g.img = nil
+	}
+	g.disposed = true
+}
+
+// IsVisible reports whether this group is visible.
+// Use SetVisibility to change this flag value.
+func (g *CacheGroup) IsVisible() bool {
+	return g.visible
+}
+
+// SetVisibility changes the Visible flag value.
+// It can be used to show or hide the group.
+// Use IsVisible to get the current flag value.
+func (g *CacheGroup) SetVisibility(visible bool) {
+	g.visible = visible
+}
+
+// GetSize returns the group's offscreen image size.
+func (g *CacheGroup) GetSize() (w, h int) {
+	return g.width, g.height
+}
+
+func (g *CacheGroup) AddChild(o DisposableObject) {
+	g.objects = append(g.objects, o)
+	connectDirtySource(o, g)
+	g.MarkDirty()
+}
+
+// MarkDirty forces the group to re-render its children during the next Draw call.
+// The group dependents are marked dirty too (see [DirtySignal]).
+func (g *CacheGroup) MarkDirty() {
+	g.dirty = true
+	g.changed.Emit()
+}
+
+// DirtySignal returns a signal that is emitted when the group is marked dirty.
+// It implements the [DirtySource] interface.
+func (g *CacheGroup) DirtySignal() *DirtySignal {
+	return &g.changed
+}
+
+// SetDebugOverlay enables or disables the debug overlay for this group.
+// It works like [Container.SetDebugOverlay].
+//
+// The overlay is not a part of the cached image:
+// it's drawn on top of it, so the group is not re-rendered every frame.
+func (g *CacheGroup) SetDebugOverlay(enabled bool) {
+	g.debugOverlay = enabled
+}
+
+// IsDebugOverlay reports whether the debug overlay is enabled.
+// Use SetDebugOverlay to change it.
+func (g *CacheGroup) IsDebugOverlay() bool {
+	return g.debugOverlay
+}
+
+// IsDirty reports whether the group will be re-rendered during the next Draw call.
+func (g *CacheGroup) IsDirty() bool {
+	return g.dirty
+}
+
+func (g *CacheGroup) BoundsRect() gmath.Rect {
+	pos := g.Pos.Resolve()
+	return gmath.Rect{
+		Min: pos,
+		Max: pos.Add(gmath.Vec{X: float64(g.width), Y: float64(g.height)}),
+	}
+}
+
+func (g *CacheGroup) Draw(dst *ebiten.Image) {
+	g.DrawWithOptions(dst, DrawOptions{})
+}
+
+func (g *CacheGroup) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !g.visible {
+		return
+	}
+
+	liveObjects := g.objects[:0]
+	for _, o := range g.objects {
+		if o.IsDisposed() {
+			g.dirty = true
+			continue
+		}
+		liveObjects = append(liveObjects, o)
+	}
+	g.objects = liveObjects
+
+	if g.dirty {
+		g.dirty = false
+		if g.img == nil {
+			g.img = ebiten.NewImage(g.width, g.height)
+		} else {
+			clearImage(g.img)
+		}
+		for _, o := range g.objects {
+			drawObject(g.img, o, DrawOptions{})
+		}
+	}
+
+	pos := g.Pos.Resolve().Add(opts.Offset)
+	var drawOptions ebiten.DrawImageOptions
+	if opts.Blend != nil {
+		drawOptions.Blend = *opts.Blend
+	}
+	if opts.Rotation != 0 {
+		drawOptions.GeoM.Rotate(float64(opts.Rotation))
+	}
+	drawOptions.GeoM.Translate(pos.X, pos.Y)
+	drawImage(dst, g.img, &drawOptions)
+
+	if g.debugOverlay {
+		for _, o := range g.objects {
+			drawDebugOverlay(dst, o, DrawOptions{Offset: pos})
+		}
+	}
+}
+
+func (g *CacheGroup) inspectChildren() []DisposableObject {
+	return g.objects
+}
@@ -1,6 +1,8 @@
 package graphics
 
 import (
+	"math"
+
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/quasilyte/gmath"
 )
@@ -25,6 +27,14 @@ import (
 // * screen to world: screenPos.Add(camera.GetOffset())
 // * world to screen: worldPos.Sub(camera.GetOffset())
 //
+// These simple formulas only work for the full-screen cameras
+// without zoom and rotation. Use [WorldToScreen] and [ScreenToWorld]
+// to get the results that respect all camera properties.
+//
+// The zoom and rotation are applied around the viewport rect's center.
+// The camera offset is still an unzoomed top-left corner position,
+// so [GetCenterOffset] reports the same value regardless of the zoom.
+//
 // Pay attention to the docs, they should tell you which kind of a position
 // is expected for an argument and/or method's return value.
 type Camera struct {
@@ -36,9 +46,14 @@ type Camera struct {
 	areaRect gmath.Rect
 	areaSize gmath.Vec
 
+	zoom     float64
+	rotation gmath.Rad
+
 	layerMask uint64
 
 	pp PostProcessor
+
+	shake *Shake
 }
 
 // NewCamera creates a new camera for the [SceneDrawer].
@@ -52,6 +67,7 @@ type Camera struct {
 func NewCamera() *Camera {
 	w, h := ebiten.WindowSize()
 	camera := &Camera{
+		zoom:      1,
 		layerMask: ^uint64(0),
 	}
 	camera.SetViewportRect(gmath.Rect{
@@ -64,6 +80,19 @@ func (c *Camera) SetPostProcessor(pp PostProcessor) {
 	c.pp = pp
 }
 
+// SetShake assigns a shake effect to this camera.
+// The shake offset is applied during the rendering only,
+// it doesn't affect the camera offset and the coordinates conversion.
+// A nil value removes the shake.
+func (c *Camera) SetShake(s *Shake) {
+	c.shake = s
+}
+
+// GetShake returns the shake assigned by SetShake.
+func (c *Camera) GetShake() *Shake {
+	return c.shake
+}
+
 func (c *Camera) GetBounds() gmath.Rect {
 	return c.bounds
 }
@@ -93,6 +122,115 @@ func (c *Camera) SetViewportRect(rect gmath.Rect) {
 	c.areaSize = rect.Size()
 }
 
+// GetWorldRect returns the world area that is currently rendered by the camera.
+//
+// The returned rect is in world coordinates.
+// Without zoom and rotation, it's the viewport rect translated by the camera offset.
+// For a rotated camera, it's a bounding rect of the visible area.
+func (c *Camera) GetWorldRect() gmath.Rect {
+	if !c.isTransformed() {
+		return gmath.Rect{
+			Min: c.offset,
+			Max: c.offset.Add(c.areaSize),
+		}
+	}
+
+	halfSize := c.areaSize.Mulf(0.5 / c.zoom)
+	sin, cos := math.Sincos(float64(c.rotation))
+	sin = math.Abs(sin)
+	cos = math.Abs(cos)
+	extents := gmath.Vec{
+		X: cos*halfSize.X + sin*halfSize.Y,
+		Y: sin*halfSize.X + cos*halfSize.Y,
+	}
+	center := c.getCenter()
+	return gmath.Rect{
+		Min: center.Sub(extents),
+		Max: center.Add(extents),
+	}
+}
+
+// GetZoom returns the current camera zoom factor.
+// Use SetZoom to change it.
+func (c *Camera) GetZoom() float64 {
+	return c.zoom
+}
+
+// SetZoom changes the camera zoom factor.
+// Values above 1 make objects look bigger, values below 1 make them smaller.
+// The default zoom is 1.
+//
+// The zoom value should be positive.
+// Note that the transformed camera renders its world area into
+// an offscreen image first, so a low zoom value requires a big image.
+func (c *Camera) SetZoom(zoom float64) {
+	if zoom <= 0 {
+		panic("camera zoom should be positive")
+	}
+	c.zoom = zoom
+	// The visible area size is changed, so re-apply the bounds.
+	c.setOffset(c.offset)
+}
+
+// GetRotation returns the current camera rotation.
+// Use SetRotation to change it.
+func (c *Camera) GetRotation() gmath.Rad {
+	return c.rotation
+}
+
+// SetRotation changes the camera rotation.
+// Rotating the camera clockwise makes the world appear rotated counter-clockwise.
+//
+// The camera bounds don't take the rotation into account.
+func (c *Camera) SetRotation(rotation gmath.Rad) {
+	c.rotation = rotation
+}
+
+// GetGeoM returns a world to screen transformation matrix.
+//
+// It respects the camera offset, zoom, rotation and viewport rect.
+func (c *Camera) GetGeoM() ebiten.GeoM {
+	var m ebiten.GeoM
+	center := c.getCenter()
+	m.Translate(-center.X, -center.Y)
+	m.Scale(c.zoom, c.zoom)
+	m.Rotate(float64(-c.rotation))
+	m.Translate(c.areaRect.Min.X+c.areaSize.X*0.5, c.areaRect.Min.Y+c.areaSize.Y*0.5)
+	return m
+}
+
+// WorldToScreen converts world coordinates into screen coordinates.
+// See [GetGeoM].
+func (c *Camera) WorldToScreen(pos gmath.Vec) gmath.Vec {
+	m := c.GetGeoM()
+	x, y := m.Apply(pos.X, pos.Y)
+	return gmath.Vec{X: x, Y: y}
+}
+
+// ScreenToWorld converts screen coordinates into world coordinates.
+// It's an inverse of [WorldToScreen].
+//
+// This is how to get the world position of a mouse cursor.
+func (c *Camera) ScreenToWorld(pos gmath.Vec) gmath.Vec {
+	m := c.GetGeoM()
+	m.Invert()
+	x, y := m.Apply(pos.X, pos.Y)
+	return gmath.Vec{X: x, Y: y}
+}
+
+func (c *Camera) layerEnabled(i int) bool {
+	// The mask only affects the first 64 layers.
+	return i >= 64 || uint64(1<<i)&c.layerMask != 0
+}
+
+func (c *Camera) isTransformed() bool {
+	return c.zoom != 1 || c.rotation != 0
+}
+
+func (c *Camera) getCenter() gmath.Vec {
+	return c.offset.Add(c.areaSize.Mulf(0.5))
+}
+
 // GetLayerMask returns the current camera's layer bitmask.
 // See [SetLayerMask] doc comment to learn more about the bitmask.
 func (c *Camera) GetLayerMask() uint64 {
@@ -175,10 +313,14 @@ func (c *Camera) setOffset(offset gmath.Vec) bool {
 }
 
 func (c *Camera) getDrawOffset() gmath.Vec {
-	return gmath.Vec{
-		X: -c.drawOffset.X,
-		Y: -c.drawOffset.Y,
+	return c.getShakeOffset().Sub(c.drawOffset)
+}
+
+func (c *Camera) getShakeOffset() gmath.Vec {
+	if c.shake == nil {
+		return gmath.Vec{}
 	}
+	return c.shake.Offset().Rounded()
 }
 
 func (c *Camera) clampOffset(offset gmath.Vec) gmath.Vec {
@@ -186,7 +328,18 @@ func (c *Camera) clampOffset(offset gmath.Vec) gmath.Vec {
 		return offset
 	}
 
-	offset.X = gmath.Clamp(offset.X, c.bounds.Min.X, c.bounds.Max.X-c.areaSize.X)
-	offset.Y = gmath.Clamp(offset.Y, c.bounds.Min.Y, c.bounds.Max.Y-c.areaSize.Y)
-	return offset
+	if c.zoom == 1 {
+		offset.X = gmath.Clamp(offset.X, c.bounds.Min.X, c.bounds.Max.X-c.areaSize.X)
+		offset.Y = gmath.Clamp(offset.Y, c.bounds.Min.Y, c.bounds.Max.Y-c.areaSize.Y)
+		return offset
+	}
+
+	// The zoomed camera shows a smaller (or bigger) area around its center,
+	// so clamp the center position instead.
+	halfSize := c.areaSize.Mulf(0.5)
+	visibleHalfSize := halfSize.Mulf(1 / c.zoom)
+	center := offset.Add(halfSize)
+	center.X = gmath.Clamp(center.X, c.bounds.Min.X+visibleHalfSize.X, c.bounds.Max.X-visibleHalfSize.X)
+	center.Y = gmath.Clamp(center.Y, c.bounds.Min.Y+visibleHalfSize.Y, c.bounds.Max.Y-visibleHalfSize.Y)
+	return center.Sub(halfSize)
 }
@@ -0,0 +1,46 @@
+package graphics_test
+
+import (
+	"math"
+	"testing"
+
+	graphics "github.com/quasilyte/ebitengine-graphics"
+	"github.com/quasilyte/gmath"
+)
+
+func TestCameraWorldToScreen(t *testing.T) {
+	c := graphics.NewCamera()
+	c.SetViewportRect(gmath.Rect{Max: gmath.Vec{X: 200, Y: 100}})
+	c.SetOffset(gmath.Vec{X: 50, Y: 50})
+
+	approxEqual := func(a, b gmath.Vec) bool {
+		return math.Abs(a.X-b.X) < 0.0001 && math.Abs(a.Y-b.Y) < 0.0001
+	}
+
+	if have := c.WorldToScreen(gmath.Vec{X: 60, Y: 70}); !approxEqual(have, gmath.Vec{X: 10, Y: 20}) {
+		t.Fatalf("untransformed:\nhave: %v\nwant: [10, 20]", have)
+	}
+
+	c.SetZoom(2)
+	center := c.GetCenterOffset()
+	if have := c.WorldToScreen(center); !approxEqual(have, gmath.Vec{X: 100, Y: 50}) {
+		t.Fatalf("zoomed center:\nhave: %v\nwant: [100, 50]", have)
+	}
+	if have := c.WorldToScreen(center.Add(gmath.Vec{X: 10})); !approxEqual(have, gmath.Vec{X: 120, Y: 50}) {
+		t.Fatalf("zoomed:\nhave: %v\nwant: [120, 50]", have)
+	}
+	if have := c.GetWorldRect().Size(); !approxEqual(have, gmath.Vec{X: 100, Y: 50}) {
+		t.Fatalf("zoomed world rect:\nhave: %v\nwant: [100, 50]", have)
+	}
+
+	c.SetRotation(math.Pi / 2)
+	for _, pos := range []gmath.Vec{{X: 0, Y: 0}, {X: 75, Y: 10}, {X: -40, Y: 300}} {
+		screenPos := c.WorldToScreen(pos)
+		if have := c.ScreenToWorld(screenPos); !approxEqual(have, pos) {
+			t.Fatalf("round trip for %v:\nhave: %v\nwant: %v", pos, have, pos)
+		}
+	}
+	if have := c.GetWorldRect().Size(); !approxEqual(have, gmath.Vec{X: 50, Y: 100}) {
+		t.Fatalf("rotated world rect:\nhave: %v\nwant: [50, 100]", have)
+	}
+}
@@ -5,6 +5,20 @@ import (
 	"github.com/quasilyte/gmath"
 )
 
+// Canvas renders its children into an offscreen image
+// and then draws that image onto the destination.
+//
+// The offscreen image can be drawn through a shader (see SetShader)
+// or a [PostProcessor] (see SetPostProcessor).
+// This enables the per-layer post effects like blur, CRT or color grading.
+//
+// The image can have a different resolution than the screen:
+// a 320x180 canvas with a scale of 4 renders a low-resolution UI
+// on top of a high-resolution game world.
+//
+// The children positions are relative to the canvas image.
+//
+// Canvas implements gscene Graphics interface.
 type Canvas struct {
 	Pos gmath.Pos
 
@@ -13,7 +27,10 @@ type Canvas struct {
 	spr       *Sprite
 	container *Container
 
+	pp PostProcessor
+
 	offscreen bool
+	ownsImage bool
 }
 
 func NewCanvas() *Canvas {
@@ -25,16 +42,83 @@ func NewCanvas() *Canvas {
 	return c
 }
 
+// SetDstImage assigns the [Canvas] offscreen image.
+// The canvas doesn't take the ownership of this image.
+// Use SetSize to let the canvas allocate the image on its own.
 func (c *Canvas) SetDstImage(img *ebiten.Image) {
+	c.releaseImage()
 	c.spr.SetImage(img)
 }
 
+// GetDstImage returns the canvas offscreen image.
+// It can be nil if neither SetDstImage nor SetSize were called.
+func (c *Canvas) GetDstImage() *ebiten.Image {
+	return c.spr.GetImage()
+}
+
+// SetSize allocates the canvas offscreen image of the specified size.
+// This image is released when the canvas is disposed or resized.
+func (c *Canvas) SetSize(width, height int) {
+	if img := c.spr.GetImage(); c.ownsImage && img.Bounds().Dx() == width && img.Bounds().Dy() == height {
+		return
+	}
+	c.releaseImage()
+	c.spr.SetImage(ebiten.NewImage(width, height))
+	c.ownsImage = true
+}
+
+func (c *Canvas) releaseImage() {
+	if c.ownsImage {
+		c.spr.GetImage().Deallocate()
+		c.ownsImage = false
+	}
+}
+
+// SetScale changes the offscreen image scaling factors.
+// The children are rendered in the image resolution,
+// the scaling is applied when the image is drawn.
+func (c *Canvas) SetScale(x, y float64) {
+	c.spr.SetScaleX(x)
+	c.spr.SetScaleY(y)
+}
+
+// GetScale returns the scaling factors assigned by SetScale.
+func (c *Canvas) GetScale() (x, y float64) {
+	return c.spr.GetScaleX(), c.spr.GetScaleY()
+}
+
+// SetShader assigns a shader that is used to draw the offscreen image.
+// The offscreen image is passed as the shader's first image.
+// A nil shader disables the shader rendering.
+func (c *Canvas) SetShader(shader *Shader) {
+	c.spr.Shader = shader
+}
+
+// GetShader returns the shader assigned by SetShader.
+func (c *Canvas) GetShader() *Shader {
+	return c.spr.Shader
+}
+
+// SetPostProcessor assigns a post processor that draws the offscreen image.
+// It takes priority over the shader and the scaling settings:
+// the post processor is responsible for the entire image drawing.
+//
+// The DrawOptions passed to the post processor contain the resolved
+// canvas position and rotation.
+// A nil value disables the post processing.
+func (c *Canvas) SetPostProcessor(pp PostProcessor) {
+	c.pp = pp
+}
+
 func (c *Canvas) IsDisposed() bool {
 	return c.container.IsDisposed()
 }
 
+// Dispose disposes all children and releases
+// the offscreen image allocated by SetSize.
 func (c *Canvas) Dispose() {
 	c.container.Dispose()
+	c.releaseImage()
 }
 
 func (c *Canvas) IsVisible() bool {
@@ -66,14 +150,26 @@ func (c *Canvas) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
 		return
 	}
 
-	c.spr.GetImage().Clear()
-	c.container.Draw(c.spr.GetImage())
+	img := c.spr.GetImage()
+	if img == nil {
+		return
+	}
+	clearImage(img)
+	c.container.Draw(img)
 
 	if !c.offscreen {
 		opts.Offset = opts.Offset.Add(c.Pos.Resolve())
 		if c.Rotation != nil {
 			opts.Rotation += *c.Rotation
 		}
+		if c.pp != nil {
+			c.pp.PostProcess(dst, img, opts)
+			return
+		}
 		c.spr.DrawWithOptions(dst, opts)
 	}
 }
+
+func (c *Canvas) inspectChildren() []DisposableObject {
+	return c.container.objects
+}
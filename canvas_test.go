@@ -0,0 +1,54 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+type testPostProcessor struct {
+	src  *ebiten.Image
+	opts DrawOptions
+}
+
+func (pp *testPostProcessor) PostProcess(dst, src *ebiten.Image, opts DrawOptions) {
+	pp.src = src
+	pp.opts = opts
+}
+
+func TestCanvasPostProcessor(t *testing.T) {
+	c := NewCanvas()
+	if c.GetDstImage() != nil {
+		t.Fatal("a new canvas should have no image")
+	}
+	c.Draw(ebiten.NewImage(8, 8)) // A no-op without an image
+
+	c.SetSize(32, 16)
+	img := c.GetDstImage()
+	if img == nil || img.Bounds().Dx() != 32 || img.Bounds().Dy() != 16 {
+		t.Fatal("SetSize didn't allocate the image")
+	}
+	c.SetSize(32, 16)
+	if c.GetDstImage() != img {
+		t.Fatal("SetSize with the same size should keep the image")
+	}
+
+	pp := &testPostProcessor{}
+	c.SetPostProcessor(pp)
+	c.Pos.Offset = gmath.Vec{X: 10, Y: 5}
+	c.AddChild(NewRect(4, 4))
+	c.DrawWithOptions(ebiten.NewImage(64, 64), DrawOptions{Offset: gmath.Vec{X: 1}})
+
+	if pp.src != img {
+		t.Fatal("the post processor received an unexpected src image")
+	}
+	if want := (gmath.Vec{X: 11, Y: 5}); pp.opts.Offset != want {
+		t.Fatalf("post processor offset: have %v, want %v", pp.opts.Offset, want)
+	}
+
+	c.SetScale(2, 3)
+	if x, y := c.GetScale(); x != 2 || y != 3 {
+		t.Fatalf("GetScale: have %v, %v, want 2, 3", x, y)
+	}
+}
@@ -0,0 +1,361 @@
+package graphics
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// CardTemplate describes the [CardVisual] layout.
+// A single template is usually shared by all cards of one kind.
+//
+// All rects are relative to the card top-left corner.
+type CardTemplate struct {
+	// Width and Height are the card sizes.
+	// Zero values mean the Frame image sizes.
+	Width  float64
+	Height float64
+
+	// Frame is an optional card frame image.
+	// It's rendered as a [NineSlice] using the FrameInsets.
+	Frame       *ebiten.Image
+	FrameInsets NineSliceInsets
+
+	// ArtRect is a card illustration area.
+	// The art image is scaled to fit it.
+	ArtRect gmath.Rect
+
+	// TitleRect is a card title area.
+	// The title is centered inside of it.
+	TitleRect gmath.Rect
+	TitleFace text.Face
+
+	// BodyRect is a card description area.
+	// The text is word-wrapped to fit its width.
+	BodyRect gmath.Rect
+	BodyFace text.Face
+
+	// BadgeSlots are the cost badge center positions.
+	// Use CardVisual.SetBadge to assign the badge values.
+	BadgeSlots []gmath.Vec
+
+	// BadgeImage is an optional badge background image.
+	BadgeImage *ebiten.Image
+	BadgeFace  text.Face
+
+	// BadgeSize is a badge background size.
+	// A zero value means 20.
+	BadgeSize float64
+
+	// HoverLift is a vertical offset of the hovered card.
+	// A zero value means 8.
+	HoverLift float64
+
+	// HoverTilt is a rotation of the hovered card.
+	// A zero value means no tilt.
+	HoverTilt gmath.Rad
+
+	// HoverDuration is a hover animation duration, in seconds.
+	// A zero value means 0.15.
+	HoverDuration float64
+}
+
+// CardVisual is a card-game style card composed of a frame,
+// an illustration, title and body texts and the cost badges.
+//
+// The card parts are pre-rendered into an offscreen image that is
+// re-rendered only after the card contents change.
+// This makes the hover animations (the lift and the tilt) cheap
+// and lets the entire card rotate as a single image.
+//
+// The Pos is a card top-left corner.
+// Its Update method should be called every frame to animate the card.
+//
+// CardVisual implements gscene Graphics interface.
+type CardVisual struct {
+	Pos gmath.Pos
+
+	template CardTemplate
+
+	frame  *NineSlice
+	art    *Sprite
+	title  *Label
+	body   *Label
+	badges []cardBadge
+
+	img *ebiten.Image
+
+	// hover is a hover animation progress in [0, 1] range.
+	hover float64
+
+	colorScale       ColorScale
+	ebitenColorScale ebiten.ColorScale
+
+	visible  bool
+	disposed bool
+	dirty    bool
+	hovered  bool
+}
+
+type cardBadge struct {
+	bg    *Sprite
+	label *Label
+}
+
+// NewCardVisual creates a card with the specified template.
+// The template is copied, so it can be re-used for other cards.
+func NewCardVisual(t *CardTemplate) *CardVisual {
+	template := *t
+	if template.Width == 0 && template.Frame != nil {
+		template.Width = float64(template.Frame.Bounds().Dx())
+	}
+	if template.Height == 0 && template.Frame != nil {
+		template.Height = float64(template.Frame.Bounds().Dy())
+	}
+	if template.Width <= 0 || template.Height <= 0 {
+		panic("CardTemplate size can't be zero")
+	}
+	if template.BadgeSize == 0 {
+		template.BadgeSize = 20
+	}
+	if template.HoverLift == 0 {
+		template.HoverLift = 8
+	}
+	if template.HoverDuration == 0 {
+		template.HoverDuration = 0.15
+	}
+
+	c := &CardVisual{
+		template:         template,
+		colorScale:       defaultColorScale,
+		ebitenColorScale: defaultColorScale.ToEbitenColorScale(),
+		visible:          true,
+		dirty:            true,
+	}
+
+	if template.Frame != nil {
+		c.frame = NewNineSlice(template.Frame, template.FrameInsets)
+		c.frame.SetSize(template.Width, template.Height)
+	}
+
+	c.art = NewSprite()
+	c.art.SetCentered(false)
+	c.art.Pos.Offset = template.ArtRect.Min
+
+	c.title = NewLabel(template.TitleFace)
+	c.title.Pos.Offset = template.TitleRect.Min
+	c.title.SetSize(int(template.TitleRect.Width()), int(template.TitleRect.Height()))
+	c.title.SetAlignHorizontal(AlignHorizontalCenter)
+	c.title.SetAlignVertical(AlignVerticalCenter)
+
+	c.body = NewLabel(template.BodyFace)
+	c.body.Pos.Offset = template.BodyRect.Min
+	c.body.SetSize(int(template.BodyRect.Width()), int(template.BodyRect.Height()))
+	c.body.SetWordWrap(template.BodyRect.Width())
+
+	c.badges = make([]cardBadge, len(template.BadgeSlots))
+	for i, slot := range template.BadgeSlots {
+		b := &c.badges[i]
+		if template.BadgeImage != nil {
+			b.bg = NewSprite()
+			b.bg.SetImage(template.BadgeImage)
+			bounds := template.BadgeImage.Bounds()
+			b.bg.SetScaleX(template.BadgeSize / float64(bounds.Dx()))
+			b.bg.SetScaleY(template.BadgeSize / float64(bounds.Dy()))
+			b.bg.Pos.Offset = slot
+			b.bg.SetVisibility(false)
+		}
+		b.label = NewLabel(template.BadgeFace)
+		size := int(template.BadgeSize)
+		b.label.SetSize(size, size)
+		b.label.SetAlignHorizontal(AlignHorizontalCenter)
+		b.label.SetAlignVertical(AlignVerticalCenter)
+		b.label.Pos.Offset = slot.Sub(gmath.Vec{X: float64(size) * 0.5, Y: float64(size) * 0.5})
+	}
+
+	return c
+}
+
+// SetArt changes the card illustration.
+// The image is scaled to fit the template ArtRect.
+func (c *CardVisual) SetArt(img *ebiten.Image) {
+	c.art.SetImage(img)
+	bounds := img.Bounds()
+	c.art.SetScaleX(c.template.ArtRect.Width() / float64(bounds.Dx()))
+	c.art.SetScaleY(c.template.ArtRect.Height() / float64(bounds.Dy()))
+	c.dirty = true
+}
+
+// SetTitle changes the card title text.
+func (c *CardVisual) SetTitle(s string) {
+	c.title.SetText(s)
+	c.dirty = true
+}
+
+// SetBody changes the card description text.
+func (c *CardVisual) SetBody(s string) {
+	c.body.SetText(s)
+	c.dirty = true
+}
+
+// SetBadge changes the badge text of the specified slot.
+// An empty text hides the badge.
+//
+// The slot should be a valid template BadgeSlots index.
+func (c *CardVisual) SetBadge(slot int, s string) {
+	b := &c.badges[slot]
+	b.label.SetText(s)
+	if b.bg != nil {
+		b.bg.SetVisibility(s != "")
+	}
+	c.dirty = true
+}
+
+// SetBadgeColorScale changes the badge colors of the specified slot.
+// It's useful to highlight the unaffordable costs.
+func (c *CardVisual) SetBadgeColorScale(slot int, cs ColorScale) {
+	b := &c.badges[slot]
+	b.label.SetColorScale(cs)
+	if b.bg != nil {
+		b.bg.SetColorScale(cs)
+	}
+	c.dirty = true
+}
+
+// GetColorScale returns the card color scale.
+func (c *CardVisual) GetColorScale() ColorScale {
+	return c.colorScale
+}
+
+// SetColorScale changes the entire card tint.
+// It doesn't cause the card re-rendering.
+func (c *CardVisual) SetColorScale(cs ColorScale) {
+	c.colorScale = cs
+	c.ebitenColorScale = cs.ToEbitenColorScale()
+}
+
+// IsHovered reports whether the card is in the hovered state.
+func (c *CardVisual) IsHovered() bool { return c.hovered }
+
+// SetHovered changes the card hovered state.
+// The card is animated towards the new state during the Update calls.
+func (c *CardVisual) SetHovered(hovered bool) { c.hovered = hovered }
+
+// Update advances the hover animation.
+// delta is a time passed since the last Update call, in seconds.
+func (c *CardVisual) Update(delta float64) {
+	step := delta / c.template.HoverDuration
+	if c.hovered {
+		c.hover = min(1, c.hover+step)
+	} else {
+		c.hover = max(0, c.hover-step)
+	}
+}
+
+// BoundsRect returns the card rect (without the hover lift).
+func (c *CardVisual) BoundsRect() gmath.Rect {
+	pos := c.Pos.Resolve()
+	return gmath.Rect{
+		Min: pos,
+		Max: pos.Add(gmath.Vec{X: c.template.Width, Y: c.template.Height}),
+	}
+}
+
+// Dispose marks this card for deletion and releases its offscreen image.
+// After calling this method, IsDisposed will report true.
+func (c *CardVisual) Dispose() {
+	if c.img != nil {
+		c.img.Deallocate()
+		c.img = nil
+	}
+	c.disposed = true
+}
+
+// IsDisposed reports whether this card is marked for deletion.
+func (c *CardVisual) IsDisposed() bool { return c.disposed }
+
+// IsVisible reports whether this card is visible.
+// Use SetVisibility to change this flag value.
+func (c *CardVisual) IsVisible() bool { return c.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (c *CardVisual) SetVisibility(visible bool) { c.visible = visible }
+
+// Draw renders the card onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (c *CardVisual) Draw(dst *ebiten.Image) {
+	c.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the card onto the provided dst image
+// while also using the extra provided offset.
+func (c *CardVisual) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !c.visible || c.disposed {
+		return
+	}
+
+	if c.dirty {
+		c.dirty = false
+		c.render()
+	}
+
+	// The hover animation is eased out.
+	k := 1 - (1-c.hover)*(1-c.hover)
+
+	w := c.template.Width
+	h := c.template.Height
+	pos := c.Pos.Resolve().Add(opts.Offset)
+	pos.Y -= c.template.HoverLift * k
+
+	var drawOptions ebiten.DrawImageOptions
+	if opts.Blend != nil {
+		drawOptions.Blend = *opts.Blend
+	}
+	drawOptions.ColorScale = c.ebitenColorScale
+	rotation := float64(opts.Rotation) + float64(c.template.HoverTilt)*k
+	if rotation != 0 {
+		// The card is rotated around its center.
+		drawOptions.Filter = ebiten.FilterLinear
+		drawOptions.GeoM.Translate(-w*0.5, -h*0.5)
+		drawOptions.GeoM.Rotate(rotation)
+		drawOptions.GeoM.Translate(w*0.5, h*0.5)
+	}
+	drawOptions.GeoM.Translate(math.Round(pos.X), math.Round(pos.Y))
+	drawImage(dst, c.img, &drawOptions)
+}
+
+func (c *CardVisual) render() {
+	if c.img == nil {
+		c.img = ebiten.NewImage(int(math.Ceil(c.template.Width)), int(math.Ceil(c.template.Height)))
+	} else {
+		clearImage(c.img)
+	}
+
+	if c.frame != nil {
+		c.frame.Draw(c.img)
+	}
+	if c.art.GetImage() != nil {
+		c.art.Draw(c.img)
+	}
+	if c.template.TitleFace != nil {
+		c.title.Draw(c.img)
+	}
+	if c.template.BodyFace != nil {
+		c.body.Draw(c.img)
+	}
+	for _, b := range c.badges {
+		if b.bg != nil {
+			b.bg.Draw(c.img)
+		}
+		if c.template.BadgeFace != nil {
+			b.label.Draw(c.img)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/quasilyte/gmath"
+	"golang.org/x/image/font/basicfont"
+)
+
+func TestCardVisual(t *testing.T) {
+	face := text.NewGoXFace(basicfont.Face7x13)
+	template := &CardTemplate{
+		Frame:      ebiten.NewImage(60, 90),
+		ArtRect:    gmath.Rect{Min: gmath.Vec{X: 5, Y: 20}, Max: gmath.Vec{X: 55, Y: 50}},
+		TitleRect:  gmath.Rect{Min: gmath.Vec{X: 5, Y: 5}, Max: gmath.Vec{X: 55, Y: 18}},
+		TitleFace:  face,
+		BodyRect:   gmath.Rect{Min: gmath.Vec{X: 5, Y: 55}, Max: gmath.Vec{X: 55, Y: 85}},
+		BodyFace:   face,
+		BadgeSlots: []gmath.Vec{{X: 5, Y: 5}},
+		BadgeFace:  face,
+		HoverTilt:  0.1,
+	}
+	c := NewCardVisual(template)
+	if template.HoverLift != 0 {
+		t.Fatal("the template should not be modified")
+	}
+	if have := c.BoundsRect().Size(); have != (gmath.Vec{X: 60, Y: 90}) {
+		t.Fatalf("size: have %v, want the frame size", have)
+	}
+
+	c.SetTitle("Fireball")
+	c.SetBody("Deal 6 damage to all enemies.")
+	c.SetArt(ebiten.NewImage(100, 60))
+	c.SetBadge(0, "3")
+
+	dst := ebiten.NewImage(128, 128)
+	c.Draw(dst)
+	if c.dirty {
+		t.Fatal("the card should be rendered after Draw")
+	}
+
+	c.SetHovered(true)
+	c.Update(0.1)
+	c.Update(0.1)
+	if c.hover != 1 {
+		t.Fatalf("hover progress: have %v, want 1", c.hover)
+	}
+	img := c.img
+	c.Draw(dst)
+	if c.img != img {
+		t.Fatal("the hover animation should not re-render the card")
+	}
+
+	c.SetHovered(false)
+	c.Update(1)
+	if c.hover != 0 {
+		t.Fatalf("hover progress: have %v, want 0", c.hover)
+	}
+}
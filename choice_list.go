@@ -0,0 +1,311 @@
+package graphics
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// ChoiceListConfig describes the [ChoiceList] appearance.
+type ChoiceListConfig struct {
+	// Face is used to render the option texts.
+	// This field is required.
+	Face text.Face
+
+	// Cursor is an optional highlight cursor image.
+	// It's rendered to the left of the highlighted option.
+	Cursor *ebiten.Image
+
+	// CursorSpacing is a distance between the cursor and the option text.
+	// A zero value means 4.
+	CursorSpacing float64
+
+	// LineSpacing is a vertical distance between the options.
+	// A zero value means 2.
+	LineSpacing float64
+
+	// Padding is a highlight rect extra size around the option text.
+	// A zero value means 2.
+	Padding float64
+
+	// ColorScale is a default option text color.
+	// A zero value means {1, 1, 1, 1}.
+	ColorScale ColorScale
+
+	// HighlightColorScale is a highlighted option text color.
+	// A zero value means {1, 1, 0.4, 1}.
+	HighlightColorScale ColorScale
+
+	// HighlightBackgroundColorScale is a highlighted option background rect color.
+	// A zero value means {1, 1, 1, 0.2}.
+	HighlightBackgroundColorScale ColorScale
+
+	// DisabledColorScale is a disabled option text color.
+	// A zero value means {0.5, 0.5, 0.5, 1}.
+	DisabledColorScale ColorScale
+}
+
+// ChoiceList is a vertical list of the selectable options,
+// like the dialogue choices or a simple menu.
+//
+// The list doesn't handle any input: the highlighted option index
+// is controlled by the caller via SetHighlight and MoveHighlight
+// (the latter is convenient for the keyboard and gamepad handling).
+// The disabled options are rendered with a different color
+// and they're skipped by MoveHighlight.
+//
+// The list is a screen-space widget: its Pos field is a top-left corner
+// of its bounds rect, so it should be added to a [StaticLayer].
+//
+// ChoiceList implements gscene Graphics interface.
+type ChoiceList struct {
+	// Pos is a list top-left corner location binder.
+	// See Pos documentation to learn how it works.
+	Pos gmath.Pos
+
+	config ChoiceListConfig
+
+	lineHeight float64
+
+	options []choiceListOption
+
+	highlight int
+
+	ebitenColorScale          ebiten.ColorScale
+	ebitenHighlightColorScale ebiten.ColorScale
+	ebitenBackgroundScale     ebiten.ColorScale
+	ebitenDisabledColorScale  ebiten.ColorScale
+
+	visible  bool
+	disposed bool
+}
+
+type choiceListOption struct {
+	text     string
+	width    float64
+	disabled bool
+}
+
+// NewChoiceList creates an empty list with the specified config.
+func NewChoiceList(config ChoiceListConfig) *ChoiceList {
+	if config.Face == nil {
+		panic("ChoiceListConfig.Face is required")
+	}
+	if config.CursorSpacing == 0 {
+		config.CursorSpacing = 4
+	}
+	if config.LineSpacing == 0 {
+		config.LineSpacing = 2
+	}
+	if config.Padding == 0 {
+		config.Padding = 2
+	}
+	if config.ColorScale == (ColorScale{}) {
+		config.ColorScale = defaultColorScale
+	}
+	if config.HighlightColorScale == (ColorScale{}) {
+		config.HighlightColorScale = ColorScale{R: 1, G: 1, B: 0.4, A: 1}
+	}
+	if config.HighlightBackgroundColorScale == (ColorScale{}) {
+		config.HighlightBackgroundColorScale = ColorScale{R: 1, G: 1, B: 1, A: 0.2}
+	}
+	if config.DisabledColorScale == (ColorScale{}) {
+		config.DisabledColorScale = ColorScale{R: 0.5, G: 0.5, B: 0.5, A: 1}
+	}
+	return &ChoiceList{
+		config:                    config,
+		lineHeight:                GetFontMetrics(config.Face).LineHeight,
+		ebitenColorScale:          config.ColorScale.ToEbitenColorScale(),
+		ebitenHighlightColorScale: config.HighlightColorScale.ToEbitenColorScale(),
+		ebitenBackgroundScale:     config.HighlightBackgroundColorScale.ToEbitenColorScale(),
+		ebitenDisabledColorScale:  config.DisabledColorScale.ToEbitenColorScale(),
+		visible:                   true,
+	}
+}
+
+// AddOption appends a new option to the bottom of the list.
+// It returns the added option index.
+func (l *ChoiceList) AddOption(s string) int {
+	w, _ := text.Measure(s, l.config.Face, 0)
+	l.options = append(l.options, choiceListOption{text: s, width: w})
+	return len(l.options) - 1
+}
+
+// Clear removes all options from the list.
+// The highlight index is reset to 0.
+func (l *ChoiceList) Clear() {
+	l.options = l.options[:0]
+	l.highlight = 0
+}
+
+// Len reports the number of options inside the list.
+func (l *ChoiceList) Len() int { return len(l.options) }
+
+// IsDisabled reports whether the option at index i is disabled.
+func (l *ChoiceList) IsDisabled(i int) bool { return l.options[i].disabled }
+
+// SetDisabled changes the option at index i disabled state.
+func (l *ChoiceList) SetDisabled(i int, disabled bool) {
+	l.options[i].disabled = disabled
+}
+
+// GetHighlight returns the highlighted option index.
+// Use SetHighlight or MoveHighlight to change it.
+func (l *ChoiceList) GetHighlight() int { return l.highlight }
+
+// SetHighlight changes the highlighted option index.
+// The index can point to a disabled option.
+// A negative index removes the highlight.
+func (l *ChoiceList) SetHighlight(i int) { l.highlight = i }
+
+// MoveHighlight moves the highlight by the specified number of options
+// (usually -1 for up and +1 for down) while skipping the disabled options.
+// The movement wraps around the list ends.
+//
+// If there are no enabled options, the highlight doesn't change.
+func (l *ChoiceList) MoveHighlight(delta int) {
+	n := len(l.options)
+	if n == 0 || delta == 0 {
+		return
+	}
+	step := 1
+	if delta < 0 {
+		step = -1
+		delta = -delta
+	}
+	i := max(l.highlight, 0)
+	for ; delta > 0; delta-- {
+		next, ok := l.nextEnabled(i, step)
+		if !ok {
+			return
+		}
+		i = next
+	}
+	l.highlight = i
+}
+
+func (l *ChoiceList) nextEnabled(i, step int) (int, bool) {
+	n := len(l.options)
+	for range n {
+		i = ((i+step)%n + n) % n
+		if !l.options[i].disabled {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// BoundsRect returns the list bounding rectangle.
+func (l *ChoiceList) BoundsRect() gmath.Rect {
+	pos := l.Pos.Resolve()
+	width := 0.0
+	for _, o := range l.options {
+		width = max(width, o.width)
+	}
+	return gmath.Rect{
+		Min: pos,
+		Max: pos.Add(gmath.Vec{X: l.textOffset() + width, Y: l.contentHeight()}),
+	}
+}
+
+// textOffset returns the option texts horizontal offset
+// that leaves the space for the cursor.
+func (l *ChoiceList) textOffset() float64 {
+	if l.config.Cursor == nil {
+		return 0
+	}
+	return float64(l.config.Cursor.Bounds().Dx()) + l.config.CursorSpacing
+}
+
+func (l *ChoiceList) contentHeight() float64 {
+	n := len(l.options)
+	if n == 0 {
+		return 0
+	}
+	return float64(n)*l.lineHeight + float64(n-1)*l.config.LineSpacing
+}
+
+// Dispose marks this list for deletion.
+// After calling this method, IsDisposed will report true.
+func (l *ChoiceList) Dispose() { l.disposed = true }
+
+// IsDisposed reports whether this list is marked for deletion.
+func (l *ChoiceList) IsDisposed() bool { return l.disposed }
+
+// IsVisible reports whether this list is visible.
+// Use SetVisibility to change this flag value.
+func (l *ChoiceList) IsVisible() bool { return l.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (l *ChoiceList) SetVisibility(visible bool) { l.visible = visible }
+
+// Draw renders the list onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (l *ChoiceList) Draw(dst *ebiten.Image) {
+	l.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the list onto the provided dst image
+// while also using the extra provided offset.
+func (l *ChoiceList) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !l.visible || len(l.options) == 0 {
+		return
+	}
+
+	pos := l.Pos.Resolve().Add(opts.Offset)
+	textX := pos.X + l.textOffset()
+
+	var imageOptions ebiten.DrawImageOptions
+	var textOptions text.DrawOptions
+	if opts.Blend != nil {
+		imageOptions.Blend = *opts.Blend
+		textOptions.Blend = *opts.Blend
+	}
+	textOptions.SecondaryAlign = text.AlignCenter
+
+	for i, o := range l.options {
+		y := pos.Y + float64(i)*(l.lineHeight+l.config.LineSpacing)
+		centerY := y + l.lineHeight*0.5
+
+		highlighted := i == l.highlight
+		if highlighted {
+			if l.config.HighlightBackgroundColorScale.A != 0 {
+				padding := l.config.Padding
+				imageOptions.GeoM.Reset()
+				imageOptions.GeoM.Scale(o.width+padding*2, l.lineHeight+padding*2)
+				imageOptions.GeoM.Translate(textX-padding, y-padding)
+				imageOptions.ColorScale = l.ebitenBackgroundScale
+				drawImage(dst, whitePixel, &imageOptions)
+			}
+			if l.config.Cursor != nil {
+				bounds := l.config.Cursor.Bounds()
+				imageOptions.GeoM.Reset()
+				imageOptions.GeoM.Translate(math.Round(pos.X), math.Round(centerY-float64(bounds.Dy())*0.5))
+				imageOptions.ColorScale.Reset()
+				if o.disabled {
+					imageOptions.ColorScale = l.ebitenDisabledColorScale
+				}
+				drawImage(dst, l.config.Cursor, &imageOptions)
+			}
+		}
+
+		switch {
+		case o.disabled:
+			textOptions.ColorScale = l.ebitenDisabledColorScale
+		case highlighted:
+			textOptions.ColorScale = l.ebitenHighlightColorScale
+		default:
+			textOptions.ColorScale = l.ebitenColorScale
+		}
+		textOptions.GeoM.Reset()
+		textOptions.GeoM.Translate(math.Round(textX), math.Round(centerY))
+		drawText(dst, o.text, l.config.Face, &textOptions)
+	}
+}
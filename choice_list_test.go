@@ -0,0 +1,45 @@
+package graphics_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	graphics "github.com/quasilyte/ebitengine-graphics"
+	"golang.org/x/image/font/basicfont"
+)
+
+func TestChoiceListMoveHighlight(t *testing.T) {
+	l := graphics.NewChoiceList(graphics.ChoiceListConfig{
+		Face: text.NewGoXFace(basicfont.Face7x13),
+	})
+	for _, s := range []string{"a", "b", "c", "d"} {
+		l.AddOption(s)
+	}
+	l.SetDisabled(1, true)
+
+	steps := []struct {
+		delta int
+		want  int
+	}{
+		{1, 2},
+		{1, 3},
+		{1, 0},
+		{-1, 3},
+		{-2, 0},
+		{2, 3},
+	}
+	for i, step := range steps {
+		l.MoveHighlight(step.delta)
+		if have := l.GetHighlight(); have != step.want {
+			t.Fatalf("step %d (delta=%d):\nhave: %d\nwant: %d", i, step.delta, have, step.want)
+		}
+	}
+
+	for i := 0; i < l.Len(); i++ {
+		l.SetDisabled(i, true)
+	}
+	l.MoveHighlight(1)
+	if have := l.GetHighlight(); have != 3 {
+		t.Fatalf("all disabled:\nhave: %d\nwant: 3", have)
+	}
+}
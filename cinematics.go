@@ -0,0 +1,320 @@
+package graphics
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+	"github.com/quasilyte/gmath"
+)
+
+// CinematicsConfig describes the [Cinematics] appearance.
+type CinematicsConfig struct {
+	// Width and Height are the screen sizes.
+	// Zero values mean the window sizes.
+	Width  float64
+	Height float64
+
+	// BarHeight is a letterbox bar height.
+	// A zero value means 1/8 of the screen height.
+	BarHeight float64
+
+	// BarDuration is a letterbox bars animation duration, in seconds.
+	// A zero value means 0.5.
+	BarDuration float64
+
+	// BarColorScale is a letterbox bars color.
+	// A zero value means black.
+	BarColorScale ColorScale
+
+	// FadeColorScale is a screen fade overlay color.
+	// A zero value means black.
+	FadeColorScale ColorScale
+
+	// TitleFace is used to render the titles (see AddTitle).
+	// A nil face disables the titles.
+	TitleFace text.Face
+
+	// TitleFadeDuration is a title fade in and fade out duration, in seconds.
+	// A zero value means 0.3.
+	TitleFadeDuration float64
+}
+
+// CameraKeyframe is a [Cinematics] camera path point.
+type CameraKeyframe struct {
+	// Time is a keyframe time relative to the path start, in seconds.
+	Time float64
+
+	// Pos is a camera center position (in world coordinates).
+	Pos gmath.Vec
+
+	// Easing is used to move the camera from the previous keyframe to this one.
+	// A nil value means [EaseInOutQuad].
+	Easing Easing
+}
+
+// Cinematics is a cutscene helper that plays a [Timeline] of cues:
+// the letterbox bars, the camera paths, the screen fades and the titles.
+//
+// The cues are scheduled with the Add* methods before the Play call;
+// every cue has its start time relative to the timeline start.
+// AddCue can be used to synchronize the game logic with the cutscene
+// (e.g. to start a character animation or a sound).
+//
+// The bars, fades and titles are rendered in the screen coordinates,
+// so the object should be added to a [StaticLayer].
+// Its Update method should be called every frame.
+//
+// Cinematics implements gscene Graphics interface.
+type Cinematics struct {
+	config CinematicsConfig
+
+	timeline Timeline
+
+	title     *Label
+	titleText string
+
+	// bars is a letterbox bars animation progress in [0, 1] range.
+	bars       float64
+	barsTarget float64
+
+	fadeAlpha float32
+
+	visible  bool
+	disposed bool
+}
+
+// NewCinematics creates an empty timeline with the specified config.
+//
+// It's advised to only call this function after Ebitengine game has already started.
+func NewCinematics(config CinematicsConfig) *Cinematics {
+	if config.Width == 0 || config.Height == 0 {
+		w, h := ebiten.WindowSize()
+		config.Width = float64(w)
+		config.Height = float64(h)
+	}
+	if config.BarHeight == 0 {
+		config.BarHeight = math.Round(config.Height / 8)
+	}
+	if config.BarDuration == 0 {
+		config.BarDuration = 0.5
+	}
+	config.BarColorScale.A = 1
+	config.FadeColorScale.A = 1
+	if config.TitleFadeDuration == 0 {
+		config.TitleFadeDuration = 0.3
+	}
+
+	c := &Cinematics{
+		config:  config,
+		visible: true,
+	}
+	if config.TitleFace != nil {
+		c.title = NewLabel(config.TitleFace)
+		c.title.SetSize(int(config.Width), int(config.Height))
+		c.title.SetAlignHorizontal(AlignHorizontalCenter)
+		c.title.SetAlignVertical(AlignVerticalCenter)
+		c.title.SetVisibility(false)
+	}
+	return c
+}
+
+// AddCue schedules a function call at the specified time.
+func (c *Cinematics) AddCue(at float64, f func()) {
+	c.timeline.AddAction(at, f)
+}
+
+// AddBars schedules the letterbox bars to slide in (show=true) or out.
+// The animation takes the config BarDuration seconds.
+func (c *Cinematics) AddBars(at float64, show bool) {
+	target := 0.0
+	if show {
+		target = 1
+	}
+	// The span makes the bars animation a part of the timeline duration.
+	c.timeline.AddSpan(at, c.config.BarDuration, func(float64) { c.barsTarget = target })
+}
+
+// AddFade schedules a screen fade overlay alpha change.
+// Use from=0 and to=1 to fade out to the FadeColorScale and
+// from=1 and to=0 to fade in.
+func (c *Cinematics) AddFade(at, duration float64, from, to float32) {
+	c.timeline.AddSpan(at, duration, func(t float64) {
+		c.fadeAlpha = gmath.Lerp(from, to, float32(t))
+	})
+}
+
+// AddTitle schedules a centered title text that is visible for
+// the specified number of seconds (including its fade in and fade out).
+//
+// It panics if the config TitleFace is nil.
+func (c *Cinematics) AddTitle(at, duration float64, s string) {
+	if c.title == nil {
+		panic("CinematicsConfig.TitleFace is nil")
+	}
+	fade := min(c.config.TitleFadeDuration, duration*0.5)
+	c.timeline.AddSpan(at, duration, func(t float64) {
+		if t == 1 {
+			c.title.SetVisibility(false)
+			return
+		}
+		if c.titleText != s {
+			c.titleText = s
+			c.title.SetText(s)
+		}
+		c.title.SetVisibility(true)
+		elapsed := t * duration
+		alpha := 1.0
+		if fade > 0 {
+			alpha = min(elapsed/fade, (duration-elapsed)/fade, 1)
+		}
+		c.title.SetAlpha(float32(alpha))
+	})
+}
+
+// AddCameraPath schedules a camera movement along the keyframes.
+// The keyframe times are relative to the at value.
+//
+// The camera is moved using SetCenterOffset,
+// so the camera bounds are respected.
+func (c *Cinematics) AddCameraPath(at float64, camera *Camera, keyframes []CameraKeyframe) {
+	if len(keyframes) == 0 {
+		return
+	}
+	keys := make([]CameraKeyframe, len(keyframes))
+	copy(keys, keyframes)
+	duration := keys[len(keys)-1].Time
+	c.timeline.AddSpan(at, duration, func(t float64) {
+		camera.SetCenterOffset(cameraPathPos(keys, t*duration))
+	})
+}
+
+// cameraPathPos returns the eased keyframes position at time t.
+func cameraPathPos(keys []CameraKeyframe, t float64) gmath.Vec {
+	if t <= keys[0].Time {
+		return keys[0].Pos
+	}
+	for i := 1; i < len(keys); i++ {
+		to := keys[i]
+		if t > to.Time {
+			continue
+		}
+		from := keys[i-1]
+		easing := to.Easing
+		if easing == nil {
+			easing = EaseInOutQuad
+		}
+		k := 1.0
+		if span := to.Time - from.Time; span > 0 {
+			k = easing((t - from.Time) / span)
+		}
+		return from.Pos.LinearInterpolate(to.Pos, k)
+	}
+	return keys[len(keys)-1].Pos
+}
+
+// OnComplete assigns a function that is called after the timeline is finished.
+func (c *Cinematics) OnComplete(f func()) { c.timeline.OnComplete(f) }
+
+// GetDuration returns the timeline length, in seconds.
+func (c *Cinematics) GetDuration() float64 { return c.timeline.GetDuration() }
+
+// GetTime returns the current timeline position, in seconds.
+func (c *Cinematics) GetTime() float64 { return c.timeline.GetTime() }
+
+// IsPlaying reports whether the timeline is being played.
+func (c *Cinematics) IsPlaying() bool { return c.timeline.IsPlaying() }
+
+// Play starts the timeline from the beginning.
+func (c *Cinematics) Play() { c.timeline.Play() }
+
+// Skip jumps to the end of the timeline.
+// All pending cues are applied in their final states,
+// so the camera ends up at its last keyframe position.
+func (c *Cinematics) Skip() {
+	if !c.timeline.IsPlaying() {
+		return
+	}
+	c.timeline.Seek(c.timeline.GetDuration())
+	c.bars = c.barsTarget
+}
+
+// Update advances the timeline and the bars animation.
+// delta is a time passed since the last Update call, in seconds.
+func (c *Cinematics) Update(delta float64) {
+	step := delta / c.config.BarDuration
+	if c.bars < c.barsTarget {
+		c.bars = min(c.barsTarget, c.bars+step)
+	} else {
+		c.bars = max(c.barsTarget, c.bars-step)
+	}
+
+	c.timeline.Update(delta)
+}
+
+// Dispose marks this object for deletion.
+// After calling this method, IsDisposed will report true.
+func (c *Cinematics) Dispose() { c.disposed = true }
+
+// IsDisposed reports whether this object is marked for deletion.
+func (c *Cinematics) IsDisposed() bool { return c.disposed }
+
+// IsVisible reports whether this object is visible.
+// Use SetVisibility to change this flag value.
+func (c *Cinematics) IsVisible() bool { return c.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (c *Cinematics) SetVisibility(visible bool) { c.visible = visible }
+
+// Draw renders the bars, the fade overlay and the title onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (c *Cinematics) Draw(dst *ebiten.Image) {
+	c.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the bars, the fade overlay and the title
+// onto the provided dst image while also using the extra provided offset.
+func (c *Cinematics) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !c.visible {
+		return
+	}
+
+	vertices := cache.Global.ScratchVertices[:0]
+	indices := cache.Global.ScratchIndices[:0]
+	defer func() {
+		cache.Global.ScratchVertices = vertices[:0]
+		cache.Global.ScratchIndices = indices[:0]
+	}()
+
+	pos := opts.Offset
+	w := c.config.Width
+	h := c.config.Height
+	if c.fadeAlpha > 0 {
+		cs := c.config.FadeColorScale.ToEbitenColorScale()
+		vertices, indices = appendRectQuad(vertices, indices, pos.X, pos.Y, w, h, cs, c.fadeAlpha)
+	}
+	if c.bars > 0 {
+		// The bars slide in from the screen edges.
+		barHeight := math.Round(c.config.BarHeight * EaseOutQuad(c.bars))
+		cs := c.config.BarColorScale.ToEbitenColorScale()
+		vertices, indices = appendRectQuad(vertices, indices, pos.X, pos.Y, w, barHeight, cs, 1)
+		vertices, indices = appendRectQuad(vertices, indices, pos.X, pos.Y+h-barHeight, w, barHeight, cs, 1)
+	}
+	if len(indices) != 0 {
+		var drawOptions ebiten.DrawTrianglesOptions
+		if opts.Blend != nil {
+			drawOptions.Blend = *opts.Blend
+		}
+		drawVertexColorTriangles(dst, vertices, indices, emptyImage, &drawOptions)
+	}
+
+	if c.title != nil {
+		c.title.DrawWithOptions(dst, opts)
+	}
+}
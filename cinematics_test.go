@@ -0,0 +1,87 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/quasilyte/gmath"
+)
+
+func TestCameraPathPos(t *testing.T) {
+	keys := []CameraKeyframe{
+		{Time: 0, Pos: gmath.Vec{X: 0, Y: 0}},
+		{Time: 1, Pos: gmath.Vec{X: 100, Y: 0}, Easing: EaseLinear},
+		{Time: 3, Pos: gmath.Vec{X: 100, Y: 200}, Easing: EaseLinear},
+	}
+	tests := []struct {
+		t    float64
+		want gmath.Vec
+	}{
+		{-1, gmath.Vec{X: 0, Y: 0}},
+		{0, gmath.Vec{X: 0, Y: 0}},
+		{0.5, gmath.Vec{X: 50, Y: 0}},
+		{1, gmath.Vec{X: 100, Y: 0}},
+		{2, gmath.Vec{X: 100, Y: 100}},
+		{3, gmath.Vec{X: 100, Y: 200}},
+		{10, gmath.Vec{X: 100, Y: 200}},
+	}
+	for _, test := range tests {
+		if have := cameraPathPos(keys, test.t); have != test.want {
+			t.Fatalf("pos(%v):\nhave: %v\nwant: %v", test.t, have, test.want)
+		}
+	}
+}
+
+func TestCinematicsTimeline(t *testing.T) {
+	c := NewCinematics(CinematicsConfig{
+		Width:       320,
+		Height:      240,
+		BarDuration: 1,
+	})
+
+	var events []string
+	c.AddCue(2, func() { events = append(events, "b") })
+	c.AddCue(0, func() { events = append(events, "a") })
+	c.AddBars(0, true)
+	c.AddFade(1, 2, 0, 1)
+	completed := false
+	c.OnComplete(func() { completed = true })
+
+	if have := c.GetDuration(); have != 3 {
+		t.Fatalf("duration: have %v, want 3", have)
+	}
+
+	c.Play()
+	if len(events) != 1 || events[0] != "a" {
+		t.Fatalf("events after Play: %v", events)
+	}
+
+	c.Update(0.5)
+	if !gmath.EqualApprox(c.bars, 0.5) {
+		t.Fatalf("bars: have %v, want 0.5", c.bars)
+	}
+	if c.fadeAlpha != 0 {
+		t.Fatalf("fade alpha: have %v, want 0", c.fadeAlpha)
+	}
+
+	c.Update(1.5)
+	if len(events) != 2 || events[1] != "b" {
+		t.Fatalf("events at t=2: %v", events)
+	}
+	if c.bars != 1 {
+		t.Fatalf("bars: have %v, want 1", c.bars)
+	}
+	if !gmath.EqualApprox(float64(c.fadeAlpha), 0.5) {
+		t.Fatalf("fade alpha: have %v, want 0.5", c.fadeAlpha)
+	}
+	if completed || !c.IsPlaying() {
+		t.Fatal("the timeline is finished too early")
+	}
+
+	c.Skip()
+	if c.fadeAlpha != 1 {
+		t.Fatalf("fade alpha after Skip: have %v, want 1", c.fadeAlpha)
+	}
+	if !completed || c.IsPlaying() {
+		t.Fatal("the timeline is not finished after Skip")
+	}
+}
@@ -24,6 +24,7 @@ type Circle struct {
 	centered bool
 	visible  bool
 	disposed bool
+	blendID  uint8
 }
 
 // NewCircle returns a circle of the specified radius.
@@ -187,6 +188,8 @@ func (c *Circle) Draw(dst *ebiten.Image) {
 }
 
 func (c *Circle) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	opts.Blend = resolveBlend(c.blendID, opts.Blend)
+
 	if !c.visible {
 		return
 	}
@@ -223,8 +226,27 @@ func (c *Circle) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
 	}
 	drawOptions.GeoM.Translate(pos.X, pos.Y)
 	if c.dashLength == 0 {
-		dst.DrawRectShader(int(width), int(width), cache.Global.CircleOutlineShader, &drawOptions)
+		drawRectShader(dst, int(width), int(width), cache.Global.CircleOutlineShader, &drawOptions)
 	} else {
-		dst.DrawRectShader(int(width), int(width), cache.Global.DashedCircleOutlineShader, &drawOptions)
+		drawRectShader(dst, int(width), int(width), cache.Global.DashedCircleOutlineShader, &drawOptions)
 	}
 }
+
+// GetBlend returns the blend mode assigned by SetBlend.
+// The second result value is false if there is no blend override.
+func (c *Circle) GetBlend() (ebiten.Blend, bool) {
+	return getBlend(c.blendID)
+}
+
+// SetBlend assigns a blend mode that is used to render this circle.
+// It takes priority over the DrawOptions.Blend value.
+// Use ResetBlend to remove the override.
+func (c *Circle) SetBlend(b ebiten.Blend) {
+	c.blendID = internBlend(b)
+}
+
+// ResetBlend removes the blend mode override.
+// The DrawOptions.Blend value (if any) will be used again.
+func (c *Circle) ResetBlend() {
+	c.blendID = 0
+}
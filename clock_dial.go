@@ -0,0 +1,193 @@
+package graphics
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// TimeOfDaySource is implemented by the day/night cycle controllers.
+// It can be used to drive a [ClockDial].
+type TimeOfDaySource interface {
+	// GetTimeOfDay returns a [0, 1) value, where 0 is midnight and 0.5 is noon.
+	GetTimeOfDay() float64
+}
+
+// ClockDialConfig describes the [ClockDial] appearance.
+type ClockDialConfig struct {
+	// Radius is a dial radius.
+	// A zero value means 24.
+	Radius float64
+
+	// SunIcon and MoonIcon are rendered on the dial's rim.
+	// The icons are centered around their positions.
+	// Nil values mean the simple filled circles.
+	SunIcon  *ebiten.Image
+	MoonIcon *ebiten.Image
+
+	// DayColorScale and NightColorScale are the sky colors.
+	// The dial background is interpolated between them.
+	// Zero values mean a light blue and a dark blue colors.
+	DayColorScale   ColorScale
+	NightColorScale ColorScale
+
+	// GroundColorScale is a color of the dial's lower half
+	// that hides the sun and the moon when they're below the horizon.
+	// A zero value means a dark green color.
+	GroundColorScale ColorScale
+
+	// Source is an optional time of day provider.
+	// If it's not nil, the SetTimeOfDay calls are ignored.
+	Source TimeOfDaySource
+}
+
+// ClockDial renders a time of day as a rotating sky dial.
+//
+// The sun is at the dial's top at noon and the moon is at the top at midnight.
+// The lower half of the dial is a ground that covers the celestial
+// bodies when they're below the horizon.
+//
+// The dial is a screen-space widget: its Pos field is a top-left corner
+// of its bounds rect, so it should be added to a [StaticLayer].
+//
+// ClockDial implements gscene Graphics interface.
+type ClockDial struct {
+	// Pos is a dial top-left corner location binder.
+	// See Pos documentation to learn how it works.
+	Pos gmath.Pos
+
+	config ClockDialConfig
+
+	groundColor ebiten.ColorScale
+
+	timeOfDay float64
+
+	visible  bool
+	disposed bool
+}
+
+// NewClockDial creates a dial with the specified config.
+// The initial time of day is 0.5 (noon).
+func NewClockDial(config ClockDialConfig) *ClockDial {
+	if config.Radius == 0 {
+		config.Radius = 24
+	}
+	if config.DayColorScale == (ColorScale{}) {
+		config.DayColorScale = ColorScale{R: 0.45, G: 0.7, B: 1, A: 1}
+	}
+	if config.NightColorScale == (ColorScale{}) {
+		config.NightColorScale = ColorScale{R: 0.05, G: 0.07, B: 0.25, A: 1}
+	}
+	if config.GroundColorScale == (ColorScale{}) {
+		config.GroundColorScale = ColorScale{R: 0.15, G: 0.3, B: 0.1, A: 1}
+	}
+	return &ClockDial{
+		config:      config,
+		groundColor: config.GroundColorScale.ToEbitenColorScale(),
+		timeOfDay:   0.5,
+		visible:     true,
+	}
+}
+
+// GetTimeOfDay returns the current time of day.
+// If the dial has a Source, its value is returned.
+func (d *ClockDial) GetTimeOfDay() float64 {
+	if d.config.Source != nil {
+		return wrapTimeOfDay(d.config.Source.GetTimeOfDay())
+	}
+	return d.timeOfDay
+}
+
+// SetTimeOfDay changes the displayed time of day.
+// The value is wrapped into [0, 1) range, so 1.25 becomes 0.25.
+//
+// It has no effect if the dial has a Source.
+func (d *ClockDial) SetTimeOfDay(t float64) { d.timeOfDay = wrapTimeOfDay(t) }
+
+func wrapTimeOfDay(t float64) float64 {
+	return t - math.Floor(t)
+}
+
+// BoundsRect returns the dial bounding rectangle.
+func (d *ClockDial) BoundsRect() gmath.Rect {
+	pos := d.Pos.Resolve()
+	size := 2 * d.config.Radius
+	return gmath.Rect{Min: pos, Max: pos.Add(gmath.Vec{X: size, Y: size})}
+}
+
+// Dispose marks this dial for deletion.
+// After calling this method, IsDisposed will report true.
+func (d *ClockDial) Dispose() { d.disposed = true }
+
+// IsDisposed reports whether this dial is marked for deletion.
+func (d *ClockDial) IsDisposed() bool { return d.disposed }
+
+// IsVisible reports whether this dial is visible.
+// Use SetVisibility to change this flag value.
+func (d *ClockDial) IsVisible() bool { return d.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (d *ClockDial) SetVisibility(visible bool) { d.visible = visible }
+
+// Draw renders the dial onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (d *ClockDial) Draw(dst *ebiten.Image) {
+	d.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the dial onto the provided dst image
+// while also using the extra provided offset.
+func (d *ClockDial) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !d.visible {
+		return
+	}
+
+	t := d.GetTimeOfDay()
+	r := d.config.Radius
+	center := d.BoundsRect().Add(opts.Offset).Center()
+
+	// daylight is 0 at midnight and 1 at noon.
+	daylight := float32((1 - math.Cos(2*math.Pi*t)) * 0.5)
+	day := d.config.DayColorScale
+	night := d.config.NightColorScale
+	sky := ColorScale{
+		R: gmath.Lerp(night.R, day.R, daylight),
+		G: gmath.Lerp(night.G, day.G, daylight),
+		B: gmath.Lerp(night.B, day.B, daylight),
+		A: gmath.Lerp(night.A, day.A, daylight),
+	}
+	drawEllipse(dst, opts.Blend, center, r, r, sky.ToEbitenColorScale())
+
+	// The sun is at the top at noon and the moon is right opposite to it.
+	sunAngle := 2*math.Pi*(t-0.5) - math.Pi/2
+	orbit := r * 0.65
+	bodySize := r * 0.2
+	sunPos := center.Add(gmath.RadToVec(gmath.Rad(sunAngle)).Mulf(orbit))
+	moonPos := center.Sub(gmath.RadToVec(gmath.Rad(sunAngle)).Mulf(orbit))
+	d.drawBody(dst, opts.Blend, d.config.SunIcon, sunPos, bodySize, ColorScale{R: 1, G: 0.85, B: 0.3, A: 1})
+	d.drawBody(dst, opts.Blend, d.config.MoonIcon, moonPos, bodySize, ColorScale{R: 0.85, G: 0.85, B: 0.95, A: 1})
+
+	// A filled lower half-disc is the ground.
+	drawEllipseArc(dst, opts.Blend, center, r, 1, r, 0, math.Pi, d.groundColor)
+}
+
+func (d *ClockDial) drawBody(dst *ebiten.Image, blend *ebiten.Blend, icon *ebiten.Image, pos gmath.Vec, size float64, cs ColorScale) {
+	if icon == nil {
+		drawEllipse(dst, blend, pos, size, size, cs.ToEbitenColorScale())
+		return
+	}
+
+	var drawOptions ebiten.DrawImageOptions
+	if blend != nil {
+		drawOptions.Blend = *blend
+	}
+	bounds := icon.Bounds()
+	drawOptions.GeoM.Translate(math.Round(pos.X-float64(bounds.Dx())*0.5), math.Round(pos.Y-float64(bounds.Dy())*0.5))
+	drawImage(dst, icon, &drawOptions)
+}
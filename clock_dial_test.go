@@ -0,0 +1,42 @@
+package graphics_test
+
+import (
+	"testing"
+
+	graphics "github.com/quasilyte/ebitengine-graphics"
+)
+
+type testTimeSource float64
+
+func (s testTimeSource) GetTimeOfDay() float64 { return float64(s) }
+
+func TestClockDialTimeOfDay(t *testing.T) {
+	d := graphics.NewClockDial(graphics.ClockDialConfig{})
+	if d.GetTimeOfDay() != 0.5 {
+		t.Fatalf("the initial time of day should be 0.5")
+	}
+
+	tests := []struct {
+		t    float64
+		want float64
+	}{
+		{0.25, 0.25},
+		{1, 0},
+		{1.25, 0.25},
+		{-0.25, 0.75},
+	}
+	for _, test := range tests {
+		d.SetTimeOfDay(test.t)
+		if have := d.GetTimeOfDay(); have != test.want {
+			t.Fatalf("SetTimeOfDay(%v):\nhave: %v\nwant: %v", test.t, have, test.want)
+		}
+	}
+
+	synced := graphics.NewClockDial(graphics.ClockDialConfig{
+		Source: testTimeSource(2.75),
+	})
+	synced.SetTimeOfDay(0.1)
+	if have := synced.GetTimeOfDay(); have != 0.75 {
+		t.Fatalf("synced dial time:\nhave: %v\nwant: %v", have, 0.75)
+	}
+}
@@ -0,0 +1,398 @@
+package graphics
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// CompassStyle selects the [Compass] rendering mode.
+type CompassStyle uint8
+
+const (
+	// CompassStrip is a horizontal heading strip (like in the open-world games).
+	// The current heading is always in the strip's center.
+	CompassStrip CompassStyle = iota
+
+	// CompassCircle is a rotating dial with the current heading at its top.
+	CompassCircle
+)
+
+// CompassConfig describes the [Compass] appearance.
+type CompassConfig struct {
+	// Style selects the compass rendering mode.
+	// A zero value means CompassStrip.
+	Style CompassStyle
+
+	// Width and Height are the strip sizes.
+	// They're only used by CompassStrip style.
+	// Zero values mean 200 and 20.
+	Width  float64
+	Height float64
+
+	// FieldOfView is a heading range that is covered by the strip.
+	// It's only used by CompassStrip style.
+	// A zero value means Pi (180 degrees).
+	FieldOfView gmath.Rad
+
+	// Radius is a dial radius.
+	// It's only used by CompassCircle style.
+	// A zero value means 40.
+	Radius float64
+
+	// Camera is an optional camera that drives the compass heading.
+	// If it's not nil, the heading is equal to the camera rotation
+	// (so the compass top always matches the screen top)
+	// and the SetHeading calls are ignored.
+	Camera *Camera
+
+	// Face is used to render the cardinal direction labels (N, E, S, W).
+	// A nil face disables the labels.
+	Face text.Face
+
+	// ColorScale is used to render the ticks, labels and the heading marker.
+	// A zero value means {1, 1, 1, 1}.
+	ColorScale ColorScale
+
+	// BackgroundColorScale is a color of the compass background.
+	// A zero value means a half-transparent black color.
+	BackgroundColorScale ColorScale
+}
+
+// Compass is a heading widget that also displays the registered points of interest.
+//
+// The headings are bearings: 0 is the north (the world's -Y direction)
+// and the angle grows clockwise, so the east is Pi/2.
+// The point of interest bearings are calculated relative to the
+// Observer position, which is usually bound to the player.
+//
+// The compass is a screen-space widget: its Pos field is a top-left corner
+// of its bounds rect, so it should be added to a [StaticLayer].
+//
+// Compass implements gscene Graphics interface.
+type Compass struct {
+	// Pos is a compass top-left corner location binder.
+	// See Pos documentation to learn how it works.
+	Pos gmath.Pos
+
+	// Observer is a world position that is used to
+	// calculate the points of interest bearings.
+	Observer gmath.Pos
+
+	config CompassConfig
+
+	ebitenColorScale ebiten.ColorScale
+	backgroundColor  ebiten.ColorScale
+
+	heading gmath.Rad
+
+	pois []*CompassPOI
+	free []*CompassPOI
+
+	visible  bool
+	disposed bool
+}
+
+// CompassPOI is a point of interest created by [Compass.AddPOI].
+type CompassPOI struct {
+	// Pos is a point of interest world position.
+	Pos gmath.Pos
+
+	// Icon is an optional image that is used to mark this point.
+	// A nil icon means a triangle marker.
+	Icon *ebiten.Image
+
+	colorScale       ColorScale
+	ebitenColorScale ebiten.ColorScale
+
+	disposed bool
+}
+
+// NewCompass creates a compass with the specified config.
+// The initial heading is 0 (north).
+func NewCompass(config CompassConfig) *Compass {
+	if config.Width == 0 {
+		config.Width = 200
+	}
+	if config.Height == 0 {
+		config.Height = 20
+	}
+	if config.FieldOfView == 0 {
+		config.FieldOfView = math.Pi
+	}
+	if config.Radius == 0 {
+		config.Radius = 40
+	}
+	if config.ColorScale == (ColorScale{}) {
+		config.ColorScale = defaultColorScale
+	}
+	if config.BackgroundColorScale == (ColorScale{}) {
+		config.BackgroundColorScale = ColorScale{A: 0.6}
+	}
+	return &Compass{
+		config:           config,
+		ebitenColorScale: config.ColorScale.ToEbitenColorScale(),
+		backgroundColor:  config.BackgroundColorScale.ToEbitenColorScale(),
+		visible:          true,
+	}
+}
+
+// GetHeading returns the current compass heading.
+func (c *Compass) GetHeading() gmath.Rad {
+	if c.config.Camera != nil {
+		return c.config.Camera.GetRotation()
+	}
+	return c.heading
+}
+
+// SetHeading changes the compass heading.
+// It has no effect if the compass is driven by a camera.
+func (c *Compass) SetHeading(heading gmath.Rad) { c.heading = heading }
+
+// AddPOI registers a new point of interest.
+// The point is rendered with the compass color scale by default.
+func (c *Compass) AddPOI(pos gmath.Pos) *CompassPOI {
+	var poi *CompassPOI
+	if n := len(c.free); n != 0 {
+		poi = c.free[n-1]
+		c.free = c.free[:n-1]
+	} else {
+		poi = &CompassPOI{}
+	}
+	*poi = CompassPOI{
+		Pos:              pos,
+		colorScale:       c.config.ColorScale,
+		ebitenColorScale: c.ebitenColorScale,
+	}
+	c.pois = append(c.pois, poi)
+	return poi
+}
+
+// GetColorScale returns the point marker color scale.
+// Use SetColorScale to change it.
+func (poi *CompassPOI) GetColorScale() ColorScale { return poi.colorScale }
+
+// SetColorScale changes the point marker color scale.
+// It's also applied to the icon (if any).
+func (poi *CompassPOI) SetColorScale(cs ColorScale) {
+	if poi.colorScale == cs {
+		return
+	}
+	poi.colorScale = cs
+	poi.ebitenColorScale = cs.ToEbitenColorScale()
+}
+
+// Dispose removes the point of interest from the compass.
+// The point object will be re-used by the next AddPOI call,
+// so it should not be accessed after the Dispose call.
+func (poi *CompassPOI) Dispose() { poi.disposed = true }
+
+// BearingTo returns a bearing from the observer to the world position.
+func (c *Compass) BearingTo(pos gmath.Vec) gmath.Rad {
+	return (c.Observer.Resolve().AngleToPoint(pos) + math.Pi/2).Normalized()
+}
+
+// BoundsRect returns the compass bounding rectangle.
+func (c *Compass) BoundsRect() gmath.Rect {
+	pos := c.Pos.Resolve()
+	size := gmath.Vec{X: c.config.Width, Y: c.config.Height}
+	if c.config.Style == CompassCircle {
+		size = gmath.Vec{X: 2 * c.config.Radius, Y: 2 * c.config.Radius}
+	}
+	return gmath.Rect{Min: pos, Max: pos.Add(size)}
+}
+
+// Dispose marks this compass for deletion.
+// After calling this method, IsDisposed will report true.
+func (c *Compass) Dispose() { c.disposed = true }
+
+// IsDisposed reports whether this compass is marked for deletion.
+func (c *Compass) IsDisposed() bool { return c.disposed }
+
+// IsVisible reports whether this compass is visible.
+// Use SetVisibility to change this flag value.
+func (c *Compass) IsVisible() bool { return c.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (c *Compass) SetVisibility(visible bool) { c.visible = visible }
+
+// Draw renders the compass onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (c *Compass) Draw(dst *ebiten.Image) {
+	c.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the compass onto the provided dst image
+// while also using the extra provided offset.
+func (c *Compass) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	livePOIs := c.pois[:0]
+	for _, poi := range c.pois {
+		if poi.disposed {
+			poi.Icon = nil
+			poi.Pos = gmath.Pos{}
+			c.free = append(c.free, poi)
+			continue
+		}
+		livePOIs = append(livePOIs, poi)
+	}
+	clear(c.pois[len(livePOIs):])
+	c.pois = livePOIs
+
+	if !c.visible {
+		return
+	}
+
+	rect := c.BoundsRect().Add(opts.Offset)
+	if c.config.Style == CompassCircle {
+		c.drawCircle(dst, opts.Blend, rect)
+	} else {
+		c.drawStrip(dst, opts.Blend, rect)
+	}
+}
+
+// compassLabels are the cardinal direction labels, one per 90 degrees.
+var compassLabels = [4]string{"N", "E", "S", "W"}
+
+func (c *Compass) drawStrip(dst *ebiten.Image, blend *ebiten.Blend, rect gmath.Rect) {
+	cs := c.ebitenColorScale
+	heading := c.GetHeading()
+	fov := float64(c.config.FieldOfView)
+	width := rect.Width()
+	height := rect.Height()
+
+	// stripX returns a strip x coordinate for the bearing delta.
+	stripX := func(delta gmath.Rad) float64 {
+		return rect.Min.X + width*(0.5+float64(delta)/fov)
+	}
+
+	var drawOptions ebiten.DrawImageOptions
+	if blend != nil {
+		drawOptions.Blend = *blend
+	}
+	drawOptions.GeoM.Scale(width, height)
+	drawOptions.GeoM.Translate(rect.Min.X, rect.Min.Y)
+	drawOptions.ColorScale = c.backgroundColor
+	drawImage(dst, whitePixel, &drawOptions)
+
+	// The ticks are placed every 15 degrees;
+	// every third of them (45 degrees) is a major tick.
+	const numTicks = 24
+	for i := 0; i < numTicks; i++ {
+		bearing := gmath.Rad(2 * math.Pi * float64(i) / numTicks)
+		delta := heading.AngleDelta(bearing)
+		if math.Abs(float64(delta)) > fov*0.5 {
+			continue
+		}
+		x := math.Round(stripX(delta)) + 0.5
+		tickHeight := height * 0.25
+		if i%3 == 0 {
+			tickHeight = height * 0.5
+		}
+		drawLine(dst, blend, gmath.Vec{X: x, Y: rect.Max.Y}, gmath.Vec{X: x, Y: rect.Max.Y - tickHeight}, 1, cs)
+		if c.config.Face != nil && i%6 == 0 {
+			c.drawLabel(dst, blend, compassLabels[i/6], gmath.Vec{X: x, Y: rect.Min.Y + height*0.4})
+		}
+	}
+
+	// The heading marker.
+	center := rect.Center()
+	markerSize := height * 0.25
+	drawTriangle(dst, blend,
+		gmath.Vec{X: center.X, Y: rect.Min.Y + markerSize},
+		gmath.Vec{X: center.X - markerSize, Y: rect.Min.Y},
+		gmath.Vec{X: center.X + markerSize, Y: rect.Min.Y},
+		cs)
+
+	// The points of interest are clamped to the strip edges,
+	// so the off-view points are still visible.
+	for _, poi := range c.pois {
+		delta := heading.AngleDelta(c.BearingTo(poi.Pos.Resolve()))
+		delta = gmath.Clamp(delta, -gmath.Rad(fov*0.5), gmath.Rad(fov*0.5))
+		c.drawPOI(dst, blend, poi, gmath.Vec{X: stripX(delta), Y: rect.Max.Y - markerSize}, markerSize, 0)
+	}
+}
+
+func (c *Compass) drawCircle(dst *ebiten.Image, blend *ebiten.Blend, rect gmath.Rect) {
+	cs := c.ebitenColorScale
+	heading := c.GetHeading()
+	r := c.config.Radius
+	center := rect.Center()
+
+	// dialPoint returns a point on the dial for the bearing and distance
+	// from the center; the current heading is always at the top.
+	dialPoint := func(bearing gmath.Rad, dist float64) gmath.Vec {
+		angle := float64(bearing-heading) - math.Pi/2
+		sin, cos := math.Sincos(angle)
+		return center.Add(gmath.Vec{X: cos * dist, Y: sin * dist})
+	}
+
+	drawEllipse(dst, blend, center, r, r, c.backgroundColor)
+	drawEllipseArc(dst, blend, center, r, 1, 1, 0, 2*math.Pi, cs)
+
+	const numTicks = 24
+	for i := 0; i < numTicks; i++ {
+		bearing := gmath.Rad(2 * math.Pi * float64(i) / numTicks)
+		tickLength := r * 0.1
+		if i%3 == 0 {
+			tickLength = r * 0.2
+		}
+		drawLine(dst, blend, dialPoint(bearing, r), dialPoint(bearing, r-tickLength), 1, cs)
+		if c.config.Face != nil && i%6 == 0 {
+			c.drawLabel(dst, blend, compassLabels[i/6], dialPoint(bearing, r*0.6))
+		}
+	}
+
+	// The heading marker.
+	markerSize := r * 0.15
+	drawTriangle(dst, blend,
+		gmath.Vec{X: center.X, Y: rect.Min.Y + markerSize},
+		gmath.Vec{X: center.X - markerSize*0.5, Y: rect.Min.Y - markerSize*0.5},
+		gmath.Vec{X: center.X + markerSize*0.5, Y: rect.Min.Y - markerSize*0.5},
+		cs)
+
+	for _, poi := range c.pois {
+		bearing := c.BearingTo(poi.Pos.Resolve())
+		c.drawPOI(dst, blend, poi, dialPoint(bearing, r-markerSize), markerSize, float64(bearing-heading))
+	}
+}
+
+// drawPOI renders a point of interest marker centered at pos.
+// The triangle marker is rotated by the angle (0 means it's pointing up).
+func (c *Compass) drawPOI(dst *ebiten.Image, blend *ebiten.Blend, poi *CompassPOI, pos gmath.Vec, size, angle float64) {
+	if poi.Icon == nil {
+		sin, cos := math.Sincos(angle)
+		rotated := func(x, y float64) gmath.Vec {
+			return pos.Add(gmath.Vec{X: x*cos - y*sin, Y: x*sin + y*cos})
+		}
+		drawTriangle(dst, blend, rotated(0, -size), rotated(-size*0.6, size*0.6), rotated(size*0.6, size*0.6), poi.ebitenColorScale)
+		return
+	}
+
+	var drawOptions ebiten.DrawImageOptions
+	if blend != nil {
+		drawOptions.Blend = *blend
+	}
+	drawOptions.ColorScale = poi.ebitenColorScale
+	bounds := poi.Icon.Bounds()
+	drawOptions.GeoM.Translate(math.Round(pos.X-float64(bounds.Dx())*0.5), math.Round(pos.Y-float64(bounds.Dy())*0.5))
+	drawImage(dst, poi.Icon, &drawOptions)
+}
+
+func (c *Compass) drawLabel(dst *ebiten.Image, blend *ebiten.Blend, s string, pos gmath.Vec) {
+	var drawOptions text.DrawOptions
+	if blend != nil {
+		drawOptions.Blend = *blend
+	}
+	drawOptions.ColorScale = c.ebitenColorScale
+	drawOptions.PrimaryAlign = text.AlignCenter
+	drawOptions.SecondaryAlign = text.AlignCenter
+	drawOptions.GeoM.Translate(math.Round(pos.X), math.Round(pos.Y))
+	drawText(dst, s, c.config.Face, &drawOptions)
+}
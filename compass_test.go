@@ -0,0 +1,32 @@
+package graphics_test
+
+import (
+	"math"
+	"testing"
+
+	graphics "github.com/quasilyte/ebitengine-graphics"
+	"github.com/quasilyte/gmath"
+)
+
+func TestCompassBearing(t *testing.T) {
+	c := graphics.NewCompass(graphics.CompassConfig{})
+	c.Observer.Offset = gmath.Vec{X: 100, Y: 100}
+
+	tests := []struct {
+		pos  gmath.Vec
+		want float64
+	}{
+		{gmath.Vec{X: 100, Y: 0}, 0},
+		{gmath.Vec{X: 200, Y: 100}, math.Pi / 2},
+		{gmath.Vec{X: 100, Y: 200}, math.Pi},
+		{gmath.Vec{X: 0, Y: 100}, 3 * math.Pi / 2},
+		{gmath.Vec{X: 200, Y: 0}, math.Pi / 4},
+	}
+
+	for _, test := range tests {
+		have := float64(c.BearingTo(test.pos))
+		if math.Abs(have-test.want) > 0.0001 {
+			t.Fatalf("BearingTo(%v):\nhave: %v\nwant: %v", test.pos, have, test.want)
+		}
+	}
+}
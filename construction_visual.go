@@ -0,0 +1,185 @@
+package graphics
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// ConstructionVisualConfig describes the [ConstructionVisual] appearance.
+type ConstructionVisualConfig struct {
+	// GhostColorScale is used to render the not yet constructed part of the sprite.
+	// A zero value means {1, 1, 1, 0.25}.
+	GhostColorScale ColorScale
+
+	// ScaffoldColorScale is a color of the diagonal stripes pattern
+	// that covers the constructed part of the sprite.
+	// A zero value means a half-transparent orange color.
+	// Use a color with zero alpha and non-zero RGB to disable the pattern.
+	ScaffoldColorScale ColorScale
+}
+
+// ConstructionVisual renders a sprite that is being constructed.
+//
+// The sprite image is revealed bottom-to-top according to the progress value.
+// The unrevealed part is drawn as a transparent "ghost" and the revealed part
+// is covered with a scaffold stripes pattern. When the progress reaches 1,
+// the sprite is rendered as usual.
+//
+// The wrapped sprite should not be added to any layer,
+// the ConstructionVisual object should be added instead.
+// The sprite position, rotation, scaling and flipping are respected,
+// but the sprite shader is ignored during the construction.
+//
+// ConstructionVisual implements gscene Graphics interface.
+type ConstructionVisual struct {
+	sprite *Sprite
+
+	config ConstructionVisualConfig
+
+	ghostColor    ebiten.ColorScale
+	scaffoldColor ebiten.ColorScale
+
+	progress float64
+
+	visible  bool
+	disposed bool
+}
+
+// NewConstructionVisual wraps a sprite into a construction visual.
+// The initial progress is 0.
+func NewConstructionVisual(s *Sprite, config ConstructionVisualConfig) *ConstructionVisual {
+	if config.GhostColorScale == (ColorScale{}) {
+		config.GhostColorScale = ColorScale{R: 1, G: 1, B: 1, A: 0.25}
+	}
+	if config.ScaffoldColorScale == (ColorScale{}) {
+		config.ScaffoldColorScale = ColorScale{R: 1, G: 0.7, B: 0.3, A: 0.5}
+	}
+	return &ConstructionVisual{
+		sprite:        s,
+		config:        config,
+		ghostColor:    config.GhostColorScale.ToEbitenColorScale(),
+		scaffoldColor: config.ScaffoldColorScale.ToEbitenColorScale(),
+		visible:       true,
+	}
+}
+
+// GetSprite returns the wrapped sprite.
+func (v *ConstructionVisual) GetSprite() *Sprite { return v.sprite }
+
+// GetProgress returns the current construction progress.
+// Use SetProgress to change it.
+func (v *ConstructionVisual) GetProgress() float64 { return v.progress }
+
+// SetProgress changes the construction progress.
+// The value is clamped to [0, 1].
+func (v *ConstructionVisual) SetProgress(progress float64) {
+	v.progress = gmath.Clamp(progress, 0, 1)
+}
+
+// BoundsRect returns the wrapped sprite bounds.
+func (v *ConstructionVisual) BoundsRect() gmath.Rect { return v.sprite.BoundsRect() }
+
+// Dispose marks this visual for deletion.
+// After calling this method, IsDisposed will report true.
+//
+// The wrapped sprite is not disposed.
+func (v *ConstructionVisual) Dispose() { v.disposed = true }
+
+// IsDisposed reports whether this visual is marked for deletion.
+//
+// The visual is also considered to be disposed if its sprite is disposed.
+func (v *ConstructionVisual) IsDisposed() bool { return v.disposed || v.sprite.IsDisposed() }
+
+// IsVisible reports whether this visual is visible.
+// Use SetVisibility to change this flag value.
+//
+// An invisible sprite is not rendered even if the visual itself is visible.
+func (v *ConstructionVisual) IsVisible() bool { return v.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (v *ConstructionVisual) SetVisibility(visible bool) { v.visible = visible }
+
+// Draw renders the visual onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (v *ConstructionVisual) Draw(dst *ebiten.Image) {
+	v.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the visual onto the provided dst image
+// while also using the extra provided offset.
+func (v *ConstructionVisual) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	s := v.sprite
+	if !v.visible || !s.IsVisible() || s.image == nil || s.colorScale.A == 0 {
+		return
+	}
+	opts.Blend = resolveBlend(s.blendID, opts.Blend)
+	if v.progress >= 1 {
+		s.DrawWithOptions(dst, opts)
+		return
+	}
+
+	geom := s.calculateGeoM(opts)
+	h := float64(s.frameHeight)
+	revealY := h * (1 - v.progress)
+
+	var ghostColor ebiten.ColorScale
+	ghostColor.ScaleWithColorScale(s.ebitenColorScale)
+	ghostColor.ScaleWithColorScale(v.ghostColor)
+
+	var drawOptions ebiten.DrawTrianglesOptions
+	if opts.Blend != nil {
+		drawOptions.Blend = *opts.Blend
+	}
+	drawOptions.ColorScaleMode = ebiten.ColorScaleModePremultipliedAlpha
+
+	if revealY > 0 {
+		v.drawRegion(dst, s.image, &drawOptions, geom, 0, revealY, false, ghostColor)
+	}
+	if revealY < h {
+		v.drawRegion(dst, s.image, &drawOptions, geom, revealY, h, false, s.ebitenColorScale)
+		if v.scaffoldColor.A() != 0 {
+			drawOptions.Address = ebiten.AddressRepeat
+			v.drawRegion(dst, getZoneStripesImage(), &drawOptions, geom, revealY, h, true, v.scaffoldColor)
+		}
+	}
+}
+
+// drawRegion draws a horizontal sprite strip between y1 and y2 (in frame-local coordinates).
+// If pattern is true, src is treated as a repeated texture instead of the sprite image.
+func (v *ConstructionVisual) drawRegion(dst, src *ebiten.Image, drawOptions *ebiten.DrawTrianglesOptions, geom ebiten.GeoM, y1, y2 float64, pattern bool, cs ebiten.ColorScale) {
+	s := v.sprite
+	w := float64(s.frameWidth)
+
+	srcOffset := gmath.Vec{X: float64(s.frameOffsetX), Y: float64(s.frameOffsetY)}
+	if pattern {
+		srcOffset = gmath.Vec{}
+	}
+
+	vertex := func(x, y float64) ebiten.Vertex {
+		dstX, dstY := geom.Apply(x, y)
+		return ebiten.Vertex{
+			DstX:   float32(dstX),
+			DstY:   float32(dstY),
+			SrcX:   float32(srcOffset.X + x),
+			SrcY:   float32(srcOffset.Y + y),
+			ColorR: cs.R(),
+			ColorG: cs.G(),
+			ColorB: cs.B(),
+			ColorA: cs.A(),
+		}
+	}
+
+	vertices := [4]ebiten.Vertex{
+		vertex(0, y1),
+		vertex(w, y1),
+		vertex(0, y2),
+		vertex(w, y2),
+	}
+	indices := [6]uint16{0, 1, 2, 1, 2, 3}
+	drawTriangles(dst, vertices[:], indices[:], src, drawOptions)
+}
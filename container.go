@@ -1,6 +1,11 @@
 package graphics
 
 import (
+	"image"
+	"math"
+	"slices"
+	"sort"
+
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/quasilyte/gmath"
 )
@@ -12,8 +17,27 @@ type Container struct {
 
 	objects []DisposableObject
 
-	visible  bool
-	disposed bool
+	// zindex is allocated on the first SetChildZ call.
+	// It's a parallel slice for objects: zindex[i] is objects[i] z-index.
+	zindex []int
+
+	// zseq is allocated along with zindex.
+	// It's a parallel slice for objects that holds the children insertion
+	// sequence numbers, so the equal z-index children keep the insertion order.
+	zseq    []uint32
+	nextSeq uint32
+
+	// clip is allocated on the first SetClipRect call.
+	clip *containerClip
+
+	shake *Shake
+
+	changed DirtySignal
+
+	visible      bool
+	disposed     bool
+	unsorted     bool
+	debugOverlay bool
 }
 
 type DisposableObject interface {
@@ -59,8 +83,172 @@ func (c *Container) Draw(dst *ebiten.Image) {
 	c.DrawWithOptions(dst, DrawOptions{})
 }
 
+// AddChild adds an object to the container.
+// The new object has a z-index of 0.
 func (c *Container) AddChild(o DisposableObject) {
 	c.objects = append(c.objects, o)
+	if c.zindex != nil {
+		c.zindex = append(c.zindex, 0)
+		c.zseq = append(c.zseq, c.nextSeq)
+		c.nextSeq++
+		c.unsorted = true
+	}
+	connectDirtySource(o, c)
+	c.changed.Emit()
+}
+
+// MarkDirty notifies the container dependents about a change.
+// The container itself doesn't cache anything,
+// it only propagates the changes of its children (see [DirtySignal]).
+func (c *Container) MarkDirty() {
+	c.changed.Emit()
+}
+
+// DirtySignal returns a signal that is emitted when the container
+// or one of its [DirtySource] children is changed.
+// It implements the [DirtySource] interface.
+func (c *Container) DirtySignal() *DirtySignal {
+	return &c.changed
+}
+
+// AddChildWithZ is like AddChild, but it also assigns the object z-index.
+// See SetChildZ.
+func (c *Container) AddChildWithZ(o DisposableObject, z int) {
+	c.AddChild(o)
+	c.SetChildZ(o, z)
+}
+
+// SetChildZ changes the z-index of the container's child.
+//
+// Children with higher z-index are drawn on top of the ones with lower z-index.
+// Children with equal z-index are drawn in the order they were added.
+// The default z-index is 0.
+//
+// This method is a no-op if o is not a child of this container.
+func (c *Container) SetChildZ(o DisposableObject, z int) {
+	i := slices.Index(c.objects, o)
+	if i == -1 {
+		return
+	}
+	if c.zindex == nil {
+		if z == 0 {
+			return
+		}
+		// The children are not sorted yet, so their
+		// current order is the insertion order.
+		c.zindex = make([]int, len(c.objects))
+		c.zseq = make([]uint32, len(c.objects))
+		for j := range c.zseq {
+			c.zseq[j] = uint32(j)
+		}
+		c.nextSeq = uint32(len(c.objects))
+	}
+	c.zindex[i] = z
+	c.unsorted = true
+	c.changed.Emit()
+}
+
+// GetChildZ returns the z-index of the container's child.
+// See SetChildZ.
+func (c *Container) GetChildZ(o DisposableObject) int {
+	if c.zindex == nil {
+		return 0
+	}
+	if i := slices.Index(c.objects, o); i != -1 {
+		return c.zindex[i]
+	}
+	return 0
+}
+
+// SetDebugOverlay enables or disables the debug overlay for this container.
+//
+// When enabled, every container child is drawn as usual, and then
+// its BoundsRect, its origin point (the resolved Pos) and
+// its type name are drawn on top of it.
+// It helps to diagnose the layout problems, like a centered
+// label growing in the wrong direction.
+//
+// Only the direct children are affected; use this method on
+// the nested containers to debug them as well.
+// Unlike [SetDebugWireframe], it's not a global mode.
+func (c *Container) SetDebugOverlay(enabled bool) {
+	c.debugOverlay = enabled
+}
+
+// IsDebugOverlay reports whether the debug overlay is enabled.
+// Use SetDebugOverlay to change it.
+func (c *Container) IsDebugOverlay() bool {
+	return c.debugOverlay
+}
+
+// SetShake assigns a shake effect to this container.
+// The shake offset is added to the children draw offset.
+// A nil value removes the shake.
+func (c *Container) SetShake(s *Shake) {
+	c.shake = s
+}
+
+// GetShake returns the shake assigned by SetShake.
+func (c *Container) GetShake() *Shake {
+	return c.shake
+}
+
+// SetClipRect restricts the children rendering to the rect.
+// The rect is relative to the container position,
+// so it moves with the container and respects the camera offsets.
+// Everything outside of this rect is not drawn.
+//
+// This is useful for the scrollable lists, minimaps and reveal effects.
+// The clipping area is axis-aligned: the container rotation doesn't affect it.
+//
+// Use ResetClipRect to disable the clipping.
+func (c *Container) SetClipRect(rect gmath.Rect) {
+	if c.clip == nil {
+		c.clip = new(containerClip)
+	}
+	c.clip.rect = rect
+}
+
+// GetClipRect returns the clipping rect assigned by SetClipRect.
+// The second result value is false if there is no clipping.
+func (c *Container) GetClipRect() (gmath.Rect, bool) {
+	if c.clip == nil {
+		return gmath.Rect{}, false
+	}
+	return c.clip.rect, true
+}
+
+// ResetClipRect removes the clipping rect.
+func (c *Container) ResetClipRect() {
+	c.clip = nil
+}
+
+func (c *Container) sortChildren() {
+	c.unsorted = false
+	sort.Sort(containerChildren{c})
+}
+
+// containerChildren implements sort.Interface for the parallel
+// objects, zindex and zseq slices.
+type containerChildren struct{ c *Container }
+
+func (cc containerChildren) Len() int { return len(cc.c.objects) }
+
+func (cc containerChildren) Less(i, j int) bool {
+	zindex := cc.c.zindex
+	if zindex[i] != zindex[j] {
+		return zindex[i] < zindex[j]
+	}
+	return cc.c.zseq[i] < cc.c.zseq[j]
+}
+
+func (cc containerChildren) Swap(i, j int) {
+	objects := cc.c.objects
+	zindex := cc.c.zindex
+	zseq := cc.c.zseq
+	objects[i], objects[j] = objects[j], objects[i]
+	zindex[i], zindex[j] = zindex[j], zindex[i]
+	zseq[i], zseq[j] = zseq[j], zseq[i]
 }
 
 func (c *Container) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
@@ -69,17 +257,97 @@ func (c *Container) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
 	}
 
 	opts.Offset = opts.Offset.Add(c.Pos.Resolve())
+	if c.shake != nil {
+		opts.Offset = opts.Offset.Add(c.shake.Offset().Rounded())
+	}
 	if c.Rotation != nil {
 		opts.Rotation += *c.Rotation
 	}
 
+	if c.unsorted {
+		c.sortChildren()
+	}
+
+	if c.clip != nil {
+		// If the entire container is clipped, dst becomes nil;
+		// the children are not drawn, but they're still filtered.
+		dst = c.clip.image(dst, c.clip.rect.Add(opts.Offset))
+	}
+
 	liveObjects := c.objects[:0]
-	for _, o := range c.objects {
+	for i, o := range c.objects {
 		if o.IsDisposed() {
 			continue
 		}
+		if c.zindex != nil {
+			c.zindex[len(liveObjects)] = c.zindex[i]
+			c.zseq[len(liveObjects)] = c.zseq[i]
+		}
 		liveObjects = append(liveObjects, o)
-		o.DrawWithOptions(dst, opts)
+		if dst != nil {
+			drawObject(dst, o, opts)
+			if c.debugOverlay {
+				drawDebugOverlay(dst, o, opts)
+			}
+		}
 	}
 	c.objects = liveObjects
+	if c.zindex != nil {
+		c.zindex = c.zindex[:len(liveObjects)]
+		c.zseq = c.zseq[:len(liveObjects)]
+	}
+}
+
+// clipImage returns a dst sub-image that is limited by the rect
+// (the rect is in the dst coordinates).
+// It returns nil if the rect doesn't intersect the dst image.
+//
+// An Ebitengine sub-image shares the coordinate system with
+// its original image, so the objects can be drawn onto it
+// using the same offsets.
+func clipImage(dst *ebiten.Image, rect gmath.Rect) *ebiten.Image {
+	r := clipBounds(dst, rect)
+	if r.Empty() {
+		return nil
+	}
+	return subImage(dst, r)
+}
+
+func clipBounds(dst *ebiten.Image, rect gmath.Rect) image.Rectangle {
+	return image.Rect(
+		int(math.Floor(rect.Min.X)),
+		int(math.Floor(rect.Min.Y)),
+		int(math.Ceil(rect.Max.X)),
+		int(math.Ceil(rect.Max.Y)),
+	).Intersect(dst.Bounds())
+}
+
+// containerClip holds the container clip rect along with
+// the last sub-image created for it.
+// The sub-image is rebuilt only when the dst image or
+// the resulting clip bounds change, so a stationary clipped
+// container doesn't allocate a new sub-image every frame.
+type containerClip struct {
+	rect gmath.Rect
+
+	dst    *ebiten.Image
+	bounds image.Rectangle
+	sub    *ebiten.Image
+}
+
+func (clip *containerClip) image(dst *ebiten.Image, rect gmath.Rect) *ebiten.Image {
+	r := clipBounds(dst, rect)
+	if r.Empty() {
+		return nil
+	}
+	if clip.sub == nil || clip.dst != dst || clip.bounds != r {
+		clip.dst = dst
+		clip.bounds = r
+		clip.sub = subImage(dst, r)
+	}
+	return clip.sub
+}
+
+func (c *Container) inspectChildren() []DisposableObject {
+	return c.objects
 }
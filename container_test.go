@@ -0,0 +1,149 @@
+package graphics
+
+import (
+	"image"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+func TestClipImage(t *testing.T) {
+	dst := ebiten.NewImage(64, 32)
+
+	tests := []struct {
+		rect gmath.Rect
+		want image.Rectangle
+	}{
+		{
+			rect: gmath.Rect{Min: gmath.Vec{X: 10, Y: 5}, Max: gmath.Vec{X: 20, Y: 15}},
+			want: image.Rect(10, 5, 20, 15),
+		},
+		{
+			rect: gmath.Rect{Min: gmath.Vec{X: 10.5, Y: 5.5}, Max: gmath.Vec{X: 19.5, Y: 14.5}},
+			want: image.Rect(10, 5, 20, 15),
+		},
+		{
+			rect: gmath.Rect{Min: gmath.Vec{X: -10, Y: -10}, Max: gmath.Vec{X: 100, Y: 100}},
+			want: image.Rect(0, 0, 64, 32),
+		},
+	}
+	for _, test := range tests {
+		clipped := clipImage(dst, test.rect)
+		if clipped == nil {
+			t.Fatalf("clipImage(%v): unexpected nil", test.rect)
+		}
+		if have := clipped.Bounds(); have != test.want {
+			t.Fatalf("clipImage(%v):\nhave: %v\nwant: %v", test.rect, have, test.want)
+		}
+	}
+
+	outside := gmath.Rect{Min: gmath.Vec{X: 70, Y: 0}, Max: gmath.Vec{X: 80, Y: 10}}
+	if clipped := clipImage(dst, outside); clipped != nil {
+		t.Fatalf("clipImage(%v): expected nil, got %v", outside, clipped.Bounds())
+	}
+}
+
+func TestContainerClipRect(t *testing.T) {
+	c := NewContainer()
+	if _, ok := c.GetClipRect(); ok {
+		t.Fatal("a new container should have no clip rect")
+	}
+
+	rect := gmath.Rect{Max: gmath.Vec{X: 32, Y: 16}}
+	c.SetClipRect(rect)
+	if have, ok := c.GetClipRect(); !ok || have != rect {
+		t.Fatalf("GetClipRect:\nhave: %v, %v\nwant: %v, true", have, ok, rect)
+	}
+
+	// The clip sub-image is reused until the clip bounds change.
+	dst := ebiten.NewImage(64, 64)
+	c.Draw(dst)
+	sub := c.clip.sub
+	c.Draw(dst)
+	if c.clip.sub != sub {
+		t.Fatal("the clip sub-image is rebuilt for the same bounds")
+	}
+	c.Pos.Offset = gmath.Vec{X: 8}
+	c.Draw(dst)
+	if have := c.clip.sub.Bounds(); have != image.Rect(8, 0, 40, 16) {
+		t.Fatalf("clip sub-image bounds after the move: %v", have)
+	}
+
+	// A fully clipped container still removes its disposed children.
+	child := NewRect(4, 4)
+	c.AddChild(child)
+	child.Dispose()
+	c.Pos.Offset = gmath.Vec{X: 1000}
+	c.Draw(ebiten.NewImage(8, 8))
+	if n := len(c.objects); n != 0 {
+		t.Fatalf("expected the disposed child to be removed, %d children left", n)
+	}
+
+	c.ResetClipRect()
+	if _, ok := c.GetClipRect(); ok {
+		t.Fatal("ResetClipRect should remove the clip rect")
+	}
+}
+
+func TestContainerDebugOverlay(t *testing.T) {
+	c := NewContainer()
+	if c.IsDebugOverlay() {
+		t.Fatal("the debug overlay should be disabled by default")
+	}
+	c.SetDebugOverlay(true)
+	if !c.IsDebugOverlay() {
+		t.Fatal("SetDebugOverlay(true) is not applied")
+	}
+
+	// A child without bounds (like a container) only gets the origin marker.
+	c.AddChild(NewRect(4, 4))
+	c.AddChild(NewContainer())
+	c.Draw(ebiten.NewImage(8, 8))
+
+	if have := inspectPosField(c.objects[0]); have == nil {
+		t.Fatal("the rect origin can't be found")
+	}
+}
+
+func TestContainerChildZ(t *testing.T) {
+	c := NewContainer()
+	a := NewRect(1, 1)
+	b := NewRect(1, 1)
+	d := NewRect(1, 1)
+	c.AddChild(a)
+	c.AddChild(b)
+	c.AddChild(d)
+
+	check := func(want ...DisposableObject) {
+		t.Helper()
+		c.sortChildren()
+		for i, o := range want {
+			if c.objects[i] != o {
+				t.Fatalf("objects[%d] has a wrong draw order (z=%d)", i, c.GetChildZ(c.objects[i]))
+			}
+		}
+	}
+
+	c.SetChildZ(a, 1)
+	check(b, d, a)
+	c.SetChildZ(d, -1)
+	check(d, b, a)
+
+	// The equal z-index children are drawn in the order they were added,
+	// regardless of their previous z-index values.
+	c.SetChildZ(a, 0)
+	c.SetChildZ(d, 0)
+	check(a, b, d)
+
+	e := NewRect(1, 1)
+	c.AddChildWithZ(e, 0)
+	c.SetChildZ(a, 2)
+	c.SetChildZ(a, 0)
+	check(a, b, d, e)
+
+	b.Dispose()
+	c.Draw(ebiten.NewImage(4, 4))
+	c.SetChildZ(e, -1)
+	check(e, a, d)
+}
@@ -0,0 +1,37 @@
+package graphics
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// objectCuller implements the viewport culling for the layers.
+//
+// The visible area is calculated from the dst image bounds and
+// the draw offset, so it works for every camera (including the
+// zoomed and rotated ones, as they render into an offscreen image
+// that covers the camera's world rect).
+type objectCuller struct {
+	enabled bool
+	margin  float64
+}
+
+// visibleRect returns the world area that is rendered to dst.
+func (c *objectCuller) visibleRect(dst *ebiten.Image, opts DrawOptions) gmath.Rect {
+	margin := gmath.Vec{X: c.margin, Y: c.margin}
+	r := gmath.RectFromStd(dst.Bounds())
+	return gmath.Rect{
+		Min: r.Min.Sub(opts.Offset).Sub(margin),
+		Max: r.Max.Sub(opts.Offset).Add(margin),
+	}
+}
+
+// isCulled reports whether o can be skipped during the rendering.
+// Objects that don't implement [BoundedObject] are never culled.
+func (c *objectCuller) isCulled(o Object, visibleRect gmath.Rect) bool {
+	b, ok := o.(BoundedObject)
+	if !ok {
+		return false
+	}
+	return !b.BoundsRect().Intersects(visibleRect)
+}
@@ -0,0 +1,145 @@
+package graphics
+
+import (
+	"cmp"
+	"image"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// DamageOverlays renders a sprite with the damage state overlays on top of it.
+//
+// Every overlay is an image (cracks, fire decals, etc.) that is
+// displayed when the health value drops to its threshold or below.
+// The matching overlays are stacked: the overlays with higher
+// thresholds are drawn first.
+//
+// The overlay images are expected to have the same layout as the
+// sprite image, so the overlays are always aligned with the current
+// sprite frame. They also share the sprite transformation (position,
+// rotation, scaling and flipping) and its alpha.
+//
+// The wrapped sprite should not be added to any layer,
+// the DamageOverlays object should be added instead.
+//
+// DamageOverlays implements gscene Graphics interface.
+type DamageOverlays struct {
+	sprite *Sprite
+
+	overlays []damageOverlay
+
+	health float64
+
+	visible  bool
+	disposed bool
+}
+
+type damageOverlay struct {
+	threshold float64
+	image     *ebiten.Image
+
+	// The sub-image is re-created only when the sprite frame changes.
+	subImage  *ebiten.Image
+	frameRect image.Rectangle
+}
+
+// NewDamageOverlays wraps a sprite into a damage overlays renderer.
+// The initial health value is 1.
+func NewDamageOverlays(s *Sprite) *DamageOverlays {
+	return &DamageOverlays{
+		sprite:  s,
+		health:  1,
+		visible: true,
+	}
+}
+
+// AddOverlay registers a new damage overlay.
+// The overlay is displayed when the health is less or equal to the threshold.
+func (d *DamageOverlays) AddOverlay(threshold float64, img *ebiten.Image) {
+	d.overlays = append(d.overlays, damageOverlay{
+		threshold: threshold,
+		image:     img,
+	})
+	slices.SortStableFunc(d.overlays, func(a, b damageOverlay) int {
+		return cmp.Compare(b.threshold, a.threshold)
+	})
+}
+
+// GetSprite returns the wrapped sprite.
+func (d *DamageOverlays) GetSprite() *Sprite { return d.sprite }
+
+// GetHealth returns the current health value.
+// Use SetHealth to change it.
+func (d *DamageOverlays) GetHealth() float64 { return d.health }
+
+// SetHealth changes the health value that is used to select the overlays.
+// It's usually a [0, 1] value, but any scale can be used
+// as long as the thresholds use the same scale.
+func (d *DamageOverlays) SetHealth(health float64) { d.health = health }
+
+// BoundsRect returns the wrapped sprite bounds.
+func (d *DamageOverlays) BoundsRect() gmath.Rect { return d.sprite.BoundsRect() }
+
+// Dispose marks this object for deletion.
+// After calling this method, IsDisposed will report true.
+//
+// The wrapped sprite is not disposed.
+func (d *DamageOverlays) Dispose() { d.disposed = true }
+
+// IsDisposed reports whether this object is marked for deletion.
+//
+// The object is also considered to be disposed if its sprite is disposed.
+func (d *DamageOverlays) IsDisposed() bool { return d.disposed || d.sprite.IsDisposed() }
+
+// IsVisible reports whether this object is visible.
+// Use SetVisibility to change this flag value.
+func (d *DamageOverlays) IsVisible() bool { return d.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (d *DamageOverlays) SetVisibility(visible bool) { d.visible = visible }
+
+// Draw renders the sprite and its overlays onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (d *DamageOverlays) Draw(dst *ebiten.Image) {
+	d.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the sprite and its overlays onto the provided dst image
+// while also using the extra provided offset.
+func (d *DamageOverlays) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	s := d.sprite
+	if !d.visible || !s.IsVisible() || s.image == nil || s.colorScale.A == 0 {
+		return
+	}
+	opts.Blend = resolveBlend(s.blendID, opts.Blend)
+
+	s.DrawWithOptions(dst, opts)
+
+	var drawOptions ebiten.DrawImageOptions
+	if opts.Blend != nil {
+		drawOptions.Blend = *opts.Blend
+	}
+	drawOptions.ColorScale.ScaleAlpha(s.colorScale.A)
+	drawOptions.GeoM = s.calculateGeoM(opts)
+
+	frameRect := s.frameRect()
+	for i := range d.overlays {
+		o := &d.overlays[i]
+		if d.health > o.threshold {
+			// The overlays are sorted by their thresholds.
+			break
+		}
+		if o.subImage == nil || o.frameRect != frameRect {
+			o.subImage = subImage(o.image, frameRect)
+			o.frameRect = frameRect
+		}
+		drawImage(dst, o.subImage, &drawOptions)
+	}
+}
@@ -0,0 +1,184 @@
+package graphics
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+	"github.com/quasilyte/gmath"
+)
+
+var debugWireframeColor = ColorScale{R: 0.2, G: 1, B: 0.4, A: 1}
+
+// SetDebugWireframe enables or disables the global wireframe debug mode.
+//
+// In this mode, every object that implements [BoundedObject]
+// is rendered as its bounds rectangle with its type name
+// inside the rect instead of its normal graphics.
+// This makes layout and culling problems visible at a glance.
+//
+// Only objects that are drawn by layers and containers of this
+// package are affected. Objects without bounds are drawn as usual.
+func SetDebugWireframe(enabled bool) {
+	cache.Global.DebugWireframe = enabled
+}
+
+// IsDebugWireframe reports whether the wireframe debug mode is enabled.
+// Use SetDebugWireframe to change it.
+func IsDebugWireframe() bool {
+	return cache.Global.DebugWireframe
+}
+
+// drawObject should be used by layer-like objects instead of
+// calling o.DrawWithOptions directly, so the debug modes can be applied.
+func drawObject(dst *ebiten.Image, o Object, opts DrawOptions) {
+	if cache.Global.StrictMode != 0 {
+		strictBeginDraw()
+		defer strictEndDraw()
+	}
+	if cache.Global.DebugWireframe {
+		if b, ok := o.(BoundedObject); ok {
+			if v, ok := o.(visibleObject); ok && !v.IsVisible() {
+				return
+			}
+			drawDebugWireframe(dst, b.BoundsRect().Add(opts.Offset), inspectTypeName(o), debugWireframeColor)
+			return
+		}
+	}
+	o.DrawWithOptions(dst, opts)
+}
+
+// drawDebugOverlay renders the object's bounds, origin point
+// and type name on top of this object.
+// See [Container.SetDebugOverlay].
+func drawDebugOverlay(dst *ebiten.Image, o Object, opts DrawOptions) {
+	if v, ok := o.(visibleObject); ok && !v.IsVisible() {
+		return
+	}
+	if b, ok := o.(BoundedObject); ok {
+		drawDebugWireframe(dst, b.BoundsRect().Add(opts.Offset), inspectTypeName(o), debugWireframeColor)
+	}
+	if pos := inspectPosField(o); pos != nil {
+		origin := pos.Resolve().Add(opts.Offset)
+		cs := debugOriginColor.ToEbitenColorScale()
+		const size = 3
+		drawLine(dst, nil, origin.Sub(gmath.Vec{X: size}), origin.Add(gmath.Vec{X: size}), 1, cs)
+		drawLine(dst, nil, origin.Sub(gmath.Vec{Y: size}), origin.Add(gmath.Vec{Y: size}), 1, cs)
+	}
+}
+
+func drawDebugWireframe(dst *ebiten.Image, rect gmath.Rect, label string, clr ColorScale) {
+	drawDebugRect(dst, rect, clr)
+	if label != "" {
+		ebitenutil.DebugPrintAt(dst, label, int(rect.Min.X)+1, int(rect.Min.Y)+1)
+	}
+}
+
+func drawDebugRect(dst *ebiten.Image, rect gmath.Rect, clr ColorScale) {
+	cs := clr.ToEbitenColorScale()
+	topRight := gmath.Vec{X: rect.Max.X, Y: rect.Min.Y}
+	bottomLeft := gmath.Vec{X: rect.Min.X, Y: rect.Max.Y}
+	drawLine(dst, nil, rect.Min, topRight, 1, cs)
+	drawLine(dst, nil, topRight, rect.Max, 1, cs)
+	drawLine(dst, nil, rect.Max, bottomLeft, 1, cs)
+	drawLine(dst, nil, bottomLeft, rect.Min, 1, cs)
+}
+
+var (
+	debugCulledColor  = ColorScale{R: 1, G: 0.2, B: 0.2, A: 1}
+	debugVisibleColor = debugWireframeColor
+	debugCameraColor  = defaultColorScale
+	debugOverlayColor = ColorScale{A: 0.6}
+	debugOriginColor  = ColorScale{R: 1, G: 0.2, B: 1, A: 1}
+)
+
+// SetDebugCullingOverlay enables a minimap-style debug overlay
+// that shows all camera-dependent objects of the scene.
+//
+// The overlay is drawn inside the rect (in screen coordinates)
+// on top of everything else.
+// Objects that are seen by at least one camera are outlined green,
+// objects that are outside of the cameras view (the culling candidates) are red.
+// The camera view rects are outlined white.
+//
+// Only top-level [BoundedObject] objects of the [Layer] layers are displayed.
+// A zero rect disables the overlay.
+func (d *SceneDrawer) SetDebugCullingOverlay(rect gmath.Rect) {
+	d.debugCullingRect = rect
+}
+
+func (d *SceneDrawer) drawCullingOverlay(dst *ebiten.Image, cameras []installedCamera) {
+	// The first pass calculates the world area to display.
+	var world gmath.Rect
+	for i := range cameras {
+		world = unionRect(world, cameras[i].c.GetWorldRect())
+	}
+	d.walkCullingObjects(func(bounds gmath.Rect) {
+		world = unionRect(world, bounds)
+	})
+	if world.Width() == 0 || world.Height() == 0 {
+		return
+	}
+
+	inset := d.debugCullingRect
+	scale := min(inset.Width()/world.Width(), inset.Height()/world.Height())
+	toInset := func(r gmath.Rect) gmath.Rect {
+		return gmath.Rect{
+			Min: r.Min.Sub(world.Min).Mulf(scale).Add(inset.Min),
+			Max: r.Max.Sub(world.Min).Mulf(scale).Add(inset.Min),
+		}
+	}
+
+	var drawOptions ebiten.DrawImageOptions
+	drawOptions.GeoM.Scale(inset.Width(), inset.Height())
+	drawOptions.GeoM.Translate(inset.Min.X, inset.Min.Y)
+	drawOptions.ColorScale = debugOverlayColor.ToEbitenColorScale()
+	drawImage(dst, whitePixel, &drawOptions)
+
+	// The second pass draws the objects.
+	d.walkCullingObjects(func(bounds gmath.Rect) {
+		clr := debugCulledColor
+		for i := range cameras {
+			if cameras[i].c.GetWorldRect().Intersects(bounds) {
+				clr = debugVisibleColor
+				break
+			}
+		}
+		drawDebugRect(dst, toInset(bounds), clr)
+	})
+	for i := range cameras {
+		drawDebugRect(dst, toInset(cameras[i].c.GetWorldRect()), debugCameraColor)
+	}
+}
+
+func (d *SceneDrawer) walkCullingObjects(visit func(bounds gmath.Rect)) {
+	for _, l := range d.layers {
+		l, ok := l.(*Layer)
+		if !ok {
+			continue
+		}
+		for _, o := range l.objects {
+			if o.IsDisposed() {
+				continue
+			}
+			if v, ok := o.(visibleObject); ok && !v.IsVisible() {
+				continue
+			}
+			if b, ok := o.(BoundedObject); ok {
+				visit(b.BoundsRect())
+			}
+		}
+	}
+}
+
+func unionRect(a, b gmath.Rect) gmath.Rect {
+	if a.IsZero() {
+		return b
+	}
+	if b.IsZero() {
+		return a
+	}
+	return gmath.Rect{
+		Min: gmath.Vec{X: min(a.Min.X, b.Min.X), Y: min(a.Min.Y, b.Min.Y)},
+		Max: gmath.Vec{X: max(a.Max.X, b.Max.X), Y: max(a.Max.Y, b.Max.Y)},
+	}
+}
@@ -0,0 +1,178 @@
+// Package debugserver implements an optional HTTP endpoint for the scene inspection.
+//
+// It's a separate package to avoid the net/http dependency
+// for the games that don't need it.
+package debugserver
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"time"
+
+	graphics "github.com/quasilyte/ebitengine-graphics"
+)
+
+// Server serves the scene introspection data and accepts property-edit commands.
+//
+// Endpoints:
+//   - GET /scene returns the [graphics.SceneDrawer.Inspect] results as JSON
+//   - POST /property with a {"path", "field", "value"} JSON object body
+//     calls [graphics.SceneDrawer.SetProperty]
+//
+// The HTTP handlers run in their own goroutines while the scene objects
+// can only be accessed from the game loop. This is why every request is queued
+// and then executed during the Server.Update call; the handler waits for it.
+// Update should be called once per frame (usually, from the game's Update).
+//
+// The requests that have an Origin header other than the allowed one
+// (see SetAllowedOrigin) are rejected; the tools like curl send no Origin.
+// The property-edit requests also require an "application/json"
+// content type: a browser can't send such a request to another origin
+// without a CORS preflight, and the server never approves it.
+// This way, the web pages opened by the developer can't modify the scene.
+//
+// Server implements http.Handler, so it can be mounted on any mux.
+// For the platforms where listening is not possible (like wasm),
+// the handler can be used by a custom relay transport.
+type Server struct {
+	drawer   *graphics.SceneDrawer
+	requests chan *request
+
+	allowedOrigin string
+}
+
+type request struct {
+	fn   func() (any, error)
+	done chan response
+}
+
+type response struct {
+	result any
+	err    error
+}
+
+// NewServer returns a debug server for the given scene drawer.
+func NewServer(d *graphics.SceneDrawer) *Server {
+	return &Server{
+		drawer:   d,
+		requests: make(chan *request, 8),
+	}
+}
+
+// SetAllowedOrigin permits the specified origin (like "http://localhost:3000")
+// to read the GET /scene results from a browser.
+// The property-edit requests never get the CORS headers,
+// so they can't be sent from a browser even by the allowed origin.
+//
+// An empty origin (the default) disables the cross-origin access.
+// This method should be called before the server starts handling requests.
+func (s *Server) SetAllowedOrigin(origin string) {
+	s.allowedOrigin = origin
+}
+
+// ListenAndServe starts an HTTP server that uses s as a handler.
+// It blocks, so it's usually called with a go statement.
+func (s *Server) ListenAndServe(addr string) error {
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           s,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return httpServer.ListenAndServe()
+}
+
+// Update executes all pending requests.
+// It should be called from the game loop goroutine.
+func (s *Server) Update() {
+	for {
+		select {
+		case req := <-s.requests:
+			result, err := req.fn()
+			req.done <- response{result: result, err: err}
+		default:
+			return
+		}
+	}
+}
+
+// maxPropertyRequestSize limits the POST /property body size.
+const maxPropertyRequestSize = 64 * 1024
+
+// propertyRequest is a POST /property request body.
+type propertyRequest struct {
+	Path  string `json:"path"`
+	Field string `json:"field"`
+	Value string `json:"value"`
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if origin := r.Header.Get("Origin"); origin != "" && origin != s.allowedOrigin {
+		http.Error(w, "cross-origin request rejected", http.StatusForbidden)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/scene":
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if s.allowedOrigin != "" {
+			w.Header().Set("Access-Control-Allow-Origin", s.allowedOrigin)
+		}
+		s.serve(w, r, func() (any, error) {
+			return s.drawer.Inspect(), nil
+		})
+
+	case "/property":
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil || mediaType != "application/json" {
+			http.Error(w, "expected application/json content type", http.StatusUnsupportedMediaType)
+			return
+		}
+		var req propertyRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxPropertyRequestSize)).Decode(&req); err != nil {
+			http.Error(w, "decode request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.serve(w, r, func() (any, error) {
+			return "ok", s.drawer.SetProperty(req.Path, req.Field, req.Value)
+		})
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request, fn func() (any, error)) {
+	// The buffered channel makes it possible for Update to
+	// complete the request even if the client has gone away.
+	req := &request{fn: fn, done: make(chan response, 1)}
+
+	select {
+	case s.requests <- req:
+	case <-r.Context().Done():
+		return
+	}
+
+	var resp response
+	select {
+	case resp = <-req.done:
+	case <-r.Context().Done():
+		return
+	}
+
+	if resp.err != nil {
+		http.Error(w, resp.err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp.result); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
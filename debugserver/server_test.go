@@ -0,0 +1,129 @@
+package debugserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+
+	graphics "github.com/quasilyte/ebitengine-graphics"
+)
+
+func newTestServer() (*Server, *graphics.Rect) {
+	layer := graphics.NewLayer()
+	r := graphics.NewRect(8, 8)
+	layer.AddChild(r)
+	layer.SetObjectName(r, "box")
+	return NewServer(graphics.NewSceneDrawer([]graphics.SceneLayerDrawer{layer})), r
+}
+
+// serveRequest runs the handler while emulating the game loop Update calls.
+func serveRequest(s *Server, r *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		s.ServeHTTP(w, r)
+		close(done)
+	}()
+	for {
+		select {
+		case <-done:
+			return w
+		default:
+			s.Update()
+			runtime.Gosched()
+		}
+	}
+}
+
+func newPropertyRequest(body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/property", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	return r
+}
+
+func TestServerScene(t *testing.T) {
+	s, _ := newTestServer()
+
+	w := serveRequest(s, httptest.NewRequest(http.MethodGet, "/scene", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /scene: status %d: %s", w.Code, w.Body)
+	}
+	var layers []graphics.LayerInfo
+	if err := json.Unmarshal(w.Body.Bytes(), &layers); err != nil {
+		t.Fatal(err)
+	}
+	if len(layers) != 1 || len(layers[0].Objects) != 1 || layers[0].Objects[0].Name != "box" {
+		t.Fatalf("GET /scene: unexpected result %s", w.Body)
+	}
+	if have := w.Header().Get("Access-Control-Allow-Origin"); have != "" {
+		t.Fatalf("GET /scene: unexpected CORS header %q", have)
+	}
+
+	s.SetAllowedOrigin("http://localhost:3000")
+	r := httptest.NewRequest(http.MethodGet, "/scene", nil)
+	r.Header.Set("Origin", "http://localhost:3000")
+	w = serveRequest(s, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /scene from the allowed origin: status %d", w.Code)
+	}
+	if have := w.Header().Get("Access-Control-Allow-Origin"); have != "http://localhost:3000" {
+		t.Fatalf("GET /scene: CORS header is %q", have)
+	}
+}
+
+func TestServerProperty(t *testing.T) {
+	s, box := newTestServer()
+
+	w := serveRequest(s, newPropertyRequest(`{"path": "0/box", "field": "visible", "value": "false"}`))
+	if w.Code != http.StatusOK {
+		t.Fatalf("POST /property: status %d: %s", w.Code, w.Body)
+	}
+	if box.IsVisible() {
+		t.Fatal("POST /property: the object is still visible")
+	}
+
+	w = serveRequest(s, newPropertyRequest(`{"path": "0/box", "field": "speed", "value": "1"}`))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("POST /property with unsupported field: status %d", w.Code)
+	}
+}
+
+func TestServerRejectedRequests(t *testing.T) {
+	s, box := newTestServer()
+	s.SetAllowedOrigin("http://localhost:3000")
+
+	form := httptest.NewRequest(http.MethodPost, "/property", strings.NewReader("path=0/box&field=visible&value=false"))
+	form.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	crossOrigin := newPropertyRequest(`{"path": "0/box", "field": "visible", "value": "false"}`)
+	crossOrigin.Header.Set("Origin", "http://example.com")
+
+	crossOriginScene := httptest.NewRequest(http.MethodGet, "/scene", nil)
+	crossOriginScene.Header.Set("Origin", "http://example.com")
+
+	tests := []struct {
+		name string
+		r    *http.Request
+		want int
+	}{
+		{"POST /scene", httptest.NewRequest(http.MethodPost, "/scene", nil), http.StatusMethodNotAllowed},
+		{"GET /property", httptest.NewRequest(http.MethodGet, "/property", nil), http.StatusMethodNotAllowed},
+		{"form POST /property", form, http.StatusUnsupportedMediaType},
+		{"malformed POST /property", newPropertyRequest(`{"path"`), http.StatusBadRequest},
+		{"cross-origin POST /property", crossOrigin, http.StatusForbidden},
+		{"cross-origin GET /scene", crossOriginScene, http.StatusForbidden},
+		{"GET /unknown", httptest.NewRequest(http.MethodGet, "/unknown", nil), http.StatusNotFound},
+	}
+	for _, test := range tests {
+		w := serveRequest(s, test.r)
+		if w.Code != test.want {
+			t.Fatalf("%s: have status %d, want %d", test.name, w.Code, test.want)
+		}
+	}
+	if !box.IsVisible() {
+		t.Fatal("a rejected request changed the object")
+	}
+}
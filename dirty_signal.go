@@ -0,0 +1,86 @@
+package graphics
+
+import (
+	"slices"
+)
+
+// Invalidatable is implemented by the objects that keep some derived state
+// (like a pre-rendered image) that can be marked for re-calculation.
+//
+// [CacheGroup] and [Container] implement this interface.
+type Invalidatable interface {
+	MarkDirty()
+}
+
+// DirtySource is implemented by the objects that notify their dependents
+// about the changes via [DirtySignal].
+//
+// The parent objects like [CacheGroup] and [Container] connect themselves
+// to the signals of their children automatically, so a change of a child
+// is propagated through the entire chain: a tile changed inside a
+// [TileMap] that is a part of a container inside a cache group
+// makes the cache group re-render itself.
+type DirtySource interface {
+	DirtySignal() *DirtySignal
+}
+
+// DirtySignal is a lightweight change notification list.
+// Emitting a signal marks all connected dependents as dirty.
+//
+// The disposed dependents (the ones with IsDisposed method reporting true)
+// are disconnected automatically during the next Emit call.
+// Cyclic dependencies are tolerated: the re-entrant Emit calls are ignored.
+//
+// A zero value is ready to use.
+type DirtySignal struct {
+	deps     []Invalidatable
+	emitting bool
+}
+
+// Connect adds a dependent that is marked dirty on every Emit call.
+// Connecting the same dependent twice is a no-op.
+func (s *DirtySignal) Connect(dep Invalidatable) {
+	if slices.Contains(s.deps, dep) {
+		return
+	}
+	s.deps = append(s.deps, dep)
+}
+
+// Disconnect removes the dependent added by Connect.
+func (s *DirtySignal) Disconnect(dep Invalidatable) {
+	if i := slices.Index(s.deps, dep); i != -1 {
+		s.deps = slices.Delete(s.deps, i, i+1)
+	}
+}
+
+// NumConnected reports the number of the connected dependents.
+func (s *DirtySignal) NumConnected() int {
+	return len(s.deps)
+}
+
+// Emit marks all connected dependents as dirty.
+func (s *DirtySignal) Emit() {
+	if s.emitting || len(s.deps) == 0 {
+		return
+	}
+	s.emitting = true
+	liveDeps := s.deps[:0]
+	for _, dep := range s.deps {
+		if d, ok := dep.(interface{ IsDisposed() bool }); ok && d.IsDisposed() {
+			continue
+		}
+		liveDeps = append(liveDeps, dep)
+		dep.MarkDirty()
+	}
+	clear(s.deps[len(liveDeps):])
+	s.deps = liveDeps
+	s.emitting = false
+}
+
+// connectDirtySource connects the parent to the child's dirty signal
+// if the child is a dirty source.
+func connectDirtySource(child any, parent Invalidatable) {
+	if src, ok := child.(DirtySource); ok {
+		src.DirtySignal().Connect(parent)
+	}
+}
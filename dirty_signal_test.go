@@ -0,0 +1,83 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type testInvalidatable struct {
+	n        int
+	disposed bool
+	signal   *DirtySignal
+}
+
+func (o *testInvalidatable) MarkDirty() {
+	o.n++
+	if o.signal != nil {
+		o.signal.Emit()
+	}
+}
+
+func (o *testInvalidatable) IsDisposed() bool { return o.disposed }
+
+func TestDirtySignal(t *testing.T) {
+	var s DirtySignal
+	a := &testInvalidatable{}
+	b := &testInvalidatable{}
+	s.Connect(a)
+	s.Connect(a)
+	s.Connect(b)
+	if have := s.NumConnected(); have != 2 {
+		t.Fatalf("connected:\nhave: %d\nwant: 2", have)
+	}
+
+	s.Emit()
+	b.disposed = true
+	s.Emit()
+	if a.n != 2 || b.n != 1 {
+		t.Fatalf("unexpected notifications: a=%d b=%d", a.n, b.n)
+	}
+	if have := s.NumConnected(); have != 1 {
+		t.Fatalf("connected after dispose:\nhave: %d\nwant: 1", have)
+	}
+
+	s.Disconnect(a)
+	s.Emit()
+	if a.n != 2 {
+		t.Fatalf("a disconnected dependent is notified")
+	}
+}
+
+func TestDirtySignalCycle(t *testing.T) {
+	var s DirtySignal
+	o := &testInvalidatable{signal: &s}
+	s.Connect(o)
+	s.Emit()
+	if o.n != 1 {
+		t.Fatalf("notifications:\nhave: %d\nwant: 1", o.n)
+	}
+}
+
+func TestDirtyPropagation(t *testing.T) {
+	m := NewTileMap(TileMapConfig{
+		Tileset:    ebiten.NewImage(32, 32),
+		TileWidth:  16,
+		TileHeight: 16,
+	}, 4, 4)
+	c := NewContainer()
+	c.AddChild(m)
+	g := NewCacheGroup(64, 64)
+	g.AddChild(c)
+	outer := NewCacheGroup(64, 64)
+	outer.AddChild(g)
+
+	// Emulate the rendered state.
+	g.dirty = false
+	outer.dirty = false
+
+	m.SetTile(1, 1, 0)
+	if !g.IsDirty() || !outer.IsDirty() {
+		t.Fatalf("the change is not propagated: %v %v", g.IsDirty(), outer.IsDirty())
+	}
+}
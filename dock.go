@@ -0,0 +1,248 @@
+package graphics
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// DockSide is a [DockContainer] edge the child is attached to.
+type DockSide uint8
+
+const (
+	DockTop DockSide = iota
+	DockBottom
+	DockLeft
+	DockRight
+
+	// DockFill places the child inside the area that is left
+	// after all previous children are docked.
+	// It doesn't consume any space.
+	DockFill
+)
+
+// DockParams describes the [DockContainer] child placement.
+type DockParams struct {
+	Side DockSide
+
+	// Margin is an extra space around the child.
+	// A zero value means no margin.
+	Margin float64
+
+	// Align is a child alignment along the docked edge.
+	// For DockTop and DockBottom it's a horizontal alignment,
+	// for DockLeft and DockRight it's a vertical one;
+	// DockFill uses it for both axes.
+	// A zero value means BoxAlignStart.
+	Align BoxAlign
+}
+
+// DockContainer attaches its children to its edges.
+// It's useful to compose the full-screen HUDs: a resource bar
+// at the top, a minimap at the bottom-right corner, and so on.
+//
+// The children are docked in the order they were added:
+// every docked child takes its space from the remaining area,
+// so the next child is placed inside of what is left.
+//
+// Like [BoxContainer], it places the children using their BoundsRect values.
+// The invisible children don't take any space.
+// The layout is re-computed during every Draw call, so the HUD follows
+// the container size changes (see SetSize) immediately.
+//
+// The Pos is a container top-left corner.
+//
+// DockContainer implements gscene Graphics interface.
+type DockContainer struct {
+	Pos gmath.Pos
+
+	objects []DisposableObject
+
+	// params and offsets are parallel slices for objects.
+	// The offsets are computed by the last layout call.
+	params  []DockParams
+	offsets []gmath.Vec
+
+	size    gmath.Vec
+	padding float64
+
+	changed DirtySignal
+
+	visible  bool
+	disposed bool
+}
+
+// NewDock creates a dock container of the specified size.
+//
+// For a full-screen HUD, it's usually a camera viewport size;
+// call SetSize when the window is resized.
+func NewDock(width, height float64) *DockContainer {
+	return &DockContainer{
+		objects: make([]DisposableObject, 0, 4),
+		size:    gmath.Vec{X: width, Y: height},
+		visible: true,
+	}
+}
+
+// AddChild docks an object according to the params.
+func (d *DockContainer) AddChild(o DisposableObject, params DockParams) {
+	d.objects = append(d.objects, o)
+	d.params = append(d.params, params)
+	connectDirtySource(o, d)
+	d.changed.Emit()
+}
+
+// MarkDirty notifies the container dependents about a change.
+func (d *DockContainer) MarkDirty() {
+	d.changed.Emit()
+}
+
+// DirtySignal returns a signal that is emitted when the container
+// or one of its [DirtySource] children is changed.
+// It implements the [DirtySource] interface.
+func (d *DockContainer) DirtySignal() *DirtySignal {
+	return &d.changed
+}
+
+// GetSize returns the container size.
+func (d *DockContainer) GetSize() (w, h float64) {
+	return d.size.X, d.size.Y
+}
+
+// SetSize changes the container size.
+func (d *DockContainer) SetSize(w, h float64) {
+	d.size = gmath.Vec{X: w, Y: h}
+	d.changed.Emit()
+}
+
+// GetPadding returns the current padding value.
+func (d *DockContainer) GetPadding() float64 { return d.padding }
+
+// SetPadding changes the extra space between the container edges and its children.
+func (d *DockContainer) SetPadding(padding float64) {
+	d.padding = padding
+	d.changed.Emit()
+}
+
+// IsDisposed reports whether this container is marked for deletion.
+func (d *DockContainer) IsDisposed() bool { return d.disposed }
+
+// Dispose marks this container and all of its children for deletion.
+func (d *DockContainer) Dispose() {
+	for _, o := range d.objects {
+		o.Dispose()
+	}
+	d.disposed = true
+}
+
+// IsVisible reports whether this container is visible.
+// Use SetVisibility to change this flag value.
+func (d *DockContainer) IsVisible() bool { return d.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (d *DockContainer) SetVisibility(visible bool) { d.visible = visible }
+
+// BoundsRect returns the container area.
+func (d *DockContainer) BoundsRect() gmath.Rect {
+	pos := d.Pos.Resolve()
+	return gmath.Rect{Min: pos, Max: pos.Add(d.size)}
+}
+
+// Draw renders the container children onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (d *DockContainer) Draw(dst *ebiten.Image) {
+	d.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the container children onto the provided dst image
+// while also using the extra provided offset.
+func (d *DockContainer) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !d.visible {
+		return
+	}
+
+	d.layout()
+
+	offset := opts.Offset.Add(d.Pos.Resolve())
+	for i, o := range d.objects {
+		if v, ok := o.(visibleObject); ok && !v.IsVisible() {
+			continue
+		}
+		opts.Offset = offset.Add(d.offsets[i])
+		drawObject(dst, o, opts)
+	}
+}
+
+// layout removes the disposed children and re-computes the children offsets.
+func (d *DockContainer) layout() {
+	liveObjects := d.objects[:0]
+	liveParams := d.params[:0]
+	for i, o := range d.objects {
+		if o.IsDisposed() {
+			continue
+		}
+		liveObjects = append(liveObjects, o)
+		liveParams = append(liveParams, d.params[i])
+	}
+	clear(d.objects[len(liveObjects):])
+	d.objects = liveObjects
+	d.params = liveParams
+
+	d.offsets = d.offsets[:0]
+
+	pad := gmath.Vec{X: d.padding, Y: d.padding}
+	area := gmath.Rect{Min: pad, Max: d.size.Sub(pad)}
+	for i, o := range d.objects {
+		bounds, ok := layoutChildBounds(o)
+		if !ok {
+			d.offsets = append(d.offsets, gmath.Vec{})
+			continue
+		}
+		p := d.params[i]
+		m := p.Margin
+		inner := gmath.Rect{
+			Min: area.Min.Add(gmath.Vec{X: m, Y: m}),
+			Max: area.Max.Sub(gmath.Vec{X: m, Y: m}),
+		}
+		size := bounds.Size()
+
+		var pos gmath.Vec
+		switch p.Side {
+		case DockTop:
+			pos.X = dockAlign(inner.Min.X, inner.Max.X, size.X, p.Align)
+			pos.Y = inner.Min.Y
+			area.Min.Y += size.Y + 2*m
+		case DockBottom:
+			pos.X = dockAlign(inner.Min.X, inner.Max.X, size.X, p.Align)
+			pos.Y = inner.Max.Y - size.Y
+			area.Max.Y -= size.Y + 2*m
+		case DockLeft:
+			pos.X = inner.Min.X
+			pos.Y = dockAlign(inner.Min.Y, inner.Max.Y, size.Y, p.Align)
+			area.Min.X += size.X + 2*m
+		case DockRight:
+			pos.X = inner.Max.X - size.X
+			pos.Y = dockAlign(inner.Min.Y, inner.Max.Y, size.Y, p.Align)
+			area.Max.X -= size.X + 2*m
+		case DockFill:
+			pos.X = dockAlign(inner.Min.X, inner.Max.X, size.X, p.Align)
+			pos.Y = dockAlign(inner.Min.Y, inner.Max.Y, size.Y, p.Align)
+		}
+		d.offsets = append(d.offsets, pos.Sub(bounds.Min))
+	}
+}
+
+func dockAlign(from, to, size float64, align BoxAlign) float64 {
+	switch align {
+	case BoxAlignCenter:
+		return from + (to-from-size)*0.5
+	case BoxAlignEnd:
+		return to - size
+	default:
+		return from
+	}
+}
@@ -0,0 +1,46 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/quasilyte/gmath"
+)
+
+func TestDockLayout(t *testing.T) {
+	newRect := func(w, h float64) *Rect {
+		r := NewRect(w, h)
+		r.SetCentered(false)
+		return r
+	}
+
+	d := NewDock(100, 80)
+	d.SetPadding(2)
+	d.AddChild(newRect(50, 10), DockParams{Side: DockTop, Align: BoxAlignCenter})
+	d.AddChild(newRect(20, 20), DockParams{Side: DockBottom, Margin: 1, Align: BoxAlignEnd})
+	d.AddChild(newRect(10, 30), DockParams{Side: DockLeft})
+	d.AddChild(newRect(10, 10), DockParams{Side: DockFill, Align: BoxAlignCenter})
+
+	d.layout()
+	wantOffsets := []gmath.Vec{
+		// Centered inside [2, 98].
+		{X: 25, Y: 2},
+		// The bottom-right corner minus the margin.
+		{X: 77, Y: 57},
+		// Below the top child.
+		{X: 2, Y: 12},
+		// Centered inside x=[12, 98], y=[12, 56].
+		{X: 50, Y: 29},
+	}
+	for i, want := range wantOffsets {
+		if have := d.offsets[i]; have != want {
+			t.Fatalf("offsets[%d]:\nhave: %v\nwant: %v", i, have, want)
+		}
+	}
+
+	// The layout follows the size changes.
+	d.SetSize(200, 80)
+	d.layout()
+	if have := d.offsets[1]; have != (gmath.Vec{X: 177, Y: 57}) {
+		t.Fatalf("offsets[1] after resize: have %v, want {177, 57}", have)
+	}
+}
@@ -20,6 +20,7 @@ type DottedLine struct {
 
 	visible  bool
 	disposed bool
+	blendID  uint8
 }
 
 // NewDottedLine returns a line that is drawn from begin pos to end pos.
@@ -131,6 +132,8 @@ func (l *DottedLine) Draw(dst *ebiten.Image) {
 }
 
 func (l *DottedLine) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	opts.Blend = resolveBlend(l.blendID, opts.Blend)
+
 	if !l.visible {
 		return
 	}
@@ -152,5 +155,24 @@ func (l *DottedLine) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
 		drawOptions.Blend = *opts.Blend
 	}
 	drawOptions.GeoM.Translate(pos.X, pos.Y)
-	dst.DrawRectShader(int(width), int(height), cache.Global.DottedLineShader, &drawOptions)
+	drawRectShader(dst, int(width), int(height), cache.Global.DottedLineShader, &drawOptions)
+}
+
+// GetBlend returns the blend mode assigned by SetBlend.
+// The second result value is false if there is no blend override.
+func (l *DottedLine) GetBlend() (ebiten.Blend, bool) {
+	return getBlend(l.blendID)
+}
+
+// SetBlend assigns a blend mode that is used to render this line.
+// It takes priority over the DrawOptions.Blend value.
+// Use ResetBlend to remove the override.
+func (l *DottedLine) SetBlend(b ebiten.Blend) {
+	l.blendID = internBlend(b)
+}
+
+// ResetBlend removes the blend mode override.
+// The DrawOptions.Blend value (if any) will be used again.
+func (l *DottedLine) ResetBlend() {
+	l.blendID = 0
 }
@@ -0,0 +1,186 @@
+package graphics
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// DragVisualConfig describes the [DragVisual] appearance.
+type DragVisualConfig struct {
+	// Alpha is a drag ghost opacity.
+	// A zero value means 0.6.
+	Alpha float32
+
+	// ValidColorScale is a ghost tint used when the drop is allowed.
+	// A zero value means {1, 1, 1, 1}.
+	ValidColorScale ColorScale
+
+	// InvalidColorScale is a ghost tint used when the drop is not allowed.
+	// A zero value means a red-ish color.
+	InvalidColorScale ColorScale
+}
+
+// DragVisual renders a semi-transparent copy of the dragged object
+// that follows the cursor.
+// It's intended for the inventory-style drag-and-drop UIs.
+//
+// The dragged object is rendered into an offscreen image once,
+// when the dragging starts; the changes of the original object
+// are not reflected by the ghost until the next Start call.
+//
+// The Pos is a cursor position binder.
+// The ghost keeps the initial cursor offset relative to the object,
+// so it doesn't jump under the cursor when the dragging starts.
+//
+// DragVisual implements gscene Graphics interface.
+type DragVisual struct {
+	Pos gmath.Pos
+
+	config DragVisualConfig
+
+	img *ebiten.Image
+
+	// grabOffset is a cursor position relative to the ghost top-left corner.
+	grabOffset gmath.Vec
+
+	validColor   ebiten.ColorScale
+	invalidColor ebiten.ColorScale
+
+	dragging bool
+	valid    bool
+	visible  bool
+	disposed bool
+}
+
+// DraggableObject is an object that can be dragged using [DragVisual].
+type DraggableObject interface {
+	Object
+	BoundedObject
+}
+
+// NewDragVisual creates an inactive drag visual with the specified config.
+func NewDragVisual(config DragVisualConfig) *DragVisual {
+	if config.Alpha == 0 {
+		config.Alpha = 0.6
+	}
+	if config.ValidColorScale == (ColorScale{}) {
+		config.ValidColorScale = defaultColorScale
+	}
+	if config.InvalidColorScale == (ColorScale{}) {
+		config.InvalidColorScale = ColorScale{R: 1, G: 0.3, B: 0.3, A: 1}
+	}
+	d := &DragVisual{
+		config:  config,
+		valid:   true,
+		visible: true,
+	}
+	validColor := config.ValidColorScale.ScaleAlpha(config.Alpha)
+	invalidColor := config.InvalidColorScale.ScaleAlpha(config.Alpha)
+	d.validColor = validColor.ToEbitenColorScale()
+	d.invalidColor = invalidColor.ToEbitenColorScale()
+	return d
+}
+
+// Start captures the object image and starts the dragging.
+// The cursor is a current cursor position
+// (in the same coordinates as the object bounds).
+//
+// The drop validity is reset to true.
+func (d *DragVisual) Start(o DraggableObject, cursor gmath.Vec) {
+	bounds := o.BoundsRect()
+	origin := gmath.Vec{X: math.Floor(bounds.Min.X), Y: math.Floor(bounds.Min.Y)}
+	width := int(math.Ceil(bounds.Max.X - origin.X))
+	height := int(math.Ceil(bounds.Max.Y - origin.Y))
+
+	d.img = ensureImageSize(d.img, max(width, 1), max(height, 1))
+	clearImage(d.img)
+	o.DrawWithOptions(d.img, DrawOptions{Offset: origin.Neg()})
+
+	d.grabOffset = cursor.Sub(origin)
+	d.Pos.Offset = cursor
+	d.dragging = true
+	d.valid = true
+}
+
+// Stop finishes the dragging and hides the ghost.
+func (d *DragVisual) Stop() { d.dragging = false }
+
+// IsDragging reports whether the dragging is in progress.
+func (d *DragVisual) IsDragging() bool { return d.dragging }
+
+// IsValid reports whether the drop is allowed.
+// Use SetValid to change it.
+func (d *DragVisual) IsValid() bool { return d.valid }
+
+// SetValid changes the drop validity tint.
+func (d *DragVisual) SetValid(valid bool) { d.valid = valid }
+
+// BoundsRect returns the current ghost rect.
+func (d *DragVisual) BoundsRect() gmath.Rect {
+	if d.img == nil {
+		return gmath.Rect{}
+	}
+	pos := d.Pos.Resolve().Sub(d.grabOffset)
+	bounds := d.img.Bounds()
+	return gmath.Rect{
+		Min: pos,
+		Max: pos.Add(gmath.Vec{X: float64(bounds.Dx()), Y: float64(bounds.Dy())}),
+	}
+}
+
+// Dispose marks this object for deletion and releases its offscreen image.
+// After calling this method, IsDisposed will report true.
+func (d *DragVisual) Dispose() {
+	if d.img != nil {
+		d.img.Deallocate()
+		d.img = nil
+	}
+	d.dragging = false
+	d.disposed = true
+}
+
+// IsDisposed reports whether this object is marked for deletion.
+func (d *DragVisual) IsDisposed() bool { return d.disposed }
+
+// IsVisible reports whether this object is visible.
+// Use SetVisibility to change this flag value.
+//
+// The ghost is only rendered during the dragging, regardless of this flag.
+func (d *DragVisual) IsVisible() bool { return d.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (d *DragVisual) SetVisibility(visible bool) { d.visible = visible }
+
+// Draw renders the drag ghost onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (d *DragVisual) Draw(dst *ebiten.Image) {
+	d.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the drag ghost onto the provided dst image
+// while also using the extra provided offset.
+func (d *DragVisual) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !d.visible || !d.dragging {
+		return
+	}
+
+	pos := d.BoundsRect().Min.Add(opts.Offset)
+	var drawOptions ebiten.DrawImageOptions
+	if opts.Blend != nil {
+		drawOptions.Blend = *opts.Blend
+	}
+	if d.valid {
+		drawOptions.ColorScale = d.validColor
+	} else {
+		drawOptions.ColorScale = d.invalidColor
+	}
+	drawOptions.GeoM.Translate(math.Round(pos.X), math.Round(pos.Y))
+	drawImage(dst, d.img, &drawOptions)
+}
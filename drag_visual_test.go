@@ -0,0 +1,43 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+func TestDragVisual(t *testing.T) {
+	d := NewDragVisual(DragVisualConfig{})
+	if d.IsDragging() {
+		t.Fatal("a new drag visual should be inactive")
+	}
+
+	item := NewRect(16, 16)
+	item.Pos.Offset = gmath.Vec{X: 50, Y: 50}
+
+	// Grab the item at its bottom-right quarter.
+	d.Start(item, gmath.Vec{X: 54, Y: 56})
+	if !d.IsDragging() || !d.IsValid() {
+		t.Fatal("Start should activate the dragging")
+	}
+	want := gmath.Rect{Min: gmath.Vec{X: 42, Y: 42}, Max: gmath.Vec{X: 58, Y: 58}}
+	if have := d.BoundsRect(); have != want {
+		t.Fatalf("bounds:\nhave: %v\nwant: %v", have, want)
+	}
+
+	// The ghost keeps the grab offset.
+	d.Pos.Offset = gmath.Vec{X: 104, Y: 106}
+	want = gmath.Rect{Min: gmath.Vec{X: 92, Y: 92}, Max: gmath.Vec{X: 108, Y: 108}}
+	if have := d.BoundsRect(); have != want {
+		t.Fatalf("bounds after move:\nhave: %v\nwant: %v", have, want)
+	}
+
+	d.SetValid(false)
+	d.Draw(ebiten.NewImage(128, 128))
+
+	d.Stop()
+	if d.IsDragging() {
+		t.Fatal("Stop should finish the dragging")
+	}
+}
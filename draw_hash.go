@@ -0,0 +1,491 @@
+package graphics
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"math"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+)
+
+// SetDrawHashing enables or disables the draw command stream hashing.
+//
+// When it's enabled, every draw call of this package
+// (images, triangles, shaders and texts) is hashed along with
+// its parameters: the destination bounds, the source image identity,
+// the resolved transformation matrix, the color scale, the blend mode,
+// the vertices, the shader uniforms and so on.
+// The hash of the draw calls that were rendered into
+// the destination image by the last [SceneDrawer.Draw]
+// is reported by [SceneDrawer.DrawHash].
+//
+// The hash is deterministic for the same scene updates sequence,
+// so CI can compare the frame hashes to detect unintended
+// rendering changes without pixel comparisons.
+//
+// The source images are identified by their registry keys (see [RegisterImage]),
+// the sub-images are identified by their original images and bounds.
+// The offscreen images (like the label caches and camera buffers)
+// are identified by the draw calls that were rendered into them,
+// so a cached object affects the hash even if it's not re-rendered
+// during this frame, and an unchanged scene has the same hash
+// in every frame.
+// The unregistered images that were never drawn into are identified
+// by their first appearance order during the frame.
+//
+// The hashing should be enabled before the first scene Draw call,
+// otherwise the images that were created (and rendered)
+// before that are not identified properly.
+//
+// The hashing makes the rendering noticeably slower,
+// so it's intended to be used for testing and debugging only.
+func SetDrawHashing(enabled bool) {
+	switch {
+	case enabled && drawHashState == nil:
+		drawHashState = newDrawHash()
+	case !enabled:
+		drawHashState = nil
+	}
+}
+
+// IsDrawHashing reports whether the draw command stream hashing is enabled.
+// Use SetDrawHashing to change it.
+func IsDrawHashing() bool {
+	return drawHashState != nil
+}
+
+// DrawHash returns the hash of the draw command stream
+// that was rendered into the destination image by the last Draw call.
+// It's zero if the draw hashing is disabled (see [SetDrawHashing]).
+func (d *SceneDrawer) DrawHash() uint64 { return d.lastDrawHash }
+
+// drawHashState is non-nil while the draw hashing is enabled.
+var drawHashState *drawHash
+
+// drawHash hashes the draw calls.
+//
+// Every draw call is hashed separately;
+// the call hash is mixed into the destination image content hash,
+// so the offscreen images can be identified by their contents.
+// The calls that draw onto the frame target image are
+// also added to the frame hash.
+type drawHash struct {
+	frame hash.Hash64
+	call  hash.Hash64
+	buf   [8]byte
+
+	// depth is greater than zero while the scene is drawn.
+	// The calls outside of the scene drawing still update
+	// the images contents, but they don't affect the frame hash.
+	depth int
+
+	// frameTarget is the scene drawing destination.
+	frameTarget *drawHashImage
+
+	numFrames uint64
+
+	// nextOrder is used to number the images in their
+	// first appearance order during the frame.
+	nextOrder uint64
+
+	// nextID is used to identify the shaders and font faces.
+	nextID uint64
+
+	images  map[*ebiten.Image]*drawHashImage
+	shaders map[*ebiten.Shader]uint64
+	faces   map[text.Face]uint64
+
+	uniformKeys []string
+}
+
+type drawHashImage struct {
+	key string
+
+	// order is the image first appearance order
+	// during the orderFrame frame.
+	order      uint64
+	orderFrame uint64
+
+	// root is an original image of the sub-image (see subImage).
+	// It's nil for the original images.
+	root *ebiten.Image
+
+	// content is a hash of the draw calls that were
+	// rendered into this image since the last clearing.
+	content uint64
+
+	// frame is the last frame this image was used.
+	// The images that are used between the frames are
+	// assigned to the next frame.
+	// The unused images are removed at the frame end.
+	frame uint64
+}
+
+func newDrawHash() *drawHash {
+	return &drawHash{
+		frame:   fnv.New64a(),
+		call:    fnv.New64a(),
+		images:  make(map[*ebiten.Image]*drawHashImage),
+		shaders: make(map[*ebiten.Shader]uint64),
+		faces:   make(map[text.Face]uint64),
+	}
+}
+
+func (h *drawHash) beginFrame(dst *ebiten.Image) {
+	if h.depth == 0 {
+		h.frame.Reset()
+		h.numFrames++
+		h.nextOrder = 0
+		h.depth++
+		h.frameTarget = h.target(dst)
+		return
+	}
+	h.depth++
+}
+
+func (h *drawHash) endFrame() uint64 {
+	h.depth--
+	if h.depth != 0 {
+		return 0
+	}
+	h.frameTarget = nil
+	for img, e := range h.images {
+		if e.frame < h.numFrames {
+			delete(h.images, img)
+		}
+	}
+	return h.frame.Sum64()
+}
+
+func (h *drawHash) image(img *ebiten.Image) *drawHashImage {
+	e := h.images[img]
+	if e == nil {
+		e = &drawHashImage{key: cache.Global.FindImageKey(img)}
+		h.images[img] = e
+	}
+	e.frame = h.numFrames
+	if h.depth == 0 {
+		e.frame++
+	}
+	return e
+}
+
+// target returns the image that holds the img contents.
+func (h *drawHash) target(img *ebiten.Image) *drawHashImage {
+	e := h.image(img)
+	if e.root != nil {
+		return h.image(e.root)
+	}
+	return e
+}
+
+func (h *drawHash) addSubImage(sub, img *ebiten.Image) {
+	root := img
+	if e := h.images[img]; e != nil && e.root != nil {
+		root = e.root
+	}
+	h.image(sub).root = root
+}
+
+func (h *drawHash) beginCall(kind uint8, dst *ebiten.Image) {
+	h.call.Reset()
+	h.writeInt(int64(kind))
+	h.writeBounds(dst.Bounds())
+}
+
+func (h *drawHash) endCall(dst *ebiten.Image) {
+	sum := h.call.Sum64()
+	e := h.target(dst)
+	e.content = (e.content ^ sum) * 1099511628211
+	if e == h.frameTarget {
+		binary.LittleEndian.PutUint64(h.buf[:], sum)
+		h.frame.Write(h.buf[:])
+	}
+}
+
+func (h *drawHash) writeImage(img *ebiten.Image) {
+	if img == nil {
+		h.writeInt(0)
+		return
+	}
+	e := h.target(img)
+	if e.key != "" {
+		h.writeInt(1)
+		h.writeString(e.key)
+	} else if e.content != 0 {
+		h.writeInt(2)
+		h.writeUint(e.content)
+	} else {
+		if e.orderFrame != h.numFrames {
+			h.nextOrder++
+			e.order = h.nextOrder
+			e.orderFrame = h.numFrames
+		}
+		h.writeInt(3)
+		h.writeUint(e.order)
+	}
+	h.writeBounds(img.Bounds())
+}
+
+func (h *drawHash) writeShader(shader *ebiten.Shader) {
+	id, ok := h.shaders[shader]
+	if !ok {
+		h.nextID++
+		id = h.nextID
+		h.shaders[shader] = id
+	}
+	h.writeInt(int64(id))
+}
+
+func (h *drawHash) writeFace(face text.Face) {
+	id, ok := h.faces[face]
+	if !ok {
+		h.nextID++
+		id = h.nextID
+		h.faces[face] = id
+	}
+	h.writeInt(int64(id))
+}
+
+func (h *drawHash) writeUniforms(uniforms map[string]any) {
+	keys := h.uniformKeys[:0]
+	for k := range uniforms {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	h.writeInt(int64(len(keys)))
+	for _, k := range keys {
+		h.writeString(k)
+		switch v := uniforms[k].(type) {
+		case float32:
+			h.writeFloat(float64(v))
+		case []float32:
+			h.writeInt(int64(len(v)))
+			for _, x := range v {
+				h.writeFloat(float64(x))
+			}
+		case int:
+			h.writeInt(int64(v))
+		default:
+			h.writeString(fmt.Sprint(v))
+		}
+	}
+	h.uniformKeys = keys[:0]
+}
+
+func (h *drawHash) writeGeoM(m *ebiten.GeoM) {
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 3; j++ {
+			h.writeFloat(m.Element(i, j))
+		}
+	}
+}
+
+func (h *drawHash) writeColorScale(cs *ebiten.ColorScale) {
+	h.writeFloat(float64(cs.R()))
+	h.writeFloat(float64(cs.G()))
+	h.writeFloat(float64(cs.B()))
+	h.writeFloat(float64(cs.A()))
+}
+
+func (h *drawHash) writeBlend(b *ebiten.Blend) {
+	h.writeInt(int64(b.BlendFactorSourceRGB))
+	h.writeInt(int64(b.BlendFactorSourceAlpha))
+	h.writeInt(int64(b.BlendFactorDestinationRGB))
+	h.writeInt(int64(b.BlendFactorDestinationAlpha))
+	h.writeInt(int64(b.BlendOperationRGB))
+	h.writeInt(int64(b.BlendOperationAlpha))
+}
+
+func (h *drawHash) writeDrawImageOptions(opts *ebiten.DrawImageOptions) {
+	h.writeGeoM(&opts.GeoM)
+	h.writeColorScale(&opts.ColorScale)
+	h.writeBlend(&opts.Blend)
+	h.writeInt(int64(opts.Filter))
+}
+
+func (h *drawHash) writeTriangles(vertices []ebiten.Vertex, indices []uint16) {
+	h.writeInt(int64(len(vertices)))
+	for i := range vertices {
+		v := &vertices[i]
+		h.writeFloat(float64(v.DstX))
+		h.writeFloat(float64(v.DstY))
+		h.writeFloat(float64(v.SrcX))
+		h.writeFloat(float64(v.SrcY))
+		h.writeFloat(float64(v.ColorR))
+		h.writeFloat(float64(v.ColorG))
+		h.writeFloat(float64(v.ColorB))
+		h.writeFloat(float64(v.ColorA))
+	}
+	h.writeInt(int64(len(indices)))
+	for _, idx := range indices {
+		h.writeInt(int64(idx))
+	}
+}
+
+func (h *drawHash) writeBounds(r image.Rectangle) {
+	h.writeInt(int64(r.Min.X))
+	h.writeInt(int64(r.Min.Y))
+	h.writeInt(int64(r.Max.X))
+	h.writeInt(int64(r.Max.Y))
+}
+
+func (h *drawHash) writeString(s string) {
+	h.writeInt(int64(len(s)))
+	h.call.Write([]byte(s))
+}
+
+func (h *drawHash) writeBool(v bool) {
+	if v {
+		h.writeInt(1)
+	} else {
+		h.writeInt(0)
+	}
+}
+
+func (h *drawHash) writeInt(v int64) {
+	h.writeUint(uint64(v))
+}
+
+func (h *drawHash) writeUint(v uint64) {
+	binary.LittleEndian.PutUint64(h.buf[:], v)
+	h.call.Write(h.buf[:])
+}
+
+func (h *drawHash) writeFloat(v float64) {
+	h.writeUint(math.Float64bits(v))
+}
+
+// The draw call kinds.
+const (
+	drawHashImageCall uint8 = iota + 1
+	drawHashTrianglesCall
+	drawHashRectShaderCall
+	drawHashTrianglesShaderCall
+	drawHashTextCall
+	drawHashClearCall
+	drawHashFillCall
+)
+
+// The functions below should be used instead of the ebiten.Image
+// drawing methods, so the draw calls can be hashed (see SetDrawHashing).
+
+// subImage returns an img sub-image.
+// All sub-images should be created through this function,
+// so the draw hashing knows their original images.
+func subImage(img *ebiten.Image, r image.Rectangle) *ebiten.Image {
+	sub := img.SubImage(r).(*ebiten.Image)
+	if h := drawHashState; h != nil {
+		h.addSubImage(sub, img)
+	}
+	return sub
+}
+
+func drawImage(dst, src *ebiten.Image, opts *ebiten.DrawImageOptions) {
+	if h := drawHashState; h != nil {
+		h.beginCall(drawHashImageCall, dst)
+		h.writeImage(src)
+		h.writeDrawImageOptions(opts)
+		h.endCall(dst)
+	}
+	dst.DrawImage(src, opts)
+}
+
+func drawTriangles(dst *ebiten.Image, vertices []ebiten.Vertex, indices []uint16, src *ebiten.Image, opts *ebiten.DrawTrianglesOptions) {
+	if h := drawHashState; h != nil {
+		h.beginCall(drawHashTrianglesCall, dst)
+		h.writeImage(src)
+		h.writeTriangles(vertices, indices)
+		h.writeInt(int64(opts.ColorScaleMode))
+		h.writeBlend(&opts.Blend)
+		h.writeInt(int64(opts.Filter))
+		h.writeInt(int64(opts.Address))
+		h.writeInt(int64(opts.FillRule))
+		h.writeBool(opts.AntiAlias)
+		h.endCall(dst)
+	}
+	dst.DrawTriangles(vertices, indices, src, opts)
+}
+
+func drawRectShader(dst *ebiten.Image, width, height int, shader *ebiten.Shader, opts *ebiten.DrawRectShaderOptions) {
+	if h := drawHashState; h != nil {
+		h.beginCall(drawHashRectShaderCall, dst)
+		h.writeInt(int64(width))
+		h.writeInt(int64(height))
+		h.writeShader(shader)
+		for _, img := range opts.Images {
+			h.writeImage(img)
+		}
+		h.writeUniforms(opts.Uniforms)
+		h.writeGeoM(&opts.GeoM)
+		h.writeColorScale(&opts.ColorScale)
+		h.writeBlend(&opts.Blend)
+		h.endCall(dst)
+	}
+	dst.DrawRectShader(width, height, shader, opts)
+}
+
+func drawTrianglesShader(dst *ebiten.Image, vertices []ebiten.Vertex, indices []uint16, shader *ebiten.Shader, opts *ebiten.DrawTrianglesShaderOptions) {
+	if h := drawHashState; h != nil {
+		h.beginCall(drawHashTrianglesShaderCall, dst)
+		h.writeTriangles(vertices, indices)
+		h.writeShader(shader)
+		for _, img := range opts.Images {
+			h.writeImage(img)
+		}
+		h.writeUniforms(opts.Uniforms)
+		h.writeBlend(&opts.Blend)
+		h.writeInt(int64(opts.FillRule))
+		h.writeBool(opts.AntiAlias)
+		h.endCall(dst)
+	}
+	dst.DrawTrianglesShader(vertices, indices, shader, opts)
+}
+
+func drawText(dst *ebiten.Image, s string, face text.Face, opts *text.DrawOptions) {
+	if h := drawHashState; h != nil {
+		h.beginCall(drawHashTextCall, dst)
+		h.writeString(s)
+		h.writeFace(face)
+		h.writeDrawImageOptions(&opts.DrawImageOptions)
+		h.writeFloat(opts.LineSpacing)
+		h.writeInt(int64(opts.PrimaryAlign))
+		h.writeInt(int64(opts.SecondaryAlign))
+		h.endCall(dst)
+	}
+	text.Draw(dst, s, face, opts)
+}
+
+func clearImage(img *ebiten.Image) {
+	if h := drawHashState; h != nil {
+		h.beginCall(drawHashClearCall, img)
+		h.endCall(img)
+		// The cleared image content doesn't depend on its previous draws.
+		// For the sub-images, only a part of the image is cleared,
+		// so their contents are still accumulated.
+		if e := h.image(img); e.root == nil {
+			e.content = 0
+		}
+	}
+	img.Clear()
+}
+
+func fillImage(img *ebiten.Image, clr color.Color) {
+	if h := drawHashState; h != nil {
+		h.beginCall(drawHashFillCall, img)
+		r, g, b, a := clr.RGBA()
+		h.writeUint(uint64(r)<<48 | uint64(g)<<32 | uint64(b)<<16 | uint64(a))
+		if e := h.image(img); e.root == nil {
+			e.content = 0
+		}
+		h.endCall(img)
+	}
+	img.Fill(clr)
+}
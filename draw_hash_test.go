@@ -0,0 +1,75 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+func TestDrawHash(t *testing.T) {
+	SetDrawHashing(true)
+	defer SetDrawHashing(false)
+
+	defer FreeImage("test/draw_hash1.png")
+	defer FreeImage("test/draw_hash2.png")
+	img1 := ebiten.NewImage(16, 16)
+	img2 := ebiten.NewImage(16, 16)
+	RegisterImage("test/draw_hash1.png", img1)
+	RegisterImage("test/draw_hash2.png", img2)
+
+	world := NewLayer()
+	d := &SceneDrawer{
+		layers:        []SceneLayerDrawer{world},
+		defaultCamera: []installedCamera{{c: NewCamera()}},
+	}
+	s := NewSprite()
+	s.SetImage(img1)
+	world.AddChild(s)
+	line := NewLine(gmath.Pos{}, gmath.Pos{Offset: gmath.Vec{X: 10}})
+	world.AddChild(line)
+	g := NewCacheGroup(64, 64)
+	g.AddChild(NewRect(8, 8))
+	world.AddChild(g)
+
+	dst := ebiten.NewImage(64, 64)
+	draw := func() uint64 {
+		d.Draw(dst)
+		return d.DrawHash()
+	}
+
+	h := draw()
+	if h == 0 || draw() != h {
+		t.Fatal("an unchanged scene has a different hash")
+	}
+
+	// A same-sized texture is identified by its registry key.
+	s.SetImage(img2)
+	if draw() == h {
+		t.Fatal("a texture change doesn't affect the hash")
+	}
+	s.SetImage(img1)
+	if draw() != h {
+		t.Fatal("the original texture has a different hash")
+	}
+
+	line.EndPos.Offset.X = 20
+	if draw() == h {
+		t.Fatal("a line endpoint change doesn't affect the hash")
+	}
+	line.EndPos.Offset.X = 10
+	if draw() != h {
+		t.Fatal("the original line has a different hash")
+	}
+
+	s.SetAlpha(0.5)
+	if draw() == h {
+		t.Fatal("a color scale change doesn't affect the hash")
+	}
+	s.SetAlpha(1)
+
+	SetDrawHashing(false)
+	if draw() != 0 {
+		t.Fatal("a disabled draw hashing reports a non-zero hash")
+	}
+}
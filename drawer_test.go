@@ -3,4 +3,6 @@ package graphics
 var (
 	_ SceneLayerDrawer = (*Layer)(nil)
 	_ SceneLayerDrawer = (*StaticLayer)(nil)
+	_ SceneLayerDrawer = (*ShadowLayer)(nil)
+	_ SceneLayerDrawer = (*YSortLayer)(nil)
 )
@@ -0,0 +1,298 @@
+package graphics
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+	"github.com/quasilyte/gmath"
+)
+
+// Ellipse is a filled ellipse graphical primitive with an optional outline.
+//
+// Unlike [Circle], it doesn't require any shaders: it's rendered
+// using a cached unit circle mesh that is scaled to the ellipse radii,
+// so it's cheap to render a lot of ellipses (radar blips, area markers).
+//
+// The ellipse is always centered around its Pos.
+type Ellipse struct {
+	Pos gmath.Pos
+
+	// Rotation is an optional ellipse rotation binder.
+	// A nil value means no rotation.
+	Rotation *gmath.Rad
+
+	rx float64
+	ry float64
+
+	outlineWidth float64
+
+	fillColorScale          ColorScale
+	outlineColorScale       ColorScale
+	ebitenFillColorScale    ebiten.ColorScale
+	ebitenOutlineColorScale ebiten.ColorScale
+
+	visible  bool
+	disposed bool
+	blendID  uint8
+}
+
+// NewEllipse returns an ellipse with the specified radii.
+// Use SetRadius or SetRadii to resize it afterwards.
+//
+// By default, an ellipse has these properties:
+// * Visible=true
+// * The FillColorScale is {1, 1, 1, 1}
+// * The OutlineColorScale is {0, 0, 0, 0} (invisible)
+// * OutlineWidth is 0
+func NewEllipse(rx, ry float64) *Ellipse {
+	return &Ellipse{
+		rx:                      rx,
+		ry:                      ry,
+		fillColorScale:          defaultColorScale,
+		ebitenFillColorScale:    defaultColorScale.ToEbitenColorScale(),
+		outlineColorScale:       transparentColor,
+		ebitenOutlineColorScale: transparentColor.ToEbitenColorScale(),
+		visible:                 true,
+	}
+}
+
+// unitCircleNumSegments is a number of segments of the cached unit circle mesh.
+const unitCircleNumSegments = 64
+
+// unitCircleMesh contains the precomputed (cos, sin) pairs,
+// so the ellipse rendering doesn't need any trigonometry.
+var unitCircleMesh = func() [unitCircleNumSegments]gmath.Vec {
+	var mesh [unitCircleNumSegments]gmath.Vec
+	for i := range mesh {
+		sin, cos := math.Sincos(2 * math.Pi * float64(i) / unitCircleNumSegments)
+		mesh[i] = gmath.Vec{X: cos, Y: sin}
+	}
+	return mesh
+}()
+
+// BoundsRect returns a rectangle that fully contains the ellipse.
+// The rotation is taken into account.
+//
+// This is useful when trying to calculate whether this object is contained
+// inside some area or not (like a camera view area).
+func (e *Ellipse) BoundsRect() gmath.Rect {
+	pos := e.Pos.Resolve()
+	half := gmath.Vec{X: e.rx, Y: e.ry}
+	if e.Rotation != nil && *e.Rotation != 0 {
+		sin, cos := math.Sincos(float64(*e.Rotation))
+		half = gmath.Vec{
+			X: math.Hypot(e.rx*cos, e.ry*sin),
+			Y: math.Hypot(e.rx*sin, e.ry*cos),
+		}
+	}
+	return gmath.Rect{
+		Min: pos.Sub(half),
+		Max: pos.Add(half),
+	}
+}
+
+// Dispose marks this ellipse for deletion.
+// After calling this method, IsDisposed will report true.
+func (e *Ellipse) Dispose() {
+	e.disposed = true
+}
+
+// IsDisposed reports whether this ellipse is marked for deletion.
+// IsDisposed returns true only after Disposed was called on this ellipse.
+func (e *Ellipse) IsDisposed() bool {
+	return e.disposed
+}
+
+// IsVisible reports whether this ellipse is visible.
+// Use SetVisibility to change this flag value.
+//
+// When ellipse is invisible (visible=false), it will not be rendered at all.
+// This is an efficient way to temporarily hide an ellipse.
+func (e *Ellipse) IsVisible() bool { return e.visible }
+
+// SetVisibility changes the Visible flag value.
+// It can be used to show or hide the ellipse.
+// Use IsVisible to get the current flag value.
+func (e *Ellipse) SetVisibility(visible bool) { e.visible = visible }
+
+// GetRadii reports the current horizontal and vertical radii.
+// Use SetRadii to change them.
+func (e *Ellipse) GetRadii() (rx, ry float64) {
+	return e.rx, e.ry
+}
+
+// SetRadii changes the horizontal and vertical radii.
+func (e *Ellipse) SetRadii(rx, ry float64) {
+	e.rx = rx
+	e.ry = ry
+}
+
+// SetRadius turns the ellipse into a circle of the specified radius.
+func (e *Ellipse) SetRadius(r float64) {
+	e.rx = r
+	e.ry = r
+}
+
+// GetOutlineWidth reports the current outline width.
+// Use SetOutlineWidth to change it.
+func (e *Ellipse) GetOutlineWidth() float64 {
+	return e.outlineWidth
+}
+
+// SetOutlineWidth changes the outline width.
+// The outline is drawn inside the ellipse.
+func (e *Ellipse) SetOutlineWidth(w float64) {
+	e.outlineWidth = w
+}
+
+// GetFillColorScale is used to retrieve the current fill color scale value of the ellipse.
+// Use SetFillColorScale to change it.
+func (e *Ellipse) GetFillColorScale() ColorScale {
+	return e.fillColorScale
+}
+
+// SetFillColorScale assigns a new fill ColorScale to this ellipse.
+// Use GetFillColorScale to retrieve the current color scale.
+func (e *Ellipse) SetFillColorScale(cs ColorScale) {
+	if e.fillColorScale == cs {
+		return
+	}
+	e.fillColorScale = cs
+	e.ebitenFillColorScale = cs.ToEbitenColorScale()
+}
+
+// GetOutlineColorScale is used to retrieve the current outline color scale value of the ellipse.
+// Use SetOutlineColorScale to change it.
+func (e *Ellipse) GetOutlineColorScale() ColorScale {
+	return e.outlineColorScale
+}
+
+// SetOutlineColorScale assigns a new outline ColorScale to this ellipse.
+// Use GetOutlineColorScale to retrieve the current color scale.
+func (e *Ellipse) SetOutlineColorScale(cs ColorScale) {
+	if e.outlineColorScale == cs {
+		return
+	}
+	e.outlineColorScale = cs
+	e.ebitenOutlineColorScale = cs.ToEbitenColorScale()
+}
+
+// Draw renders the ellipse onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (e *Ellipse) Draw(dst *ebiten.Image) {
+	e.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the ellipse onto the provided dst image
+// while also using the extra provided offset and other options.
+//
+// Both fill and outline are rendered in a single draw call.
+func (e *Ellipse) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !e.visible || e.rx <= 0 || e.ry <= 0 {
+		return
+	}
+	hasOutline := e.outlineColorScale.A != 0 && e.outlineWidth > 0
+	if e.fillColorScale.A == 0 && !hasOutline {
+		return
+	}
+
+	opts.Blend = resolveBlend(e.blendID, opts.Blend)
+
+	vertices := cache.Global.ScratchVertices[:0]
+	indices := cache.Global.ScratchIndices[:0]
+	defer func() {
+		cache.Global.ScratchVertices = vertices[:0]
+		cache.Global.ScratchIndices = indices[:0]
+	}()
+
+	center := e.Pos.Resolve().Add(opts.Offset)
+	var rotSin, rotCos float64 = 0, 1
+	if e.Rotation != nil {
+		rotSin, rotCos = math.Sincos(float64(*e.Rotation))
+	}
+
+	// The vertex positions are unit circle points scaled by the radii
+	// and then rotated (which is a no-op for unrotated ellipses).
+	vertex := func(p gmath.Vec, rx, ry float64, cs ebiten.ColorScale) ebiten.Vertex {
+		x := p.X * rx
+		y := p.Y * ry
+		return ebiten.Vertex{
+			DstX:   float32(center.X + x*rotCos - y*rotSin),
+			DstY:   float32(center.Y + x*rotSin + y*rotCos),
+			SrcX:   1.5,
+			SrcY:   1.5,
+			ColorR: cs.R(),
+			ColorG: cs.G(),
+			ColorB: cs.B(),
+			ColorA: cs.A(),
+		}
+	}
+
+	// The fill covers the area inside the outline.
+	fillRX := e.rx
+	fillRY := e.ry
+	if hasOutline {
+		fillRX = max(0, e.rx-e.outlineWidth)
+		fillRY = max(0, e.ry-e.outlineWidth)
+	}
+
+	if e.fillColorScale.A != 0 && fillRX > 0 && fillRY > 0 {
+		cs := e.ebitenFillColorScale
+		vertices = append(vertices, vertex(gmath.Vec{}, 0, 0, cs))
+		for _, p := range unitCircleMesh {
+			vertices = append(vertices, vertex(p, fillRX, fillRY, cs))
+		}
+		for i := uint16(0); i < unitCircleNumSegments; i++ {
+			indices = append(indices, 0, 1+i, 1+(i+1)%unitCircleNumSegments)
+		}
+	}
+
+	if hasOutline {
+		cs := e.ebitenOutlineColorScale
+		base := uint16(len(vertices))
+		for _, p := range unitCircleMesh {
+			vertices = append(vertices,
+				vertex(p, e.rx, e.ry, cs),
+				vertex(p, fillRX, fillRY, cs),
+			)
+		}
+		for i := uint16(0); i < unitCircleNumSegments; i++ {
+			j := base + i*2
+			k := base + ((i+1)%unitCircleNumSegments)*2
+			indices = append(indices,
+				j, j+1, k,
+				j+1, k, k+1,
+			)
+		}
+	}
+
+	var drawOptions ebiten.DrawTrianglesOptions
+	if opts.Blend != nil {
+		drawOptions.Blend = *opts.Blend
+	}
+	drawVertexColorTriangles(dst, vertices, indices, emptyImage, &drawOptions)
+}
+
+// GetBlend returns the blend mode assigned by SetBlend.
+// The second result value is false if there is no blend override.
+func (e *Ellipse) GetBlend() (ebiten.Blend, bool) {
+	return getBlend(e.blendID)
+}
+
+// SetBlend assigns a blend mode that is used to render this ellipse.
+// It takes priority over the DrawOptions.Blend value.
+// Use ResetBlend to remove the override.
+func (e *Ellipse) SetBlend(b ebiten.Blend) {
+	e.blendID = internBlend(b)
+}
+
+// ResetBlend removes the blend mode override.
+// The DrawOptions.Blend value (if any) will be used again.
+func (e *Ellipse) ResetBlend() {
+	e.blendID = 0
+}
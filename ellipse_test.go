@@ -0,0 +1,34 @@
+package graphics_test
+
+import (
+	"math"
+	"testing"
+
+	graphics "github.com/quasilyte/ebitengine-graphics"
+	"github.com/quasilyte/gmath"
+)
+
+func TestEllipseBounds(t *testing.T) {
+	e := graphics.NewEllipse(20, 10)
+	e.Pos.Offset = gmath.Vec{X: 100, Y: 50}
+
+	rotation := gmath.Rad(0)
+	e.Rotation = &rotation
+
+	tests := []struct {
+		rotation gmath.Rad
+		want     gmath.Rect
+	}{
+		{0, gmath.Rect{Min: gmath.Vec{X: 80, Y: 40}, Max: gmath.Vec{X: 120, Y: 60}}},
+		{math.Pi / 2, gmath.Rect{Min: gmath.Vec{X: 90, Y: 30}, Max: gmath.Vec{X: 110, Y: 70}}},
+		{math.Pi, gmath.Rect{Min: gmath.Vec{X: 80, Y: 40}, Max: gmath.Vec{X: 120, Y: 60}}},
+	}
+
+	for _, test := range tests {
+		rotation = test.rotation
+		have := e.BoundsRect()
+		if !have.Min.EqualApprox(test.want.Min) || !have.Max.EqualApprox(test.want.Max) {
+			t.Fatalf("rotation=%v bounds:\nhave: %v\nwant: %v", test.rotation, have, test.want)
+		}
+	}
+}
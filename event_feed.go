@@ -0,0 +1,362 @@
+package graphics
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// EventFeedConfig describes the [EventFeed] behavior and appearance.
+type EventFeedConfig struct {
+	// Face is used to render the entry texts.
+	// This field is required.
+	Face text.Face
+
+	// MaxEntries is a maximum number of the displayed entries.
+	// When a new entry exceeds this limit, the oldest one is removed.
+	// A zero value means 5.
+	MaxEntries int
+
+	// Lifetime is a number of seconds an entry stays in the feed.
+	// A zero value means 5.
+	Lifetime float64
+
+	// FadeDuration is a fade out duration in seconds;
+	// the fading happens at the end of the entry lifetime.
+	// A zero value means 1.
+	FadeDuration float64
+
+	// LineSpacing is a vertical distance between the entries.
+	// A zero value means 2.
+	LineSpacing float64
+
+	// PartSpacing is a horizontal distance between the entry parts.
+	// A zero value means 4.
+	PartSpacing float64
+
+	// ScrollSpeed is how fast (in pixels per second) the entries move
+	// to their new positions after the older entries are removed.
+	// A zero value means 80.
+	ScrollSpeed float64
+
+	// Align controls the entries horizontal alignment relative to the Pos.
+	// With AlignHorizontalRight, the Pos is a top-right corner of the feed
+	// (a typical kill feed placement).
+	Align AlignHorizontal
+
+	// ColorScale is a default text color.
+	// A zero value means {1, 1, 1, 1}.
+	ColorScale ColorScale
+}
+
+// EventFeedPart is a single [EventFeed] entry element: an icon or a text.
+type EventFeedPart struct {
+	// Icon is an optional part image.
+	// If it's not nil, the Text is ignored.
+	Icon *ebiten.Image
+
+	Text string
+
+	// ColorScale is this part's color (it's applied to the icon too).
+	// A zero value means "use the feed's color scale".
+	ColorScale ColorScale
+}
+
+// EventFeed is a capped list of the event entries, like a kill feed or an event log.
+//
+// Every entry is a row of the icons and colored text parts
+// (e.g. "Player1 [rifle icon] Player2").
+// The entries fade out after a timeout; the remaining entries
+// smoothly scroll to fill the freed space.
+// The newest entry is always at the bottom.
+//
+// The entry objects are pooled and their layout is computed only once,
+// so adding the entries doesn't generate garbage after a warmup.
+// The animations are driven by the Update method.
+//
+// The feed is a screen-space widget,
+// so it should be added to a [StaticLayer].
+//
+// EventFeed implements gscene Graphics interface.
+type EventFeed struct {
+	// Pos is a feed anchor location binder.
+	// See Pos documentation to learn how it works.
+	Pos gmath.Pos
+
+	config EventFeedConfig
+
+	lineHeight float64
+
+	entries []*eventFeedEntry
+	free    []*eventFeedEntry
+
+	visible  bool
+	disposed bool
+}
+
+type eventFeedEntry struct {
+	parts []eventFeedPart
+
+	width  float64
+	height float64
+
+	// y is a current entry offset relative to the feed's top.
+	y   float64
+	age float64
+}
+
+type eventFeedPart struct {
+	icon *ebiten.Image
+	text string
+	x    float64
+
+	ebitenColorScale ebiten.ColorScale
+}
+
+// NewEventFeed creates an empty feed with the specified config.
+func NewEventFeed(config EventFeedConfig) *EventFeed {
+	if config.Face == nil {
+		panic("EventFeedConfig.Face is required")
+	}
+	if config.MaxEntries == 0 {
+		config.MaxEntries = 5
+	}
+	if config.Lifetime == 0 {
+		config.Lifetime = 5
+	}
+	if config.FadeDuration == 0 {
+		config.FadeDuration = 1
+	}
+	if config.LineSpacing == 0 {
+		config.LineSpacing = 2
+	}
+	if config.PartSpacing == 0 {
+		config.PartSpacing = 4
+	}
+	if config.ScrollSpeed == 0 {
+		config.ScrollSpeed = 80
+	}
+	if config.ColorScale == (ColorScale{}) {
+		config.ColorScale = defaultColorScale
+	}
+	return &EventFeed{
+		config:     config,
+		lineHeight: GetFontMetrics(config.Face).LineHeight,
+		visible:    true,
+	}
+}
+
+// AddEntry appends a new entry to the bottom of the feed.
+// The parts are copied, so the slice can be re-used by the caller.
+func (f *EventFeed) AddEntry(parts ...EventFeedPart) {
+	if len(f.entries) >= f.config.MaxEntries {
+		f.releaseEntry(f.entries[0])
+		copy(f.entries, f.entries[1:])
+		f.entries[len(f.entries)-1] = nil
+		f.entries = f.entries[:len(f.entries)-1]
+	}
+
+	var e *eventFeedEntry
+	if n := len(f.free); n != 0 {
+		e = f.free[n-1]
+		f.free = f.free[:n-1]
+	} else {
+		e = &eventFeedEntry{}
+	}
+
+	e.parts = e.parts[:0]
+	e.height = f.lineHeight
+	x := 0.0
+	for i, p := range parts {
+		if i != 0 {
+			x += f.config.PartSpacing
+		}
+		cs := p.ColorScale
+		if cs == (ColorScale{}) {
+			cs = f.config.ColorScale
+		}
+		part := eventFeedPart{
+			icon:             p.Icon,
+			text:             p.Text,
+			x:                x,
+			ebitenColorScale: cs.ToEbitenColorScale(),
+		}
+		if p.Icon != nil {
+			bounds := p.Icon.Bounds()
+			x += float64(bounds.Dx())
+			e.height = max(e.height, float64(bounds.Dy()))
+		} else {
+			w, _ := text.Measure(p.Text, f.config.Face, 0)
+			x += w
+		}
+		e.parts = append(e.parts, part)
+	}
+	e.width = x
+	e.age = 0
+	e.y = 0
+	if n := len(f.entries); n != 0 {
+		// The previous entry can still be scrolling,
+		// the new entry should not overlap it.
+		last := f.entries[n-1]
+		e.y = max(f.contentHeight(), last.y+last.height) + f.config.LineSpacing
+	}
+
+	f.entries = append(f.entries, e)
+}
+
+// Clear removes all entries from the feed.
+func (f *EventFeed) Clear() {
+	for _, e := range f.entries {
+		f.releaseEntry(e)
+	}
+	clear(f.entries)
+	f.entries = f.entries[:0]
+}
+
+// Len reports the number of entries inside the feed.
+func (f *EventFeed) Len() int { return len(f.entries) }
+
+func (f *EventFeed) releaseEntry(e *eventFeedEntry) {
+	// Release the icon references, but keep the parts capacity.
+	clear(e.parts)
+	e.parts = e.parts[:0]
+	f.free = append(f.free, e)
+}
+
+// contentHeight returns the entries total height using their
+// final (not animated) positions.
+func (f *EventFeed) contentHeight() float64 {
+	h := 0.0
+	for i, e := range f.entries {
+		if i != 0 {
+			h += f.config.LineSpacing
+		}
+		h += e.height
+	}
+	return h
+}
+
+// Update advances the feed animations and removes the expired entries.
+func (f *EventFeed) Update(delta float64) {
+	liveEntries := f.entries[:0]
+	for _, e := range f.entries {
+		e.age += delta
+		if e.age >= f.config.Lifetime {
+			f.releaseEntry(e)
+			continue
+		}
+		liveEntries = append(liveEntries, e)
+	}
+	clear(f.entries[len(liveEntries):])
+	f.entries = liveEntries
+
+	targetY := 0.0
+	maxStep := f.config.ScrollSpeed * delta
+	for _, e := range f.entries {
+		if e.y > targetY {
+			e.y = max(targetY, e.y-maxStep)
+		} else {
+			e.y = targetY
+		}
+		targetY += e.height + f.config.LineSpacing
+	}
+}
+
+// BoundsRect returns the feed bounding rectangle.
+func (f *EventFeed) BoundsRect() gmath.Rect {
+	pos := f.Pos.Resolve()
+	width := 0.0
+	for _, e := range f.entries {
+		width = max(width, e.width)
+	}
+	switch f.config.Align {
+	case AlignHorizontalCenter:
+		pos.X -= width * 0.5
+	case AlignHorizontalRight:
+		pos.X -= width
+	}
+	return gmath.Rect{
+		Min: pos,
+		Max: pos.Add(gmath.Vec{X: width, Y: f.contentHeight()}),
+	}
+}
+
+// Dispose marks this feed for deletion.
+// After calling this method, IsDisposed will report true.
+func (f *EventFeed) Dispose() { f.disposed = true }
+
+// IsDisposed reports whether this feed is marked for deletion.
+func (f *EventFeed) IsDisposed() bool { return f.disposed }
+
+// IsVisible reports whether this feed is visible.
+// Use SetVisibility to change this flag value.
+func (f *EventFeed) IsVisible() bool { return f.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (f *EventFeed) SetVisibility(visible bool) { f.visible = visible }
+
+// Draw renders the feed onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (f *EventFeed) Draw(dst *ebiten.Image) {
+	f.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the feed onto the provided dst image
+// while also using the extra provided offset.
+func (f *EventFeed) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !f.visible || len(f.entries) == 0 {
+		return
+	}
+
+	pos := f.Pos.Resolve().Add(opts.Offset)
+
+	var iconOptions ebiten.DrawImageOptions
+	var textOptions text.DrawOptions
+	if opts.Blend != nil {
+		iconOptions.Blend = *opts.Blend
+		textOptions.Blend = *opts.Blend
+	}
+	textOptions.SecondaryAlign = text.AlignCenter
+
+	fadeStart := f.config.Lifetime - f.config.FadeDuration
+	for _, e := range f.entries {
+		alpha := float32(1)
+		if e.age > fadeStart {
+			alpha = float32(1 - (e.age-fadeStart)/f.config.FadeDuration)
+		}
+
+		x := pos.X
+		switch f.config.Align {
+		case AlignHorizontalCenter:
+			x -= e.width * 0.5
+		case AlignHorizontalRight:
+			x -= e.width
+		}
+		centerY := pos.Y + e.y + e.height*0.5
+
+		for i := range e.parts {
+			p := &e.parts[i]
+			cs := p.ebitenColorScale
+			cs.ScaleAlpha(alpha)
+			if p.icon != nil {
+				bounds := p.icon.Bounds()
+				iconOptions.GeoM.Reset()
+				iconOptions.GeoM.Translate(math.Round(x+p.x), math.Round(centerY-float64(bounds.Dy())*0.5))
+				iconOptions.ColorScale = cs
+				drawImage(dst, p.icon, &iconOptions)
+				continue
+			}
+			textOptions.GeoM.Reset()
+			textOptions.GeoM.Translate(math.Round(x+p.x), math.Round(centerY))
+			textOptions.ColorScale = cs
+			drawText(dst, p.text, f.config.Face, &textOptions)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package graphics_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	graphics "github.com/quasilyte/ebitengine-graphics"
+	"golang.org/x/image/font/basicfont"
+)
+
+func TestEventFeedLifetime(t *testing.T) {
+	f := graphics.NewEventFeed(graphics.EventFeedConfig{
+		Face:       text.NewGoXFace(basicfont.Face7x13),
+		MaxEntries: 3,
+		Lifetime:   2,
+	})
+
+	f.AddEntry(graphics.EventFeedPart{Text: "a"})
+	f.Update(1)
+	f.AddEntry(graphics.EventFeedPart{Text: "b"})
+	f.AddEntry(graphics.EventFeedPart{Text: "g"}, graphics.EventFeedPart{Text: "x"})
+	f.AddEntry(graphics.EventFeedPart{Text: "d"})
+	if f.Len() != 3 {
+		t.Fatalf("the entries should be capped:\nhave: %d\nwant: %d", f.Len(), 3)
+	}
+
+	// Every basicfont glyph is 7 pixels wide, the default part spacing is 4.
+	if w := f.BoundsRect().Width(); w != 18 {
+		t.Fatalf("bounds width:\nhave: %v\nwant: %v", w, 18)
+	}
+
+	f.Update(1.5)
+	if f.Len() != 0 {
+		t.Fatalf("all entries should expire:\nhave: %d\nwant: %d", f.Len(), 0)
+	}
+	if w := f.BoundsRect().Width(); w != 0 {
+		t.Fatalf("empty feed bounds width:\nhave: %v\nwant: %v", w, 0)
+	}
+}
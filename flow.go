@@ -0,0 +1,241 @@
+package graphics
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// FlowConfig describes the [FlowContainer] layout parameters.
+type FlowConfig struct {
+	// MaxWidth is a max line width (excluding the padding).
+	// A child that doesn't fit into the current line is moved to the next one.
+	// A zero value means no wrapping.
+	MaxWidth float64
+
+	// Spacing is a horizontal distance between the adjacent children.
+	// A zero value means no spacing.
+	Spacing float64
+
+	// LineSpacing is a vertical distance between the lines.
+	// A zero value means no spacing.
+	LineSpacing float64
+
+	// Padding is an extra space around the container contents.
+	// A zero value means no padding.
+	Padding float64
+
+	// Align is a vertical alignment of the children inside their line.
+	// A zero value means BoxAlignStart.
+	Align BoxAlign
+}
+
+// FlowContainer arranges its children from the left to the right
+// and wraps them to the next line when the MaxWidth is exceeded.
+// It's useful for the tag lists, ability icon sets and loadout displays.
+//
+// Like [BoxContainer], it places the children using their BoundsRect values.
+// The invisible children don't take any space.
+// The layout is re-computed during every Draw and BoundsRect call.
+//
+// A child that is wider than MaxWidth occupies a separate line.
+//
+// The Pos is a container top-left corner.
+//
+// FlowContainer implements gscene Graphics interface.
+type FlowContainer struct {
+	Pos gmath.Pos
+
+	config FlowConfig
+
+	objects []DisposableObject
+
+	// offsets is a parallel slice for objects that contains
+	// the draw offsets computed by the last layout call.
+	offsets  []gmath.Vec
+	size     gmath.Vec
+	numLines int
+
+	changed DirtySignal
+
+	visible  bool
+	disposed bool
+}
+
+// NewFlow creates a flow container with the specified config.
+func NewFlow(config FlowConfig) *FlowContainer {
+	return &FlowContainer{
+		config:  config,
+		objects: make([]DisposableObject, 0, 4),
+		visible: true,
+	}
+}
+
+// AddChild adds an object to the end of the container.
+func (f *FlowContainer) AddChild(o DisposableObject) {
+	f.objects = append(f.objects, o)
+	connectDirtySource(o, f)
+	f.changed.Emit()
+}
+
+// MarkDirty notifies the container dependents about a change.
+func (f *FlowContainer) MarkDirty() {
+	f.changed.Emit()
+}
+
+// DirtySignal returns a signal that is emitted when the container
+// or one of its [DirtySource] children is changed.
+// It implements the [DirtySource] interface.
+func (f *FlowContainer) DirtySignal() *DirtySignal {
+	return &f.changed
+}
+
+// GetMaxWidth returns the current max line width.
+func (f *FlowContainer) GetMaxWidth() float64 { return f.config.MaxWidth }
+
+// SetMaxWidth changes the max line width.
+// A zero value disables the wrapping.
+func (f *FlowContainer) SetMaxWidth(w float64) {
+	f.config.MaxWidth = w
+	f.changed.Emit()
+}
+
+// NumLines reports the number of lines computed by the last layout.
+func (f *FlowContainer) NumLines() int {
+	f.layout()
+	return f.numLines
+}
+
+// IsDisposed reports whether this container is marked for deletion.
+func (f *FlowContainer) IsDisposed() bool { return f.disposed }
+
+// Dispose marks this container and all of its children for deletion.
+func (f *FlowContainer) Dispose() {
+	for _, o := range f.objects {
+		o.Dispose()
+	}
+	f.disposed = true
+}
+
+// IsVisible reports whether this container is visible.
+// Use SetVisibility to change this flag value.
+func (f *FlowContainer) IsVisible() bool { return f.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (f *FlowContainer) SetVisibility(visible bool) { f.visible = visible }
+
+// BoundsRect returns the container area, including its padding.
+func (f *FlowContainer) BoundsRect() gmath.Rect {
+	f.layout()
+	pos := f.Pos.Resolve()
+	return gmath.Rect{Min: pos, Max: pos.Add(f.size)}
+}
+
+// Draw renders the container children onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (f *FlowContainer) Draw(dst *ebiten.Image) {
+	f.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the container children onto the provided dst image
+// while also using the extra provided offset.
+func (f *FlowContainer) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !f.visible {
+		return
+	}
+
+	f.layout()
+
+	offset := opts.Offset.Add(f.Pos.Resolve())
+	for i, o := range f.objects {
+		if v, ok := o.(visibleObject); ok && !v.IsVisible() {
+			continue
+		}
+		opts.Offset = offset.Add(f.offsets[i])
+		drawObject(dst, o, opts)
+	}
+}
+
+// layout removes the disposed children and
+// re-computes the children offsets and the container size.
+func (f *FlowContainer) layout() {
+	liveObjects := f.objects[:0]
+	for _, o := range f.objects {
+		if o.IsDisposed() {
+			continue
+		}
+		liveObjects = append(liveObjects, o)
+	}
+	clear(f.objects[len(liveObjects):])
+	f.objects = liveObjects
+
+	f.offsets = f.offsets[:0]
+	f.numLines = 0
+
+	pad := f.config.Padding
+	width := 0.0
+	y := pad
+	lineStart := 0
+	lineWidth := 0.0
+	lineHeight := 0.0
+	numInLine := 0
+
+	// finishLine applies the vertical alignment to the line children.
+	// The offsets are computed before the line height is known,
+	// so the line children are shifted after that.
+	finishLine := func(end int) {
+		if numInLine == 0 {
+			return
+		}
+		for i := lineStart; i < end; i++ {
+			bounds, ok := layoutChildBounds(f.objects[i])
+			if !ok {
+				continue
+			}
+			switch f.config.Align {
+			case BoxAlignCenter:
+				f.offsets[i].Y += (lineHeight - bounds.Height()) * 0.5
+			case BoxAlignEnd:
+				f.offsets[i].Y += lineHeight - bounds.Height()
+			}
+		}
+		width = max(width, lineWidth)
+		y += lineHeight + f.config.LineSpacing
+		f.numLines++
+	}
+
+	for i, o := range f.objects {
+		bounds, ok := layoutChildBounds(o)
+		if !ok {
+			f.offsets = append(f.offsets, gmath.Vec{})
+			continue
+		}
+		x := lineWidth
+		if numInLine != 0 {
+			x += f.config.Spacing
+		}
+		if numInLine != 0 && f.config.MaxWidth != 0 && x+bounds.Width() > f.config.MaxWidth {
+			finishLine(i)
+			lineStart = i
+			lineWidth = 0
+			lineHeight = 0
+			numInLine = 0
+			x = 0
+		}
+		f.offsets = append(f.offsets, gmath.Vec{X: pad + x, Y: y}.Sub(bounds.Min))
+		lineWidth = x + bounds.Width()
+		lineHeight = max(lineHeight, bounds.Height())
+		numInLine++
+	}
+	finishLine(len(f.objects))
+
+	height := y - pad
+	if f.numLines != 0 {
+		height -= f.config.LineSpacing
+	}
+	f.size = gmath.Vec{X: width + 2*pad, Y: height + 2*pad}
+}
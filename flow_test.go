@@ -0,0 +1,51 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/quasilyte/gmath"
+)
+
+func TestFlowLayout(t *testing.T) {
+	newRect := func(w, h float64) *Rect {
+		r := NewRect(w, h)
+		r.SetCentered(false)
+		return r
+	}
+
+	f := NewFlow(FlowConfig{
+		MaxWidth:    25,
+		Spacing:     2,
+		LineSpacing: 1,
+		Padding:     1,
+		Align:       BoxAlignEnd,
+	})
+	f.AddChild(newRect(10, 4))
+	f.AddChild(newRect(10, 6))
+	f.AddChild(newRect(10, 5)) // Wrapped: 10+2+10+2+10 > 25
+	f.AddChild(newRect(30, 2)) // Too wide, occupies a separate line
+
+	if have := f.NumLines(); have != 3 {
+		t.Fatalf("NumLines: have %d, want 3", have)
+	}
+	// Width: 1 + 30 + 1; height: 1 + 6 + 1 + 5 + 1 + 2 + 1.
+	if have := f.BoundsRect().Size(); have != (gmath.Vec{X: 32, Y: 17}) {
+		t.Fatalf("size: have %v, want {32, 17}", have)
+	}
+	wantOffsets := []gmath.Vec{
+		{X: 1, Y: 3},
+		{X: 13, Y: 1},
+		{X: 1, Y: 8},
+		{X: 1, Y: 14},
+	}
+	for i, want := range wantOffsets {
+		if have := f.offsets[i]; have != want {
+			t.Fatalf("offsets[%d]:\nhave: %v\nwant: %v", i, have, want)
+		}
+	}
+
+	f.SetMaxWidth(0)
+	if have := f.NumLines(); have != 1 {
+		t.Fatalf("NumLines without wrapping: have %d, want 1", have)
+	}
+}
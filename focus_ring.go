@@ -0,0 +1,207 @@
+package graphics
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// FocusRing is an animated outline that highlights the focused object.
+//
+// It's intended to be used for gamepad/keyboard navigation visuals.
+// The ring follows the target's BoundsRect, so it can be attached
+// to any widget or sprite.
+//
+// The ring fades in and out when the focus changes, therefore
+// its Update method should be called every frame.
+//
+// FocusRing implements gscene Graphics interface.
+type FocusRing struct {
+	target BoundedObject
+
+	outline *Rect
+
+	colorScale ColorScale
+
+	padding      float64
+	outlineWidth float64
+	pulseSpeed   float64
+	fadeSpeed    float32
+
+	t     float64
+	alpha float32
+
+	glowWidth uint8
+
+	focused  bool
+	visible  bool
+	disposed bool
+}
+
+// NewFocusRing returns a focus ring attached to the target object.
+//
+// By default, a focus ring has these properties:
+// * Focused=false (it's hidden until SetFocused(true) is called)
+// * Visible=true
+// * The ColorScale is {1, 1, 1, 1}
+// * OutlineWidth is 1
+// * Padding is 2
+// * GlowWidth is 2
+// * PulseSpeed is 1 (one pulse per second)
+func NewFocusRing(target BoundedObject) *FocusRing {
+	outline := NewRect(0, 0)
+	outline.SetCentered(false)
+	outline.SetFillColorScale(transparentColor)
+	return &FocusRing{
+		target:       target,
+		outline:      outline,
+		colorScale:   defaultColorScale,
+		padding:      2,
+		outlineWidth: 1,
+		pulseSpeed:   1,
+		fadeSpeed:    8,
+		glowWidth:    2,
+		visible:      true,
+	}
+}
+
+// Dispose marks this focus ring for deletion.
+// After calling this method, IsDisposed will report true.
+//
+// The ring is also considered to be disposed if its target is disposed.
+func (r *FocusRing) Dispose() {
+	r.disposed = true
+}
+
+// IsDisposed reports whether this focus ring is marked for deletion.
+func (r *FocusRing) IsDisposed() bool {
+	if r.disposed {
+		return true
+	}
+	if t, ok := r.target.(interface{ IsDisposed() bool }); ok {
+		return t.IsDisposed()
+	}
+	return false
+}
+
+// IsVisible reports whether this focus ring is visible.
+// Use SetVisibility to change this flag value.
+//
+// An invisible ring is not rendered even if it's focused.
+func (r *FocusRing) IsVisible() bool { return r.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (r *FocusRing) SetVisibility(visible bool) { r.visible = visible }
+
+// IsFocused reports whether this focus ring is in the focused state.
+// Use SetFocused to change it.
+func (r *FocusRing) IsFocused() bool { return r.focused }
+
+// SetFocused changes the focused state of the ring.
+// The ring fades in (or out) smoothly during the next Update calls.
+func (r *FocusRing) SetFocused(focused bool) {
+	if r.focused == focused {
+		return
+	}
+	r.focused = focused
+	if focused {
+		// Restart the pulse, so the ring always starts from its brightest phase.
+		r.t = 0
+	}
+}
+
+// GetColorScale is used to retrieve the current color scale value of the ring.
+// Use SetColorScale to change it.
+func (r *FocusRing) GetColorScale() ColorScale { return r.colorScale }
+
+// SetColorScale assigns a new ColorScale to this ring.
+// Use GetColorScale to retrieve the current color scale.
+func (r *FocusRing) SetColorScale(cs ColorScale) { r.colorScale = cs }
+
+// GetOutlineWidth reports the current ring outline width.
+// Use SetOutlineWidth to change it.
+func (r *FocusRing) GetOutlineWidth() float64 { return r.outlineWidth }
+
+// SetOutlineWidth changes the ring outline width.
+func (r *FocusRing) SetOutlineWidth(w float64) { r.outlineWidth = w }
+
+// GetPadding reports the distance between the target bounds and the ring.
+// Use SetPadding to change it.
+func (r *FocusRing) GetPadding() float64 { return r.padding }
+
+// SetPadding changes the distance between the target bounds and the ring.
+func (r *FocusRing) SetPadding(padding float64) { r.padding = padding }
+
+// GetGlowWidth reports the number of extra glow outlines drawn around the ring.
+// Use SetGlowWidth to change it.
+func (r *FocusRing) GetGlowWidth() int { return int(r.glowWidth) }
+
+// SetGlowWidth changes the number of extra glow outlines.
+// Every glow outline is 1 pixel wide and is twice as transparent as the previous one.
+// A zero value disables the glow effect.
+func (r *FocusRing) SetGlowWidth(w int) { r.glowWidth = uint8(w) }
+
+// GetPulseSpeed reports the number of pulses per second.
+// Use SetPulseSpeed to change it.
+func (r *FocusRing) GetPulseSpeed() float64 { return r.pulseSpeed }
+
+// SetPulseSpeed changes the number of pulses per second.
+// A zero value disables the pulsing animation.
+func (r *FocusRing) SetPulseSpeed(speed float64) { r.pulseSpeed = speed }
+
+// Update advances the ring animation.
+// delta is a time passed since the last Update call, in seconds.
+func (r *FocusRing) Update(delta float64) {
+	r.t += delta
+	if r.focused {
+		r.alpha = min(1, r.alpha+r.fadeSpeed*float32(delta))
+	} else {
+		r.alpha = max(0, r.alpha-r.fadeSpeed*float32(delta))
+	}
+}
+
+// Draw renders the ring onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (r *FocusRing) Draw(dst *ebiten.Image) {
+	r.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the ring onto the provided dst image
+// while also using the extra provided offset.
+func (r *FocusRing) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !r.visible || r.alpha == 0 || r.colorScale.A == 0 {
+		return
+	}
+
+	pulse := float32(1)
+	if r.pulseSpeed != 0 {
+		pulse = 0.75 + 0.25*float32(math.Cos(r.t*r.pulseSpeed*2*math.Pi))
+	}
+	cs := r.colorScale.ScaleAlpha(r.alpha * pulse)
+
+	bounds := r.target.BoundsRect()
+
+	r.drawOutline(dst, opts, bounds, r.padding, r.outlineWidth, cs)
+	glowPadding := r.padding + r.outlineWidth
+	for i := 0; i < int(r.glowWidth); i++ {
+		cs.A *= 0.5
+		r.drawOutline(dst, opts, bounds, glowPadding, 1, cs)
+		glowPadding++
+	}
+}
+
+func (r *FocusRing) drawOutline(dst *ebiten.Image, opts DrawOptions, bounds gmath.Rect, padding, width float64, cs ColorScale) {
+	pad := gmath.Vec{X: padding, Y: padding}
+	r.outline.Pos.Offset = bounds.Min.Sub(pad)
+	r.outline.SetWidth(bounds.Width() + 2*padding)
+	r.outline.SetHeight(bounds.Height() + 2*padding)
+	r.outline.SetOutlineWidth(width)
+	r.outline.SetOutlineColorScale(cs)
+	r.outline.DrawWithOptions(dst, opts)
+}
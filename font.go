@@ -0,0 +1,77 @@
+package graphics
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+)
+
+// FontMetrics describes the font face vertical metrics.
+// All values are in pixels and they're all non-negative.
+//
+// See [GetFontMetrics].
+type FontMetrics struct {
+	// Ascent is a distance from the line top to the baseline.
+	Ascent float64
+
+	// Descent is a distance from the baseline to the line bottom.
+	Descent float64
+
+	// CapHeight is a height of the capital letters.
+	// It's zero if the font doesn't specify it.
+	CapHeight float64
+
+	// XHeight is a height of the lowercase letters (like 'x').
+	// It's zero if the font doesn't specify it.
+	XHeight float64
+
+	// LineHeight is a distance between the consecutive baselines.
+	// It's Ascent+Descent plus the font's line gap.
+	LineHeight float64
+}
+
+// GetFontMetrics returns the font face metrics.
+//
+// The face is interned in the cache (the same way as NewLabel does),
+// so the metrics are computed only once per face.
+// This function can be called from any goroutine.
+func GetFontMetrics(ff text.Face) FontMetrics {
+	id := cache.Global.InternFontFace(ff)
+	return fontMetricsByID(id)
+}
+
+// SetFontFallbacks registers the fallback faces for the font face.
+//
+// When ff doesn't have a glyph for some rune, the first fallback
+// face that has it is used instead (see [text.MultiFace]).
+// This makes it possible to render mixed-script texts
+// (like CJK characters or symbols inside a Latin text)
+// without the "tofu" boxes.
+//
+// All labels that use ff as their font (including the rich text segments)
+// are affected. The layout is not recalculated for the existing labels,
+// so it's better to register the fallbacks before creating the labels.
+// Calling this function with an empty fallbacks list removes the fallbacks.
+//
+// The font metrics (see [GetFontMetrics]) are always taken from ff.
+// All faces must have the same direction.
+//
+// This function can be called from any goroutine.
+func SetFontFallbacks(ff text.Face, fallbacks ...text.Face) error {
+	return cache.Global.SetFontFallbacks(ff, fallbacks)
+}
+
+// GetFontMetrics returns the metrics of the label's font face.
+func (l *Label) GetFontMetrics() FontMetrics {
+	return fontMetricsByID(l.fontID)
+}
+
+func fontMetricsByID(id uint16) FontMetrics {
+	info := cache.Global.GetFontInfo(id)
+	return FontMetrics{
+		Ascent:     info.Ascent,
+		Descent:    info.Descent,
+		CapHeight:  info.CapHeight,
+		XHeight:    info.XHeight,
+		LineHeight: info.LineHeight,
+	}
+}
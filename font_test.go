@@ -0,0 +1,61 @@
+package graphics
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+	"golang.org/x/image/font/basicfont"
+)
+
+func TestConcurrentInterning(t *testing.T) {
+	faces := make([]text.Face, 8)
+	for i := range faces {
+		faces[i] = text.NewGoXFace(basicfont.Face7x13)
+	}
+	blends := []ebiten.Blend{ebiten.BlendLighter, ebiten.BlendCopy, BlendMultiply}
+
+	const numWorkers = 8
+	fontIDs := make([][]uint16, numWorkers)
+	blendIDs := make([][]uint8, numWorkers)
+	var wg sync.WaitGroup
+	for w := 0; w < numWorkers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for _, ff := range faces {
+				fontIDs[w] = append(fontIDs[w], NewLabel(ff).fontID)
+				_ = GetFontMetrics(ff)
+			}
+			for _, b := range blends {
+				blendIDs[w] = append(blendIDs[w], internBlend(b))
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	for w := 1; w < numWorkers; w++ {
+		for i := range faces {
+			if fontIDs[w][i] != fontIDs[0][i] {
+				t.Fatalf("face %d: different IDs: %d and %d", i, fontIDs[w][i], fontIDs[0][i])
+			}
+		}
+		for i := range blends {
+			if blendIDs[w][i] != blendIDs[0][i] {
+				t.Fatalf("blend %d: different IDs: %d and %d", i, blendIDs[w][i], blendIDs[0][i])
+			}
+		}
+	}
+	for i, ff := range faces {
+		if cache.Global.GetFontInfo(fontIDs[0][i]).Face != ff {
+			t.Fatalf("face %d: the interned face doesn't match", i)
+		}
+	}
+	for i, b := range blends {
+		if have, _ := getBlend(blendIDs[0][i]); have != b {
+			t.Fatalf("blend %d: the interned blend doesn't match", i)
+		}
+	}
+}
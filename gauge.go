@@ -0,0 +1,271 @@
+package graphics
+
+import (
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// GaugeDirection describes the [Gauge] fill growth direction.
+type GaugeDirection uint8
+
+const (
+	GaugeLeftToRight GaugeDirection = iota
+	GaugeRightToLeft
+	GaugeBottomToTop
+	GaugeTopToBottom
+)
+
+// GaugeConfig describes the [Gauge] appearance.
+type GaugeConfig struct {
+	// Background is an optional background (frame) image.
+	// If it's nil, a BackgroundColorScale rect is used instead.
+	Background *ebiten.Image
+
+	// Fill is an optional fill image.
+	// The image is cropped (not scaled) according to the gauge value.
+	// If it's nil, a FillColorScale rect is used instead.
+	Fill *ebiten.Image
+
+	// Width and Height are the gauge sizes.
+	// They're ignored if the Background image is set.
+	// Zero values mean 100 and 8.
+	Width  float64
+	Height float64
+
+	// FillOffset is a fill area offset relative to the gauge top-left corner.
+	// Without a Fill image, the fill area is shrinked by this
+	// offset from the both sides, so it can be used as a frame width.
+	FillOffset gmath.Vec
+
+	// BackgroundColorScale is applied to the background.
+	// A zero value means a half-transparent black color
+	// (or {1, 1, 1, 1} if the Background image is set).
+	BackgroundColorScale ColorScale
+
+	// FillColorScale is applied to the fill.
+	// A zero value means a green color
+	// (or {1, 1, 1, 1} if the Fill image is set).
+	FillColorScale ColorScale
+
+	// FlashColorScale is a fill color used when the gauge is over-filled
+	// (when a value above 1 is assigned, like an overheal).
+	// A zero value disables the over-fill flash.
+	FlashColorScale ColorScale
+
+	// FlashDuration is an over-fill flash duration in seconds.
+	// A zero value means 0.3.
+	FlashDuration float64
+
+	Direction GaugeDirection
+}
+
+// Gauge is a general purpose progress bar: health, mana, reload progress, etc.
+//
+// The gauge consists of a background and a fill that is clipped
+// according to the gauge value; both parts can be either images or
+// the colored rects. See [GaugeConfig] for the customization options.
+//
+// The over-fill flash animation is driven by the Update method.
+//
+// The Pos is a top-left corner of the gauge.
+//
+// Gauge implements gscene Graphics interface.
+type Gauge struct {
+	// Pos is a gauge top-left corner location binder.
+	// See Pos documentation to learn how it works.
+	Pos gmath.Pos
+
+	config GaugeConfig
+
+	size     gmath.Vec
+	fillSize gmath.Vec
+
+	ebitenBackgroundColorScale ebiten.ColorScale
+	ebitenFillColorScale       ebiten.ColorScale
+
+	value float64
+
+	// flash is a remaining over-fill flash time.
+	flash float64
+
+	visible  bool
+	disposed bool
+}
+
+// NewGauge creates a gauge with the specified config.
+// The initial value is 1.
+func NewGauge(config GaugeConfig) *Gauge {
+	size := gmath.Vec{X: config.Width, Y: config.Height}
+	if config.Background != nil {
+		bounds := config.Background.Bounds()
+		size = gmath.Vec{X: float64(bounds.Dx()), Y: float64(bounds.Dy())}
+	}
+	if size.X == 0 {
+		size.X = 100
+	}
+	if size.Y == 0 {
+		size.Y = 8
+	}
+	fillSize := size.Sub(config.FillOffset.Mulf(2))
+	if config.Fill != nil {
+		bounds := config.Fill.Bounds()
+		fillSize = gmath.Vec{X: float64(bounds.Dx()), Y: float64(bounds.Dy())}
+	}
+
+	if config.BackgroundColorScale == (ColorScale{}) {
+		if config.Background != nil {
+			config.BackgroundColorScale = defaultColorScale
+		} else {
+			config.BackgroundColorScale = ColorScale{A: 0.5}
+		}
+	}
+	if config.FillColorScale == (ColorScale{}) {
+		if config.Fill != nil {
+			config.FillColorScale = defaultColorScale
+		} else {
+			config.FillColorScale = ColorScale{R: 0.3, G: 0.9, B: 0.3, A: 1}
+		}
+	}
+	if config.FlashDuration == 0 {
+		config.FlashDuration = 0.3
+	}
+
+	return &Gauge{
+		config:                     config,
+		size:                       size,
+		fillSize:                   fillSize,
+		ebitenBackgroundColorScale: config.BackgroundColorScale.ToEbitenColorScale(),
+		ebitenFillColorScale:       config.FillColorScale.ToEbitenColorScale(),
+		value:                      1,
+		visible:                    true,
+	}
+}
+
+// GetValue returns the current gauge value in [0, 1] range.
+func (g *Gauge) GetValue() float64 { return g.value }
+
+// SetValue changes the gauge value.
+// The value is clamped to [0, 1].
+//
+// A value above 1 triggers the over-fill flash (if enabled by the config).
+func (g *Gauge) SetValue(v float64) {
+	if v > 1 && g.config.FlashColorScale.A != 0 {
+		g.flash = g.config.FlashDuration
+	}
+	g.value = gmath.Clamp(v, 0, 1)
+}
+
+// Update advances the gauge animations.
+func (g *Gauge) Update(delta float64) {
+	g.flash = max(0, g.flash-delta)
+}
+
+// BoundsRect returns the gauge bounding rectangle.
+func (g *Gauge) BoundsRect() gmath.Rect {
+	pos := g.Pos.Resolve()
+	return gmath.Rect{Min: pos, Max: pos.Add(g.size)}
+}
+
+// Dispose marks this gauge for deletion.
+// After calling this method, IsDisposed will report true.
+func (g *Gauge) Dispose() { g.disposed = true }
+
+// IsDisposed reports whether this gauge is marked for deletion.
+func (g *Gauge) IsDisposed() bool { return g.disposed }
+
+// IsVisible reports whether this gauge is visible.
+// Use SetVisibility to change this flag value.
+func (g *Gauge) IsVisible() bool { return g.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (g *Gauge) SetVisibility(visible bool) { g.visible = visible }
+
+// Draw renders the gauge onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (g *Gauge) Draw(dst *ebiten.Image) {
+	g.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the gauge onto the provided dst image
+// while also using the extra provided offset.
+func (g *Gauge) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !g.visible {
+		return
+	}
+
+	pos := g.Pos.Resolve().Add(opts.Offset)
+
+	var drawOptions ebiten.DrawImageOptions
+	if opts.Blend != nil {
+		drawOptions.Blend = *opts.Blend
+	}
+
+	if g.config.BackgroundColorScale.A != 0 {
+		drawOptions.ColorScale = g.ebitenBackgroundColorScale
+		img := g.config.Background
+		if img == nil {
+			img = whitePixel
+			drawOptions.GeoM.Scale(g.size.X, g.size.Y)
+		}
+		drawOptions.GeoM.Translate(math.Round(pos.X), math.Round(pos.Y))
+		drawImage(dst, img, &drawOptions)
+	}
+
+	fill := gaugeFillRect(g.fillSize, g.value, g.config.Direction)
+	if fill.IsEmpty() {
+		return
+	}
+	drawOptions.ColorScale = g.ebitenFillColorScale
+	if g.flash > 0 {
+		// The flash starts with a flash color and fades back to normal.
+		t := float32(g.flash / g.config.FlashDuration)
+		cs := g.config.FillColorScale.Lerp(g.config.FlashColorScale, t)
+		drawOptions.ColorScale = cs.ToEbitenColorScale()
+	}
+	drawOptions.GeoM.Reset()
+	img := g.config.Fill
+	if img == nil {
+		img = whitePixel
+		drawOptions.GeoM.Scale(fill.Width(), fill.Height())
+	} else {
+		bounds := img.Bounds()
+		rect := image.Rect(
+			bounds.Min.X+int(math.Round(fill.Min.X)),
+			bounds.Min.Y+int(math.Round(fill.Min.Y)),
+			bounds.Min.X+int(math.Round(fill.Max.X)),
+			bounds.Min.Y+int(math.Round(fill.Max.Y)),
+		)
+		if rect.Empty() {
+			return
+		}
+		img = subImage(img, rect)
+	}
+	fillPos := pos.Add(g.config.FillOffset).Add(fill.Min)
+	drawOptions.GeoM.Translate(math.Round(fillPos.X), math.Round(fillPos.Y))
+	drawImage(dst, img, &drawOptions)
+}
+
+// gaugeFillRect returns a visible part of the fill area
+// relative to the fill area top-left corner.
+func gaugeFillRect(size gmath.Vec, value float64, dir GaugeDirection) gmath.Rect {
+	r := gmath.Rect{Max: size}
+	switch dir {
+	case GaugeLeftToRight:
+		r.Max.X = size.X * value
+	case GaugeRightToLeft:
+		r.Min.X = size.X * (1 - value)
+	case GaugeBottomToTop:
+		r.Min.Y = size.Y * (1 - value)
+	case GaugeTopToBottom:
+		r.Max.Y = size.Y * value
+	}
+	return r
+}
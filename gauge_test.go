@@ -0,0 +1,41 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/quasilyte/gmath"
+)
+
+func TestGaugeFillRect(t *testing.T) {
+	size := gmath.Vec{X: 100, Y: 10}
+	tests := []struct {
+		dir  GaugeDirection
+		want gmath.Rect
+	}{
+		{GaugeLeftToRight, gmath.Rect{Max: gmath.Vec{X: 25, Y: 10}}},
+		{GaugeRightToLeft, gmath.Rect{Min: gmath.Vec{X: 75}, Max: gmath.Vec{X: 100, Y: 10}}},
+		{GaugeBottomToTop, gmath.Rect{Min: gmath.Vec{Y: 7.5}, Max: gmath.Vec{X: 100, Y: 10}}},
+		{GaugeTopToBottom, gmath.Rect{Max: gmath.Vec{X: 100, Y: 2.5}}},
+	}
+	for _, test := range tests {
+		if have := gaugeFillRect(size, 0.25, test.dir); have != test.want {
+			t.Fatalf("direction %d:\nhave: %v\nwant: %v", test.dir, have, test.want)
+		}
+	}
+}
+
+func TestGaugeSetValue(t *testing.T) {
+	g := NewGauge(GaugeConfig{FlashColorScale: ColorScale{R: 1, G: 1, B: 1, A: 1}})
+	g.SetValue(0.5)
+	if g.GetValue() != 0.5 || g.flash != 0 {
+		t.Fatalf("unexpected state after SetValue(0.5): value=%v flash=%v", g.GetValue(), g.flash)
+	}
+	g.SetValue(1.2)
+	if g.GetValue() != 1 || g.flash == 0 {
+		t.Fatalf("unexpected state after SetValue(1.2): value=%v flash=%v", g.GetValue(), g.flash)
+	}
+	g.Update(1)
+	if g.flash != 0 {
+		t.Fatalf("flash is not finished: %v", g.flash)
+	}
+}
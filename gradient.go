@@ -0,0 +1,85 @@
+package graphics
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// Gradient describes a four-corner color gradient.
+//
+// The colors are interpolated bilinearly between the corners;
+// the rendering uses the vertex colors, so a gradient fill
+// costs the same as a solid color fill.
+//
+// Use [VerticalGradient] and [HorizontalGradient] for
+// the most common two-color gradients.
+type Gradient struct {
+	TopLeft     ColorScale
+	TopRight    ColorScale
+	BottomLeft  ColorScale
+	BottomRight ColorScale
+}
+
+// VerticalGradient returns a top-to-bottom gradient.
+func VerticalGradient(top, bottom ColorScale) Gradient {
+	return Gradient{
+		TopLeft:     top,
+		TopRight:    top,
+		BottomLeft:  bottom,
+		BottomRight: bottom,
+	}
+}
+
+// HorizontalGradient returns a left-to-right gradient.
+func HorizontalGradient(left, right ColorScale) Gradient {
+	return Gradient{
+		TopLeft:     left,
+		TopRight:    right,
+		BottomLeft:  left,
+		BottomRight: right,
+	}
+}
+
+// At returns the gradient color at the specified point.
+// The u and v are the normalized coordinates in [0, 1] range:
+// {0, 0} is the top-left corner, {1, 1} is the bottom-right corner.
+func (g Gradient) At(u, v float32) ColorScale {
+	top := g.TopLeft.Lerp(g.TopRight, u)
+	bottom := g.BottomLeft.Lerp(g.BottomRight, u)
+	return top.Lerp(bottom, v)
+}
+
+// drawGradientRect draws a filled rectangle using the gradient colors
+// multiplied by the color scale.
+//
+// The uv rect specifies the gradient area that is mapped onto the rect,
+// so a part of the bigger gradient can be drawn.
+func drawGradientRect(dst *ebiten.Image, blend *ebiten.Blend, rect, uv gmath.Rect, g Gradient, cs ColorScale) {
+	vertex := func(x, y float64, u, v float64) ebiten.Vertex {
+		clr := g.At(float32(u), float32(v)).Mul(cs)
+		ecs := clr.ToEbitenColorScale()
+		return ebiten.Vertex{
+			DstX:   float32(x),
+			DstY:   float32(y),
+			SrcX:   1.5,
+			SrcY:   1.5,
+			ColorR: ecs.R(),
+			ColorG: ecs.G(),
+			ColorB: ecs.B(),
+			ColorA: ecs.A(),
+		}
+	}
+	vertices := [4]ebiten.Vertex{
+		vertex(rect.Min.X, rect.Min.Y, uv.Min.X, uv.Min.Y),
+		vertex(rect.Max.X, rect.Min.Y, uv.Max.X, uv.Min.Y),
+		vertex(rect.Min.X, rect.Max.Y, uv.Min.X, uv.Max.Y),
+		vertex(rect.Max.X, rect.Max.Y, uv.Max.X, uv.Max.Y),
+	}
+	indices := [6]uint16{0, 1, 2, 1, 2, 3}
+
+	var drawOptions ebiten.DrawTrianglesOptions
+	if blend != nil {
+		drawOptions.Blend = *blend
+	}
+	drawVertexColorTriangles(dst, vertices[:], indices[:], emptyImage, &drawOptions)
+}
@@ -0,0 +1,63 @@
+package graphics_test
+
+import (
+	"testing"
+
+	graphics "github.com/quasilyte/ebitengine-graphics"
+)
+
+func TestGradientAt(t *testing.T) {
+	black := graphics.ColorScale{A: 1}
+	white := graphics.ColorScale{R: 1, G: 1, B: 1, A: 1}
+	red := graphics.ColorScale{R: 1, A: 1}
+	blue := graphics.ColorScale{B: 1, A: 1}
+
+	g := graphics.Gradient{
+		TopLeft:     black,
+		TopRight:    white,
+		BottomLeft:  red,
+		BottomRight: blue,
+	}
+	tests := []struct {
+		u, v float32
+		want graphics.ColorScale
+	}{
+		{0, 0, black},
+		{1, 0, white},
+		{0, 1, red},
+		{1, 1, blue},
+		{0.5, 0, graphics.ColorScale{R: 0.5, G: 0.5, B: 0.5, A: 1}},
+		{0.5, 1, graphics.ColorScale{R: 0.5, B: 0.5, A: 1}},
+		{0.5, 0.5, graphics.ColorScale{R: 0.5, G: 0.25, B: 0.5, A: 1}},
+	}
+	for _, test := range tests {
+		if have := g.At(test.u, test.v); have != test.want {
+			t.Fatalf("At(%v, %v):\nhave: %v\nwant: %v", test.u, test.v, have, test.want)
+		}
+	}
+
+	vertical := graphics.VerticalGradient(black, white)
+	if vertical.TopRight != black || vertical.BottomLeft != white {
+		t.Fatalf("unexpected vertical gradient: %+v", vertical)
+	}
+	horizontal := graphics.HorizontalGradient(black, white)
+	if horizontal.TopRight != white || horizontal.BottomLeft != black {
+		t.Fatalf("unexpected horizontal gradient: %+v", horizontal)
+	}
+}
+
+func TestRectFillGradient(t *testing.T) {
+	r := graphics.NewRect(10, 10)
+	if _, ok := r.GetFillGradient(); ok {
+		t.Fatal("a new rect has a gradient")
+	}
+	g := graphics.VerticalGradient(graphics.ColorScale{A: 1}, graphics.ColorScale{R: 1, A: 1})
+	r.SetFillGradient(g)
+	if have, ok := r.GetFillGradient(); !ok || have != g {
+		t.Fatalf("gradient:\nhave: %+v\nwant: %+v", have, g)
+	}
+	r.ResetFillGradient()
+	if _, ok := r.GetFillGradient(); ok {
+		t.Fatal("the gradient is not removed")
+	}
+}
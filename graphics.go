@@ -24,6 +24,18 @@ type Object interface {
 	DrawWithOptions(dst *ebiten.Image, o DrawOptions)
 }
 
+// BoundedObject is implemented by most graphical objects of this package.
+//
+// The bounds rectangle is expected to be in the same coordinates
+// the object is positioned in (usually, world coordinates).
+type BoundedObject interface {
+	BoundsRect() gmath.Rect
+}
+
+type visibleObject interface {
+	IsVisible() bool
+}
+
 type PostProcessor interface {
 	PostProcess(dst, src *ebiten.Image, o DrawOptions)
 }
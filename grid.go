@@ -0,0 +1,293 @@
+package graphics
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// GridConfig describes the [GridContainer] layout parameters.
+type GridConfig struct {
+	// Columns is a number of the grid columns.
+	// The number of rows depends on the number of children.
+	// A zero value means 1.
+	Columns int
+
+	// CellWidth is a fixed column width.
+	// A zero value makes every column as wide as its widest child.
+	CellWidth float64
+
+	// CellHeight is a fixed row height.
+	// A zero value makes every row as tall as its tallest child.
+	CellHeight float64
+
+	// ColumnSpacing is a horizontal distance between the cells.
+	// A zero value means no spacing.
+	ColumnSpacing float64
+
+	// RowSpacing is a vertical distance between the cells.
+	// A zero value means no spacing.
+	RowSpacing float64
+
+	// Padding is an extra space around the grid contents.
+	// A zero value means no padding.
+	Padding float64
+
+	// AlignHorizontal controls the child placement inside its cell.
+	// A zero value means AlignHorizontalLeft.
+	AlignHorizontal AlignHorizontal
+
+	// AlignVertical controls the child placement inside its cell.
+	// A zero value means AlignVerticalTop.
+	AlignVertical AlignVertical
+}
+
+// GridContainer arranges its children in rows and columns.
+// The children fill the grid row by row, in the order they were added.
+// It's useful for the inventory grids, level-select screens and skill trees.
+//
+// Like [BoxContainer], it places the children using their BoundsRect values.
+// The invisible children don't occupy any cells.
+// The layout is re-computed during every Draw and BoundsRect call.
+//
+// The Pos is a grid top-left corner.
+//
+// GridContainer implements gscene Graphics interface.
+type GridContainer struct {
+	Pos gmath.Pos
+
+	config GridConfig
+
+	objects []DisposableObject
+
+	// offsets is a parallel slice for objects that contains
+	// the draw offsets computed by the last layout call.
+	offsets []gmath.Vec
+
+	// colWidths and rowHeights are the cell sizes
+	// computed by the last layout call.
+	colWidths  []float64
+	rowHeights []float64
+	size       gmath.Vec
+
+	changed DirtySignal
+
+	visible  bool
+	disposed bool
+}
+
+// NewGrid creates a grid container with the specified config.
+func NewGrid(config GridConfig) *GridContainer {
+	if config.Columns == 0 {
+		config.Columns = 1
+	}
+	if config.Columns < 0 {
+		panic("GridConfig.Columns can't be negative")
+	}
+	return &GridContainer{
+		config:    config,
+		objects:   make([]DisposableObject, 0, 4),
+		colWidths: make([]float64, config.Columns),
+		visible:   true,
+	}
+}
+
+// AddChild adds an object to the next grid cell.
+func (g *GridContainer) AddChild(o DisposableObject) {
+	g.objects = append(g.objects, o)
+	connectDirtySource(o, g)
+	g.changed.Emit()
+}
+
+// MarkDirty notifies the grid dependents about a change.
+func (g *GridContainer) MarkDirty() {
+	g.changed.Emit()
+}
+
+// DirtySignal returns a signal that is emitted when the grid
+// or one of its [DirtySource] children is changed.
+// It implements the [DirtySource] interface.
+func (g *GridContainer) DirtySignal() *DirtySignal {
+	return &g.changed
+}
+
+// NumColumns reports the number of the grid columns.
+func (g *GridContainer) NumColumns() int { return g.config.Columns }
+
+// NumRows reports the number of the grid rows
+// computed by the last layout.
+func (g *GridContainer) NumRows() int {
+	g.layout()
+	return len(g.rowHeights)
+}
+
+// CellRect returns the specified cell area.
+// The rect includes the grid position, so it can be used
+// to match a cursor position against the grid cells.
+//
+// The row should be in [0, NumRows) range.
+func (g *GridContainer) CellRect(col, row int) gmath.Rect {
+	g.layout()
+	pos := g.Pos.Resolve()
+	pos.X += g.config.Padding + g.config.ColumnSpacing*float64(col)
+	for _, w := range g.colWidths[:col] {
+		pos.X += w
+	}
+	pos.Y += g.config.Padding + g.config.RowSpacing*float64(row)
+	for _, h := range g.rowHeights[:row] {
+		pos.Y += h
+	}
+	size := gmath.Vec{X: g.colWidths[col], Y: g.rowHeights[row]}
+	return gmath.Rect{Min: pos, Max: pos.Add(size)}
+}
+
+// IsDisposed reports whether this grid is marked for deletion.
+func (g *GridContainer) IsDisposed() bool { return g.disposed }
+
+// Dispose marks this grid and all of its children for deletion.
+func (g *GridContainer) Dispose() {
+	for _, o := range g.objects {
+		o.Dispose()
+	}
+	g.disposed = true
+}
+
+// IsVisible reports whether this grid is visible.
+// Use SetVisibility to change this flag value.
+func (g *GridContainer) IsVisible() bool { return g.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (g *GridContainer) SetVisibility(visible bool) { g.visible = visible }
+
+// BoundsRect returns the grid area, including its padding.
+func (g *GridContainer) BoundsRect() gmath.Rect {
+	g.layout()
+	pos := g.Pos.Resolve()
+	return gmath.Rect{Min: pos, Max: pos.Add(g.size)}
+}
+
+// Draw renders the grid children onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (g *GridContainer) Draw(dst *ebiten.Image) {
+	g.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the grid children onto the provided dst image
+// while also using the extra provided offset.
+func (g *GridContainer) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !g.visible {
+		return
+	}
+
+	g.layout()
+
+	offset := opts.Offset.Add(g.Pos.Resolve())
+	for i, o := range g.objects {
+		if v, ok := o.(visibleObject); ok && !v.IsVisible() {
+			continue
+		}
+		opts.Offset = offset.Add(g.offsets[i])
+		drawObject(dst, o, opts)
+	}
+}
+
+// layout removes the disposed children and
+// re-computes the cell sizes and the children offsets.
+func (g *GridContainer) layout() {
+	liveObjects := g.objects[:0]
+	for _, o := range g.objects {
+		if o.IsDisposed() {
+			continue
+		}
+		liveObjects = append(liveObjects, o)
+	}
+	clear(g.objects[len(liveObjects):])
+	g.objects = liveObjects
+
+	numCols := g.config.Columns
+
+	// The first pass computes the cell sizes.
+	clear(g.colWidths)
+	g.rowHeights = g.rowHeights[:0]
+	cell := 0
+	for _, o := range g.objects {
+		bounds, ok := layoutChildBounds(o)
+		if !ok {
+			continue
+		}
+		col := cell % numCols
+		row := cell / numCols
+		if row == len(g.rowHeights) {
+			g.rowHeights = append(g.rowHeights, g.config.CellHeight)
+		}
+		if g.config.CellWidth == 0 {
+			g.colWidths[col] = max(g.colWidths[col], bounds.Width())
+		}
+		if g.config.CellHeight == 0 {
+			g.rowHeights[row] = max(g.rowHeights[row], bounds.Height())
+		}
+		cell++
+	}
+	if g.config.CellWidth != 0 {
+		for i := range g.colWidths {
+			g.colWidths[i] = g.config.CellWidth
+		}
+	}
+
+	// The second pass places the children inside their cells.
+	pad := g.config.Padding
+	g.offsets = g.offsets[:0]
+	cell = 0
+	cellPos := gmath.Vec{X: pad, Y: pad}
+	for _, o := range g.objects {
+		bounds, ok := layoutChildBounds(o)
+		if !ok {
+			g.offsets = append(g.offsets, gmath.Vec{})
+			continue
+		}
+		col := cell % numCols
+		row := cell / numCols
+		if col == 0 && row != 0 {
+			cellPos.X = pad
+			cellPos.Y += g.rowHeights[row-1] + g.config.RowSpacing
+		}
+		pos := cellPos
+		switch g.config.AlignHorizontal {
+		case AlignHorizontalCenter:
+			pos.X += (g.colWidths[col] - bounds.Width()) * 0.5
+		case AlignHorizontalRight:
+			pos.X += g.colWidths[col] - bounds.Width()
+		}
+		switch g.config.AlignVertical {
+		case AlignVerticalCenter:
+			pos.Y += (g.rowHeights[row] - bounds.Height()) * 0.5
+		case AlignVerticalBottom:
+			pos.Y += g.rowHeights[row] - bounds.Height()
+		}
+		g.offsets = append(g.offsets, pos.Sub(bounds.Min))
+		cellPos.X += g.colWidths[col] + g.config.ColumnSpacing
+		cell++
+	}
+
+	// The empty trailing columns don't take any space.
+	usedCols := min(cell, numCols)
+	width := 0.0
+	for _, w := range g.colWidths[:usedCols] {
+		width += w
+	}
+	if usedCols != 0 {
+		width += g.config.ColumnSpacing * float64(usedCols-1)
+	}
+	height := 0.0
+	for _, h := range g.rowHeights {
+		height += h
+	}
+	if len(g.rowHeights) != 0 {
+		height += g.config.RowSpacing * float64(len(g.rowHeights)-1)
+	}
+	g.size = gmath.Vec{X: width + 2*pad, Y: height + 2*pad}
+}
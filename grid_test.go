@@ -0,0 +1,78 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/quasilyte/gmath"
+)
+
+func TestGridLayout(t *testing.T) {
+	newRect := func(w, h float64) *Rect {
+		r := NewRect(w, h)
+		r.SetCentered(false)
+		return r
+	}
+
+	g := NewGrid(GridConfig{
+		Columns:       2,
+		ColumnSpacing: 1,
+		RowSpacing:    2,
+		Padding:       3,
+		AlignVertical: AlignVerticalBottom,
+	})
+	g.AddChild(newRect(10, 4))
+	g.AddChild(newRect(6, 8))
+	hidden := newRect(100, 100)
+	hidden.SetVisibility(false)
+	g.AddChild(hidden)
+	g.AddChild(newRect(12, 5))
+
+	if have := g.NumRows(); have != 2 {
+		t.Fatalf("NumRows: have %d, want 2", have)
+	}
+	// Width: 3 + 12 + 1 + 6 + 3; height: 3 + 8 + 2 + 5 + 3.
+	if have := g.BoundsRect().Size(); have != (gmath.Vec{X: 25, Y: 21}) {
+		t.Fatalf("size: have %v, want {25, 21}", have)
+	}
+	wantOffsets := []gmath.Vec{
+		{X: 3, Y: 7},
+		{X: 16, Y: 3},
+		{},
+		{X: 3, Y: 13},
+	}
+	for i, want := range wantOffsets {
+		if have := g.offsets[i]; have != want {
+			t.Fatalf("offsets[%d]:\nhave: %v\nwant: %v", i, have, want)
+		}
+	}
+
+	cell := g.CellRect(1, 1)
+	wantCell := gmath.Rect{Min: gmath.Vec{X: 16, Y: 13}, Max: gmath.Vec{X: 22, Y: 18}}
+	if cell != wantCell {
+		t.Fatalf("CellRect(1, 1):\nhave: %v\nwant: %v", cell, wantCell)
+	}
+}
+
+func TestGridFixedCellSize(t *testing.T) {
+	g := NewGrid(GridConfig{
+		Columns:         3,
+		CellWidth:       20,
+		CellHeight:      20,
+		AlignHorizontal: AlignHorizontalCenter,
+		AlignVertical:   AlignVerticalCenter,
+	})
+	for i := 0; i < 4; i++ {
+		// A centered rect: its bounds start at (-5, -5).
+		g.AddChild(NewRect(10, 10))
+	}
+
+	if have := g.BoundsRect().Size(); have != (gmath.Vec{X: 60, Y: 40}) {
+		t.Fatalf("size: have %v, want {60, 40}", have)
+	}
+	if have := g.offsets[2]; have != (gmath.Vec{X: 50, Y: 10}) {
+		t.Fatalf("offsets[2]: have %v, want {50, 10}", have)
+	}
+	if have := g.offsets[3]; have != (gmath.Vec{X: 10, Y: 30}) {
+		t.Fatalf("offsets[3]: have %v, want {10, 30}", have)
+	}
+}
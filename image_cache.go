@@ -0,0 +1,97 @@
+package graphics
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+)
+
+// The image registry lets the objects share the same image handles
+// and controls the lifetime of the images that are loaded by key
+// (like the level textures).
+//
+// Every image has a reference counter:
+// AcquireImage and LoadImage increment it, ReleaseImage decrements it.
+// The unreferenced images are not freed immediately,
+// so the level restarts don't reload the same textures;
+// call EvictImages to free them (e.g. after a level change).
+//
+// All registry functions can be called from any goroutine.
+
+// RegisterImage adds an image to the registry under the key.
+// The registered image has no references yet,
+// use AcquireImage to get it.
+//
+// It panics if the key is already registered.
+func RegisterImage(key string, img *ebiten.Image) {
+	if !cache.Global.RegisterImage(key, img) {
+		panic("image " + key + " is already registered")
+	}
+}
+
+// AcquireImage returns a registered image and increments its reference counter.
+// Every AcquireImage call should be paired with a ReleaseImage call.
+//
+// It returns nil if the key is not registered.
+func AcquireImage(key string) *ebiten.Image {
+	return cache.Global.AcquireImage(key)
+}
+
+// LoadImage is like AcquireImage, but it calls load to create
+// and register the image if the key is not registered yet.
+//
+// The load function is called without holding the registry lock,
+// so several goroutines can load different images concurrently.
+// If the same key is loaded concurrently, only one of the loaded
+// images is registered; the other one is deallocated.
+func LoadImage(key string, load func() *ebiten.Image) *ebiten.Image {
+	if img := cache.Global.AcquireImage(key); img != nil {
+		return img
+	}
+	img := load()
+	if !cache.Global.RegisterImage(key, img) {
+		img.Deallocate()
+	}
+	return cache.Global.AcquireImage(key)
+}
+
+// ReleaseImage decrements the image reference counter.
+// The image is not freed even if it has no references left,
+// see EvictImages.
+//
+// It panics if the key is not registered or the image is not referenced.
+func ReleaseImage(key string) {
+	if !cache.Global.ReleaseImage(key) {
+		panic("unbalanced ReleaseImage call for " + key)
+	}
+}
+
+// GetImageRefs reports the image reference counter value.
+// The second result value is false if the key is not registered.
+func GetImageRefs(key string) (refs int, ok bool) {
+	return cache.Global.GetImageRefs(key)
+}
+
+// NumRegisteredImages reports the number of the registered images.
+func NumRegisteredImages() int {
+	return cache.Global.NumImages()
+}
+
+// FreeImage unregisters and deallocates the image regardless of its references.
+// The objects that still use this image will render nothing.
+//
+// It's a no-op if the key is not registered.
+func FreeImage(key string) {
+	if img := cache.Global.RemoveImage(key); img != nil {
+		img.Deallocate()
+	}
+}
+
+// EvictImages unregisters and deallocates all images that have no references.
+// It returns the number of the freed images.
+func EvictImages() int {
+	images := cache.Global.RemoveUnusedImages(nil)
+	for _, img := range images {
+		img.Deallocate()
+	}
+	return len(images)
+}
@@ -0,0 +1,79 @@
+package graphics_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	graphics "github.com/quasilyte/ebitengine-graphics"
+)
+
+func TestImageRegistry(t *testing.T) {
+	defer graphics.EvictImages()
+
+	img := ebiten.NewImage(4, 4)
+	graphics.RegisterImage("test/a.png", img)
+	if refs, ok := graphics.GetImageRefs("test/a.png"); !ok || refs != 0 {
+		t.Fatalf("refs after RegisterImage: have %d (%v), want 0", refs, ok)
+	}
+
+	if have := graphics.AcquireImage("test/a.png"); have != img {
+		t.Fatalf("AcquireImage: have %p, want %p", have, img)
+	}
+	if have := graphics.AcquireImage("test/missing.png"); have != nil {
+		t.Fatalf("AcquireImage for an unregistered key: have %p, want nil", have)
+	}
+
+	numLoads := 0
+	load := func() *ebiten.Image {
+		numLoads++
+		return ebiten.NewImage(2, 2)
+	}
+	b1 := graphics.LoadImage("test/b.png", load)
+	b2 := graphics.LoadImage("test/b.png", load)
+	if b1 != b2 || numLoads != 1 {
+		t.Fatalf("LoadImage: the image is loaded %d times", numLoads)
+	}
+	if refs, _ := graphics.GetImageRefs("test/b.png"); refs != 2 {
+		t.Fatalf("b.png refs: have %d, want 2", refs)
+	}
+
+	// Only the released a.png is evicted.
+	graphics.ReleaseImage("test/a.png")
+	graphics.ReleaseImage("test/b.png")
+	if have := graphics.EvictImages(); have != 1 {
+		t.Fatalf("evicted: have %d, want 1", have)
+	}
+	if _, ok := graphics.GetImageRefs("test/a.png"); ok {
+		t.Fatal("a.png is still registered after EvictImages")
+	}
+
+	graphics.FreeImage("test/b.png")
+	if _, ok := graphics.GetImageRefs("test/b.png"); ok {
+		t.Fatal("b.png is still registered after FreeImage")
+	}
+}
+
+func TestImageRegistryPanics(t *testing.T) {
+	defer graphics.FreeImage("test/c.png")
+
+	graphics.RegisterImage("test/c.png", ebiten.NewImage(1, 1))
+
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{"duplicate", func() { graphics.RegisterImage("test/c.png", ebiten.NewImage(1, 1)) }},
+		{"unbalanced", func() { graphics.ReleaseImage("test/c.png") }},
+		{"unregistered", func() { graphics.ReleaseImage("test/missing.png") }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Fatal("expected a panic")
+				}
+			}()
+			test.f()
+		})
+	}
+}
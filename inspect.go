@@ -0,0 +1,266 @@
+package graphics
+
+import (
+	"fmt"
+	"image"
+	"reflect"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// LayerInfo is a scene layer descriptor produced by the introspection API.
+// See [SceneDrawer.Inspect].
+type LayerInfo struct {
+	Index   int          `json:"index"`
+	Type    string       `json:"type"`
+	Objects []ObjectInfo `json:"objects"`
+}
+
+// ObjectInfo is a graphical object descriptor produced by the introspection API.
+//
+// It's a snapshot of the object state that is suitable for an in-game
+// inspector panel or for a JSON dump attached to the bug report.
+// Changing the ObjectInfo fields doesn't affect the object.
+type ObjectInfo struct {
+	Type string   `json:"type"`
+	Name string   `json:"name,omitempty"`
+	Tags []string `json:"tags,omitempty"`
+
+	// Pos is a resolved object position.
+	// For objects without a Pos field it's a BoundsRect().Min value.
+	Pos gmath.Vec `json:"pos"`
+
+	// Bounds is nil for objects that don't implement [BoundedObject].
+	Bounds *gmath.Rect `json:"bounds,omitempty"`
+
+	// Rotation is a resolved object rotation.
+	// It's zero for objects without a Rotation field.
+	Rotation gmath.Rad `json:"rotation,omitempty"`
+
+	// ColorScale is nil for objects that don't have a GetColorScale method.
+	ColorScale *ColorScale `json:"color_scale,omitempty"`
+
+	// Texture is nil for objects that are not backed by a texture.
+	Texture *TextureInfo `json:"texture,omitempty"`
+
+	// Text is a rendered text of the objects like [Label].
+	Text string `json:"text,omitempty"`
+
+	// Scale is nil for objects that don't have GetScaleX and GetScaleY methods.
+	Scale *gmath.Vec `json:"scale,omitempty"`
+
+	// Blend is nil for objects that don't have a custom blend mode.
+	Blend *ebiten.Blend `json:"blend,omitempty"`
+
+	Visible bool `json:"visible"`
+
+	// Z is an object rendering order inside its parent.
+	// Objects with higher Z are drawn on top of the objects with lower Z.
+	Z int `json:"z"`
+
+	Children []ObjectInfo `json:"children,omitempty"`
+}
+
+// TextureInfo describes the texture used by the graphical object.
+//
+// The image pointers are not reported as they're not stable between
+// the runs, use the size and the frame rectangle for identification.
+type TextureInfo struct {
+	Width  int             `json:"width"`
+	Height int             `json:"height"`
+	Frame  image.Rectangle `json:"frame"`
+}
+
+// Inspect walks the scene layers and returns their descriptors.
+//
+// This operation is relatively expensive: it allocates
+// the entire objects tree and uses the reflection.
+// It's intended to be used for debugging only.
+func (d *SceneDrawer) Inspect() []LayerInfo {
+	layers := make([]LayerInfo, len(d.layers))
+	for i, l := range d.layers {
+		layers[i] = InspectLayer(l)
+		layers[i].Index = i
+	}
+	return layers
+}
+
+// InspectLayer returns the layer descriptor.
+//
+// The shadow layers report their selection markers only:
+// the shadows are derived from the sprites of other layers.
+// Custom layer types are reported without their objects.
+func InspectLayer(l SceneLayerDrawer) LayerInfo {
+	info := LayerInfo{Type: inspectTypeName(l)}
+	switch l := l.(type) {
+	case *Layer:
+		info.Objects = make([]ObjectInfo, 0, len(l.objects))
+		for _, o := range l.objects {
+			if o.IsDisposed() {
+				continue
+			}
+			info.Objects = append(info.Objects, inspectObject(o, &l.registry, len(info.Objects)))
+		}
+	case *StaticLayer:
+		info.Objects = make([]ObjectInfo, 0, len(l.objects))
+		for _, o := range l.objects {
+			if o.IsDisposed() {
+				continue
+			}
+			info.Objects = append(info.Objects, inspectObject(o, &l.registry, len(info.Objects)))
+		}
+	case inspectableLayer:
+		objects := l.inspectObjects()
+		info.Objects = make([]ObjectInfo, 0, len(objects))
+		for _, o := range objects {
+			if o.IsDisposed() {
+				continue
+			}
+			info.Objects = append(info.Objects, inspectObject(o, nil, len(info.Objects)))
+		}
+	}
+	return info
+}
+
+// InspectObject returns the object descriptor.
+//
+// Since the names and tags are stored inside layers,
+// objects inspected this way have no such metadata.
+func InspectObject(o gsceneGraphics) ObjectInfo {
+	return inspectObject(o, nil, 0)
+}
+
+// inspectableLayer is implemented by the layers that
+// report their objects to the introspection API.
+// The objects of [Layer] and [StaticLayer] are inspected
+// directly as they have names and tags.
+type inspectableLayer interface {
+	inspectObjects() []Object
+}
+
+type inspectableParent interface {
+	inspectChildren() []DisposableObject
+}
+
+type texturedObject interface {
+	inspectTexture() *TextureInfo
+}
+
+type textObject interface {
+	inspectText() string
+}
+
+type scaledObject interface {
+	GetScaleX() float64
+	GetScaleY() float64
+}
+
+type blendedObject interface {
+	GetBlend() (ebiten.Blend, bool)
+}
+
+func inspectObject(o gsceneGraphics, registry *objectRegistry, z int) ObjectInfo {
+	info := inspectObjectState(o)
+	info.Z = z
+	if registry != nil {
+		info.Name = registry.GetName(o)
+		info.Tags = registry.GetTags(o)
+	}
+
+	if p, ok := o.(inspectableParent); ok {
+		children := p.inspectChildren()
+		info.Children = make([]ObjectInfo, 0, len(children))
+		for _, child := range children {
+			if child.IsDisposed() {
+				continue
+			}
+			info.Children = append(info.Children, inspectObject(child, nil, len(info.Children)))
+		}
+	}
+
+	return info
+}
+
+// inspectObjectState returns the object descriptor without
+// its metadata, Z value and children.
+func inspectObjectState(o gsceneGraphics) ObjectInfo {
+	info := ObjectInfo{
+		Type:    inspectTypeName(o),
+		Visible: true,
+	}
+	if v, ok := o.(visibleObject); ok {
+		info.Visible = v.IsVisible()
+	}
+	if b, ok := o.(BoundedObject); ok {
+		bounds := b.BoundsRect()
+		info.Bounds = &bounds
+		info.Pos = bounds.Min
+	}
+	if pos := inspectPosField(o); pos != nil {
+		info.Pos = pos.Resolve()
+	}
+	if rotation := inspectRotationField(o); rotation != nil {
+		info.Rotation = *rotation
+	}
+	if c, ok := o.(interface{ GetColorScale() ColorScale }); ok {
+		cs := c.GetColorScale()
+		info.ColorScale = &cs
+	}
+	if t, ok := o.(texturedObject); ok {
+		info.Texture = t.inspectTexture()
+	}
+	if t, ok := o.(textObject); ok {
+		info.Text = t.inspectText()
+	}
+	if sc, ok := o.(scaledObject); ok {
+		info.Scale = &gmath.Vec{X: sc.GetScaleX(), Y: sc.GetScaleY()}
+	}
+	if b, ok := o.(blendedObject); ok {
+		if blend, ok := b.GetBlend(); ok {
+			info.Blend = &blend
+		}
+	}
+	return info
+}
+
+func inspectTypeName(o any) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", o), "*")
+}
+
+// inspectPosField returns a pointer to object's Pos field.
+// It returns nil if there is no such field.
+func inspectPosField(o any) *gmath.Pos {
+	f := inspectField(o, "Pos", reflect.TypeOf(gmath.Pos{}))
+	if !f.IsValid() {
+		return nil
+	}
+	return f.Addr().Interface().(*gmath.Pos)
+}
+
+// inspectRotationField returns the object's Rotation field value.
+// It returns nil if there is no such field or if it's not bound.
+func inspectRotationField(o any) *gmath.Rad {
+	f := inspectField(o, "Rotation", reflect.TypeOf((*gmath.Rad)(nil)))
+	if !f.IsValid() {
+		return nil
+	}
+	return f.Interface().(*gmath.Rad)
+}
+
+func inspectField(o any, name string, typ reflect.Type) reflect.Value {
+	v := reflect.ValueOf(o)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return reflect.Value{}
+	}
+	v = v.Elem()
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	f := v.FieldByName(name)
+	if !f.IsValid() || f.Type() != typ {
+		return reflect.Value{}
+	}
+	return f
+}
@@ -3,6 +3,10 @@ package cache
 import (
 	"image/color"
 	"math"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
@@ -27,14 +31,54 @@ func init() {
 
 // cache is a storage that is shared between all
 // graphical elements.
+//
+// The font and blend tables can be accessed from any goroutine:
+// the readers get an immutable snapshot (a single atomic load),
+// the writers are serialized by the mutex and publish a new
+// copy of the table (copy-on-write).
+// The tables are tiny and they're rarely changed,
+// so the copying is cheaper than locking every read.
+//
+// Other fields are only used by the rendering code,
+// so they're accessed from the game goroutine only.
 type cache struct {
-	FontInfoList []FontInfo
-	FontInfoMap  map[text.Face]uint16
+	// mu guards the tables updates (the fields below).
+	mu sync.Mutex
+
+	fontInfoList atomic.Pointer[[]FontInfo]
+	fontInfoMap  map[text.Face]uint16
+
+	// images is a registry of the shared images.
+	// Unlike the font and blend tables, it's guarded by mu for the reads too.
+	images map[string]*imageEntry
+
+	// imageKeys maps the registered images to their keys.
+	imageKeys map[*ebiten.Image]string
+
+	// blends is a table of the interned blend modes.
+	// The graphical objects store a blend index+1,
+	// so a zero value means "no blend override".
+	blends atomic.Pointer[[]ebiten.Blend]
 
 	ShadersCompiled           bool
 	CircleOutlineShader       *ebiten.Shader
 	DashedCircleOutlineShader *ebiten.Shader
 	DottedLineShader          *ebiten.Shader
+	TeamColorShader           *ebiten.Shader
+	PaletteShader             *ebiten.Shader
+
+	// DebugWireframe is a global debug rendering mode flag.
+	DebugWireframe bool
+
+	// StrictMode is a graphics.StrictMode value.
+	StrictMode uint8
+
+	// BootFontImage is a lazily created boot font glyphs sheet.
+	BootFontImage *ebiten.Image
+
+	// IntStrings is a lazily allocated table of the interned
+	// small integer strings (see InternInt).
+	IntStrings []string
 
 	Rand            gmath.Rand
 	WhitePixel      *ebiten.Image
@@ -42,23 +86,78 @@ type cache struct {
 	ScratchIndices  []uint16
 }
 
+type imageEntry struct {
+	img  *ebiten.Image
+	refs int
+}
+
 type FontInfo struct {
 	Face       text.Face
+	Ascent     float64
+	Descent    float64
 	CapHeight  float64
+	XHeight    float64
 	LineHeight float64
+
+	// RightToLeft is set for the faces with right-to-left direction.
+	RightToLeft bool
+
+	// NumericAdvances are the advances of the numeric text characters:
+	// the digits, followed by '-' and '.'.
+	// See NumericAdvanceIndex.
+	NumericAdvances [12]float64
+}
+
+// NumericAdvanceIndex returns the FontInfo.NumericAdvances index for ch.
+// It returns -1 for non-numeric characters.
+func NumericAdvanceIndex(ch byte) int {
+	switch {
+	case ch >= '0' && ch <= '9':
+		return int(ch - '0')
+	case ch == '-':
+		return 10
+	case ch == '.':
+		return 11
+	default:
+		return -1
+	}
+}
+
+// GetFontInfo returns the info of the interned font face.
+// The returned value should not be modified.
+func (c *cache) GetFontInfo(id uint16) *FontInfo {
+	return &(*c.fontInfoList.Load())[id]
+}
+
+// NumFonts reports the number of interned font faces.
+func (c *cache) NumFonts() int {
+	list := c.fontInfoList.Load()
+	if list == nil {
+		return 0
+	}
+	return len(*list)
 }
 
 func (c *cache) InternFontFace(ff text.Face) uint16 {
-	if c.FontInfoMap == nil {
-		c.FontInfoMap = make(map[text.Face]uint16, 8)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.internFontFace(ff)
+}
+
+func (c *cache) internFontFace(ff text.Face) uint16 {
+	if c.fontInfoMap == nil {
+		c.fontInfoMap = make(map[text.Face]uint16, 8)
 	}
 
-	if id, ok := c.FontInfoMap[ff]; ok {
+	if id, ok := c.fontInfoMap[ff]; ok {
 		return id
 	}
 
-	id := uint16(len(c.FontInfoList))
-	c.FontInfoMap[ff] = id
+	var list []FontInfo
+	if p := c.fontInfoList.Load(); p != nil {
+		list = *p
+	}
+	id := uint16(len(list))
 
 	m := ff.Metrics()
 	capHeight := math.Abs(m.CapHeight)
@@ -68,11 +167,231 @@ func (c *cache) InternFontFace(ff text.Face) uint16 {
 	// > HDescent:  fixed26_6ToFloat64(fm.Descent)
 	lineHeight := m.HLineGap + m.HAscent + m.HDescent
 
-	c.FontInfoList = append(c.FontInfoList, FontInfo{
+	info := FontInfo{
 		Face:       ff,
+		Ascent:     m.HAscent,
+		Descent:    m.HDescent,
 		CapHeight:  capHeight,
+		XHeight:    math.Abs(m.XHeight),
 		LineHeight: lineHeight,
-	})
+	}
+	if f, ok := ff.(*text.GoTextFace); ok && f.Direction == text.DirectionRightToLeft {
+		info.RightToLeft = true
+	}
+	for i, ch := range "0123456789-." {
+		info.NumericAdvances[i] = text.Advance(string(ch), ff)
+	}
+
+	// The old snapshot can be used by the readers right now,
+	// so it's never modified; the appended slice is always a new one.
+	newList := make([]FontInfo, len(list), len(list)+1)
+	copy(newList, list)
+	newList = append(newList, info)
+	c.fontInfoList.Store(&newList)
+	c.fontInfoMap[ff] = id
 
 	return id
 }
+
+// SetFontFallbacks replaces the interned font face with a multi-face
+// that uses the fallback faces for the glyphs ff doesn't have.
+// The font metrics are not changed: they're always taken from ff.
+func (c *cache) SetFontFallbacks(ff text.Face, fallbacks []text.Face) error {
+	face := ff
+	if len(fallbacks) != 0 {
+		faces := make([]text.Face, 0, len(fallbacks)+1)
+		faces = append(faces, ff)
+		faces = append(faces, fallbacks...)
+		multiFace, err := text.NewMultiFace(faces...)
+		if err != nil {
+			return err
+		}
+		face = multiFace
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	id := c.internFontFace(ff)
+	newList := slices.Clone(*c.fontInfoList.Load())
+	newList[id].Face = face
+	c.fontInfoList.Store(&newList)
+	return nil
+}
+
+// NumInternedInts is a number of the integers that can be interned.
+const NumInternedInts = 10000
+
+// InternInt returns a string representation of n.
+// The strings of [0, NumInternedInts) values are allocated once,
+// the other values are always allocated.
+func (c *cache) InternInt(n int) string {
+	if n < 0 || n >= NumInternedInts {
+		return strconv.Itoa(n)
+	}
+	if c.IntStrings == nil {
+		c.IntStrings = make([]string, NumInternedInts)
+	}
+	s := c.IntStrings[n]
+	if s == "" {
+		s = strconv.Itoa(n)
+		c.IntStrings[n] = s
+	}
+	return s
+}
+
+// GetBlend returns the interned blend mode by its index+1.
+// The returned value should not be modified.
+func (c *cache) GetBlend(id uint8) *ebiten.Blend {
+	return &(*c.blends.Load())[id-1]
+}
+
+// InternBlend returns a blend table index+1 for the blend mode.
+// Equal blend modes share the same index.
+func (c *cache) InternBlend(b ebiten.Blend) uint8 {
+	if id := c.findBlend(b); id != 0 {
+		return id
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Re-check under the lock: the blend could be added concurrently.
+	if id := c.findBlend(b); id != 0 {
+		return id
+	}
+	var blends []ebiten.Blend
+	if p := c.blends.Load(); p != nil {
+		blends = *p
+	}
+	if len(blends) == math.MaxUint8 {
+		panic("too many unique blend modes")
+	}
+	newBlends := make([]ebiten.Blend, len(blends), len(blends)+1)
+	copy(newBlends, blends)
+	newBlends = append(newBlends, b)
+	c.blends.Store(&newBlends)
+	return uint8(len(newBlends))
+}
+
+func (c *cache) findBlend(b ebiten.Blend) uint8 {
+	p := c.blends.Load()
+	if p == nil {
+		return 0
+	}
+	for i, existing := range *p {
+		if existing == b {
+			return uint8(i + 1)
+		}
+	}
+	return 0
+}
+
+// RegisterImage adds an unreferenced image to the registry.
+// It reports false if the key is already registered.
+func (c *cache) RegisterImage(key string, img *ebiten.Image) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.images[key]; ok {
+		return false
+	}
+	if c.images == nil {
+		c.images = make(map[string]*imageEntry, 16)
+		c.imageKeys = make(map[*ebiten.Image]string, 16)
+	}
+	c.images[key] = &imageEntry{img: img}
+	c.imageKeys[img] = key
+	return true
+}
+
+// AcquireImage increments the image reference counter.
+// It returns nil if the key is not registered.
+func (c *cache) AcquireImage(key string) *ebiten.Image {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e := c.images[key]
+	if e == nil {
+		return nil
+	}
+	e.refs++
+	return e.img
+}
+
+// ReleaseImage decrements the image reference counter.
+// It reports false if the key is not registered or it's not referenced.
+func (c *cache) ReleaseImage(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e := c.images[key]
+	if e == nil || e.refs == 0 {
+		return false
+	}
+	e.refs--
+	return true
+}
+
+// GetImage returns a registered image without changing its reference counter.
+// It returns nil if the key is not registered.
+func (c *cache) GetImage(key string) *ebiten.Image {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e := c.images[key]
+	if e == nil {
+		return nil
+	}
+	return e.img
+}
+
+// FindImageKey returns the registry key of the image.
+// It returns an empty string if the image is not registered.
+func (c *cache) FindImageKey(img *ebiten.Image) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.imageKeys[img]
+}
+
+// GetImageRefs returns the image reference counter.
+func (c *cache) GetImageRefs(key string) (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e := c.images[key]
+	if e == nil {
+		return 0, false
+	}
+	return e.refs, true
+}
+
+// NumImages reports the number of the registered images.
+func (c *cache) NumImages() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.images)
+}
+
+// RemoveImage unregisters the image regardless of its references.
+// The removed image is returned, so it can be deallocated by the caller.
+func (c *cache) RemoveImage(key string) *ebiten.Image {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e := c.images[key]
+	if e == nil {
+		return nil
+	}
+	delete(c.images, key)
+	delete(c.imageKeys, e.img)
+	return e.img
+}
+
+// RemoveUnusedImages unregisters all unreferenced images.
+// The removed images are appended to dst.
+func (c *cache) RemoveUnusedImages(dst []*ebiten.Image) []*ebiten.Image {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, e := range c.images {
+		if e.refs != 0 {
+			continue
+		}
+		delete(c.images, key)
+		delete(c.imageKeys, e.img)
+		dst = append(dst, e.img)
+	}
+	return dst
+}
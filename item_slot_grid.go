@@ -0,0 +1,405 @@
+package graphics
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+	"github.com/quasilyte/gmath"
+)
+
+// ItemSlotGridConfig describes the [ItemSlotGrid] appearance.
+type ItemSlotGridConfig struct {
+	// Columns is a number of the slots per row.
+	// A zero value means 8.
+	Columns int
+
+	// SlotSize is a size of a single (square) slot.
+	// The item icons are scaled to fit it.
+	// A zero value means 32.
+	SlotSize float64
+
+	// Spacing is a distance between the slots.
+	// A zero value means 2.
+	Spacing float64
+
+	// SlotImage is an optional slot background image.
+	// It's scaled to the SlotSize and drawn for every slot.
+	SlotImage *ebiten.Image
+
+	// SlotColorScale is a background color of the occupied slots.
+	// A zero value means a half-transparent black color.
+	SlotColorScale ColorScale
+
+	// EmptyColorScale is a background color of the empty slots.
+	// A zero value means a more transparent black color.
+	EmptyColorScale ColorScale
+
+	// BorderWidth is a rarity border width.
+	// The highlight border is twice as wide.
+	// A zero value means 1.
+	BorderWidth float64
+
+	// RarityColorScales are the rarity border colors.
+	// The item rarity is an index into this slice;
+	// the items with out of range rarities have no border.
+	RarityColorScales []ColorScale
+
+	// HighlightColorScale is a highlighted slot border color.
+	// A zero value means {1, 1, 1, 1}.
+	HighlightColorScale ColorScale
+
+	// Face is used to render the stack counts.
+	// A nil face disables the stack count labels.
+	Face text.Face
+
+	// TextColorScale is a stack count label color.
+	// A zero value means {1, 1, 1, 1}.
+	TextColorScale ColorScale
+}
+
+// ItemSlotGrid renders an inventory-style grid of the item slots.
+//
+// Every slot can hold an item icon with a stack count label
+// (for the counts above 1) and a rarity-colored border.
+// One of the slots can be highlighted (e.g. the hovered or selected one).
+//
+// The slots are not separate objects: the backgrounds and all borders
+// are rendered using a single DrawTriangles call each,
+// so the big inventories are cheap to draw.
+// Use the same atlas image for all icons to let Ebitengine
+// batch the icon draw calls as well.
+//
+// The Pos is a grid top-left corner.
+//
+// ItemSlotGrid implements gscene Graphics interface.
+type ItemSlotGrid struct {
+	Pos gmath.Pos
+
+	config ItemSlotGridConfig
+
+	slots []itemSlot
+
+	highlighted int
+
+	slotColor      ebiten.ColorScale
+	emptyColor     ebiten.ColorScale
+	highlightColor ebiten.ColorScale
+	textColor      ebiten.ColorScale
+	rarityColors   []ebiten.ColorScale
+
+	visible  bool
+	disposed bool
+}
+
+type itemSlot struct {
+	icon   *ebiten.Image
+	count  int
+	rarity int
+}
+
+// NewItemSlotGrid creates a grid of numSlots empty slots.
+func NewItemSlotGrid(config ItemSlotGridConfig, numSlots int) *ItemSlotGrid {
+	if config.Columns == 0 {
+		config.Columns = 8
+	}
+	if config.SlotSize == 0 {
+		config.SlotSize = 32
+	}
+	if config.Spacing == 0 {
+		config.Spacing = 2
+	}
+	if config.SlotColorScale == (ColorScale{}) {
+		config.SlotColorScale = ColorScale{A: 0.5}
+	}
+	if config.EmptyColorScale == (ColorScale{}) {
+		config.EmptyColorScale = ColorScale{A: 0.25}
+	}
+	if config.BorderWidth == 0 {
+		config.BorderWidth = 1
+	}
+	if config.HighlightColorScale == (ColorScale{}) {
+		config.HighlightColorScale = defaultColorScale
+	}
+	if config.TextColorScale == (ColorScale{}) {
+		config.TextColorScale = defaultColorScale
+	}
+
+	g := &ItemSlotGrid{
+		config:         config,
+		slots:          make([]itemSlot, numSlots),
+		highlighted:    -1,
+		slotColor:      config.SlotColorScale.ToEbitenColorScale(),
+		emptyColor:     config.EmptyColorScale.ToEbitenColorScale(),
+		highlightColor: config.HighlightColorScale.ToEbitenColorScale(),
+		textColor:      config.TextColorScale.ToEbitenColorScale(),
+		rarityColors:   make([]ebiten.ColorScale, len(config.RarityColorScales)),
+		visible:        true,
+	}
+	for i := range config.RarityColorScales {
+		g.rarityColors[i] = config.RarityColorScales[i].ToEbitenColorScale()
+	}
+	return g
+}
+
+// NumSlots reports the number of the grid slots.
+func (g *ItemSlotGrid) NumSlots() int { return len(g.slots) }
+
+// NumRows reports the number of the grid rows.
+// The last row can be incomplete.
+func (g *ItemSlotGrid) NumRows() int {
+	return (len(g.slots) + g.config.Columns - 1) / g.config.Columns
+}
+
+// SetItem puts an item into the slot.
+// A nil icon makes the slot empty.
+func (g *ItemSlotGrid) SetItem(slot int, icon *ebiten.Image, count, rarity int) {
+	g.slots[slot] = itemSlot{icon: icon, count: count, rarity: rarity}
+}
+
+// GetItem returns the slot contents assigned by SetItem.
+func (g *ItemSlotGrid) GetItem(slot int) (icon *ebiten.Image, count, rarity int) {
+	s := &g.slots[slot]
+	return s.icon, s.count, s.rarity
+}
+
+// SetCount changes the slot stack count.
+func (g *ItemSlotGrid) SetCount(slot, count int) { g.slots[slot].count = count }
+
+// ClearSlot makes the slot empty.
+func (g *ItemSlotGrid) ClearSlot(slot int) { g.slots[slot] = itemSlot{} }
+
+// IsEmpty reports whether the slot has no item.
+func (g *ItemSlotGrid) IsEmpty(slot int) bool { return g.slots[slot].icon == nil }
+
+// GetHighlighted returns the highlighted slot index.
+// It returns -1 if there is no highlighted slot.
+func (g *ItemSlotGrid) GetHighlighted() int { return g.highlighted }
+
+// SetHighlighted changes the highlighted slot.
+// A negative value removes the highlight.
+func (g *ItemSlotGrid) SetHighlighted(slot int) {
+	if slot < 0 || slot >= len(g.slots) {
+		slot = -1
+	}
+	g.highlighted = slot
+}
+
+// SlotRect returns the slot rect (in the same coordinates as the Pos).
+func (g *ItemSlotGrid) SlotRect(slot int) gmath.Rect {
+	return g.slotRect(g.Pos.Resolve(), slot)
+}
+
+func (g *ItemSlotGrid) slotRect(origin gmath.Vec, slot int) gmath.Rect {
+	step := g.config.SlotSize + g.config.Spacing
+	col := slot % g.config.Columns
+	row := slot / g.config.Columns
+	pos := origin.Add(gmath.Vec{X: float64(col) * step, Y: float64(row) * step})
+	return gmath.Rect{
+		Min: pos,
+		Max: pos.Add(gmath.Vec{X: g.config.SlotSize, Y: g.config.SlotSize}),
+	}
+}
+
+// SlotAt returns the index of the slot located at pos.
+// It returns -1 if there is no such slot (the spacing areas included).
+func (g *ItemSlotGrid) SlotAt(pos gmath.Vec) int {
+	local := pos.Sub(g.Pos.Resolve())
+	if local.X < 0 || local.Y < 0 {
+		return -1
+	}
+	step := g.config.SlotSize + g.config.Spacing
+	col := int(local.X / step)
+	row := int(local.Y / step)
+	if col >= g.config.Columns {
+		return -1
+	}
+	if local.X-float64(col)*step >= g.config.SlotSize || local.Y-float64(row)*step >= g.config.SlotSize {
+		return -1
+	}
+	slot := row*g.config.Columns + col
+	if slot >= len(g.slots) {
+		return -1
+	}
+	return slot
+}
+
+// BoundsRect returns the entire grid rect.
+func (g *ItemSlotGrid) BoundsRect() gmath.Rect {
+	pos := g.Pos.Resolve()
+	step := g.config.SlotSize + g.config.Spacing
+	columns := min(g.config.Columns, len(g.slots))
+	rows := g.NumRows()
+	return gmath.Rect{
+		Min: pos,
+		Max: pos.Add(gmath.Vec{
+			X: max(0, float64(columns)*step-g.config.Spacing),
+			Y: max(0, float64(rows)*step-g.config.Spacing),
+		}),
+	}
+}
+
+// Dispose marks this grid for deletion.
+// After calling this method, IsDisposed will report true.
+func (g *ItemSlotGrid) Dispose() { g.disposed = true }
+
+// IsDisposed reports whether this grid is marked for deletion.
+func (g *ItemSlotGrid) IsDisposed() bool { return g.disposed }
+
+// IsVisible reports whether this grid is visible.
+// Use SetVisibility to change this flag value.
+func (g *ItemSlotGrid) IsVisible() bool { return g.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (g *ItemSlotGrid) SetVisibility(visible bool) { g.visible = visible }
+
+// Draw renders the grid onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (g *ItemSlotGrid) Draw(dst *ebiten.Image) {
+	g.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the grid onto the provided dst image
+// while also using the extra provided offset.
+func (g *ItemSlotGrid) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !g.visible || len(g.slots) == 0 {
+		return
+	}
+
+	origin := g.Pos.Resolve().Add(opts.Offset).Rounded()
+	size := g.config.SlotSize
+
+	g.drawBackgrounds(dst, opts.Blend, origin)
+
+	var drawOptions ebiten.DrawImageOptions
+	if opts.Blend != nil {
+		drawOptions.Blend = *opts.Blend
+	}
+	drawOptions.Filter = ebiten.FilterLinear
+	drawImage := func(img *ebiten.Image, pos gmath.Vec) {
+		bounds := img.Bounds()
+		drawOptions.GeoM.Reset()
+		drawOptions.GeoM.Scale(size/float64(bounds.Dx()), size/float64(bounds.Dy()))
+		drawOptions.GeoM.Translate(pos.X, pos.Y)
+		drawImage(dst, img, &drawOptions)
+	}
+	if g.config.SlotImage != nil {
+		for i := range g.slots {
+			drawImage(g.config.SlotImage, g.slotRect(origin, i).Min)
+		}
+	}
+	for i := range g.slots {
+		if icon := g.slots[i].icon; icon != nil {
+			drawImage(icon, g.slotRect(origin, i).Min)
+		}
+	}
+
+	g.drawBorders(dst, opts.Blend, origin)
+
+	if g.config.Face == nil {
+		return
+	}
+	var textOptions text.DrawOptions
+	if opts.Blend != nil {
+		textOptions.Blend = *opts.Blend
+	}
+	textOptions.ColorScale = g.textColor
+	textOptions.PrimaryAlign = text.AlignEnd
+	textOptions.SecondaryAlign = text.AlignEnd
+	for i := range g.slots {
+		s := &g.slots[i]
+		if s.icon == nil || s.count <= 1 {
+			continue
+		}
+		r := g.slotRect(origin, i)
+		textOptions.GeoM.Reset()
+		textOptions.GeoM.Translate(r.Max.X-2, r.Max.Y)
+		drawText(dst, strconv.Itoa(s.count), g.config.Face, &textOptions)
+	}
+}
+
+// drawBackgrounds renders all slot backgrounds in a single draw call.
+func (g *ItemSlotGrid) drawBackgrounds(dst *ebiten.Image, blend *ebiten.Blend, origin gmath.Vec) {
+	vertices := cache.Global.ScratchVertices[:0]
+	indices := cache.Global.ScratchIndices[:0]
+	defer func() {
+		cache.Global.ScratchVertices = vertices[:0]
+		cache.Global.ScratchIndices = indices[:0]
+	}()
+
+	var drawOptions ebiten.DrawTrianglesOptions
+	if blend != nil {
+		drawOptions.Blend = *blend
+	}
+
+	size := g.config.SlotSize
+	for i := range g.slots {
+		if len(vertices)+4 > math.MaxUint16 {
+			drawVertexColorTriangles(dst, vertices, indices, emptyImage, &drawOptions)
+			vertices = vertices[:0]
+			indices = indices[:0]
+		}
+		cs := g.emptyColor
+		if g.slots[i].icon != nil {
+			cs = g.slotColor
+		}
+		r := g.slotRect(origin, i)
+		vertices, indices = appendRectQuad(vertices, indices, r.Min.X, r.Min.Y, size, size, cs, 1)
+	}
+	if len(indices) != 0 {
+		drawVertexColorTriangles(dst, vertices, indices, emptyImage, &drawOptions)
+	}
+}
+
+// drawBorders renders all rarity borders and the highlight border
+// in a single draw call.
+func (g *ItemSlotGrid) drawBorders(dst *ebiten.Image, blend *ebiten.Blend, origin gmath.Vec) {
+	vertices := cache.Global.ScratchVertices[:0]
+	indices := cache.Global.ScratchIndices[:0]
+	defer func() {
+		cache.Global.ScratchVertices = vertices[:0]
+		cache.Global.ScratchIndices = indices[:0]
+	}()
+
+	var drawOptions ebiten.DrawTrianglesOptions
+	if blend != nil {
+		drawOptions.Blend = *blend
+	}
+
+	appendBorder := func(r gmath.Rect, width float64, cs ebiten.ColorScale) {
+		if len(vertices)+16 > math.MaxUint16 {
+			drawVertexColorTriangles(dst, vertices, indices, emptyImage, &drawOptions)
+			vertices = vertices[:0]
+			indices = indices[:0]
+		}
+		w := r.Width()
+		h := r.Height()
+		vertices, indices = appendRectQuad(vertices, indices, r.Min.X, r.Min.Y, w, width, cs, 1)
+		vertices, indices = appendRectQuad(vertices, indices, r.Min.X, r.Max.Y-width, w, width, cs, 1)
+		vertices, indices = appendRectQuad(vertices, indices, r.Min.X, r.Min.Y+width, width, h-2*width, cs, 1)
+		vertices, indices = appendRectQuad(vertices, indices, r.Max.X-width, r.Min.Y+width, width, h-2*width, cs, 1)
+	}
+
+	for i := range g.slots {
+		s := &g.slots[i]
+		if s.icon == nil || s.rarity < 0 || s.rarity >= len(g.rarityColors) {
+			continue
+		}
+		appendBorder(g.slotRect(origin, i), g.config.BorderWidth, g.rarityColors[s.rarity])
+	}
+	if g.highlighted != -1 {
+		// The highlight is drawn last, so it covers the rarity border.
+		appendBorder(g.slotRect(origin, g.highlighted), g.config.BorderWidth*2, g.highlightColor)
+	}
+
+	if len(indices) != 0 {
+		drawVertexColorTriangles(dst, vertices, indices, emptyImage, &drawOptions)
+	}
+}
@@ -0,0 +1,84 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/quasilyte/gmath"
+)
+
+func TestItemSlotGridLayout(t *testing.T) {
+	g := NewItemSlotGrid(ItemSlotGridConfig{
+		Columns:  4,
+		SlotSize: 10,
+		Spacing:  2,
+	}, 10)
+	g.Pos.Offset = gmath.Vec{X: 100, Y: 50}
+
+	if have := g.NumRows(); have != 3 {
+		t.Fatalf("rows: have %d, want 3", have)
+	}
+	wantBounds := gmath.Rect{
+		Min: gmath.Vec{X: 100, Y: 50},
+		Max: gmath.Vec{X: 146, Y: 84},
+	}
+	if have := g.BoundsRect(); have != wantBounds {
+		t.Fatalf("bounds:\nhave: %v\nwant: %v", have, wantBounds)
+	}
+	wantRect := gmath.Rect{
+		Min: gmath.Vec{X: 112, Y: 62},
+		Max: gmath.Vec{X: 122, Y: 72},
+	}
+	if have := g.SlotRect(5); have != wantRect {
+		t.Fatalf("slot rect:\nhave: %v\nwant: %v", have, wantRect)
+	}
+
+	tests := []struct {
+		pos  gmath.Vec
+		want int
+	}{
+		{gmath.Vec{X: 100, Y: 50}, 0},
+		{gmath.Vec{X: 109, Y: 59}, 0},
+		{gmath.Vec{X: 111, Y: 55}, -1}, // Spacing
+		{gmath.Vec{X: 115, Y: 65}, 5},
+		{gmath.Vec{X: 115, Y: 75}, 9},
+		{gmath.Vec{X: 125, Y: 75}, -1}, // The last row is incomplete
+		{gmath.Vec{X: 99, Y: 55}, -1},
+		{gmath.Vec{X: 148, Y: 55}, -1},
+	}
+	for _, test := range tests {
+		if have := g.SlotAt(test.pos); have != test.want {
+			t.Fatalf("SlotAt(%v): have %d, want %d", test.pos, have, test.want)
+		}
+	}
+}
+
+func TestItemSlotGridSlots(t *testing.T) {
+	g := NewItemSlotGrid(ItemSlotGridConfig{}, 4)
+
+	if !g.IsEmpty(1) {
+		t.Fatal("a new slot is not empty")
+	}
+	g.SetItem(1, emptyImage, 5, 2)
+	if g.IsEmpty(1) {
+		t.Fatal("the slot is empty after SetItem")
+	}
+	if icon, count, rarity := g.GetItem(1); icon != emptyImage || count != 5 || rarity != 2 {
+		t.Fatalf("unexpected slot contents: %v %d %d", icon, count, rarity)
+	}
+	g.ClearSlot(1)
+	if !g.IsEmpty(1) {
+		t.Fatal("the slot is not empty after ClearSlot")
+	}
+
+	if have := g.GetHighlighted(); have != -1 {
+		t.Fatalf("highlighted: have %d, want -1", have)
+	}
+	g.SetHighlighted(3)
+	if have := g.GetHighlighted(); have != 3 {
+		t.Fatalf("highlighted: have %d, want 3", have)
+	}
+	g.SetHighlighted(4)
+	if have := g.GetHighlighted(); have != -1 {
+		t.Fatalf("highlighted: have %d, want -1", have)
+	}
+}
@@ -2,7 +2,9 @@ package graphics
 
 import (
 	"math"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
@@ -47,7 +49,8 @@ const (
 // Label is a simple text rendering object.
 //
 // It supports different kinds of grow/aling settings.
-// The text color can be changed, but only for the whole text.
+// The text color can be changed for the whole text (SetColorScale)
+// or per text segment (SetTextSegments).
 //
 // Label implements gscene Graphics interface.
 type Label struct {
@@ -58,7 +61,10 @@ type Label struct {
 
 	Pos gmath.Pos
 
-	shadow *labelShadowData
+	// ext contains the rarely used label settings.
+	// It points to a shared defaultLabelExt until
+	// one of these settings is changed.
+	ext *labelExtData
 
 	flags        labelFlag
 	fontID       uint16
@@ -66,15 +72,118 @@ type Label struct {
 	height       uint16
 	boundsWidth  uint16
 	boundsHeight uint16
+	blendID      uint8
 }
 
-type labelShadowData struct {
-	enabled          bool
+type labelExtData struct {
+	shadowEnabled          bool
+	shadowEbitenColorScale ebiten.ColorScale
+	shadowOffset           gmath.Vec
+
+	outlineEnabled          bool
+	outlineDirty            bool
+	outlineThickness        int
+	outlineEbitenColorScale ebiten.ColorScale
+	// outlineImage is a pre-rendered outline of the current text.
+	// It's re-rendered lazily when outlineDirty is set
+	// or when the container width changes.
+	outlineImage          *ebiten.Image
+	outlineContainerWidth float64
+
+	// The cached rendering mode works like the outline cache,
+	// but the entire label is rendered to the cacheImage.
+	cacheEnabled        bool
+	cacheDirty          bool
+	cacheImage          *ebiten.Image
+	cacheContainerWidth float64
+
+	// shader is applied to the cached image,
+	// so the shader mode implies the cached rendering.
+	shader *Shader
+
+	// visibleRunes is a typewriter effect limit.
+	// It's only used if limitRunes is true.
+	limitRunes   bool
+	visibleRunes int
+
+	// wrapWidth is a word wrapping max line width.
+	// Zero means "no wrapping", negative value means "use a container width".
+	wrapWidth float64
+	// rawText is an original (unwrapped) plain text.
+	// It's only used for the plain text labels with word wrapping.
+	rawText string
+
+	// segments are only used for the rich text labels.
+	segments []labelSegment
+	runs     []labelRun
+	lines    []float64 // Every line width
+
+	// The rich text heights are calculated by the layout builder
+	// as the lines can have different heights.
+	// textVisualHeight doesn't include the last line gap.
+	textHeight       float64
+	textVisualHeight float64
+
+	// Extra spacing values, in pixels.
+	lineSpacing   float64
+	letterSpacing float64
+}
+
+type labelSegment struct {
+	text             string
 	ebitenColorScale ebiten.ColorScale
+	hasColorScale    bool
+	hasFont          bool
+	fontID           uint16
+	script           TextScript
+
+	hasBackground        bool
+	backgroundColorScale ebiten.ColorScale
 }
 
-var disabledShadow = &labelShadowData{
-	enabled: false,
+// labelRun is a pre-computed part of a rich text line.
+// Every run belongs to a single segment and a single line.
+type labelRun struct {
+	text    string
+	x       float64
+	y       float64
+	width   float64
+	line    uint16
+	segment uint16
+	fontID  uint16
+	script  TextScript
+}
+
+var defaultLabelExt = &labelExtData{}
+
+// TextSegment is a part of the rich text.
+// See [Label.SetTextSegments].
+type TextSegment struct {
+	Text string
+
+	// ColorScale is this segment's color.
+	// A zero value means "use the label's color scale".
+	ColorScale ColorScale
+
+	// Face is this segment's font face.
+	// A nil value means "use the label's font face".
+	//
+	// The faces of different sizes are aligned by their baselines.
+	// The line height is determined by the tallest face of that line.
+	Face text.Face
+
+	// Script makes the segment a superscript or a subscript.
+	// Such segments are rendered smaller and with a shifted baseline.
+	// See also [ParseScriptMarkup].
+	Script TextScript
+
+	// BackgroundColorScale is this segment's background color.
+	// The background is a rounded "pill" drawn behind the segment text,
+	// it's commonly used to highlight the key names like [SPACE] inside a text.
+	// The background is slightly bigger than the text itself,
+	// but it doesn't affect the layout.
+	// A zero value means "no background".
+	BackgroundColorScale ColorScale
 }
 
 type labelFlag uint16
@@ -98,31 +207,187 @@ const (
 	labelFlagDisposed
 )
 
+// NewLabel creates a label that uses the specified font face.
+//
+// Any text/v2 face can be used here.
+// For the complex scripts (Arabic, Hebrew, ligatures, variable fonts),
+// use a [text.GoTextFace] with the proper Direction, Language and Script
+// settings: the text will be shaped by the go-text engine.
+// A legacy golang.org/x/image/font.Face can be wrapped by [text.NewGoXFace].
+// The prebaked glyph atlases can be used via [BitmapFont] Face method.
+//
+// The right-to-left faces are laid out inside the label's container
+// the same way as the left-to-right ones; use AlignHorizontalRight
+// to get the natural alignment for such scripts.
+// Vertical text directions are not supported.
+//
+// A nil face is treated as a missing resource: a placeholder face
+// is used instead and the error is reported (see [MissingFontFace]).
+//
+// The labels can be created by the background loading goroutines:
+// the font face interning is goroutine-safe.
+// The created label itself should only be used by one goroutine at a time.
 func NewLabel(ff text.Face) *Label {
+	if ff == nil {
+		ff = MissingFontFace("")
+	}
 	fontID := cache.Global.InternFontFace(ff)
 	return &Label{
 		fontID: fontID,
 		flags:  labelFlagVisible,
-		shadow: disabledShadow,
+		ext:    defaultLabelExt,
 	}
 }
 
+// mutableExt returns the label ext data that can be modified.
+// It allocates a label-owned copy of the ext data on the first call.
+func (l *Label) mutableExt() *labelExtData {
+	if l.ext == defaultLabelExt {
+		ext := *defaultLabelExt
+		l.ext = &ext
+	}
+	return l.ext
+}
+
 // SetShadow enables rendered text shadows.
 //
-// The shadow support is experimental and is inefficient.
-// Right now only a 1 pixel-tall, straight vertical shadow is supported.
+// It's a classic 1 pixel-tall, straight vertical shadow.
+// Use SetShadowWithOffset to specify a custom shadow offset.
 //
-// Experimental: the API will change in the future.
+// A zero alpha color scale disables the shadow.
 func (l *Label) SetShadow(cs ColorScale) {
+	l.SetShadowWithOffset(cs, gmath.Vec{Y: 1})
+}
+
+// SetShadowWithOffset is like SetShadow, but the shadow
+// is drawn with the specified offset instead of {Y: 1}.
+//
+// The shadow is the same text drawn with the cs color
+// below the label's text.
+func (l *Label) SetShadowWithOffset(cs ColorScale, offset gmath.Vec) {
 	if cs.A == 0 {
-		l.shadow = disabledShadow
+		if l.ext != defaultLabelExt {
+			l.ext.shadowEnabled = false
+			l.updateBounds()
+		}
+		return
+	}
+
+	ext := l.mutableExt()
+	ext.shadowEnabled = true
+	ext.shadowEbitenColorScale = cs.ToEbitenColorScale()
+	ext.shadowOffset = offset
+	l.updateBounds()
+}
+
+// SetOutline enables the text outline of the given thickness (in pixels).
+//
+// The outline is rendered to an offscreen image once
+// and then re-used until the text, the font or the
+// container width are changed. This makes the outline
+// drawing cost a single image draw call most of the time.
+//
+// The outline is not included into the label bounds.
+//
+// A zero alpha color scale or a non-positive thickness disables the outline.
+func (l *Label) SetOutline(cs ColorScale, thickness int) {
+	if cs.A == 0 || thickness <= 0 {
+		if l.ext != defaultLabelExt && l.ext.outlineEnabled {
+			l.ext.outlineEnabled = false
+			if l.ext.outlineImage != nil {
+				l.ext.outlineImage.Deallocate()
+				l.ext.outlineImage = nil
+			}
+			l.invalidateRender()
+		}
 		return
 	}
 
-	if l.shadow == disabledShadow {
-		l.shadow = &labelShadowData{enabled: true}
+	ext := l.mutableExt()
+	ext.outlineEnabled = true
+	l.invalidateRender()
+	ext.outlineThickness = thickness
+	ext.outlineEbitenColorScale = cs.ToEbitenColorScale()
+}
+
+// SetCached enables or disables the offscreen rendering cache.
+//
+// A cached label renders itself to an offscreen image and then
+// every Draw call becomes a single image draw.
+// The image is re-rendered automatically after the text,
+// colors, size or other rendering settings change.
+//
+// This mode is good for the labels that rarely change, especially
+// for the multiline centered texts and the texts with outlines.
+// It's not a good fit for the labels that change every frame
+// (like a fading label that has its alpha changed): it would
+// re-render the image too often.
+//
+// The cached image size is at least the label's container size,
+// so avoid using this mode for the labels with huge containers.
+func (l *Label) SetCached(cached bool) {
+	if !cached {
+		if l.ext != defaultLabelExt && l.ext.cacheEnabled {
+			l.ext.cacheEnabled = false
+			if l.ext.cacheImage != nil && l.ext.shader == nil {
+				l.ext.cacheImage.Deallocate()
+				l.ext.cacheImage = nil
+			}
+		}
+		return
 	}
-	l.shadow.ebitenColorScale = cs.ToEbitenColorScale()
+
+	ext := l.mutableExt()
+	ext.cacheEnabled = true
+	ext.cacheDirty = true
+}
+
+// GetShader returns the currently assigned label shader.
+// Use SetShader to change it.
+func (l *Label) GetShader() *Shader {
+	return l.ext.shader
+}
+
+// SetShader assigns a shader that is used to render the label.
+// A nil value removes the shader.
+//
+// Use the Shader object methods to set its uniform values.
+// The shader's Images[0] is the rendered label image
+// (including its shadow and outline).
+//
+// The label with a shader uses the cached rendering mode internally,
+// so the same trade-offs apply (see SetCached).
+// Changing the shader uniforms doesn't re-render the cached image.
+func (l *Label) SetShader(shader *Shader) {
+	if shader == nil {
+		if l.ext != defaultLabelExt && l.ext.shader != nil {
+			l.ext.shader = nil
+			if !l.ext.cacheEnabled && l.ext.cacheImage != nil {
+				l.ext.cacheImage.Deallocate()
+				l.ext.cacheImage = nil
+			}
+		}
+		return
+	}
+
+	ext := l.mutableExt()
+	ext.shader = shader
+	ext.cacheDirty = true
+}
+
+// IsCached reports whether the cached rendering mode is enabled.
+// Use SetCached to change it.
+func (l *Label) IsCached() bool {
+	return l.ext.cacheEnabled
+}
+
+// invalidateRender marks label's pre-rendered images as outdated.
+func (l *Label) invalidateRender() {
+	if l.ext == defaultLabelExt {
+		return
+	}
+	l.ext.outlineDirty = true
+	l.ext.cacheDirty = true
 }
 
 // GetColorScale is used to retrieve the current color scale value of the label's text.
@@ -134,11 +399,15 @@ func (l *Label) GetColorScale() ColorScale {
 // SetColorScale assigns a new ColorScale to this label's text.
 // Use GetColorScale to retrieve the current color scale.
 func (l *Label) SetColorScale(cs ColorScale) {
+	if cache.Global.StrictMode != 0 {
+		strictCheckMutation(l, l.IsDisposed())
+	}
 	if l.colorScale == cs {
 		return
 	}
 	l.colorScale = cs
 	l.ebitenColorScale = l.colorScale.ToEbitenColorScale()
+	l.invalidateRender()
 }
 
 // GetAlpha is a shorthand for GetColorScale().A expression.
@@ -147,15 +416,27 @@ func (l *Label) GetAlpha() float32 { return l.colorScale.A }
 
 // SetAlpha is a convenient way to change the alpha value of the ColorScale.
 func (l *Label) SetAlpha(a float32) {
+	if cache.Global.StrictMode != 0 {
+		strictCheckMutation(l, l.IsDisposed())
+	}
 	if l.colorScale.A == a {
 		return
 	}
 	l.colorScale.A = a
 	l.ebitenColorScale = l.colorScale.ToEbitenColorScale()
+	l.invalidateRender()
 }
 
 func (l *Label) Dispose() {
 	l.flags |= labelFlagDisposed
+	if l.ext.outlineImage != nil {
+		l.ext.outlineImage.Deallocate()
+		l.ext.outlineImage = nil
+	}
+	if l.ext.cacheImage != nil {
+		l.ext.cacheImage.Deallocate()
+		l.ext.cacheImage = nil
+	}
 }
 
 func (l *Label) IsDisposed() bool {
@@ -167,8 +448,16 @@ func (l *Label) GetSize() (w, h int) {
 }
 
 func (l *Label) SetSize(w, h int) {
+	if cache.Global.StrictMode != 0 {
+		strictCheckMutation(l, l.IsDisposed())
+		strictCheckSize(l, "width", w)
+		strictCheckSize(l, "height", h)
+	}
 	l.width = uint16(w)
 	l.height = uint16(h)
+	if l.ext.wrapWidth < 0 {
+		l.relayout()
+	}
 }
 
 func (l *Label) GetAlignVertical() AlignVertical {
@@ -176,8 +465,12 @@ func (l *Label) GetAlignVertical() AlignVertical {
 }
 
 func (l *Label) SetAlignVertical(a AlignVertical) {
+	flags := l.flags
 	l.flags &^= labelFlagAlignVerticalBit1 | labelFlagAlignVerticalBit2
 	l.flags |= labelFlag(a&0b11) << 1
+	if l.flags != flags {
+		l.invalidateRender()
+	}
 }
 
 func (l *Label) GetAlignHorizontal() AlignHorizontal {
@@ -185,8 +478,12 @@ func (l *Label) GetAlignHorizontal() AlignHorizontal {
 }
 
 func (l *Label) SetAlignHorizontal(a AlignHorizontal) {
+	flags := l.flags
 	l.flags &^= labelFlagAlignHorizontalBit1 | labelFlagAlignHorizontalBit2
 	l.flags |= labelFlag(a&0b11) << 3
+	if l.flags != flags {
+		l.invalidateRender()
+	}
 }
 
 func (l *Label) GetGrowVertical() GrowVertical {
@@ -194,8 +491,12 @@ func (l *Label) GetGrowVertical() GrowVertical {
 }
 
 func (l *Label) SetGrowVertical(g GrowVertical) {
+	flags := l.flags
 	l.flags &^= labelFlagGrowVerticalBit1 | labelFlagGrowVerticalBit2
 	l.flags |= labelFlag(g&0b11) << 5
+	if l.flags != flags {
+		l.invalidateRender()
+	}
 }
 
 func (l *Label) GetGrowHorizontal() GrowHorizontal {
@@ -203,8 +504,12 @@ func (l *Label) GetGrowHorizontal() GrowHorizontal {
 }
 
 func (l *Label) SetGrowHorizontal(g GrowHorizontal) {
+	flags := l.flags
 	l.flags &^= labelFlagGrowHorizontalBit1 | labelFlagGrowHorizontalBit2
 	l.flags |= labelFlag(g&0b11) << 7
+	if l.flags != flags {
+		l.invalidateRender()
+	}
 }
 
 func (l *Label) IsVisible() bool {
@@ -215,21 +520,369 @@ func (l *Label) IsVisible() bool {
 // It can be used to show or hide the label.
 // Use IsVisible to get the current flag value.
 func (l *Label) SetVisibility(visible bool) {
+	if cache.Global.StrictMode != 0 {
+		strictCheckMutation(l, l.IsDisposed())
+	}
 	setFlag(&l.flags, labelFlagVisible, visible)
 }
 
+// SetText assigns the label's text.
+// It discards the segments assigned by SetTextSegments.
 func (l *Label) SetText(s string) {
+	if cache.Global.StrictMode != 0 {
+		strictCheckMutation(l, l.IsDisposed())
+	}
+	if l.ext.segments != nil {
+		l.ext.segments = nil
+	}
+	if l.ext.wrapWidth != 0 {
+		l.ext.rawText = s
+	}
+	l.layoutText(s)
+}
+
+// SetTextInt is like SetText(strconv.Itoa(n)),
+// but it's optimized for the frequently updated numeric labels
+// like score counters and damage numbers.
+//
+// Assigning the same value is a no-op.
+// The strings for small non-negative values are interned,
+// so they're only allocated once.
+// The text width is calculated using the cached digit metrics
+// instead of the complete text measuring.
+func (l *Label) SetTextInt(n int) {
+	var buf [24]byte
+	l.setNumericText(strconv.AppendInt(buf[:0], int64(n), 10), n)
+}
+
+// SetTextFloat is like SetText(strconv.FormatFloat(v, 'f', precision, 64)),
+// but it's optimized for the frequently updated numeric labels.
+//
+// Assigning a value that produces the same text is a no-op,
+// so the text is only allocated when the displayed value changes.
+// See SetTextInt for more info.
+func (l *Label) SetTextFloat(v float64, precision int) {
+	var buf [32]byte
+	l.setNumericText(strconv.AppendFloat(buf[:0], v, 'f', precision, 64), -1)
+}
+
+// setNumericText assigns a numeric label text.
+// n is an integer value of b (a negative n means no interning).
+func (l *Label) setNumericText(b []byte, n int) {
+	if l.ext.segments != nil || l.ext.wrapWidth != 0 {
+		// The rich text and word wrapping need the complete layout.
+		l.SetText(string(b))
+		return
+	}
+	if cache.Global.StrictMode != 0 {
+		strictCheckMutation(l, l.IsDisposed())
+	}
+	if string(b) == l.text {
+		return
+	}
+	if n >= 0 {
+		l.text = cache.Global.InternInt(n)
+	} else {
+		l.text = string(b)
+	}
+
+	fontInfo := cache.Global.GetFontInfo(l.fontID)
+	w := 0.0
+	for _, ch := range b {
+		i := cache.NumericAdvanceIndex(ch)
+		if i == -1 {
+			// A NaN or Inf value.
+			l.updateBounds()
+			return
+		}
+		w += fontInfo.NumericAdvances[i]
+	}
+	w += l.ext.letterSpacing * float64(len(b))
+	if l.ext != defaultLabelExt {
+		l.ext.lines = l.ext.lines[:0]
+	}
+	// This is what text.Measure reports for a single line text.
+	l.setBounds(w, fontInfo.Ascent+fontInfo.Descent)
+}
+
+// SetTextSegments assigns a rich text to the label.
+//
+// Every segment can have its own color and font face.
+// The segments can contain newlines, the alignment is
+// applied to the lines (not to the segments).
+//
+// Use SetText to go back to the simple text rendering mode.
+func (l *Label) SetTextSegments(segments []TextSegment) {
+	if cache.Global.StrictMode != 0 {
+		strictCheckMutation(l, l.IsDisposed())
+	}
+	ext := l.mutableExt()
+	ext.segments = ext.segments[:0]
+	for _, seg := range segments {
+		s := labelSegment{
+			text:             seg.Text,
+			ebitenColorScale: seg.ColorScale.ToEbitenColorScale(),
+			hasColorScale:    seg.ColorScale != (ColorScale{}),
+			script:           seg.Script,
+		}
+		if seg.BackgroundColorScale != (ColorScale{}) {
+			s.hasBackground = true
+			s.backgroundColorScale = seg.BackgroundColorScale.ToEbitenColorScale()
+		}
+		if seg.Face != nil {
+			s.hasFont = true
+			s.fontID = cache.Global.InternFontFace(seg.Face)
+		}
+		ext.segments = append(ext.segments, s)
+	}
+	if ext.segments == nil {
+		// Keep the rich text mode even for an empty segments list.
+		ext.segments = make([]labelSegment, 0, 1)
+	}
+	l.layoutSegments()
+}
+
+// WordWrapContainerWidth can be used as SetWordWrap argument
+// to wrap the text using the label's container width (see SetSize).
+const WordWrapContainerWidth = -1
+
+// SetWordWrap enables the automatic line breaking.
+//
+// Lines are broken at spaces so they don't exceed the specified width.
+// A word that is longer than the width is never broken.
+// Use [WordWrapContainerWidth] to use the container width as a limit.
+// A zero width disables the wrapping.
+//
+// It works for both SetText and SetTextSegments texts.
+func (l *Label) SetWordWrap(width float64) {
+	if l.ext.wrapWidth == width {
+		return
+	}
+	ext := l.mutableExt()
+	if ext.wrapWidth == 0 && ext.segments == nil {
+		// Enabling the wrapping: the current text is unwrapped.
+		ext.rawText = l.text
+	}
+	ext.wrapWidth = width
+	l.relayout()
+}
+
+// SetVisibleRunes limits the number of text runes that are rendered.
+//
+// It can be used to implement a typewriter-like text reveal effect.
+// The alignment is calculated for the entire text, so the revealed
+// parts of the centered or right-aligned lines don't move around.
+// Newlines are not counted as runes (see GetNumRunes).
+//
+// A negative n removes the limit.
+func (l *Label) SetVisibleRunes(n int) {
+	if n < 0 {
+		if l.ext != defaultLabelExt && l.ext.limitRunes {
+			l.ext.limitRunes = false
+			l.invalidateRender()
+		}
+		return
+	}
+	ext := l.mutableExt()
+	if ext.limitRunes && ext.visibleRunes == n {
+		return
+	}
+	ext.limitRunes = true
+	ext.visibleRunes = n
+	l.invalidateRender()
+}
+
+// GetVisibleRunes returns the current visible runes limit.
+// It returns -1 if there is no limit.
+// Use SetVisibleRunes to change it.
+func (l *Label) GetVisibleRunes() int {
+	if !l.ext.limitRunes {
+		return -1
+	}
+	return l.ext.visibleRunes
+}
+
+// GetNumRunes returns the number of text runes that can be revealed by SetVisibleRunes.
+// The newlines are not included in this number.
+func (l *Label) GetNumRunes() int {
+	return utf8.RuneCountInString(l.text) - strings.Count(l.text, "\n")
+}
+
+// SetLineSpacing changes the distance between the lines.
+//
+// The spacing is added to the font's line height,
+// so a negative value makes the lines tighter.
+// A zero value means "use the font's line height as is".
+func (l *Label) SetLineSpacing(spacing float64) {
+	if l.ext.lineSpacing == spacing {
+		return
+	}
+	l.mutableExt().lineSpacing = spacing
+	l.relayout()
+}
+
+// GetLineSpacing returns the current extra line spacing.
+// Use SetLineSpacing to change it.
+func (l *Label) GetLineSpacing() float64 {
+	return l.ext.lineSpacing
+}
+
+// SetLetterSpacing changes the distance between the glyphs.
+//
+// The spacing is added after every rune (including spaces),
+// a negative value makes the text tighter.
+// It's mostly useful for the pixel-art fonts manual tweaking.
+//
+// Note that a non-zero letter spacing makes the text drawing slightly slower
+// as every glyph is drawn separately.
+func (l *Label) SetLetterSpacing(spacing float64) {
+	if l.ext.letterSpacing == spacing {
+		return
+	}
+	l.mutableExt().letterSpacing = spacing
+	l.relayout()
+}
+
+// GetLetterSpacing returns the current extra letter spacing.
+// Use SetLetterSpacing to change it.
+func (l *Label) GetLetterSpacing() float64 {
+	return l.ext.letterSpacing
+}
+
+// lineHeight returns the distance between the lines baselines.
+func (l *Label) lineHeight() float64 {
+	return cache.Global.GetFontInfo(l.fontID).LineHeight + l.ext.lineSpacing
+}
+
+// GetWordWrap returns the current wrap width.
+// Use SetWordWrap to change it.
+func (l *Label) GetWordWrap() float64 {
+	return l.ext.wrapWidth
+}
+
+func (l *Label) relayout() {
+	if l.ext.segments != nil {
+		l.layoutSegments()
+		return
+	}
+	if l.ext.wrapWidth != 0 || l.ext.rawText != "" {
+		l.layoutText(l.ext.rawText)
+		if l.ext.wrapWidth == 0 {
+			l.ext.rawText = ""
+		}
+		return
+	}
+	l.layoutText(l.text)
+}
+
+func (l *Label) wordWrapWidth() float64 {
+	if l.ext.wrapWidth < 0 {
+		return float64(l.width)
+	}
+	return l.ext.wrapWidth
+}
+
+func (l *Label) layoutText(s string) {
+	if maxWidth := l.wordWrapWidth(); maxWidth > 0 {
+		fontInfo := cache.Global.GetFontInfo(l.fontID)
+		s = wrapText(s, fontInfo.Face, maxWidth, l.ext.letterSpacing)
+	}
 	l.text = s
+	l.updateBounds()
+}
 
-	fontInfo := cache.Global.FontInfoList[l.fontID]
+func (l *Label) layoutSegments() {
+	var b labelLayoutBuilder
+	b.Reset(l.ext, l.fontID, l.wordWrapWidth())
+	for i, seg := range l.ext.segments {
+		fontID := l.fontID
+		if seg.hasFont {
+			fontID = seg.fontID
+		}
+		b.AddSegment(i, fontID, seg.script, seg.text)
+	}
+	l.text = b.Finish()
+	l.updateBounds()
+}
+
+func (l *Label) updateBounds() {
+	fontInfo := cache.Global.GetFontInfo(l.fontID)
 
-	w, h := text.Measure(l.text, fontInfo.Face, fontInfo.LineHeight)
+	var w, h float64
+	if l.ext.segments != nil {
+		for _, lineWidth := range l.ext.lines {
+			w = max(w, lineWidth)
+		}
+		h = l.ext.textHeight
+	} else {
+		_, h = text.Measure(l.text, fontInfo.Face, l.lineHeight())
+		w = l.measurePlainLines()
+	}
+	l.setBounds(w, h)
+}
+
+func (l *Label) setBounds(w, h float64) {
+	if l.ext.shadowEnabled {
+		w += math.Ceil(math.Abs(l.ext.shadowOffset.X))
+		h += math.Ceil(math.Abs(l.ext.shadowOffset.Y))
+	}
+	if cache.Global.StrictMode != 0 {
+		strictCheckSize(l, "text width", int(w))
+		strictCheckSize(l, "text height", int(h))
+	}
 	l.boundsWidth = uint16(w)
 	l.boundsHeight = uint16(h)
+	l.invalidateRender()
+}
+
+// measurePlainLines returns the max line width of the plain text.
+//
+// The multiline text widths are stored in ext.lines,
+// so the aligned text rendering doesn't need to measure them every frame.
+// The single line labels don't allocate the ext data for that:
+// their line width can be derived from the bounds (see plainLineWidth).
+func (l *Label) measurePlainLines() float64 {
+	fontInfo := cache.Global.GetFontInfo(l.fontID)
+
+	if strings.IndexByte(l.text, '\n') == -1 {
+		if l.ext != defaultLabelExt {
+			l.ext.lines = l.ext.lines[:0]
+		}
+		return measureLine(l.text, fontInfo.Face, l.ext.letterSpacing)
+	}
+
+	ext := l.mutableExt()
+	ext.lines = ext.lines[:0]
+	w := 0.0
+	textRemaining := l.text
+	for {
+		nextLine := strings.IndexByte(textRemaining, '\n')
+		lineText := textRemaining
+		if nextLine != -1 {
+			lineText = textRemaining[:nextLine]
+			textRemaining = textRemaining[nextLine+len("\n"):]
+		}
+		lineWidth := measureLine(lineText, fontInfo.Face, ext.letterSpacing)
+		ext.lines = append(ext.lines, lineWidth)
+		w = max(w, lineWidth)
+		if nextLine == -1 {
+			break
+		}
+	}
+	return w
+}
 
-	if l.shadow.enabled {
-		l.boundsHeight++
+// plainLineWidth returns the pre-calculated plain text line width.
+func (l *Label) plainLineWidth(line int) float64 {
+	if len(l.ext.lines) != 0 {
+		return l.ext.lines[line]
+	}
+	// A single line text: the width is stored inside the bounds.
+	w := float64(l.boundsWidth)
+	if l.ext.shadowEnabled {
+		w -= math.Ceil(math.Abs(l.ext.shadowOffset.X))
 	}
+	return w
 }
 
 func (l *Label) BoundsRect() gmath.Rect {
@@ -241,6 +894,12 @@ func (l *Label) Draw(dst *ebiten.Image) {
 }
 
 func (l *Label) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if cache.Global.StrictMode != 0 {
+		strictCheckDraw(l, l.IsDisposed())
+	}
+
+	opts.Blend = resolveBlend(l.blendID, opts.Blend)
+
 	if !l.IsVisible() || l.text == "" {
 		return
 	}
@@ -261,14 +920,237 @@ func (l *Label) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
 		pos.Y += containerRect.Height() - l.estimateHeight(numLines)
 	}
 
-	if l.shadow.enabled {
-		l.drawText(dst, opts.Blend, containerRect, pos, offset.Add(gmath.Vec{Y: 1}), l.shadow.ebitenColorScale)
+	if l.ext.cacheEnabled || l.ext.shader != nil {
+		l.drawCached(dst, opts.Blend, containerRect, pos, offset, numLines)
+		return
+	}
+
+	l.drawContents(dst, opts.Blend, containerRect, pos, offset)
+}
+
+// drawCached draws the cached label image, re-rendering it if necessary.
+func (l *Label) drawCached(dst *ebiten.Image, blend *ebiten.Blend, containerRect gmath.Rect, pos, offset gmath.Vec, numLines int) {
+	ext := l.ext
+
+	// The padding makes the shadow and the outline fit the image.
+	pad := math.Ceil(float64(ext.outlineThickness))
+	if ext.shadowEnabled {
+		pad += math.Ceil(max(math.Abs(ext.shadowOffset.X), math.Abs(ext.shadowOffset.Y)))
+	}
+
+	if ext.cacheImage == nil || ext.cacheDirty || ext.cacheContainerWidth != containerRect.Width() {
+		ext.cacheDirty = false
+		ext.cacheContainerWidth = containerRect.Width()
+
+		w := int(math.Ceil(max(containerRect.Width(), float64(l.boundsWidth)) + 2*pad))
+		h := int(math.Ceil(max(l.estimateHeight(numLines), float64(l.boundsHeight)) + 2*pad))
+		if img := ext.cacheImage; img == nil || img.Bounds().Dx() < w || img.Bounds().Dy() < h {
+			if img != nil {
+				img.Deallocate()
+			}
+			ext.cacheImage = ebiten.NewImage(w, h)
+		} else {
+			clearImage(img)
+		}
+		l.drawContents(ext.cacheImage, nil, containerRect, gmath.Vec{X: pad, Y: pad}, gmath.Vec{})
+	}
+
+	var drawOptions ebiten.DrawImageOptions
+	if blend != nil {
+		drawOptions.Blend = *blend
+	}
+	drawOptions.GeoM.Translate(math.Round(pos.X)-pad, math.Round(pos.Y)-pad)
+	drawOptions.GeoM.Translate(offset.X, offset.Y)
+
+	if ext.shader == nil || !ext.shader.Enabled {
+		drawImage(dst, ext.cacheImage, &drawOptions)
+		return
+	}
+
+	bounds := ext.cacheImage.Bounds()
+	var options ebiten.DrawRectShaderOptions
+	options.Blend = drawOptions.Blend
+	options.GeoM = drawOptions.GeoM
+	options.Images[0] = ext.cacheImage
+	options.Images[1] = ext.shader.Texture1
+	options.Images[2] = ext.shader.Texture2
+	options.Images[3] = ext.shader.Texture3
+	options.Uniforms = ext.shader.shaderData
+	drawRectShader(dst, bounds.Dx(), bounds.Dy(), ext.shader.compiled, &options)
+}
+
+func (l *Label) drawContents(dst *ebiten.Image, blend *ebiten.Blend, containerRect gmath.Rect, pos, offset gmath.Vec) {
+	if l.ext.segments != nil {
+		// The backgrounds go below everything else, so the shadow
+		// and the outline of one run don't get covered by the next run background.
+		l.drawRunBackgrounds(dst, blend, containerRect, pos, offset)
+		if l.ext.shadowEnabled {
+			l.drawSegments(dst, blend, containerRect, pos, offset.Add(l.ext.shadowOffset), &l.ext.shadowEbitenColorScale)
+		}
+		if l.ext.outlineEnabled {
+			l.drawOutline(dst, blend, containerRect, pos, offset)
+		}
+		l.drawSegments(dst, blend, containerRect, pos, offset, nil)
+		return
+	}
+
+	if l.ext.shadowEnabled {
+		l.drawText(dst, blend, containerRect, pos, offset.Add(l.ext.shadowOffset), l.ext.shadowEbitenColorScale)
+	}
+	if l.ext.outlineEnabled {
+		l.drawOutline(dst, blend, containerRect, pos, offset)
+	}
+	l.drawText(dst, blend, containerRect, pos, offset, l.ebitenColorScale)
+}
+
+// drawOutline draws the cached outline image, re-rendering it if necessary.
+func (l *Label) drawOutline(dst *ebiten.Image, blend *ebiten.Blend, containerRect gmath.Rect, pos, offset gmath.Vec) {
+	ext := l.ext
+	t := float64(ext.outlineThickness)
+
+	if ext.outlineDirty || ext.outlineContainerWidth != containerRect.Width() {
+		ext.outlineDirty = false
+		ext.outlineContainerWidth = containerRect.Width()
+
+		numLines := strings.Count(l.text, "\n") + 1
+		w := int(math.Ceil(max(containerRect.Width(), float64(l.boundsWidth)) + 2*t))
+		h := int(math.Ceil(l.estimateHeight(numLines) + 2*t))
+		if img := ext.outlineImage; img == nil || img.Bounds().Dx() < w || img.Bounds().Dy() < h {
+			if img != nil {
+				img.Deallocate()
+			}
+			ext.outlineImage = ebiten.NewImage(w, h)
+		} else {
+			clearImage(img)
+		}
+
+		// The outline is approximated by the text copies drawn around
+		// the original position. Thicker outlines need more copies.
+		origin := gmath.Vec{X: t, Y: t}
+		numSteps := 8 * ext.outlineThickness
+		for i := 0; i < numSteps; i++ {
+			angle := gmath.Rad(2 * math.Pi * float64(i) / float64(numSteps))
+			delta := gmath.RadToVec(angle).Mulf(t)
+			if ext.segments != nil {
+				l.drawSegments(ext.outlineImage, nil, containerRect, origin, delta, &ext.outlineEbitenColorScale)
+			} else {
+				l.drawText(ext.outlineImage, nil, containerRect, origin, delta, ext.outlineEbitenColorScale)
+			}
+		}
+	}
+
+	var drawOptions ebiten.DrawImageOptions
+	if blend != nil {
+		drawOptions.Blend = *blend
+	}
+	drawOptions.GeoM.Translate(math.Round(pos.X)-t, math.Round(pos.Y)-t)
+	drawOptions.GeoM.Translate(offset.X, offset.Y)
+	drawImage(dst, ext.outlineImage, &drawOptions)
+}
+
+// drawSegments renders the rich text runs.
+// If clr is not nil, it overrides the segment colors (used for shadows).
+func (l *Label) drawSegments(dst *ebiten.Image, blend *ebiten.Blend, containerRect gmath.Rect, pos, offset gmath.Vec, clr *ebiten.ColorScale) {
+	fontInfo := cache.Global.GetFontInfo(l.fontID)
+
+	var drawOptions text.DrawOptions
+	if blend != nil {
+		drawOptions.Blend = *blend
+	}
+	drawOptions.Filter = ebiten.FilterLinear
+	drawOptions.LineSpacing = fontInfo.LineHeight
+
+	budget := l.GetVisibleRunes()
+	align := l.GetAlignHorizontal()
+	for _, run := range l.ext.runs {
+		runText := run.text
+		if budget != -1 {
+			if budget == 0 {
+				break
+			}
+			runText, budget = truncateRunes(runText, budget)
+		}
+
+		offsetX := run.x
+		switch align {
+		case AlignHorizontalCenter:
+			offsetX += (containerRect.Width() - l.ext.lines[run.line]) / 2
+		case AlignHorizontalRight:
+			offsetX += containerRect.Width() - l.ext.lines[run.line]
+		}
+		offsetY := run.y
+
+		switch {
+		case clr != nil:
+			drawOptions.ColorScale = *clr
+		case l.ext.segments[run.segment].hasColorScale:
+			drawOptions.ColorScale = l.ext.segments[run.segment].ebitenColorScale
+		default:
+			drawOptions.ColorScale = l.ebitenColorScale
+		}
+
+		drawOptions.GeoM.Reset()
+		if run.script != TextScriptNormal {
+			scale := run.script.scale()
+			drawOptions.GeoM.Scale(scale, scale)
+		}
+		drawOptions.GeoM.Translate(math.Round(pos.X+offsetX), math.Round(pos.Y+offsetY))
+		drawOptions.GeoM.Translate(offset.X, offset.Y)
+		runFont := cache.Global.GetFontInfo(run.fontID)
+		drawOptions.PrimaryAlign = text.AlignStart
+		if runFont.RightToLeft {
+			drawOptions.PrimaryAlign = text.AlignEnd
+		}
+		drawSpacedText(dst, runText, runFont.Face, &drawOptions, l.ext.letterSpacing)
+	}
+}
+
+// drawRunBackgrounds draws the segment backgrounds of the visible runs.
+// A partially visible run background covers only its visible part.
+func (l *Label) drawRunBackgrounds(dst *ebiten.Image, blend *ebiten.Blend, containerRect gmath.Rect, pos, offset gmath.Vec) {
+	budget := l.GetVisibleRunes()
+	align := l.GetAlignHorizontal()
+	for i := range l.ext.runs {
+		run := &l.ext.runs[i]
+		runText := run.text
+		if budget != -1 {
+			if budget == 0 {
+				break
+			}
+			runText, budget = truncateRunes(runText, budget)
+		}
+		if !l.ext.segments[run.segment].hasBackground {
+			continue
+		}
+
+		runFont := cache.Global.GetFontInfo(run.fontID)
+		width := run.width
+		if len(runText) != len(run.text) {
+			width = measureLine(runText, runFont.Face, l.ext.letterSpacing) * run.script.scale()
+		}
+
+		offsetX := run.x
+		switch align {
+		case AlignHorizontalCenter:
+			offsetX += (containerRect.Width() - l.ext.lines[run.line]) / 2
+		case AlignHorizontalRight:
+			offsetX += containerRect.Width() - l.ext.lines[run.line]
+		}
+		runPos := pos.Add(offset).Add(gmath.Vec{X: offsetX, Y: run.y})
+
+		h := (runFont.Ascent + runFont.Descent) * run.script.scale()
+		padding := gmath.Vec{X: math.Round(h * 0.25), Y: 1}
+		runPos = gmath.Vec{X: math.Round(runPos.X), Y: math.Round(runPos.Y)}
+		rect := gmath.Rect{
+			Min: runPos.Sub(padding),
+			Max: runPos.Add(gmath.Vec{X: width, Y: h}).Add(padding),
+		}
+		drawPill(dst, blend, rect, l.ext.segments[run.segment].backgroundColorScale)
 	}
-	l.drawText(dst, opts.Blend, containerRect, pos, offset, l.ebitenColorScale)
 }
 
 func (l *Label) drawText(dst *ebiten.Image, blend *ebiten.Blend, rect gmath.Rect, pos, offset gmath.Vec, clr ebiten.ColorScale) {
-	fontInfo := cache.Global.FontInfoList[l.fontID]
+	fontInfo := cache.Global.GetFontInfo(l.fontID)
 	containerRect := rect
 
 	var drawOptions text.DrawOptions
@@ -277,12 +1159,24 @@ func (l *Label) drawText(dst *ebiten.Image, blend *ebiten.Blend, rect gmath.Rect
 	}
 	drawOptions.ColorScale = clr
 	drawOptions.Filter = ebiten.FilterLinear
-	drawOptions.LineSpacing = fontInfo.LineHeight
+	drawOptions.LineSpacing = l.lineHeight()
+	if fontInfo.RightToLeft {
+		// By default, RTL text is rendered to the left of its origin.
+		// The alignment is handled by the label, so the text
+		// should start at the origin, like the LTR text does.
+		drawOptions.PrimaryAlign = text.AlignEnd
+	}
+
+	budget := l.GetVisibleRunes()
 
-	if l.GetAlignHorizontal() == AlignHorizontalLeft {
+	if l.GetAlignHorizontal() == AlignHorizontalLeft && l.ext.letterSpacing == 0 {
+		s := l.text
+		if budget != -1 {
+			s, _ = truncateRunes(s, budget)
+		}
 		drawOptions.GeoM.Translate(math.Round(pos.X), math.Round(pos.Y))
 		drawOptions.GeoM.Translate(offset.X, offset.Y)
-		text.Draw(dst, l.text, fontInfo.Face, &drawOptions)
+		drawText(dst, s, fontInfo.Face, &drawOptions)
 		return
 	}
 
@@ -290,6 +1184,7 @@ func (l *Label) drawText(dst *ebiten.Image, blend *ebiten.Blend, rect gmath.Rect
 
 	textRemaining := l.text
 	offsetY := 0.0
+	line := 0
 	for {
 		nextLine := strings.IndexByte(textRemaining, '\n')
 		lineText := textRemaining
@@ -298,7 +1193,7 @@ func (l *Label) drawText(dst *ebiten.Image, blend *ebiten.Blend, rect gmath.Rect
 			textRemaining = textRemaining[nextLine+len("\n"):]
 		}
 
-		lineBoundsWidth, _ := text.Measure(lineText, fontInfo.Face, fontInfo.LineHeight)
+		lineBoundsWidth := l.plainLineWidth(line)
 		offsetX := 0.0
 		switch l.GetAlignHorizontal() {
 		case AlignHorizontalCenter:
@@ -306,14 +1201,20 @@ func (l *Label) drawText(dst *ebiten.Image, blend *ebiten.Blend, rect gmath.Rect
 		case AlignHorizontalRight:
 			offsetX = containerRect.Width() - lineBoundsWidth
 		}
+		if budget != -1 {
+			// The line width is measured before the truncation,
+			// so the alignment is not affected by the visible runes limit.
+			lineText, budget = truncateRunes(lineText, budget)
+		}
 		drawOptions.GeoM.Reset()
 		drawOptions.GeoM.Translate(math.Round(pos.X+offsetX), math.Round(pos.Y+offsetY))
 		drawOptions.GeoM.Translate(offset.X, offset.Y)
-		text.Draw(dst, lineText, fontInfo.Face, &drawOptions)
-		if nextLine == -1 {
+		drawSpacedText(dst, lineText, fontInfo.Face, &drawOptions, l.ext.letterSpacing)
+		if nextLine == -1 || budget == 0 {
 			break
 		}
-		offsetY += fontInfo.LineHeight
+		offsetY += l.lineHeight()
+		line++
 	}
 }
 
@@ -391,14 +1292,42 @@ func (l *Label) containerRect(pos gmath.Vec) gmath.Rect {
 	return containerRect
 }
 
+// estimateHeight returns the visual text height used for the vertical alignment.
+//
+// The last line doesn't need the line gap, so only
+// its ascent and descent are taken into account.
+// Otherwise the centered text would be shifted up by a half of the line gap.
 func (l *Label) estimateHeight(numLines int) float64 {
-	fontInfo := cache.Global.FontInfoList[l.fontID]
-	estimatedHeight := fontInfo.LineHeight
-	if numLines >= 2 {
-		estimatedHeight += (float64(numLines) - 1) * fontInfo.LineHeight
+	fontInfo := cache.Global.GetFontInfo(l.fontID)
+	estimatedHeight := fontInfo.Ascent + fontInfo.Descent
+	if l.ext.segments != nil {
+		estimatedHeight = l.ext.textVisualHeight
+	} else if numLines >= 2 {
+		estimatedHeight += (float64(numLines) - 1) * l.lineHeight()
 	}
-	if l.shadow.enabled {
-		estimatedHeight++
+	if l.ext.shadowEnabled {
+		estimatedHeight += math.Abs(l.ext.shadowOffset.Y)
 	}
 	return estimatedHeight
 }
+
+func (l *Label) inspectText() string { return l.text }
+
+// GetBlend returns the blend mode assigned by SetBlend.
+// The second result value is false if there is no blend override.
+func (l *Label) GetBlend() (ebiten.Blend, bool) {
+	return getBlend(l.blendID)
+}
+
+// SetBlend assigns a blend mode that is used to render this label.
+// It takes priority over the DrawOptions.Blend value.
+// Use ResetBlend to remove the override.
+func (l *Label) SetBlend(b ebiten.Blend) {
+	l.blendID = internBlend(b)
+}
+
+// ResetBlend removes the blend mode override.
+// The DrawOptions.Blend value (if any) will be used again.
+func (l *Label) ResetBlend() {
+	l.blendID = 0
+}
@@ -0,0 +1,254 @@
+package graphics
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+)
+
+// labelLayoutBuilder turns the rich text segments into the positioned runs.
+//
+// It also performs the word wrapping if maxWidth is positive.
+//
+// Segments can use different fonts; the runs of every line
+// are aligned by the baseline of the line's tallest font.
+type labelLayoutBuilder struct {
+	ext    *labelExtData
+	font   *cache.FontInfo
+	script TextScript
+
+	maxWidth   float64
+	spaceWidth float64
+
+	// sb accumulates the resulting plain text (with inserted line breaks).
+	sb strings.Builder
+
+	lineWidth float64
+	lineY     float64
+	lineStart int // The first run index of the current line
+
+	// The current line metrics are the max values of its runs fonts.
+	lineAscent  float64
+	lineDescent float64
+	lineHeight  float64
+
+	// The pending run is flushed on the segment end and on the line break.
+	pending      strings.Builder
+	pendingX     float64
+	pendingWidth float64
+
+	// spaces is a number of spaces that are not written yet.
+	// They're either written before the next word or discarded
+	// if the line break happens.
+	spaces      int
+	spacesWidth float64
+}
+
+func (b *labelLayoutBuilder) Reset(ext *labelExtData, fontID uint16, maxWidth float64) {
+	*b = labelLayoutBuilder{
+		ext:      ext,
+		font:     cache.Global.GetFontInfo(fontID),
+		maxWidth: maxWidth,
+	}
+	ext.runs = ext.runs[:0]
+	ext.lines = ext.lines[:0]
+}
+
+func (b *labelLayoutBuilder) AddSegment(segment int, fontID uint16, script TextScript, s string) {
+	b.font = cache.Global.GetFontInfo(fontID)
+	b.script = script
+	scale := script.scale()
+	spacing := b.ext.letterSpacing
+	b.spaceWidth = measureLine(" ", b.font.Face, spacing) * scale
+
+	for i, line := range strings.Split(s, "\n") {
+		if i > 0 {
+			b.flush(segment, fontID)
+			b.newline()
+		}
+		if b.maxWidth <= 0 {
+			b.write(line, measureLine(line, b.font.Face, spacing)*scale)
+			continue
+		}
+		for j, word := range strings.Split(line, " ") {
+			if j > 0 {
+				b.spaces++
+				b.spacesWidth += b.spaceWidth
+			}
+			if word == "" {
+				continue
+			}
+			w := measureLine(word, b.font.Face, spacing) * scale
+			currentWidth := b.lineWidth + b.pendingWidth
+			if b.spaces > 0 && currentWidth > 0 && currentWidth+b.spacesWidth+w > b.maxWidth {
+				// The spaces are replaced by a line break.
+				b.flush(segment, fontID)
+				b.newline()
+			} else if b.spaces > 0 {
+				b.write(strings.Repeat(" ", b.spaces), b.spacesWidth)
+			}
+			b.spaces = 0
+			b.spacesWidth = 0
+			b.write(word, w)
+		}
+	}
+	b.flush(segment, fontID)
+}
+
+func (b *labelLayoutBuilder) Finish() string {
+	b.finishLine()
+	// The line spacing is not needed after the last line.
+	height := b.lineY - b.ext.lineSpacing
+	b.ext.textHeight = height
+	b.ext.textVisualHeight = height - b.lineHeight + b.lineAscent + b.lineDescent
+	return b.sb.String()
+}
+
+func (b *labelLayoutBuilder) write(s string, width float64) {
+	if s == "" {
+		return
+	}
+	if b.pending.Len() == 0 {
+		b.pendingX = b.lineWidth
+	}
+	b.pending.WriteString(s)
+	b.pendingWidth += width
+	b.sb.WriteString(s)
+	ascent, descent, lineHeight := b.script.metrics(b.font)
+	b.lineAscent = max(b.lineAscent, ascent)
+	b.lineDescent = max(b.lineDescent, descent)
+	b.lineHeight = max(b.lineHeight, lineHeight)
+}
+
+func (b *labelLayoutBuilder) flush(segment int, fontID uint16) {
+	if b.pending.Len() == 0 {
+		return
+	}
+	b.ext.runs = append(b.ext.runs, labelRun{
+		text:    b.pending.String(),
+		x:       b.pendingX,
+		width:   b.pendingWidth,
+		line:    uint16(len(b.ext.lines)),
+		segment: uint16(segment),
+		fontID:  fontID,
+		script:  b.script,
+	})
+	b.lineWidth += b.pendingWidth
+	b.pending.Reset()
+	b.pendingWidth = 0
+}
+
+func (b *labelLayoutBuilder) newline() {
+	b.finishLine()
+	// The line metrics are not reset inside finishLine
+	// as Finish needs the last line metrics.
+	b.lineAscent = 0
+	b.lineDescent = 0
+	b.lineHeight = 0
+	b.lineWidth = 0
+	b.spaces = 0
+	b.spacesWidth = 0
+	b.sb.WriteByte('\n')
+}
+
+func (b *labelLayoutBuilder) finishLine() {
+	if b.lineHeight == 0 {
+		// An empty line uses the current font metrics.
+		b.lineAscent = b.font.Ascent
+		b.lineDescent = b.font.Descent
+		b.lineHeight = b.font.LineHeight
+	}
+	for i := b.lineStart; i < len(b.ext.runs); i++ {
+		run := &b.ext.runs[i]
+		runFont := cache.Global.GetFontInfo(run.fontID)
+		// y is the run's top: its baseline minus its (scaled) ascent.
+		baseline := b.lineY + b.lineAscent + run.script.baselineShift(runFont)
+		run.y = baseline - runFont.Ascent*run.script.scale()
+	}
+	b.ext.lines = append(b.ext.lines, b.lineWidth)
+	b.lineStart = len(b.ext.runs)
+	b.lineY += b.lineHeight + b.ext.lineSpacing
+}
+
+// wrapText inserts the line breaks into s, so every line
+// fits the maxWidth (unless it contains a word that is too long).
+// The letterSpacing is added after every rune (see measureLine).
+func wrapText(s string, face text.Face, maxWidth, letterSpacing float64) string {
+	var sb strings.Builder
+	sb.Grow(len(s))
+	spaceWidth := measureLine(" ", face, letterSpacing)
+	for i, line := range strings.Split(s, "\n") {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		lineWidth := 0.0
+		for j, word := range strings.Split(line, " ") {
+			w := measureLine(word, face, letterSpacing)
+			if j > 0 {
+				if lineWidth > 0 && lineWidth+spaceWidth+w > maxWidth {
+					sb.WriteByte('\n')
+					lineWidth = 0
+				} else {
+					sb.WriteByte(' ')
+					lineWidth += spaceWidth
+				}
+			}
+			sb.WriteString(word)
+			lineWidth += w
+		}
+	}
+	return sb.String()
+}
+
+// measureLine returns a single line text width.
+// The letterSpacing is added after every rune.
+func measureLine(s string, face text.Face, letterSpacing float64) float64 {
+	w := text.Advance(s, face)
+	if letterSpacing != 0 {
+		w += letterSpacing * float64(utf8.RuneCountInString(s))
+	}
+	return w
+}
+
+// scratchGlyphs is a reusable glyphs buffer for drawSpacedText.
+var scratchGlyphs []text.Glyph
+
+// drawSpacedText is like text.Draw, but it adds the letterSpacing after every glyph.
+// s should be a single line text.
+func drawSpacedText(dst *ebiten.Image, s string, face text.Face, opts *text.DrawOptions, letterSpacing float64) {
+	if letterSpacing == 0 {
+		drawText(dst, s, face, opts)
+		return
+	}
+
+	scratchGlyphs = text.AppendGlyphs(scratchGlyphs[:0], s, face, &opts.LayoutOptions)
+	drawOptions := opts.DrawImageOptions
+	for i, g := range scratchGlyphs {
+		if g.Image == nil {
+			continue
+		}
+		drawOptions.GeoM.Reset()
+		drawOptions.GeoM.Translate(g.X+float64(i)*letterSpacing, g.Y)
+		drawOptions.GeoM.Concat(opts.GeoM)
+		drawImage(dst, g.Image, &drawOptions)
+	}
+}
+
+// truncateRunes returns the s prefix that contains at most n runes
+// and the remaining runes budget (n minus the number of runes in the prefix).
+// Newlines are always included and they don't consume the budget.
+func truncateRunes(s string, n int) (string, int) {
+	for i, ch := range s {
+		if ch == '\n' {
+			continue
+		}
+		if n == 0 {
+			return s[:i], 0
+		}
+		n--
+	}
+	return s, n
+}
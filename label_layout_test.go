@@ -0,0 +1,196 @@
+package graphics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+	"golang.org/x/image/font/basicfont"
+)
+
+func TestWrapText(t *testing.T) {
+	// Every basicfont glyph is 7 pixels wide.
+	face := text.NewGoXFace(basicfont.Face7x13)
+
+	tests := []struct {
+		input    string
+		maxWidth float64
+		want     string
+	}{
+		{"", 70, ""},
+		{"hello", 70, "hello"},
+		{"hello world foo", 80, "hello world\nfoo"},
+		{"hello world foo", 40, "hello\nworld\nfoo"},
+		{"a verylongword b", 35, "a\nverylongword\nb"},
+		{"ab cd\nef gh", 35, "ab cd\nef gh"},
+		{"ab cd\nef gh", 20, "ab\ncd\nef\ngh"},
+	}
+
+	for _, test := range tests {
+		have := wrapText(test.input, face, test.maxWidth, 0)
+		if have != test.want {
+			t.Fatalf("wrapText(%q, %v):\nhave: %q\nwant: %q", test.input, test.maxWidth, have, test.want)
+		}
+	}
+}
+
+func TestLabelLayoutSegmentsWrap(t *testing.T) {
+	face := text.NewGoXFace(basicfont.Face7x13)
+
+	fontID := cache.Global.InternFontFace(face)
+	lineHeight := cache.Global.GetFontInfo(fontID).LineHeight
+
+	ext := &labelExtData{}
+	var b labelLayoutBuilder
+	b.Reset(ext, fontID, 50)
+	b.AddSegment(0, fontID, TextScriptNormal, "press ")
+	b.AddSegment(1, fontID, TextScriptNormal, "[SPACE]")
+	b.AddSegment(0, fontID, TextScriptNormal, " to go")
+	have := b.Finish()
+
+	want := "press\n[SPACE]\nto go"
+	if have != want {
+		t.Fatalf("layout text:\nhave: %q\nwant: %q", have, want)
+	}
+	wantLines := []float64{35, 49, 35}
+	if len(ext.lines) != len(wantLines) {
+		t.Fatalf("lines:\nhave: %v\nwant: %v", ext.lines, wantLines)
+	}
+	for i := range wantLines {
+		if ext.lines[i] != wantLines[i] {
+			t.Fatalf("lines[%d]:\nhave: %v\nwant: %v", i, ext.lines[i], wantLines[i])
+		}
+	}
+	for _, run := range ext.runs {
+		if want := float64(run.line) * lineHeight; run.y != want {
+			t.Fatalf("run %q y:\nhave: %v\nwant: %v", run.text, run.y, want)
+		}
+	}
+	if want := 3 * lineHeight; ext.textHeight != want {
+		t.Fatalf("text height:\nhave: %v\nwant: %v", ext.textHeight, want)
+	}
+}
+
+func TestTruncateRunes(t *testing.T) {
+	tests := []struct {
+		input      string
+		n          int
+		want       string
+		wantBudget int
+	}{
+		{"", 0, "", 0},
+		{"", 3, "", 3},
+		{"abc", 0, "", 0},
+		{"abc", 2, "ab", 0},
+		{"abc", 5, "abc", 2},
+		{"ab\ncd", 2, "ab\n", 0},
+		{"ab\ncd", 3, "ab\nc", 0},
+		{"привет", 3, "при", 0},
+	}
+	for _, test := range tests {
+		have, haveBudget := truncateRunes(test.input, test.n)
+		if have != test.want || haveBudget != test.wantBudget {
+			t.Fatalf("truncateRunes(%q, %d):\nhave: %q, %d\nwant: %q, %d",
+				test.input, test.n, have, haveBudget, test.want, test.wantBudget)
+		}
+	}
+}
+
+func TestLabelLayoutScripts(t *testing.T) {
+	face := text.NewGoXFace(basicfont.Face7x13)
+	fontID := cache.Global.InternFontFace(face)
+
+	ext := &labelExtData{}
+	var b labelLayoutBuilder
+	b.Reset(ext, fontID, 0)
+	b.AddSegment(0, fontID, TextScriptNormal, "x")
+	b.AddSegment(1, fontID, TextScriptSuper, "2")
+	b.AddSegment(2, fontID, TextScriptSub, "i")
+	b.Finish()
+
+	if len(ext.runs) != 3 {
+		t.Fatalf("expected 3 runs, found %d", len(ext.runs))
+	}
+	ascent := cache.Global.GetFontInfo(fontID).Ascent
+	baseline := func(run labelRun) float64 {
+		return run.y + ascent*run.script.scale()
+	}
+	normal, super, sub := ext.runs[0], ext.runs[1], ext.runs[2]
+	if !(baseline(super) < baseline(normal)) {
+		t.Fatalf("superscript baseline %v is not above the normal one %v", baseline(super), baseline(normal))
+	}
+	if !(baseline(sub) > baseline(normal)) {
+		t.Fatalf("subscript baseline %v is not below the normal one %v", baseline(sub), baseline(normal))
+	}
+	if want := 7 + 2*7*scriptScale; math.Abs(ext.lines[0]-want) > 0.001 {
+		t.Fatalf("line width:\nhave: %v\nwant: %v", ext.lines[0], want)
+	}
+}
+
+func TestMeasureLineLetterSpacing(t *testing.T) {
+	face := text.NewGoXFace(basicfont.Face7x13)
+	tests := []struct {
+		s       string
+		spacing float64
+		want    float64
+	}{
+		{"", 2, 0},
+		{"abc", 0, 21},
+		{"abc", 2, 27},
+		{"a b", -1, 18},
+	}
+	for _, test := range tests {
+		have := measureLine(test.s, face, test.spacing)
+		if have != test.want {
+			t.Fatalf("measureLine(%q, %v):\nhave: %v\nwant: %v", test.s, test.spacing, have, test.want)
+		}
+	}
+}
+
+func TestLabelPlainLineWidths(t *testing.T) {
+	face := text.NewGoXFace(basicfont.Face7x13)
+
+	l := NewLabel(face)
+	l.SetText("ab\nabcd\n")
+	wantLines := []float64{14, 28, 0}
+	for i, want := range wantLines {
+		if have := l.plainLineWidth(i); have != want {
+			t.Fatalf("line[%d] width:\nhave: %v\nwant: %v", i, have, want)
+		}
+	}
+
+	l.SetText("abc")
+	if have := l.plainLineWidth(0); have != 21 {
+		t.Fatalf("single line width:\nhave: %v\nwant: 21", have)
+	}
+}
+
+func TestLabelCacheAlignInvalidation(t *testing.T) {
+	l := NewLabel(text.NewGoXFace(basicfont.Face7x13))
+	l.SetCached(true)
+	l.SetSize(100, 40)
+	l.SetText("cached")
+
+	dst := ebiten.NewImage(100, 40)
+	setters := []struct {
+		name string
+		fn   func()
+	}{
+		{"SetAlignHorizontal", func() { l.SetAlignHorizontal(AlignHorizontalRight) }},
+		{"SetAlignVertical", func() { l.SetAlignVertical(AlignVerticalCenter) }},
+		{"SetGrowHorizontal", func() { l.SetGrowHorizontal(GrowHorizontalBoth) }},
+		{"SetGrowVertical", func() { l.SetGrowVertical(GrowVerticalBoth) }},
+	}
+	for _, setter := range setters {
+		l.Draw(dst)
+		if l.ext.cacheDirty {
+			t.Fatal("the cache is not rendered by Draw")
+		}
+		setter.fn()
+		if !l.ext.cacheDirty {
+			t.Fatalf("%s doesn't invalidate the cached image", setter.name)
+		}
+	}
+}
@@ -1,10 +1,14 @@
 package graphics_test
 
 import (
+	"math"
+	"strconv"
 	"testing"
 	"unsafe"
 
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
 	graphics "github.com/quasilyte/ebitengine-graphics"
+	"golang.org/x/image/font/basicfont"
 )
 
 func TestLabelSize(t *testing.T) {
@@ -18,3 +22,119 @@ func TestLabelSize(t *testing.T) {
 		t.Fatalf("sizeof(Label):\nhave: %d\nwant: %d", haveSize, wantSize)
 	}
 }
+
+func TestFontMetrics(t *testing.T) {
+	ff := text.NewGoXFace(basicfont.Face7x13)
+	m := graphics.GetFontMetrics(ff)
+	if m.Ascent <= 0 || m.Descent <= 0 {
+		t.Fatalf("ascent=%v descent=%v: expected positive values", m.Ascent, m.Descent)
+	}
+	if m.LineHeight < m.Ascent+m.Descent {
+		t.Fatalf("line height %v is less than ascent+descent (%v)", m.LineHeight, m.Ascent+m.Descent)
+	}
+
+	l := graphics.NewLabel(ff)
+	if have := l.GetFontMetrics(); have != m {
+		t.Fatalf("label metrics:\nhave: %+v\nwant: %+v", have, m)
+	}
+}
+
+func TestParseScriptMarkup(t *testing.T) {
+	segments := graphics.ParseScriptMarkup("H_{2}O + x^{2} ^ _{")
+	want := []graphics.TextSegment{
+		{Text: "H"},
+		{Text: "2", Script: graphics.TextScriptSub},
+		{Text: "O + x"},
+		{Text: "2", Script: graphics.TextScriptSuper},
+		{Text: " ^ _{"},
+	}
+	if len(segments) != len(want) {
+		t.Fatalf("segments:\nhave: %v\nwant: %v", segments, want)
+	}
+	for i := range want {
+		if segments[i] != want[i] {
+			t.Fatalf("segments[%d]:\nhave: %v\nwant: %v", i, segments[i], want[i])
+		}
+	}
+}
+
+func TestSetFontFallbacks(t *testing.T) {
+	ff := text.NewGoXFace(basicfont.Face7x13)
+	fallback := text.NewGoXFace(basicfont.Face7x13)
+
+	l := graphics.NewLabel(ff)
+	wantMetrics := l.GetFontMetrics()
+	if err := graphics.SetFontFallbacks(ff, fallback); err != nil {
+		t.Fatal(err)
+	}
+	if have := graphics.GetFontMetrics(ff); have != wantMetrics {
+		t.Fatalf("metrics changed after SetFontFallbacks:\nhave: %+v\nwant: %+v", have, wantMetrics)
+	}
+	if err := graphics.SetFontFallbacks(ff); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLabelShader(t *testing.T) {
+	ff := text.NewGoXFace(basicfont.Face7x13)
+	l := graphics.NewLabel(ff)
+	other := graphics.NewLabel(ff)
+
+	shader := graphics.NewShader(nil)
+	l.SetShader(shader)
+	if l.GetShader() != shader {
+		t.Fatal("the shader is not assigned")
+	}
+	if other.GetShader() != nil {
+		t.Fatal("the shader is shared between the labels")
+	}
+	if l.IsCached() {
+		t.Fatal("the shader should not enable the explicit cached mode")
+	}
+
+	l.SetShader(nil)
+	if l.GetShader() != nil {
+		t.Fatal("the shader is not removed")
+	}
+}
+
+func TestLabelSetTextNumeric(t *testing.T) {
+	ff := text.NewGoXFace(basicfont.Face7x13)
+	numeric := graphics.NewLabel(ff)
+	plain := graphics.NewLabel(ff)
+
+	check := func(s string) {
+		t.Helper()
+		plain.SetText(s)
+		if have, want := numeric.BoundsRect(), plain.BoundsRect(); have != want {
+			t.Fatalf("%q bounds:\nhave: %v\nwant: %v", s, have, want)
+		}
+	}
+
+	for _, n := range []int{0, 7, 42, 9999, 10000, -15, 1234567} {
+		numeric.SetTextInt(n)
+		check(strconv.Itoa(n))
+	}
+	numeric.SetTextFloat(3.14159, 2)
+	check("3.14")
+	numeric.SetTextFloat(math.Inf(1), 2)
+	check("+Inf")
+}
+
+func TestLabelSetTextIntAllocs(t *testing.T) {
+	ff := text.NewGoXFace(basicfont.Face7x13)
+	l := graphics.NewLabel(ff)
+
+	// Warm-up: intern the strings.
+	l.SetTextInt(1500)
+	l.SetTextInt(1501)
+
+	n := 0
+	allocs := testing.AllocsPerRun(100, func() {
+		l.SetTextInt(1500 + n%2)
+		n++
+	})
+	if allocs != 0 {
+		t.Fatalf("SetTextInt allocs: have %v, want 0", allocs)
+	}
+}
@@ -1,7 +1,12 @@
 package graphics
 
 import (
+	"slices"
+	"sort"
+
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+	"github.com/quasilyte/gmath"
 )
 
 // Layer is a simple layer that renders objects in the order they were added.
@@ -13,7 +18,21 @@ import (
 // use [StaticLayer].
 type Layer struct {
 	objects    []Object
+	registry   objectRegistry
 	needFilter bool
+
+	// seqs is allocated on the first SetObjectSortKey call.
+	// It's a parallel slice for objects that holds the objects insertion
+	// sequence numbers, so the equal key objects keep the insertion order.
+	seqs    []uint32
+	nextSeq uint32
+
+	// sorted is set after the first SetObjectSortKey call.
+	// Sorted layers re-sort their objects after the new objects are added.
+	sorted   bool
+	needSort bool
+
+	culler objectCuller
 }
 
 func NewLayer() *Layer {
@@ -21,8 +40,16 @@ func NewLayer() *Layer {
 }
 
 func (l *Layer) AddChild(g gsceneGraphics) {
+	if cache.Global.StrictMode != 0 {
+		strictCheckAddChild(g)
+	}
 	l.objects = append(l.objects, g.(Object))
 	l.needFilter = true
+	if l.sorted {
+		l.seqs = append(l.seqs, l.nextSeq)
+		l.nextSeq++
+		l.needSort = true
+	}
 }
 
 func (l *Layer) Update(_ float64) {
@@ -31,13 +58,20 @@ func (l *Layer) Update(_ float64) {
 
 func (l *Layer) filter() {
 	liveObjects := l.objects[:0]
-	for _, o := range l.objects {
+	for i, o := range l.objects {
 		if o.IsDisposed() {
+			l.registry.Forget(o)
 			continue
 		}
+		if l.sorted {
+			l.seqs[len(liveObjects)] = l.seqs[i]
+		}
 		liveObjects = append(liveObjects, o)
 	}
 	l.objects = liveObjects
+	if l.sorted {
+		l.seqs = l.seqs[:len(liveObjects)]
+	}
 }
 
 func (l *Layer) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
@@ -45,8 +79,207 @@ func (l *Layer) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
 		l.filter()
 	}
 	l.needFilter = false
+	if l.needSort {
+		l.sortObjects()
+	}
+
+	if !l.culler.enabled {
+		for _, o := range l.objects {
+			drawObject(dst, o, opts)
+		}
+		return
+	}
+
+	visibleRect := l.culler.visibleRect(dst, opts)
+	for _, o := range l.objects {
+		if l.culler.isCulled(o, visibleRect) {
+			continue
+		}
+		drawObject(dst, o, opts)
+	}
+}
+
+// SetCulling enables or disables the viewport culling for this layer.
+//
+// When enabled, the objects that implement [BoundedObject] are
+// not rendered if their bounds don't intersect the camera's view.
+// The margin extends the view rect in every direction: use it when
+// objects have visuals that go beyond their bounds
+// (like scaled or rotated sprites, label shadows, etc.)
+//
+// The culling is disabled by default.
+func (l *Layer) SetCulling(enabled bool, margin float64) {
+	l.culler = objectCuller{enabled: enabled, margin: margin}
+}
+
+// SetObjectSortKey assigns a sort key to the object that belongs to this layer.
+//
+// Objects with lower keys are rendered first.
+// Objects with equal keys are rendered in the order they were added,
+// regardless of their previous keys.
+// The default key is 0.
+//
+// This can be used to make all shadows render before the bodies
+// without splitting them into separate layers.
+func (l *Layer) SetObjectSortKey(o Object, key int) {
+	l.registry.SetSortKey(o, key)
+	if !l.sorted {
+		// The objects are not sorted yet, so their
+		// current order is the insertion order.
+		l.sorted = true
+		l.seqs = make([]uint32, len(l.objects), cap(l.objects))
+		for i := range l.seqs {
+			l.seqs[i] = uint32(i)
+		}
+		l.nextSeq = uint32(len(l.objects))
+	}
+	l.needSort = true
+}
+
+// GetObjectSortKey returns the object sort key assigned by [SetObjectSortKey].
+func (l *Layer) GetObjectSortKey(o Object) int { return l.registry.GetSortKey(o) }
+
+func (l *Layer) sortObjects() {
+	l.needSort = false
+	sort.Sort(layerObjects{l})
+}
+
+// layerObjects implements sort.Interface for the parallel
+// objects and seqs slices.
+type layerObjects struct{ l *Layer }
+
+func (lo layerObjects) Len() int { return len(lo.l.objects) }
+
+func (lo layerObjects) Less(i, j int) bool {
+	l := lo.l
+	ki := l.registry.GetSortKey(l.objects[i])
+	kj := l.registry.GetSortKey(l.objects[j])
+	if ki != kj {
+		return ki < kj
+	}
+	return l.seqs[i] < l.seqs[j]
+}
+
+func (lo layerObjects) Swap(i, j int) {
+	objects := lo.l.objects
+	seqs := lo.l.seqs
+	objects[i], objects[j] = objects[j], objects[i]
+	seqs[i], seqs[j] = seqs[j], seqs[i]
+}
+
+// ObjectsAt appends all objects that contain the pos to dst and returns the extended slice.
+// The objects are ordered from the top-most (drawn last) to the bottom-most.
+//
+// Only visible objects that implement [BoundedObject] participate in the hit-testing.
+// Disposed objects are ignored.
+//
+// The pos parameter should be in world coordinates.
+func (l *Layer) ObjectsAt(dst []Object, pos gmath.Vec) []Object {
+	if l.needSort {
+		l.sortObjects()
+	}
+	for i := len(l.objects) - 1; i >= 0; i-- {
+		o := l.objects[i]
+		if objectContains(o, pos) {
+			dst = append(dst, o)
+		}
+	}
+	return dst
+}
+
+// TopObjectAt returns the top-most object that contains the pos.
+// It returns nil if there is no such object.
+//
+// It's consistent with the rendering order: the returned object
+// is the one that is drawn on top of the others at this pos.
+// See [ObjectsAt] to learn which objects participate in the hit-testing.
+//
+// The pos parameter should be in world coordinates.
+func (l *Layer) TopObjectAt(pos gmath.Vec) Object {
+	if l.needSort {
+		l.sortObjects()
+	}
+	for i := len(l.objects) - 1; i >= 0; i-- {
+		o := l.objects[i]
+		if objectContains(o, pos) {
+			return o
+		}
+	}
+	return nil
+}
+
+func objectContains(o gsceneGraphics, pos gmath.Vec) bool {
+	if o.IsDisposed() {
+		return false
+	}
+	if v, ok := o.(visibleObject); ok && !v.IsVisible() {
+		return false
+	}
+	b, ok := o.(BoundedObject)
+	if !ok {
+		return false
+	}
+	return b.BoundsRect().Contains(pos)
+}
+
+// SetObjectName assigns a name to the object that belongs to this layer.
+// Names don't have to be unique, but [FindByName] returns only one object.
+//
+// The object metadata (names and tags) is automatically
+// removed when the object is disposed.
+// It's a no-op if o is not added to this layer,
+// so the metadata of a foreign object is never leaked.
+func (l *Layer) SetObjectName(o Object, name string) {
+	if slices.Contains(l.objects, o) {
+		l.registry.SetName(o, name)
+	}
+}
+
+// GetObjectName returns the object name assigned by [SetObjectName].
+// It returns an empty string for unnamed objects.
+func (l *Layer) GetObjectName(o Object) string { return l.registry.GetName(o) }
+
+// AddObjectTag adds a tag to the object that belongs to this layer.
+// Adding the same tag twice is a no-op.
+// Like SetObjectName, it's a no-op if o is not added to this layer.
+func (l *Layer) AddObjectTag(o Object, tag string) {
+	if slices.Contains(l.objects, o) {
+		l.registry.AddTag(o, tag)
+	}
+}
+
+// RemoveObjectTag removes the object tag added by [AddObjectTag].
+func (l *Layer) RemoveObjectTag(o Object, tag string) { l.registry.RemoveTag(o, tag) }
 
+// GetObjectTags returns all tags associated with the object.
+// The returned slice should not be modified.
+func (l *Layer) GetObjectTags(o Object) []string { return l.registry.GetTags(o) }
+
+// FindByName returns the first object (in the rendering order) with the given name.
+// It returns nil if there is no such object.
+// An empty name never matches: the unnamed objects can't be found.
+func (l *Layer) FindByName(name string) Object {
+	if name == "" || l.registry.IsEmpty() {
+		return nil
+	}
 	for _, o := range l.objects {
-		o.DrawWithOptions(dst, opts)
+		if !o.IsDisposed() && l.registry.GetName(o) == name {
+			return o
+		}
+	}
+	return nil
+}
+
+// FindByTag appends all objects that have the specified tag to dst and returns the extended slice.
+// The objects are appended in their rendering order.
+func (l *Layer) FindByTag(dst []Object, tag string) []Object {
+	if l.registry.IsEmpty() {
+		return dst
+	}
+	for _, o := range l.objects {
+		if !o.IsDisposed() && l.registry.HasTag(o, tag) {
+			dst = append(dst, o)
+		}
 	}
+	return dst
 }
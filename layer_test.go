@@ -0,0 +1,195 @@
+package graphics_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	graphics "github.com/quasilyte/ebitengine-graphics"
+	"github.com/quasilyte/gmath"
+)
+
+func TestLayerObjectsAt(t *testing.T) {
+	newRect := func(x, y float64) *graphics.Rect {
+		r := graphics.NewRect(10, 10)
+		r.SetCentered(false)
+		r.Pos.Offset = gmath.Vec{X: x, Y: y}
+		return r
+	}
+
+	bottom := newRect(0, 0)
+	middle := newRect(5, 5)
+	top := newRect(5, 5)
+	hidden := newRect(0, 0)
+	hidden.SetVisibility(false)
+	disposed := newRect(0, 0)
+	disposed.Dispose()
+
+	l := graphics.NewLayer()
+	l.AddChild(bottom)
+	l.AddChild(middle)
+	l.AddChild(top)
+	l.AddChild(hidden)
+	l.AddChild(disposed)
+
+	tests := []struct {
+		pos  gmath.Vec
+		want []graphics.Object
+	}{
+		{gmath.Vec{X: 1, Y: 1}, []graphics.Object{bottom}},
+		{gmath.Vec{X: 6, Y: 6}, []graphics.Object{top, middle, bottom}},
+		{gmath.Vec{X: 12, Y: 12}, []graphics.Object{top, middle}},
+		{gmath.Vec{X: 30, Y: 30}, nil},
+	}
+
+	for _, test := range tests {
+		have := l.ObjectsAt(nil, test.pos)
+		if len(have) != len(test.want) {
+			t.Fatalf("ObjectsAt(%v):\nhave: %d objects\nwant: %d objects", test.pos, len(have), len(test.want))
+		}
+		for i := range have {
+			if have[i] != test.want[i] {
+				t.Fatalf("ObjectsAt(%v): objects[%d] mismatch", test.pos, i)
+			}
+		}
+		var wantTop graphics.Object
+		if len(test.want) != 0 {
+			wantTop = test.want[0]
+		}
+		if haveTop := l.TopObjectAt(test.pos); haveTop != wantTop {
+			t.Fatalf("TopObjectAt(%v) returned unexpected object", test.pos)
+		}
+	}
+}
+
+func TestLayerFindByTag(t *testing.T) {
+	l := graphics.NewLayer()
+	a := graphics.NewRect(1, 1)
+	b := graphics.NewRect(1, 1)
+	c := graphics.NewRect(1, 1)
+	l.AddChild(a)
+	l.AddChild(b)
+	l.AddChild(c)
+
+	l.SetObjectName(b, "player")
+	l.AddObjectTag(a, "fx")
+	l.AddObjectTag(c, "fx")
+	l.AddObjectTag(c, "fx")
+
+	if l.FindByName("player") != graphics.Object(b) {
+		t.Fatal("FindByName(player) returned unexpected object")
+	}
+	if l.FindByName("enemy") != nil {
+		t.Fatal("FindByName(enemy) returned non-nil object")
+	}
+	if l.FindByName("") != nil {
+		t.Fatal("FindByName(\"\") returned an unnamed object")
+	}
+
+	// The objects that are not added to the layer can't be named.
+	foreign := graphics.NewRect(1, 1)
+	l.SetObjectName(foreign, "foreign")
+	l.AddObjectTag(foreign, "fx")
+	if l.GetObjectName(foreign) != "" || len(l.GetObjectTags(foreign)) != 0 {
+		t.Fatal("a foreign object metadata is stored")
+	}
+
+	fx := l.FindByTag(nil, "fx")
+	if len(fx) != 2 || fx[0] != graphics.Object(a) || fx[1] != graphics.Object(c) {
+		t.Fatalf("FindByTag(fx) returned unexpected objects")
+	}
+	if tags := l.GetObjectTags(c); len(tags) != 1 {
+		t.Fatalf("GetObjectTags:\nhave: %v\nwant: [fx]", tags)
+	}
+
+	a.Dispose()
+	fx = l.FindByTag(fx[:0], "fx")
+	if len(fx) != 1 || fx[0] != graphics.Object(c) {
+		t.Fatalf("FindByTag(fx) returned a disposed object")
+	}
+}
+
+func TestLayerSortKey(t *testing.T) {
+	body := graphics.NewRect(10, 10)
+	shadow := graphics.NewRect(10, 10)
+
+	l := graphics.NewLayer()
+	l.AddChild(body)
+	l.AddChild(shadow)
+	if l.TopObjectAt(gmath.Vec{}) != shadow {
+		t.Fatal("the last added object should be on top")
+	}
+
+	l.SetObjectSortKey(shadow, -1)
+	if l.TopObjectAt(gmath.Vec{}) != body {
+		t.Fatal("the shadow with a lower sort key should be below the body")
+	}
+
+	// New objects are sorted too.
+	shadow2 := graphics.NewRect(10, 10)
+	l.AddChild(shadow2)
+	l.SetObjectSortKey(shadow2, -1)
+	have := l.ObjectsAt(nil, gmath.Vec{})
+	want := []graphics.Object{body, shadow2, shadow}
+	for i := range want {
+		if have[i] != want[i] {
+			t.Fatalf("objects[%d] mismatch", i)
+		}
+	}
+}
+
+func TestLayerSortKeyInsertionOrder(t *testing.T) {
+	a := graphics.NewRect(10, 10)
+	b := graphics.NewRect(10, 10)
+	c := graphics.NewRect(10, 10)
+
+	l := graphics.NewLayer()
+	l.AddChild(a)
+	l.AddChild(b)
+	l.AddChild(c)
+
+	check := func(want ...graphics.Object) {
+		t.Helper()
+		// ObjectsAt returns the top-most objects first.
+		have := l.ObjectsAt(nil, gmath.Vec{})
+		for i := range want {
+			if have[len(have)-i-1] != want[i] {
+				t.Fatalf("objects[%d] has a wrong draw order", i)
+			}
+		}
+	}
+
+	l.SetObjectSortKey(a, 1)
+	check(b, c, a)
+
+	// The key reset restores the insertion order.
+	l.SetObjectSortKey(a, 0)
+	check(a, b, c)
+
+	b.Dispose()
+	// The disposed objects are removed during the next draw.
+	l.Update(0)
+	l.DrawWithOptions(ebiten.NewImage(16, 16), graphics.DrawOptions{})
+	d := graphics.NewRect(10, 10)
+	l.AddChild(d)
+	l.SetObjectSortKey(c, -1)
+	check(c, a, d)
+}
+
+func TestStaticLayerObjectsAt(t *testing.T) {
+	bottom := graphics.NewRect(10, 10)
+	top := graphics.NewRect(10, 10)
+
+	l := graphics.NewStaticLayer()
+	l.AddChild(bottom)
+	l.AddChild(top)
+
+	// The static layer results can be used the same way as the Layer results.
+	var have []graphics.Object
+	have = l.ObjectsAt(have, gmath.Vec{})
+	if len(have) != 2 || have[0] != top || have[1] != bottom {
+		t.Fatalf("ObjectsAt returned unexpected objects: %v", have)
+	}
+	if l.TopObjectAt(gmath.Vec{}) != graphics.Object(top) {
+		t.Fatal("TopObjectAt returned unexpected object")
+	}
+}
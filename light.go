@@ -0,0 +1,369 @@
+package graphics
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+	"github.com/quasilyte/gmath"
+)
+
+// Light is a point or a cone light source.
+//
+// It's intended to be added to the [LightLayer],
+// but it can also be drawn on its own as an additive glow.
+//
+// Light implements gscene Graphics interface.
+type Light struct {
+	// Pos is a light source location binder.
+	Pos gmath.Pos
+
+	// Rotation is a cone light direction binder.
+	// It's ignored for the point lights.
+	Rotation *gmath.Rad
+
+	radius    float64
+	intensity float32
+	coneAngle gmath.Rad
+
+	colorScale ColorScale
+
+	visible  bool
+	disposed bool
+}
+
+// NewLight creates a white point light with the specified radius.
+// The light intensity is linearly decreasing towards the radius.
+func NewLight(radius float64) *Light {
+	return &Light{
+		radius:     radius,
+		intensity:  1,
+		colorScale: defaultColorScale,
+		visible:    true,
+	}
+}
+
+func (l *Light) GetRadius() float64 { return l.radius }
+
+func (l *Light) SetRadius(radius float64) { l.radius = radius }
+
+// GetIntensity returns the light intensity.
+// Use SetIntensity to change it.
+func (l *Light) GetIntensity() float32 { return l.intensity }
+
+// SetIntensity changes the light center brightness multiplier.
+// The default intensity is 1.
+func (l *Light) SetIntensity(intensity float32) { l.intensity = intensity }
+
+func (l *Light) GetColorScale() ColorScale { return l.colorScale }
+
+func (l *Light) SetColorScale(cs ColorScale) { l.colorScale = cs }
+
+// GetConeAngle returns the cone light angle.
+// Use SetConeAngle to change it.
+func (l *Light) GetConeAngle() gmath.Rad { return l.coneAngle }
+
+// SetConeAngle turns this light into a cone light with the specified angle.
+// The cone is directed by the Rotation.
+// A zero value (or a value of 2*Pi and higher) makes it a point light again.
+func (l *Light) SetConeAngle(angle gmath.Rad) { l.coneAngle = angle }
+
+// BoundsRect returns the lit area bounding rectangle.
+func (l *Light) BoundsRect() gmath.Rect {
+	pos := l.Pos.Resolve()
+	offset := gmath.Vec{X: l.radius, Y: l.radius}
+	return gmath.Rect{Min: pos.Sub(offset), Max: pos.Add(offset)}
+}
+
+// Dispose marks this light for deletion.
+// After calling this method, IsDisposed will report true.
+func (l *Light) Dispose() { l.disposed = true }
+
+// IsDisposed reports whether this light is marked for deletion.
+func (l *Light) IsDisposed() bool { return l.disposed }
+
+// IsVisible reports whether this light is enabled.
+// Use SetVisibility to change this flag value.
+func (l *Light) IsVisible() bool { return l.visible }
+
+// SetVisibility enables or disables the light.
+// Use IsVisible to get the current flag value.
+func (l *Light) SetVisibility(visible bool) { l.visible = visible }
+
+// Draw renders the light onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (l *Light) Draw(dst *ebiten.Image) {
+	l.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the light onto the provided dst image
+// while also using the extra provided offset.
+//
+// The light is drawn with an additive blending unless
+// the DrawOptions.Blend is specified.
+func (l *Light) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !l.visible || l.radius <= 0 {
+		return
+	}
+	blend := opts.Blend
+	if blend == nil {
+		blend = &ebiten.BlendLighter
+	}
+	l.draw(dst, blend, l.Pos.Resolve().Add(opts.Offset))
+}
+
+func (l *Light) draw(dst *ebiten.Image, blend *ebiten.Blend, center gmath.Vec) {
+	const numSegments = 32
+
+	vertices := cache.Global.ScratchVertices[:0]
+	indices := cache.Global.ScratchIndices[:0]
+	defer func() {
+		cache.Global.ScratchVertices = vertices[:0]
+		cache.Global.ScratchIndices = indices[:0]
+	}()
+
+	cs := l.colorScale.ToEbitenColorScale()
+	vertex := func(pos gmath.Vec, k float32) ebiten.Vertex {
+		return ebiten.Vertex{
+			DstX:   float32(pos.X),
+			DstY:   float32(pos.Y),
+			SrcX:   1.5,
+			SrcY:   1.5,
+			ColorR: cs.R() * k,
+			ColorG: cs.G() * k,
+			ColorB: cs.B() * k,
+			ColorA: cs.A() * k,
+		}
+	}
+
+	// A triangle fan with the bright center and the dark rim.
+	isCone := l.coneAngle > 0 && l.coneAngle < 2*math.Pi
+	startAngle := gmath.Rad(0)
+	sweep := gmath.Rad(2 * math.Pi)
+	if isCone {
+		if l.Rotation != nil {
+			startAngle = *l.Rotation
+		}
+		startAngle -= l.coneAngle * 0.5
+		sweep = l.coneAngle
+	}
+	vertices = append(vertices, vertex(center, l.intensity))
+	for i := 0; i <= numSegments; i++ {
+		angle := startAngle + sweep*gmath.Rad(i)/numSegments
+		rim := center.Add(gmath.RadToVec(angle).Mulf(l.radius))
+		vertices = append(vertices, vertex(rim, 0))
+	}
+	for i := uint16(0); i < numSegments; i++ {
+		indices = append(indices, 0, 1+i, 2+i)
+	}
+
+	var drawOptions ebiten.DrawTrianglesOptions
+	drawOptions.Blend = *blend
+	drawVertexColorTriangles(dst, vertices, indices, emptyImage, &drawOptions)
+}
+
+// LightLayerConfig describes the [LightLayer] lighting parameters.
+type LightLayerConfig struct {
+	// AmbientColorScale is a color of the unlit areas.
+	// The alpha value is ignored.
+	// A zero value means black (the unlit areas are completely dark).
+	AmbientColorScale ColorScale
+}
+
+// LightLayer applies the lighting to everything that is drawn below it.
+//
+// The layer renders a light map: it's filled with the ambient color
+// and then every light is added on top of it.
+// The light map is composited over the destination using [BlendMultiply],
+// so the lit areas keep their colors while the rest of the scene is darkened.
+//
+// The occluders (see AddOccluder) block the light:
+// every light casts the occluder bounds shadows.
+//
+// Only [Light] objects can be added to this layer using AddChild.
+// The disposed objects are removed automatically.
+//
+// Like [Layer], the lights are rendered with respect to the camera transformation.
+type LightLayer struct {
+	config LightLayerConfig
+
+	lights    []*Light
+	occluders []BoundedObject
+
+	lightMap *ebiten.Image
+	lightBuf *ebiten.Image
+}
+
+// NewLightLayer creates a lighting layer with the specified config.
+func NewLightLayer(config LightLayerConfig) *LightLayer {
+	config.AmbientColorScale.A = 1
+	return &LightLayer{
+		config: config,
+		lights: make([]*Light, 0, 8),
+	}
+}
+
+// GetAmbientColorScale returns the current ambient color.
+func (l *LightLayer) GetAmbientColorScale() ColorScale {
+	return l.config.AmbientColorScale
+}
+
+// SetAmbientColorScale changes the color of the unlit areas.
+// It can be used to implement a day-night cycle.
+func (l *LightLayer) SetAmbientColorScale(cs ColorScale) {
+	cs.A = 1
+	l.config.AmbientColorScale = cs
+}
+
+// AddChild adds a light to this layer.
+// It panics if g is not a [Light].
+func (l *LightLayer) AddChild(g gsceneGraphics) {
+	light, ok := g.(*Light)
+	if !ok {
+		panic("unexpected light layer child type")
+	}
+	l.AddLight(light)
+}
+
+// AddLight adds a light to this layer.
+func (l *LightLayer) AddLight(light *Light) {
+	l.lights = append(l.lights, light)
+}
+
+// AddOccluder adds an object that blocks the light.
+// Its BoundsRect is used as the occluder shape.
+// The occluder area itself is not lit either.
+//
+// If the occluder implements IsDisposed method,
+// it's removed automatically after being disposed.
+func (l *LightLayer) AddOccluder(o BoundedObject) {
+	l.occluders = append(l.occluders, o)
+}
+
+func (l *LightLayer) Update(delta float64) {}
+
+func (l *LightLayer) inspectObjects() []Object {
+	objects := make([]Object, 0, len(l.lights))
+	for _, light := range l.lights {
+		objects = append(objects, light)
+	}
+	return objects
+}
+
+func (l *LightLayer) filter() {
+	liveLights := l.lights[:0]
+	for _, light := range l.lights {
+		if light.IsDisposed() {
+			continue
+		}
+		liveLights = append(liveLights, light)
+	}
+	clear(l.lights[len(liveLights):])
+	l.lights = liveLights
+
+	liveOccluders := l.occluders[:0]
+	for _, o := range l.occluders {
+		if d, ok := o.(interface{ IsDisposed() bool }); ok && d.IsDisposed() {
+			continue
+		}
+		liveOccluders = append(liveOccluders, o)
+	}
+	clear(l.occluders[len(liveOccluders):])
+	l.occluders = liveOccluders
+}
+
+func (l *LightLayer) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	l.filter()
+
+	bounds := dst.Bounds()
+	l.lightMap = ensureImageSize(l.lightMap, bounds.Dx(), bounds.Dy())
+	ambient := l.config.AmbientColorScale
+	fillImage(l.lightMap, ambient.Color())
+
+	// The light map has its own coordinates: its (0, 0)
+	// is the dst bounds top-left corner.
+	offset := opts.Offset.Sub(gmath.Vec{X: float64(bounds.Min.X), Y: float64(bounds.Min.Y)})
+	viewRect := gmath.Rect{Max: gmath.Vec{X: float64(bounds.Dx()), Y: float64(bounds.Dy())}}
+
+	for _, light := range l.lights {
+		if !light.IsVisible() || light.radius <= 0 {
+			continue
+		}
+		lightRect := light.BoundsRect().Add(offset)
+		if !lightRect.Intersects(viewRect) {
+			continue
+		}
+		center := light.Pos.Resolve().Add(offset)
+
+		if !l.hasOccluders(light) {
+			light.draw(l.lightMap, &ebiten.BlendLighter, center)
+			continue
+		}
+
+		// The shadowed light is rendered into a temporary buffer first,
+		// so its shadows don't erase the other lights.
+		l.lightBuf = ensureImageSize(l.lightBuf, bounds.Dx(), bounds.Dy())
+		clearImage(l.lightBuf)
+		light.draw(l.lightBuf, &ebiten.BlendSourceOver, center)
+		for _, o := range l.occluders {
+			l.drawShadow(light, center, o.BoundsRect().Add(offset))
+		}
+		var drawOptions ebiten.DrawImageOptions
+		drawOptions.Blend = ebiten.BlendLighter
+		drawImage(l.lightMap, l.lightBuf, &drawOptions)
+	}
+
+	var drawOptions ebiten.DrawImageOptions
+	drawOptions.Blend = BlendMultiply
+	drawOptions.GeoM.Translate(float64(bounds.Min.X), float64(bounds.Min.Y))
+	drawImage(dst, l.lightMap, &drawOptions)
+}
+
+func (l *LightLayer) hasOccluders(light *Light) bool {
+	lightRect := light.BoundsRect()
+	for _, o := range l.occluders {
+		if o.BoundsRect().Intersects(lightRect) {
+			return true
+		}
+	}
+	return false
+}
+
+// drawShadow erases the light behind the occluder rect.
+// Every rect edge is extruded away from the light source;
+// together, these quads cover the entire shadow area.
+func (l *LightLayer) drawShadow(light *Light, center gmath.Vec, rect gmath.Rect) {
+	if rect.Contains(center) {
+		// A light source inside of an occluder is fully blocked.
+		clearImage(l.lightBuf)
+		return
+	}
+
+	corners := [4]gmath.Vec{
+		rect.Min,
+		{X: rect.Max.X, Y: rect.Min.Y},
+		rect.Max,
+		{X: rect.Min.X, Y: rect.Max.Y},
+	}
+	// The extrusion distance should be big enough to
+	// push the shadow quad beyond the light radius.
+	extrude := light.radius * 2
+	project := func(p gmath.Vec) gmath.Vec {
+		dir := p.Sub(center)
+		return p.Add(dir.Normalized().Mulf(extrude + dir.Len()))
+	}
+
+	var cs ebiten.ColorScale
+	for i := range corners {
+		a := corners[i]
+		b := corners[(i+1)%len(corners)]
+		pa := project(a)
+		pb := project(b)
+		drawTriangle(l.lightBuf, &ebiten.BlendClear, a, b, pb, cs)
+		drawTriangle(l.lightBuf, &ebiten.BlendClear, a, pb, pa, cs)
+	}
+}
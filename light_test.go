@@ -0,0 +1,57 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+func TestLightLayer(t *testing.T) {
+	l := NewLightLayer(LightLayerConfig{
+		AmbientColorScale: ColorScale{R: 0.1, G: 0.1, B: 0.2, A: 0.5},
+	})
+	if have := l.GetAmbientColorScale().A; have != 1 {
+		t.Fatalf("ambient alpha: have %v, want 1", have)
+	}
+
+	torch := NewLight(32)
+	torch.Pos.Offset = gmath.Vec{X: 40, Y: 40}
+	l.AddChild(torch)
+
+	flashlight := NewLight(64)
+	flashlight.SetConeAngle(gmath.DegToRad(60))
+	l.AddLight(flashlight)
+
+	wall := NewRect(10, 40)
+	wall.Pos.Offset = gmath.Vec{X: 60, Y: 40}
+	l.AddOccluder(wall)
+
+	if !l.hasOccluders(torch) {
+		t.Fatal("the torch light should be occluded")
+	}
+
+	dst := ebiten.NewImage(128, 96)
+	l.DrawWithOptions(dst, DrawOptions{})
+	lightMap := l.lightMap
+	l.DrawWithOptions(dst, DrawOptions{})
+	if l.lightMap != lightMap {
+		t.Fatal("the light map should be re-used")
+	}
+
+	flashlight.Dispose()
+	wall.Dispose()
+	l.DrawWithOptions(dst, DrawOptions{})
+	if len(l.lights) != 1 || len(l.occluders) != 0 {
+		t.Fatalf("the disposed objects are not removed: %d lights, %d occluders", len(l.lights), len(l.occluders))
+	}
+}
+
+func TestLightLayerAddChildPanic(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic")
+		}
+	}()
+	NewLightLayer(LightLayerConfig{}).AddChild(NewSprite())
+}
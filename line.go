@@ -5,19 +5,53 @@ import (
 	"github.com/quasilyte/gmath"
 )
 
+// LineStyle selects the [Line] rendering pattern.
+type LineStyle uint8
+
+const (
+	// LineSolid is a default, continuous line style.
+	LineSolid LineStyle = iota
+
+	// LineDashed renders the line as a sequence of dashes.
+	LineDashed
+
+	// LineDotted renders the line as a sequence of square dots.
+	// The dot size is equal to the line width.
+	//
+	// See also: [DottedLine] that renders the round dots.
+	LineDotted
+)
+
 // Line is a simple 2-point line graphical primitive.
-// Its color and width can be configured.
+// Its color, width and style can be configured.
+//
+// The solid line transformation is computed only when its
+// endpoints (or width) change, so a static line is very cheap to render.
 type Line struct {
 	BeginPos gmath.Pos
 	EndPos   gmath.Pos
 
 	width float64
 
+	// geom is a cached transformation for the pos1 and pos2 endpoints.
+	// The endpoints don't include the draw offset, it's applied
+	// during every Draw call, so the moving camera doesn't invalidate the cache.
+	geom     ebiten.GeoM
+	geomPos1 gmath.Vec
+	geomPos2 gmath.Vec
+	geomOK   bool
+
+	style      LineStyle
+	dashLength float64
+	dashGap    float64
+	phase      float64
+
 	colorScale       ColorScale
 	ebitenColorScale ebiten.ColorScale
 
 	visible  bool
 	disposed bool
+	blendID  uint8
 }
 
 // NewLine returns a line that is drawn from begin pos to end pos.
@@ -27,6 +61,7 @@ type Line struct {
 // * Visible=true
 // * The ColorScale is {1, 1, 1, 1}
 // * Width is 1
+// * Style is LineSolid
 func NewLine(begin, end gmath.Pos) *Line {
 	return &Line{
 		BeginPos:         begin,
@@ -79,7 +114,45 @@ func (l *Line) GetWidth() float64 {
 // SetWidth changes the line width.
 // Use GetWidth to retrieve the current line width value.
 func (l *Line) SetWidth(w float64) {
+	if l.width == w {
+		return
+	}
 	l.width = w
+	l.geomOK = false
+}
+
+// GetStyle reports the current line style.
+// Use SetStyle to change it.
+func (l *Line) GetStyle() LineStyle {
+	return l.style
+}
+
+// SetStyle changes the line style.
+//
+// The dashLength and gap are only used by the non-solid styles.
+// A zero dashLength means 4 pixels for the dashed style.
+// The dotted style always uses the line width as a dot size.
+// A zero gap means 4 pixels for the dashed style
+// and the line width for the dotted style.
+func (l *Line) SetStyle(style LineStyle, dashLength, gap float64) {
+	l.style = style
+	l.dashLength = dashLength
+	l.dashGap = gap
+}
+
+// GetPhase reports the current dash pattern offset.
+// Use SetPhase to change it.
+func (l *Line) GetPhase() float64 {
+	return l.phase
+}
+
+// SetPhase moves the dash pattern along the line by the specified
+// number of pixels; a positive phase moves the dashes towards the end pos.
+// Changing the phase every frame creates a "marching ants" effect.
+//
+// The phase has no effect on solid lines.
+func (l *Line) SetPhase(phase float64) {
+	l.phase = phase
 }
 
 // GetColorScale is used to retrieve the current color scale value of the line.
@@ -133,7 +206,60 @@ func (l *Line) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
 		return
 	}
 
-	pos1 := l.BeginPos.Resolve().Add(opts.Offset)
-	pos2 := l.EndPos.Resolve().Add(opts.Offset)
-	drawLine(dst, opts.Blend, pos1, pos2, l.width, l.ebitenColorScale)
+	opts.Blend = resolveBlend(l.blendID, opts.Blend)
+
+	pos1 := l.BeginPos.Resolve()
+	pos2 := l.EndPos.Resolve()
+	if l.style != LineSolid {
+		l.drawPattern(dst, opts.Blend, pos1.Add(opts.Offset), pos2.Add(opts.Offset))
+		return
+	}
+	if !l.geomOK || pos1 != l.geomPos1 || pos2 != l.geomPos2 {
+		l.geom = lineGeoM(pos1, pos2, l.width)
+		l.geomPos1 = pos1
+		l.geomPos2 = pos2
+		l.geomOK = true
+	}
+	geom := l.geom
+	geom.Translate(opts.Offset.X, opts.Offset.Y)
+	drawLineWithGeoM(dst, opts.Blend, geom, l.ebitenColorScale)
+}
+
+func (l *Line) drawPattern(dst *ebiten.Image, blend *ebiten.Blend, pos1, pos2 gmath.Vec) {
+	dashLength := l.dashLength
+	gap := l.dashGap
+	switch l.style {
+	case LineDashed:
+		if dashLength == 0 {
+			dashLength = 4
+		}
+		if gap == 0 {
+			gap = 4
+		}
+	case LineDotted:
+		dashLength = l.width
+		if gap == 0 {
+			gap = l.width
+		}
+	}
+	drawDashedLine(dst, blend, pos1, pos2, l.width, dashLength, gap, l.phase, l.ebitenColorScale)
+}
+
+// GetBlend returns the blend mode assigned by SetBlend.
+// The second result value is false if there is no blend override.
+func (l *Line) GetBlend() (ebiten.Blend, bool) {
+	return getBlend(l.blendID)
+}
+
+// SetBlend assigns a blend mode that is used to render this line.
+// It takes priority over the DrawOptions.Blend value.
+// Use ResetBlend to remove the override.
+func (l *Line) SetBlend(b ebiten.Blend) {
+	l.blendID = internBlend(b)
+}
+
+// ResetBlend removes the blend mode override.
+// The DrawOptions.Blend value (if any) will be used again.
+func (l *Line) ResetBlend() {
+	l.blendID = 0
 }
@@ -0,0 +1,58 @@
+package graphics
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+func TestForEachDash(t *testing.T) {
+	tests := []struct {
+		length     float64
+		dashLength float64
+		gap        float64
+		phase      float64
+		want       string
+	}{
+		{10, 2, 2, 0, "[0 2] [4 6] [8 10] "},
+		{9, 2, 2, 0, "[0 2] [4 6] [8 9] "},
+		{10, 2, 2, 1, "[1 3] [5 7] [9 10] "},
+		{10, 2, 2, 4, "[0 2] [4 6] [8 10] "},
+		{10, 2, 2, -1, "[0 1] [3 5] [7 9] "},
+		{10, 3, 0, 0, "[0 3] [3 6] [6 9] [9 10] "},
+		{10, 0, 0, 0, ""},
+		{0, 2, 2, 0, ""},
+	}
+
+	for _, test := range tests {
+		have := ""
+		forEachDash(test.length, test.dashLength, test.gap, test.phase, func(from, to float64) {
+			have += fmt.Sprintf("[%v %v] ", from, to)
+		})
+		if have != test.want {
+			t.Fatalf("forEachDash(%v, %v, %v, %v):\nhave: %q\nwant: %q",
+				test.length, test.dashLength, test.gap, test.phase, have, test.want)
+		}
+	}
+}
+
+func TestLineGeoMCacheOffset(t *testing.T) {
+	l := NewLine(gmath.Pos{}, gmath.Pos{Offset: gmath.Vec{X: 10}})
+	dst := ebiten.NewImage(32, 32)
+
+	l.DrawWithOptions(dst, DrawOptions{Offset: gmath.Vec{X: 5, Y: 5}})
+	geom := l.geom
+	// The draw offset doesn't affect the cached transformation.
+	l.DrawWithOptions(dst, DrawOptions{Offset: gmath.Vec{X: 7, Y: 1}})
+	if l.geom != geom || l.geomPos1 != (gmath.Vec{}) {
+		t.Fatal("the draw offset invalidates the cached line geometry")
+	}
+
+	l.EndPos.Offset.X = 20
+	l.Draw(dst)
+	if l.geom == geom {
+		t.Fatal("the endpoint change doesn't invalidate the cached line geometry")
+	}
+}
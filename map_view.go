@@ -0,0 +1,402 @@
+package graphics
+
+import (
+	"math"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+	"github.com/quasilyte/gmath"
+)
+
+// MapViewConfig describes the [MapView] appearance.
+type MapViewConfig struct {
+	// Image is a map texture.
+	// A tile map can be pre-rendered into an image to be used here.
+	// The map world coordinates are the image pixel coordinates.
+	Image *ebiten.Image
+
+	// Viewport is a map rendering area (in screen coordinates).
+	// Everything outside of this rect is clipped.
+	// A zero value means the entire window.
+	Viewport gmath.Rect
+
+	// PinScale is a pin images scaling factor.
+	// The pins are not affected by the zoom,
+	// so they're always readable.
+	// A zero value means 1.
+	PinScale float64
+
+	// RegionColorScale is a fill color of the highlighted regions.
+	// A zero value means a half-transparent yellow color.
+	RegionColorScale ColorScale
+
+	// Fog enables the explored-area mask.
+	// The entire map is covered by the fog until it's revealed (see Reveal).
+	Fog bool
+
+	// FogColorScale is a color of the unexplored areas.
+	// A zero value means black.
+	FogColorScale ColorScale
+
+	// FogCellSize is a number of the map pixels per one fog mask pixel.
+	// The mask is drawn with a linear filter, so the larger values
+	// produce softer fog edges and use less memory.
+	// A zero value means 4.
+	FogCellSize int
+}
+
+// MapView renders a world map that can be panned and zoomed.
+//
+// The map is rendered through an internal [Camera] (see GetCamera);
+// the camera bounds are set to the map image size,
+// so it can't be panned outside of the map.
+//
+// The map can have the pin markers (see AddPin), the highlighted
+// polygon regions (see AddRegion) and an optional fog of war
+// that hides the unexplored areas (see MapViewConfig.Fog).
+//
+// MapView implements gscene Graphics interface.
+type MapView struct {
+	config MapViewConfig
+
+	camera *Camera
+
+	pins    []*MapPin
+	regions []*MapRegion
+
+	fog *ebiten.Image
+
+	regionColor ebiten.ColorScale
+
+	visible  bool
+	disposed bool
+}
+
+// MapPin is a map marker created by [MapView.AddPin].
+type MapPin struct {
+	// Pos is a pin location in the map world coordinates.
+	// The pin image is centered around it.
+	Pos gmath.Vec
+
+	image *ebiten.Image
+
+	visible  bool
+	disposed bool
+}
+
+// MapRegion is a map area created by [MapView.AddRegion].
+type MapRegion struct {
+	points  []gmath.Vec
+	indices []uint16
+
+	highlighted bool
+}
+
+// NewMapView creates a map view with the specified config.
+//
+// It's advised to only call this function after Ebitengine game has already started.
+func NewMapView(config MapViewConfig) *MapView {
+	if config.Image == nil {
+		panic("MapViewConfig.Image can't be nil")
+	}
+	if config.PinScale == 0 {
+		config.PinScale = 1
+	}
+	if config.RegionColorScale == (ColorScale{}) {
+		config.RegionColorScale = ColorScale{R: 1, G: 0.9, B: 0.3, A: 0.4}
+	}
+	if config.FogCellSize == 0 {
+		config.FogCellSize = 4
+	}
+	config.FogColorScale.A = 1
+
+	camera := NewCamera()
+	if !config.Viewport.IsZero() {
+		camera.SetViewportRect(config.Viewport)
+	}
+	bounds := config.Image.Bounds()
+	camera.SetBounds(gmath.Rect{
+		Max: gmath.Vec{X: float64(bounds.Dx()), Y: float64(bounds.Dy())},
+	})
+
+	m := &MapView{
+		config:      config,
+		camera:      camera,
+		regionColor: config.RegionColorScale.ToEbitenColorScale(),
+		visible:     true,
+	}
+	if config.Fog {
+		cell := config.FogCellSize
+		m.fog = ebiten.NewImage((bounds.Dx()+cell-1)/cell, (bounds.Dy()+cell-1)/cell)
+		m.ResetFog()
+	}
+	return m
+}
+
+// GetCamera returns the map camera.
+// Use its methods like Pan and SetZoom to navigate the map.
+func (m *MapView) GetCamera() *Camera { return m.camera }
+
+// AddPin adds a visible marker at the specified map world position.
+func (m *MapView) AddPin(pos gmath.Vec, img *ebiten.Image) *MapPin {
+	p := &MapPin{Pos: pos, image: img, visible: true}
+	m.pins = append(m.pins, p)
+	return p
+}
+
+// AddRegion adds a polygon area.
+// The points are in the map world coordinates.
+// The points slice is copied, so it can be re-used by the caller.
+//
+// The regions are only rendered while they're highlighted.
+func (m *MapView) AddRegion(points []gmath.Vec) *MapRegion {
+	r := &MapRegion{points: slices.Clone(points)}
+	if len(points) >= 3 {
+		r.indices = triangulatePolygon(nil, r.points)
+	}
+	m.regions = append(m.regions, r)
+	return r
+}
+
+// PinAt returns a visible pin located at the screen position.
+// It returns nil if there is no such pin.
+func (m *MapView) PinAt(screenPos gmath.Vec) *MapPin {
+	if !m.camera.GetViewportRect().Contains(screenPos) {
+		return nil
+	}
+	// The pins drawn later are on top, so they're checked first.
+	for i := len(m.pins) - 1; i >= 0; i-- {
+		p := m.pins[i]
+		if !p.visible || p.disposed || p.image == nil {
+			continue
+		}
+		if m.pinRect(p, m.camera.WorldToScreen(p.Pos)).Contains(screenPos) {
+			return p
+		}
+	}
+	return nil
+}
+
+// RegionAt returns a region that contains the screen position.
+// It returns nil if there is no such region.
+func (m *MapView) RegionAt(screenPos gmath.Vec) *MapRegion {
+	if !m.camera.GetViewportRect().Contains(screenPos) {
+		return nil
+	}
+	pos := m.camera.ScreenToWorld(screenPos)
+	for _, r := range m.regions {
+		if r.Contains(pos) {
+			return r
+		}
+	}
+	return nil
+}
+
+// Reveal clears the fog in a circle area.
+// The center is in the map world coordinates.
+//
+// It's a no-op if the fog is disabled.
+func (m *MapView) Reveal(center gmath.Vec, radius float64) {
+	if m.fog == nil {
+		return
+	}
+	k := 1 / float64(m.config.FogCellSize)
+	// Only the source alpha matters for the destination-out blending.
+	cs := defaultColorScale.ToEbitenColorScale()
+	drawEllipse(m.fog, &ebiten.BlendDestinationOut, center.Mulf(k), radius*k, radius*k, cs)
+}
+
+// ResetFog covers the entire map by the fog again.
+//
+// It's a no-op if the fog is disabled.
+func (m *MapView) ResetFog() {
+	if m.fog == nil {
+		return
+	}
+	fillImage(m.fog, m.config.FogColorScale.Color())
+}
+
+func (m *MapView) pinRect(p *MapPin, screenPos gmath.Vec) gmath.Rect {
+	bounds := p.image.Bounds()
+	half := gmath.Vec{
+		X: float64(bounds.Dx()) * m.config.PinScale * 0.5,
+		Y: float64(bounds.Dy()) * m.config.PinScale * 0.5,
+	}
+	return gmath.Rect{Min: screenPos.Sub(half), Max: screenPos.Add(half)}
+}
+
+// IsVisible reports whether this pin is visible.
+// Use SetVisibility to change this flag value.
+func (p *MapPin) IsVisible() bool { return p.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (p *MapPin) SetVisibility(visible bool) { p.visible = visible }
+
+// Dispose removes this pin from the map.
+func (p *MapPin) Dispose() { p.disposed = true }
+
+// IsHighlighted reports whether this region is highlighted.
+func (r *MapRegion) IsHighlighted() bool { return r.highlighted }
+
+// SetHighlighted changes the region highlight state.
+func (r *MapRegion) SetHighlighted(highlighted bool) { r.highlighted = highlighted }
+
+// Contains reports whether the region polygon contains the map world position.
+func (r *MapRegion) Contains(pos gmath.Vec) bool {
+	// A ray casting algorithm: count the edges crossed
+	// by a horizontal ray that starts at pos.
+	inside := false
+	points := r.points
+	for i, j := 0, len(points)-1; i < len(points); j, i = i, i+1 {
+		a := points[i]
+		b := points[j]
+		if (a.Y > pos.Y) != (b.Y > pos.Y) {
+			x := a.X + (pos.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y)
+			if pos.X < x {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+// BoundsRect returns the map viewport rect.
+func (m *MapView) BoundsRect() gmath.Rect {
+	return m.camera.GetViewportRect()
+}
+
+// Dispose marks this map for deletion and releases its fog image.
+// After calling this method, IsDisposed will report true.
+func (m *MapView) Dispose() {
+	if m.fog != nil {
+		m.fog.Deallocate()
+		m.fog = nil
+	}
+	m.disposed = true
+}
+
+// IsDisposed reports whether this map is marked for deletion.
+func (m *MapView) IsDisposed() bool { return m.disposed }
+
+// IsVisible reports whether this map is visible.
+// Use SetVisibility to change this flag value.
+func (m *MapView) IsVisible() bool { return m.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (m *MapView) SetVisibility(visible bool) { m.visible = visible }
+
+// Draw renders the map onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (m *MapView) Draw(dst *ebiten.Image) {
+	m.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the map onto the provided dst image
+// while also using the extra provided offset.
+func (m *MapView) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	livePins := m.pins[:0]
+	for _, p := range m.pins {
+		if p.disposed {
+			continue
+		}
+		livePins = append(livePins, p)
+	}
+	clear(m.pins[len(livePins):])
+	m.pins = livePins
+
+	if !m.visible {
+		return
+	}
+
+	dst = clipImage(dst, m.camera.GetViewportRect().Add(opts.Offset))
+	if dst == nil {
+		return
+	}
+
+	geom := m.camera.GetGeoM()
+	geom.Translate(opts.Offset.X, opts.Offset.Y)
+
+	var drawOptions ebiten.DrawImageOptions
+	if opts.Blend != nil {
+		drawOptions.Blend = *opts.Blend
+	}
+	drawOptions.Filter = ebiten.FilterLinear
+	drawOptions.GeoM = geom
+	drawImage(dst, m.config.Image, &drawOptions)
+
+	m.drawRegions(dst, opts.Blend, geom)
+
+	if m.fog != nil {
+		cell := float64(m.config.FogCellSize)
+		drawOptions.GeoM.Reset()
+		drawOptions.GeoM.Scale(cell, cell)
+		drawOptions.GeoM.Concat(geom)
+		drawImage(dst, m.fog, &drawOptions)
+	}
+
+	for _, p := range m.pins {
+		if !p.visible || p.image == nil {
+			continue
+		}
+		screenPos := m.camera.WorldToScreen(p.Pos).Add(opts.Offset)
+		pos := m.pinRect(p, screenPos).Min
+		drawOptions.GeoM.Reset()
+		drawOptions.GeoM.Scale(m.config.PinScale, m.config.PinScale)
+		drawOptions.GeoM.Translate(math.Round(pos.X), math.Round(pos.Y))
+		drawImage(dst, p.image, &drawOptions)
+	}
+}
+
+// drawRegions renders all highlighted regions in a single draw call.
+func (m *MapView) drawRegions(dst *ebiten.Image, blend *ebiten.Blend, geom ebiten.GeoM) {
+	vertices := cache.Global.ScratchVertices[:0]
+	indices := cache.Global.ScratchIndices[:0]
+	defer func() {
+		cache.Global.ScratchVertices = vertices[:0]
+		cache.Global.ScratchIndices = indices[:0]
+	}()
+
+	var drawOptions ebiten.DrawTrianglesOptions
+	if blend != nil {
+		drawOptions.Blend = *blend
+	}
+
+	cs := m.regionColor
+	for _, r := range m.regions {
+		if !r.highlighted || len(r.indices) == 0 {
+			continue
+		}
+		if len(vertices)+len(r.points) > math.MaxUint16 {
+			drawVertexColorTriangles(dst, vertices, indices, emptyImage, &drawOptions)
+			vertices = vertices[:0]
+			indices = indices[:0]
+		}
+		base := uint16(len(vertices))
+		for _, pt := range r.points {
+			x, y := geom.Apply(pt.X, pt.Y)
+			vertices = append(vertices, ebiten.Vertex{
+				DstX:   float32(x),
+				DstY:   float32(y),
+				SrcX:   1.5,
+				SrcY:   1.5,
+				ColorR: cs.R(),
+				ColorG: cs.G(),
+				ColorB: cs.B(),
+				ColorA: cs.A(),
+			})
+		}
+		for _, i := range r.indices {
+			indices = append(indices, base+i)
+		}
+	}
+	if len(indices) != 0 {
+		drawVertexColorTriangles(dst, vertices, indices, emptyImage, &drawOptions)
+	}
+}
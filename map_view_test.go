@@ -0,0 +1,85 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+func TestMapViewPicking(t *testing.T) {
+	m := NewMapView(MapViewConfig{
+		Image: ebiten.NewImage(400, 300),
+		Viewport: gmath.Rect{
+			Min: gmath.Vec{X: 100, Y: 100},
+			Max: gmath.Vec{X: 300, Y: 200},
+		},
+		Fog: true,
+	})
+	pin := m.AddPin(gmath.Vec{X: 20, Y: 20}, ebiten.NewImage(10, 10))
+	region := m.AddRegion([]gmath.Vec{
+		{X: 50, Y: 0},
+		{X: 100, Y: 0},
+		{X: 100, Y: 50},
+		{X: 50, Y: 50},
+	})
+
+	// The map world origin is located at the viewport top-left corner.
+	if have := m.PinAt(gmath.Vec{X: 124, Y: 116}); have != pin {
+		t.Fatalf("PinAt(124, 116): have %p, want %p", have, pin)
+	}
+	if have := m.PinAt(gmath.Vec{X: 130, Y: 120}); have != nil {
+		t.Fatalf("PinAt(130, 120): have %p, want nil", have)
+	}
+	if have := m.RegionAt(gmath.Vec{X: 175, Y: 125}); have != region {
+		t.Fatalf("RegionAt(175, 125): have %p, want %p", have, region)
+	}
+	if have := m.RegionAt(gmath.Vec{X: 125, Y: 125}); have != nil {
+		t.Fatalf("RegionAt(125, 125): have %p, want nil", have)
+	}
+
+	m.GetCamera().Pan(gmath.Vec{X: 10, Y: 10})
+	if have := m.PinAt(gmath.Vec{X: 110, Y: 110}); have != pin {
+		t.Fatalf("PinAt after pan: have %p, want %p", have, pin)
+	}
+	pin.SetVisibility(false)
+	if have := m.PinAt(gmath.Vec{X: 110, Y: 110}); have != nil {
+		t.Fatal("the invisible pins should be ignored")
+	}
+
+	region.SetHighlighted(true)
+	m.Reveal(gmath.Vec{X: 50, Y: 50}, 40)
+	m.Draw(ebiten.NewImage(320, 240))
+}
+
+func TestMapRegionContains(t *testing.T) {
+	// A concave U-shaped region.
+	r := &MapRegion{
+		points: []gmath.Vec{
+			{X: 0, Y: 0},
+			{X: 10, Y: 0},
+			{X: 10, Y: 30},
+			{X: 20, Y: 30},
+			{X: 20, Y: 0},
+			{X: 30, Y: 0},
+			{X: 30, Y: 40},
+			{X: 0, Y: 40},
+		},
+	}
+	tests := []struct {
+		pos  gmath.Vec
+		want bool
+	}{
+		{gmath.Vec{X: 5, Y: 5}, true},
+		{gmath.Vec{X: 25, Y: 5}, true},
+		{gmath.Vec{X: 15, Y: 35}, true},
+		{gmath.Vec{X: 15, Y: 5}, false},
+		{gmath.Vec{X: 35, Y: 5}, false},
+		{gmath.Vec{X: 5, Y: 45}, false},
+	}
+	for _, test := range tests {
+		if have := r.Contains(test.pos); have != test.want {
+			t.Fatalf("Contains(%v): have %v, want %v", test.pos, have, test.want)
+		}
+	}
+}
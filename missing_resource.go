@@ -0,0 +1,190 @@
+package graphics
+
+import (
+	"image"
+	"image/color"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"golang.org/x/image/font/basicfont"
+)
+
+// ResourceKind describes the [MissingResourceError] resource type.
+type ResourceKind uint8
+
+const (
+	ResourceImage ResourceKind = iota
+	ResourceFont
+)
+
+func (k ResourceKind) String() string {
+	switch k {
+	case ResourceImage:
+		return "image"
+	case ResourceFont:
+		return "font"
+	default:
+		return "resource"
+	}
+}
+
+// MissingResourceError is reported to the registered error handler
+// when a placeholder is used instead of a missing resource.
+// See [SetErrorHandler].
+type MissingResourceError struct {
+	Kind ResourceKind
+
+	// Key is a resource identifier as it was provided by the caller.
+	// It can be empty.
+	Key string
+}
+
+func (e *MissingResourceError) Error() string {
+	if e.Key == "" {
+		return "missing " + e.Kind.String()
+	}
+	return "missing " + e.Kind.String() + " " + e.Key
+}
+
+var (
+	errorHandler atomic.Pointer[func(err error)]
+
+	// missingMu guards missingImages and missingReported,
+	// as the placeholders can be requested by the background loading goroutines.
+	missingMu       sync.Mutex
+	missingImages   map[missingImageKey]*ebiten.Image
+	missingReported map[MissingResourceError]struct{}
+
+	missingFontFace = sync.OnceValue(func() text.Face {
+		return text.NewGoXFace(basicfont.Face7x13)
+	})
+)
+
+// missingImageSize is a placeholder size that is used
+// when there is no better size to pick.
+const missingImageSize = 16
+
+type missingImageKey struct {
+	key    string
+	width  int
+	height int
+}
+
+// SetErrorHandler registers a function that is called for
+// the recoverable rendering errors, like the missing resources.
+// A nil handler (the default) ignores such errors.
+//
+// The handler is called synchronously, so it should not block.
+// It can be called from any goroutine that uses the
+// functions like [MissingImage], so it should be goroutine-safe.
+func SetErrorHandler(h func(err error)) {
+	if h == nil {
+		errorHandler.Store(nil)
+		return
+	}
+	errorHandler.Store(&h)
+}
+
+func getErrorHandler() func(err error) {
+	if h := errorHandler.Load(); h != nil {
+		return *h
+	}
+	return nil
+}
+
+func reportError(err error) {
+	if h := getErrorHandler(); h != nil {
+		h(err)
+	}
+}
+
+// reportMissing reports the missing resource error
+// unless it was already reported for the same kind and key.
+func reportMissing(kind ResourceKind, key string) {
+	e := MissingResourceError{Kind: kind, Key: key}
+
+	missingMu.Lock()
+	_, reported := missingReported[e]
+	if !reported {
+		if missingReported == nil {
+			missingReported = make(map[MissingResourceError]struct{}, 4)
+		}
+		missingReported[e] = struct{}{}
+	}
+	missingMu.Unlock()
+
+	// The handler is called without holding the lock,
+	// so it can request other placeholders.
+	if !reported {
+		reportError(&e)
+	}
+}
+
+// MissingImage returns a placeholder image that should be used
+// instead of a resource that can't be found.
+// The placeholder is a magenta checkerboard with the key printed on it,
+// so it's clearly visible.
+// The error is reported to the error handler (see [SetErrorHandler])
+// only once per key, so calling this function in a loop doesn't spam the handler.
+//
+// The placeholders are cached, so calling this function in a loop
+// with the same arguments doesn't allocate new images.
+//
+// This function can be called from any goroutine.
+func MissingImage(key string, width, height int) *ebiten.Image {
+	width = max(width, 1)
+	height = max(height, 1)
+	k := missingImageKey{key: key, width: width, height: height}
+
+	missingMu.Lock()
+	img, ok := missingImages[k]
+	if !ok {
+		img = newMissingImage(key, width, height)
+		if missingImages == nil {
+			missingImages = make(map[missingImageKey]*ebiten.Image, 4)
+		}
+		missingImages[k] = img
+	}
+	missingMu.Unlock()
+
+	reportMissing(ResourceImage, key)
+	return img
+}
+
+func newMissingImage(key string, width, height int) *ebiten.Image {
+	const cellSize = 8
+	magenta := color.RGBA{R: 0xff, B: 0xff, A: 0xff}
+	black := color.RGBA{A: 0xff}
+	pixels := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := black
+			if (x/cellSize+y/cellSize)%2 == 0 {
+				c = magenta
+			}
+			pixels.SetRGBA(x, y, c)
+		}
+	}
+	img := ebiten.NewImageFromImage(pixels)
+	if key != "" {
+		ebitenutil.DebugPrintAt(img, key, 1, 1)
+	}
+	return img
+}
+
+// MissingFontFace returns a placeholder font face that should be used
+// instead of a font that can't be found.
+// The placeholder is a built-in 7x13 bitmap font.
+// The error is reported to the error handler (see [SetErrorHandler])
+// only once per key, the same way [MissingImage] does it.
+//
+// [NewLabel] uses this face when it's called with a nil face.
+//
+// This function can be called from any goroutine.
+func MissingFontFace(key string) text.Face {
+	reportMissing(ResourceFont, key)
+	return missingFontFace()
+}
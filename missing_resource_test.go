@@ -0,0 +1,60 @@
+package graphics_test
+
+import (
+	"errors"
+	"testing"
+
+	graphics "github.com/quasilyte/ebitengine-graphics"
+)
+
+func TestMissingFontFace(t *testing.T) {
+	var reported []error
+	graphics.SetErrorHandler(func(err error) {
+		reported = append(reported, err)
+	})
+	defer graphics.SetErrorHandler(nil)
+
+	// A nil face should not cause a panic.
+	graphics.NewLabel(nil)
+	_ = graphics.MissingFontFace("fonts/main.ttf")
+
+	if len(reported) != 2 {
+		t.Fatalf("reported errors:\nhave: %d\nwant: 2", len(reported))
+	}
+	var missing *graphics.MissingResourceError
+	if !errors.As(reported[1], &missing) || missing.Kind != graphics.ResourceFont {
+		t.Fatalf("unexpected error: %v", reported[1])
+	}
+	if have, want := reported[1].Error(), "missing font fonts/main.ttf"; have != want {
+		t.Fatalf("error message:\nhave: %q\nwant: %q", have, want)
+	}
+}
+
+func TestMissingResourceReportedOnce(t *testing.T) {
+	var reported []error
+	graphics.SetErrorHandler(func(err error) {
+		reported = append(reported, err)
+	})
+	defer graphics.SetErrorHandler(nil)
+
+	for i := 0; i < 3; i++ {
+		_ = graphics.MissingFontFace("fonts/once.ttf")
+		_ = graphics.MissingImage("images/once.png", 8, 8)
+		_ = graphics.MissingImage("images/once.png", 16, 16)
+	}
+	if len(reported) != 2 {
+		t.Fatalf("reported errors:\nhave: %d\nwant: 2", len(reported))
+	}
+}
+
+func TestSpriteSetNilImage(t *testing.T) {
+	s := graphics.NewSprite()
+	// A nil image should not cause a panic.
+	s.SetImage(nil)
+	if s.GetImage() == nil {
+		t.Fatal("the placeholder image is not assigned")
+	}
+	if have := s.GetFrameWidth(); have != 16 {
+		t.Fatalf("frame width:\nhave: %d\nwant: 16", have)
+	}
+}
@@ -0,0 +1,316 @@
+package graphics
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+	"github.com/quasilyte/gmath"
+)
+
+// NineSliceMode selects how the [NineSlice] edges and center are resized.
+type NineSliceMode uint8
+
+const (
+	// NineSliceStretch stretches the edges and the center parts.
+	// This is a default mode.
+	NineSliceStretch NineSliceMode = iota
+
+	// NineSliceTile repeats the edges and the center parts.
+	// The last tiles are cropped to fit the size.
+	//
+	// This mode works well for the patterned borders
+	// that look wrong when stretched.
+	// Every tile is a separate quad, so avoid the tiny
+	// source parts for the large objects.
+	NineSliceTile
+)
+
+// NineSliceInsets describe the [NineSlice] source image borders.
+// Every value is a border size in pixels.
+type NineSliceInsets struct {
+	Left   int
+	Top    int
+	Right  int
+	Bottom int
+}
+
+// NineSlice renders an image at an arbitrary size while keeping its borders intact.
+// It's also known as a 9-patch image.
+//
+// The source image is split into 9 parts by the insets:
+// the corners are never scaled, the edges are resized along
+// one axis and the center is resized along both of them.
+// See [NineSliceMode] for the resize options.
+//
+// This is a standard way to render the scalable UI panels and buttons.
+// The entire object is rendered in a single draw call.
+//
+// The Pos is a top-left corner of the object, like with a [Label].
+type NineSlice struct {
+	Pos gmath.Pos
+
+	image  *ebiten.Image
+	insets NineSliceInsets
+
+	width  float64
+	height float64
+
+	colorScale       ColorScale
+	ebitenColorScale ebiten.ColorScale
+
+	visible  bool
+	disposed bool
+	blendID  uint8
+	mode     NineSliceMode
+}
+
+// NewNineSlice returns a nine-slice object with the specified image and insets.
+// The initial object size is equal to the image size.
+//
+// The image can be a sub-image (like an atlas frame).
+func NewNineSlice(img *ebiten.Image, insets NineSliceInsets) *NineSlice {
+	bounds := img.Bounds()
+	if insets.Left+insets.Right > bounds.Dx() || insets.Top+insets.Bottom > bounds.Dy() {
+		panic("nine-slice insets are larger than the image")
+	}
+	return &NineSlice{
+		image:            img,
+		insets:           insets,
+		width:            float64(bounds.Dx()),
+		height:           float64(bounds.Dy()),
+		colorScale:       defaultColorScale,
+		ebitenColorScale: defaultColorScale.ToEbitenColorScale(),
+		visible:          true,
+	}
+}
+
+// GetImage returns the current source image.
+func (s *NineSlice) GetImage() *ebiten.Image { return s.image }
+
+// GetInsets returns the current source image insets.
+func (s *NineSlice) GetInsets() NineSliceInsets { return s.insets }
+
+// GetSize reports the current object size.
+// Use SetSize to change it.
+func (s *NineSlice) GetSize() (width, height float64) {
+	return s.width, s.height
+}
+
+// SetSize changes the object size.
+//
+// If the size is smaller than the combined insets,
+// the corners are scaled down to fit.
+func (s *NineSlice) SetSize(width, height float64) {
+	s.width = width
+	s.height = height
+}
+
+// GetMode reports the current edges and center resize mode.
+// Use SetMode to change it.
+func (s *NineSlice) GetMode() NineSliceMode { return s.mode }
+
+// SetMode changes the edges and center resize mode.
+func (s *NineSlice) SetMode(mode NineSliceMode) { s.mode = mode }
+
+// GetColorScale is used to retrieve the current color scale value of the object.
+// Use SetColorScale to change it.
+func (s *NineSlice) GetColorScale() ColorScale {
+	return s.colorScale
+}
+
+// SetColorScale assigns a new ColorScale to this object.
+// Use GetColorScale to retrieve the current color scale.
+func (s *NineSlice) SetColorScale(cs ColorScale) {
+	if s.colorScale == cs {
+		return
+	}
+	s.colorScale = cs
+	s.ebitenColorScale = cs.ToEbitenColorScale()
+}
+
+// BoundsRect returns the object bounding rectangle.
+//
+// This is useful when trying to calculate whether this object is contained
+// inside some area or not (like a camera view area).
+func (s *NineSlice) BoundsRect() gmath.Rect {
+	pos := s.Pos.Resolve()
+	return gmath.Rect{
+		Min: pos,
+		Max: pos.Add(gmath.Vec{X: s.width, Y: s.height}),
+	}
+}
+
+// Dispose marks this object for deletion.
+// After calling this method, IsDisposed will report true.
+func (s *NineSlice) Dispose() {
+	s.disposed = true
+}
+
+// IsDisposed reports whether this object is marked for deletion.
+// IsDisposed returns true only after Disposed was called on this object.
+func (s *NineSlice) IsDisposed() bool {
+	return s.disposed
+}
+
+// IsVisible reports whether this object is visible.
+// Use SetVisibility to change this flag value.
+//
+// When object is invisible (visible=false), it will not be rendered at all.
+// This is an efficient way to temporarily hide an object.
+func (s *NineSlice) IsVisible() bool { return s.visible }
+
+// SetVisibility changes the Visible flag value.
+// It can be used to show or hide the object.
+// Use IsVisible to get the current flag value.
+func (s *NineSlice) SetVisibility(visible bool) { s.visible = visible }
+
+// Draw renders the object onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (s *NineSlice) Draw(dst *ebiten.Image) {
+	s.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the object onto the provided dst image
+// while also using the extra provided offset and other options.
+func (s *NineSlice) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !s.visible || s.colorScale.A == 0 || s.width <= 0 || s.height <= 0 {
+		return
+	}
+
+	opts.Blend = resolveBlend(s.blendID, opts.Blend)
+
+	vertices := cache.Global.ScratchVertices[:0]
+	indices := cache.Global.ScratchIndices[:0]
+	defer func() {
+		cache.Global.ScratchVertices = vertices[:0]
+		cache.Global.ScratchIndices = indices[:0]
+	}()
+
+	pos := s.Pos.Resolve().Add(opts.Offset)
+	cols := nineSliceSpans(float64(s.image.Bounds().Min.X), float64(s.image.Bounds().Dx()), float64(s.insets.Left), float64(s.insets.Right), pos.X, s.width)
+	rows := nineSliceSpans(float64(s.image.Bounds().Min.Y), float64(s.image.Bounds().Dy()), float64(s.insets.Top), float64(s.insets.Bottom), pos.Y, s.height)
+
+	cs := s.ebitenColorScale
+	for i, row := range rows {
+		for j, col := range cols {
+			if s.mode == NineSliceTile && (i == 1 || j == 1) {
+				vertices, indices = appendTiledQuads(vertices, indices, col, row, j == 1, i == 1, cs)
+				continue
+			}
+			vertices, indices = appendTexturedQuad(vertices, indices, col, row, cs)
+		}
+	}
+
+	var drawOptions ebiten.DrawTrianglesOptions
+	if opts.Blend != nil {
+		drawOptions.Blend = *opts.Blend
+	}
+	drawVertexColorTriangles(dst, vertices, indices, s.image, &drawOptions)
+}
+
+// nineSliceSpan maps a source image range to a destination range along one axis.
+type nineSliceSpan struct {
+	srcFrom float64
+	srcTo   float64
+	dstFrom float64
+	dstTo   float64
+}
+
+// nineSliceSpans splits a single axis into the start, middle and end spans.
+func nineSliceSpans(srcMin, srcSize, insetStart, insetEnd, dstMin, dstSize float64) [3]nineSliceSpan {
+	dstStart := insetStart
+	dstEnd := insetEnd
+	if insets := insetStart + insetEnd; insets > dstSize {
+		// There is no room for the middle part,
+		// scale the corners down proportionally.
+		k := dstSize / insets
+		dstStart *= k
+		dstEnd *= k
+	}
+	srcMax := srcMin + srcSize
+	dstMax := dstMin + dstSize
+	return [3]nineSliceSpan{
+		{srcFrom: srcMin, srcTo: srcMin + insetStart, dstFrom: dstMin, dstTo: dstMin + dstStart},
+		{srcFrom: srcMin + insetStart, srcTo: srcMax - insetEnd, dstFrom: dstMin + dstStart, dstTo: dstMax - dstEnd},
+		{srcFrom: srcMax - insetEnd, srcTo: srcMax, dstFrom: dstMax - dstEnd, dstTo: dstMax},
+	}
+}
+
+func appendTexturedQuad(vertices []ebiten.Vertex, indices []uint16, x, y nineSliceSpan, cs ebiten.ColorScale) ([]ebiten.Vertex, []uint16) {
+	if x.dstTo <= x.dstFrom || y.dstTo <= y.dstFrom || x.srcTo <= x.srcFrom || y.srcTo <= y.srcFrom {
+		return vertices, indices
+	}
+	vertex := func(dstX, dstY, srcX, srcY float64) ebiten.Vertex {
+		return ebiten.Vertex{
+			DstX:   float32(dstX),
+			DstY:   float32(dstY),
+			SrcX:   float32(srcX),
+			SrcY:   float32(srcY),
+			ColorR: cs.R(),
+			ColorG: cs.G(),
+			ColorB: cs.B(),
+			ColorA: cs.A(),
+		}
+	}
+	idx := uint16(len(vertices))
+	vertices = append(vertices,
+		vertex(x.dstFrom, y.dstFrom, x.srcFrom, y.srcFrom),
+		vertex(x.dstTo, y.dstFrom, x.srcTo, y.srcFrom),
+		vertex(x.dstFrom, y.dstTo, x.srcFrom, y.srcTo),
+		vertex(x.dstTo, y.dstTo, x.srcTo, y.srcTo),
+	)
+	indices = append(indices,
+		idx+0, idx+1, idx+2,
+		idx+1, idx+2, idx+3,
+	)
+	return vertices, indices
+}
+
+// appendTiledQuads fills the destination rect with the source rect copies.
+// Only the axes with the tile flag set are tiled, the other axis is stretched.
+func appendTiledQuads(vertices []ebiten.Vertex, indices []uint16, x, y nineSliceSpan, tileX, tileY bool, cs ebiten.ColorScale) ([]ebiten.Vertex, []uint16) {
+	forEachTile := func(span nineSliceSpan, tile bool, f func(span nineSliceSpan)) {
+		srcSize := span.srcTo - span.srcFrom
+		if !tile || srcSize <= 0 {
+			f(span)
+			return
+		}
+		for from := span.dstFrom; from < span.dstTo; from += srcSize {
+			size := min(srcSize, span.dstTo-from)
+			f(nineSliceSpan{
+				srcFrom: span.srcFrom,
+				srcTo:   span.srcFrom + size,
+				dstFrom: from,
+				dstTo:   from + size,
+			})
+		}
+	}
+	forEachTile(y, tileY, func(y nineSliceSpan) {
+		forEachTile(x, tileX, func(x nineSliceSpan) {
+			vertices, indices = appendTexturedQuad(vertices, indices, x, y, cs)
+		})
+	})
+	return vertices, indices
+}
+
+// GetBlend returns the blend mode assigned by SetBlend.
+// The second result value is false if there is no blend override.
+func (s *NineSlice) GetBlend() (ebiten.Blend, bool) {
+	return getBlend(s.blendID)
+}
+
+// SetBlend assigns a blend mode that is used to render this object.
+// It takes priority over the DrawOptions.Blend value.
+// Use ResetBlend to remove the override.
+func (s *NineSlice) SetBlend(b ebiten.Blend) {
+	s.blendID = internBlend(b)
+}
+
+// ResetBlend removes the blend mode override.
+// The DrawOptions.Blend value (if any) will be used again.
+func (s *NineSlice) ResetBlend() {
+	s.blendID = 0
+}
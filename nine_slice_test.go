@@ -0,0 +1,38 @@
+package graphics
+
+import "testing"
+
+func TestNineSliceSpans(t *testing.T) {
+	tests := []struct {
+		name    string
+		dstSize float64
+		want    [3]nineSliceSpan
+	}{
+		{
+			name:    "grow",
+			dstSize: 100,
+			want: [3]nineSliceSpan{
+				{srcFrom: 10, srcTo: 14, dstFrom: 50, dstTo: 54},
+				{srcFrom: 14, srcTo: 24, dstFrom: 54, dstTo: 148},
+				{srcFrom: 24, srcTo: 26, dstFrom: 148, dstTo: 150},
+			},
+		},
+		{
+			name:    "shrink",
+			dstSize: 3,
+			want: [3]nineSliceSpan{
+				{srcFrom: 10, srcTo: 14, dstFrom: 50, dstTo: 52},
+				{srcFrom: 14, srcTo: 24, dstFrom: 52, dstTo: 52},
+				{srcFrom: 24, srcTo: 26, dstFrom: 52, dstTo: 53},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			have := nineSliceSpans(10, 16, 4, 2, 50, test.dstSize)
+			if have != test.want {
+				t.Fatalf("spans:\nhave: %+v\nwant: %+v", have, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,293 @@
+package graphics
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// NodeState is a [GraphNode] progression state.
+type NodeState uint8
+
+const (
+	NodeLocked NodeState = iota
+	NodeAvailable
+	NodeUnlocked
+)
+
+// NodeGraphConfig describes the [NodeGraph] appearance.
+type NodeGraphConfig struct {
+	// NodeSize is a size of a single (square) node.
+	// The icon and frame images are scaled to fit it.
+	// A zero value means 32.
+	NodeSize float64
+
+	// Frame is an optional image that is drawn behind every node icon.
+	Frame *ebiten.Image
+
+	// LockedColorScale, AvailableColorScale and UnlockedColorScale
+	// are the node tints for each of the node states.
+	// Zero values mean {0.4, 0.4, 0.4, 1}, {1, 1, 1, 1} and {1, 0.85, 0.4, 1}.
+	LockedColorScale    ColorScale
+	AvailableColorScale ColorScale
+	UnlockedColorScale  ColorScale
+
+	// EdgeWidth is a connection line width (at zoom=1).
+	// A zero value means 2.
+	EdgeWidth float64
+
+	// LockedEdgeColorScale is used for the connections that
+	// have at least one locked or available node.
+	// A zero value means {0.4, 0.4, 0.4, 1}.
+	LockedEdgeColorScale ColorScale
+
+	// UnlockedEdgeColorScale is used for the connections
+	// between two unlocked nodes.
+	// A zero value means {1, 0.85, 0.4, 1}.
+	UnlockedEdgeColorScale ColorScale
+
+	// EdgeCurvature bends the connections into the arcs.
+	// It's a control point offset relative to the connection length.
+	// A zero value means straight lines.
+	EdgeCurvature float64
+
+	// Viewport is a graph rendering area (in screen coordinates).
+	// Everything outside of this rect is clipped.
+	// A zero value means the entire window.
+	Viewport gmath.Rect
+}
+
+// NodeGraph renders a graph of the icon nodes and their connections.
+// It's useful for the skill trees, tech trees and the world maps.
+//
+// The node positions are in the graph world coordinates;
+// they're rendered through an internal [Camera], so the graph
+// can be panned and zoomed (see GetCamera).
+// The mouse picking can be done with NodeAt.
+//
+// NodeGraph implements gscene Graphics interface.
+type NodeGraph struct {
+	config NodeGraphConfig
+
+	camera *Camera
+
+	nodes []*GraphNode
+	edges []graphEdge
+
+	visible  bool
+	disposed bool
+}
+
+// GraphNode is a single node created by [NodeGraph.AddNode].
+type GraphNode struct {
+	Pos gmath.Vec
+
+	icon  *ebiten.Image
+	state NodeState
+}
+
+type graphEdge struct {
+	from *GraphNode
+	to   *GraphNode
+}
+
+// NewNodeGraph creates an empty graph with the specified config.
+//
+// It's advised to only call this function after Ebitengine game has already started.
+func NewNodeGraph(config NodeGraphConfig) *NodeGraph {
+	if config.NodeSize == 0 {
+		config.NodeSize = 32
+	}
+	if config.LockedColorScale == (ColorScale{}) {
+		config.LockedColorScale = ColorScale{R: 0.4, G: 0.4, B: 0.4, A: 1}
+	}
+	if config.AvailableColorScale == (ColorScale{}) {
+		config.AvailableColorScale = defaultColorScale
+	}
+	if config.UnlockedColorScale == (ColorScale{}) {
+		config.UnlockedColorScale = ColorScale{R: 1, G: 0.85, B: 0.4, A: 1}
+	}
+	if config.EdgeWidth == 0 {
+		config.EdgeWidth = 2
+	}
+	if config.LockedEdgeColorScale == (ColorScale{}) {
+		config.LockedEdgeColorScale = config.LockedColorScale
+	}
+	if config.UnlockedEdgeColorScale == (ColorScale{}) {
+		config.UnlockedEdgeColorScale = config.UnlockedColorScale
+	}
+
+	camera := NewCamera()
+	if !config.Viewport.IsZero() {
+		camera.SetViewportRect(config.Viewport)
+	}
+
+	return &NodeGraph{
+		config:  config,
+		camera:  camera,
+		visible: true,
+	}
+}
+
+// GetCamera returns the graph camera.
+// Use its methods like Pan and SetZoom to navigate the graph.
+func (g *NodeGraph) GetCamera() *Camera { return g.camera }
+
+// AddNode adds a locked node at the specified graph world position.
+func (g *NodeGraph) AddNode(pos gmath.Vec, icon *ebiten.Image) *GraphNode {
+	n := &GraphNode{Pos: pos, icon: icon}
+	g.nodes = append(g.nodes, n)
+	return n
+}
+
+// Connect adds a connection between the two nodes.
+// The connections are drawn below the nodes.
+func (g *NodeGraph) Connect(from, to *GraphNode) {
+	g.edges = append(g.edges, graphEdge{from: from, to: to})
+}
+
+// NumNodes reports the number of the graph nodes.
+func (g *NodeGraph) NumNodes() int { return len(g.nodes) }
+
+// NodeAt returns a node located at the screen position.
+// It returns nil if there is no such node.
+func (g *NodeGraph) NodeAt(screenPos gmath.Vec) *GraphNode {
+	if !g.camera.GetViewportRect().Contains(screenPos) {
+		return nil
+	}
+	pos := g.camera.ScreenToWorld(screenPos)
+	half := g.config.NodeSize * 0.5
+	// The nodes drawn later are on top, so they're checked first.
+	for i := len(g.nodes) - 1; i >= 0; i-- {
+		n := g.nodes[i]
+		r := gmath.Rect{
+			Min: n.Pos.Sub(gmath.Vec{X: half, Y: half}),
+			Max: n.Pos.Add(gmath.Vec{X: half, Y: half}),
+		}
+		if r.Contains(pos) {
+			return n
+		}
+	}
+	return nil
+}
+
+// GetState returns the current node state.
+func (n *GraphNode) GetState() NodeState { return n.state }
+
+// SetState changes the node state.
+// The node and its connections are re-tinted accordingly.
+func (n *GraphNode) SetState(state NodeState) { n.state = state }
+
+// BoundsRect returns the graph viewport rect.
+func (g *NodeGraph) BoundsRect() gmath.Rect {
+	return g.camera.GetViewportRect()
+}
+
+// Dispose marks this graph for deletion.
+// After calling this method, IsDisposed will report true.
+func (g *NodeGraph) Dispose() { g.disposed = true }
+
+// IsDisposed reports whether this graph is marked for deletion.
+func (g *NodeGraph) IsDisposed() bool { return g.disposed }
+
+// IsVisible reports whether this graph is visible.
+// Use SetVisibility to change this flag value.
+func (g *NodeGraph) IsVisible() bool { return g.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (g *NodeGraph) SetVisibility(visible bool) { g.visible = visible }
+
+// Draw renders the graph onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (g *NodeGraph) Draw(dst *ebiten.Image) {
+	g.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the graph onto the provided dst image
+// while also using the extra provided offset.
+func (g *NodeGraph) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !g.visible {
+		return
+	}
+
+	dst = clipImage(dst, g.camera.GetViewportRect().Add(opts.Offset))
+	if dst == nil {
+		return
+	}
+
+	zoom := g.camera.GetZoom()
+	toScreen := func(pos gmath.Vec) gmath.Vec {
+		return g.camera.WorldToScreen(pos).Add(opts.Offset)
+	}
+
+	edgeWidth := g.config.EdgeWidth * zoom
+	lockedEdgeColor := g.config.LockedEdgeColorScale.ToEbitenColorScale()
+	unlockedEdgeColor := g.config.UnlockedEdgeColorScale.ToEbitenColorScale()
+	for _, e := range g.edges {
+		cs := lockedEdgeColor
+		if e.from.state == NodeUnlocked && e.to.state == NodeUnlocked {
+			cs = unlockedEdgeColor
+		}
+		from := toScreen(e.from.Pos)
+		to := toScreen(e.to.Pos)
+		if g.config.EdgeCurvature == 0 {
+			drawLine(dst, opts.Blend, from, to, edgeWidth, cs)
+			continue
+		}
+		g.drawCurvedEdge(dst, opts.Blend, from, to, edgeWidth, cs)
+	}
+
+	size := g.config.NodeSize * zoom
+	var drawOptions ebiten.DrawImageOptions
+	if opts.Blend != nil {
+		drawOptions.Blend = *opts.Blend
+	}
+	drawOptions.Filter = ebiten.FilterLinear
+	drawImage := func(img *ebiten.Image, pos gmath.Vec, cs *ebiten.ColorScale) {
+		bounds := img.Bounds()
+		drawOptions.GeoM.Reset()
+		drawOptions.GeoM.Scale(size/float64(bounds.Dx()), size/float64(bounds.Dy()))
+		drawOptions.GeoM.Translate(pos.X, pos.Y)
+		drawOptions.ColorScale = *cs
+		drawImage(dst, img, &drawOptions)
+	}
+
+	var tints [3]ebiten.ColorScale
+	tints[NodeLocked] = g.config.LockedColorScale.ToEbitenColorScale()
+	tints[NodeAvailable] = g.config.AvailableColorScale.ToEbitenColorScale()
+	tints[NodeUnlocked] = g.config.UnlockedColorScale.ToEbitenColorScale()
+	for _, n := range g.nodes {
+		pos := toScreen(n.Pos).Sub(gmath.Vec{X: size * 0.5, Y: size * 0.5})
+		cs := &tints[n.state]
+		if g.config.Frame != nil {
+			drawImage(g.config.Frame, pos, cs)
+		}
+		if n.icon != nil {
+			drawImage(n.icon, pos, cs)
+		}
+	}
+}
+
+func (g *NodeGraph) drawCurvedEdge(dst *ebiten.Image, blend *ebiten.Blend, from, to gmath.Vec, width float64, cs ebiten.ColorScale) {
+	const numSegments = 12
+
+	// A quadratic curve with a control point that is
+	// shifted from the middle point along the normal.
+	delta := to.Sub(from)
+	normal := gmath.Vec{X: -delta.Y, Y: delta.X}
+	ctrl := from.Add(delta.Mulf(0.5)).Add(normal.Mulf(g.config.EdgeCurvature))
+
+	prev := from
+	for i := 1; i <= numSegments; i++ {
+		t := float64(i) / numSegments
+		a := from.LinearInterpolate(ctrl, t)
+		b := ctrl.LinearInterpolate(to, t)
+		p := a.LinearInterpolate(b, t)
+		drawLine(dst, blend, prev, p, width, cs)
+		prev = p
+	}
+}
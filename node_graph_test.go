@@ -0,0 +1,40 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+func TestNodeGraphNodeAt(t *testing.T) {
+	g := NewNodeGraph(NodeGraphConfig{
+		NodeSize: 20,
+		Viewport: gmath.Rect{
+			Min: gmath.Vec{X: 100, Y: 100},
+			Max: gmath.Vec{X: 300, Y: 200},
+		},
+	})
+	a := g.AddNode(gmath.Vec{X: 0, Y: 0}, nil)
+	b := g.AddNode(gmath.Vec{X: 50, Y: 20}, nil)
+	g.Connect(a, b)
+
+	// The graph world origin is located at the viewport top-left corner.
+	if have := g.NodeAt(gmath.Vec{X: 105, Y: 105}); have != a {
+		t.Fatalf("NodeAt(105, 105): have %p, want %p", have, a)
+	}
+	if have := g.NodeAt(gmath.Vec{X: 150, Y: 120}); have != b {
+		t.Fatalf("NodeAt(150, 120): have %p, want %p", have, b)
+	}
+	if have := g.NodeAt(gmath.Vec{X: 95, Y: 95}); have != nil {
+		t.Fatal("the positions outside of the viewport should be ignored")
+	}
+
+	g.GetCamera().Pan(gmath.Vec{X: 50, Y: 20})
+	if have := g.NodeAt(gmath.Vec{X: 100, Y: 100}); have != b {
+		t.Fatalf("NodeAt after pan: have %p, want %p", have, b)
+	}
+
+	b.SetState(NodeUnlocked)
+	g.Draw(ebiten.NewImage(320, 240))
+}
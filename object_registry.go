@@ -0,0 +1,99 @@
+package graphics
+
+import (
+	"slices"
+)
+
+// objectRegistry stores the optional objects metadata like names and tags.
+//
+// It's used by layers, so the graphical objects themselves
+// don't need extra fields for something that is rarely used.
+// The metadata map is allocated lazily.
+type objectRegistry struct {
+	meta map[gsceneGraphics]*objectMeta
+}
+
+type objectMeta struct {
+	name    string
+	tags    []string
+	sortKey int
+}
+
+func (r *objectRegistry) getMeta(o gsceneGraphics) *objectMeta {
+	if r.meta == nil {
+		r.meta = make(map[gsceneGraphics]*objectMeta, 8)
+	}
+	m := r.meta[o]
+	if m == nil {
+		m = &objectMeta{}
+		r.meta[o] = m
+	}
+	return m
+}
+
+func (r *objectRegistry) SetName(o gsceneGraphics, name string) {
+	r.getMeta(o).name = name
+}
+
+func (r *objectRegistry) GetName(o gsceneGraphics) string {
+	if m := r.meta[o]; m != nil {
+		return m.name
+	}
+	return ""
+}
+
+func (r *objectRegistry) AddTag(o gsceneGraphics, tag string) {
+	m := r.getMeta(o)
+	if !slices.Contains(m.tags, tag) {
+		m.tags = append(m.tags, tag)
+	}
+}
+
+func (r *objectRegistry) RemoveTag(o gsceneGraphics, tag string) {
+	m := r.meta[o]
+	if m == nil {
+		return
+	}
+	if i := slices.Index(m.tags, tag); i != -1 {
+		m.tags = slices.Delete(m.tags, i, i+1)
+	}
+}
+
+func (r *objectRegistry) GetTags(o gsceneGraphics) []string {
+	if m := r.meta[o]; m != nil {
+		return m.tags
+	}
+	return nil
+}
+
+func (r *objectRegistry) HasTag(o gsceneGraphics, tag string) bool {
+	if m := r.meta[o]; m != nil {
+		return slices.Contains(m.tags, tag)
+	}
+	return false
+}
+
+func (r *objectRegistry) SetSortKey(o gsceneGraphics, key int) {
+	if key == 0 && r.meta[o] == nil {
+		return
+	}
+	r.getMeta(o).sortKey = key
+}
+
+func (r *objectRegistry) GetSortKey(o gsceneGraphics) int {
+	if m := r.meta[o]; m != nil {
+		return m.sortKey
+	}
+	return 0
+}
+
+// Forget removes all metadata associated with the object.
+func (r *objectRegistry) Forget(o gsceneGraphics) {
+	if r.meta != nil {
+		delete(r.meta, o)
+	}
+}
+
+func (r *objectRegistry) IsEmpty() bool {
+	return len(r.meta) == 0
+}
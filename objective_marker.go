@@ -0,0 +1,232 @@
+package graphics
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// ObjectiveMarkerConfig describes the [ObjectiveMarker] appearance.
+type ObjectiveMarkerConfig struct {
+	// Camera is used to map the world position to the screen.
+	// This field is required.
+	Camera *Camera
+
+	// Icon is a marker image.
+	// This field is required.
+	Icon *ebiten.Image
+
+	// Hover is a distance between the world position and the icon's bottom.
+	// A zero value means 16.
+	Hover float64
+
+	// BounceHeight is a bounce animation amplitude.
+	// A zero value means 4.
+	BounceHeight float64
+
+	// BounceSpeed is a number of bounces per second.
+	// A zero value means 1.5.
+	BounceSpeed float64
+
+	// NearDistance and FarDistance describe the distance-based scaling
+	// (between the camera center and the marker world position).
+	// The icon has a scale of 1 up to NearDistance and it's shrinked
+	// to MinScale at FarDistance.
+	// Zero values mean 200 and 800.
+	NearDistance float64
+	FarDistance  float64
+
+	// MinScale is the icon scale at FarDistance and beyond.
+	// A zero value means 0.5.
+	MinScale float64
+
+	// FontFace is an optional font for the off-screen indicator distance label.
+	// See [OffscreenIndicatorsConfig].
+	FontFace text.Face
+
+	// ColorScale is used for the icon and the off-screen indicator.
+	// A zero value means {1, 1, 1, 1}.
+	ColorScale ColorScale
+}
+
+// ObjectiveMarker is a quest objective marker anchored to a world position.
+//
+// While the position is visible, the marker icon hovers above it
+// with a bounce animation; the icon becomes smaller when the objective
+// is far away from the camera center.
+// When the position is off-screen, the marker turns into an edge indicator
+// (see [OffscreenIndicators]) with the same icon.
+//
+// The marker is rendered in the camera viewport coordinates,
+// so it should be added to a [StaticLayer].
+// The animation is driven by the Update method.
+//
+// ObjectiveMarker implements gscene Graphics interface.
+type ObjectiveMarker struct {
+	// Pos is a marker world position binder.
+	// See Pos documentation to learn how it works.
+	Pos gmath.Pos
+
+	config ObjectiveMarkerConfig
+
+	ebitenColorScale ebiten.ColorScale
+
+	indicators *OffscreenIndicators
+
+	time float64
+
+	visible  bool
+	disposed bool
+}
+
+// objectiveAnchor is an [OffscreenIndicators] target that
+// represents the marker world position.
+type objectiveAnchor struct {
+	m *ObjectiveMarker
+}
+
+func (a objectiveAnchor) BoundsRect() gmath.Rect {
+	pos := a.m.Pos.Resolve()
+	// A 1x1 rect is used as empty rects never intersect the camera rect.
+	return gmath.Rect{Min: pos, Max: pos.Add(gmath.Vec{X: 1, Y: 1})}
+}
+
+// NewObjectiveMarker creates a marker with the specified config.
+func NewObjectiveMarker(config ObjectiveMarkerConfig) *ObjectiveMarker {
+	if config.Camera == nil {
+		panic("ObjectiveMarkerConfig.Camera can't be nil")
+	}
+	if config.Icon == nil {
+		panic("ObjectiveMarkerConfig.Icon can't be nil")
+	}
+	if config.Hover == 0 {
+		config.Hover = 16
+	}
+	if config.BounceHeight == 0 {
+		config.BounceHeight = 4
+	}
+	if config.BounceSpeed == 0 {
+		config.BounceSpeed = 1.5
+	}
+	if config.NearDistance == 0 {
+		config.NearDistance = 200
+	}
+	if config.FarDistance == 0 {
+		config.FarDistance = 800
+	}
+	if config.MinScale == 0 {
+		config.MinScale = 0.5
+	}
+	if config.ColorScale == (ColorScale{}) {
+		config.ColorScale = defaultColorScale
+	}
+
+	m := &ObjectiveMarker{
+		config:           config,
+		ebitenColorScale: config.ColorScale.ToEbitenColorScale(),
+		visible:          true,
+	}
+	m.indicators = NewOffscreenIndicators(OffscreenIndicatorsConfig{
+		Camera:     config.Camera,
+		Icon:       config.Icon,
+		FontFace:   config.FontFace,
+		ColorScale: config.ColorScale,
+	})
+	m.indicators.AddTarget(objectiveAnchor{m: m})
+	return m
+}
+
+// Update advances the bounce animation.
+func (m *ObjectiveMarker) Update(delta float64) {
+	// Keep the time in the [0, 1) range to avoid the precision loss.
+	m.time = wrapTimeOfDay(m.time + delta*m.config.BounceSpeed)
+}
+
+// IsOnScreen reports whether the marker world position is inside the camera view.
+// The camera zoom and rotation are taken into account.
+func (m *ObjectiveMarker) IsOnScreen() bool {
+	return m.indicators.isOnScreen(objectiveAnchor{m: m}.BoundsRect())
+}
+
+// GetScale returns the current distance-based icon scale.
+func (m *ObjectiveMarker) GetScale() float64 {
+	dist := m.config.Camera.GetWorldRect().Center().DistanceTo(m.Pos.Resolve())
+	near := m.config.NearDistance
+	far := m.config.FarDistance
+	if dist <= near || far <= near {
+		return 1
+	}
+	t := min(1, (dist-near)/(far-near))
+	return gmath.Lerp(1, m.config.MinScale, t)
+}
+
+// BoundsRect returns the on-screen icon rectangle in the viewport coordinates.
+// The bounce animation offset is not included.
+func (m *ObjectiveMarker) BoundsRect() gmath.Rect {
+	scale := m.GetScale()
+	bounds := m.config.Icon.Bounds()
+	size := gmath.Vec{X: float64(bounds.Dx()), Y: float64(bounds.Dy())}.Mulf(scale)
+
+	camera := m.config.Camera
+	anchor := camera.WorldToScreen(m.Pos.Resolve()).Sub(camera.GetViewportRect().Min)
+	bottom := anchor.Y - m.config.Hover*scale
+	return gmath.Rect{
+		Min: gmath.Vec{X: anchor.X - size.X*0.5, Y: bottom - size.Y},
+		Max: gmath.Vec{X: anchor.X + size.X*0.5, Y: bottom},
+	}
+}
+
+// Dispose marks this marker for deletion.
+// After calling this method, IsDisposed will report true.
+func (m *ObjectiveMarker) Dispose() { m.disposed = true }
+
+// IsDisposed reports whether this marker is marked for deletion.
+func (m *ObjectiveMarker) IsDisposed() bool { return m.disposed }
+
+// IsVisible reports whether this marker is visible.
+// Use SetVisibility to change this flag value.
+func (m *ObjectiveMarker) IsVisible() bool { return m.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (m *ObjectiveMarker) SetVisibility(visible bool) { m.visible = visible }
+
+// Draw renders the marker onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (m *ObjectiveMarker) Draw(dst *ebiten.Image) {
+	m.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the marker onto the provided dst image
+// while also using the extra provided offset.
+func (m *ObjectiveMarker) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !m.visible {
+		return
+	}
+
+	if !m.IsOnScreen() {
+		m.indicators.DrawWithOptions(dst, opts)
+		return
+	}
+
+	rect := m.BoundsRect()
+	scale := rect.Width() / float64(m.config.Icon.Bounds().Dx())
+	// The icon jumps up and falls back with a smooth landing.
+	bounce := math.Abs(math.Sin(math.Pi*m.time)) * m.config.BounceHeight * scale
+
+	var drawOptions ebiten.DrawImageOptions
+	if opts.Blend != nil {
+		drawOptions.Blend = *opts.Blend
+	}
+	drawOptions.ColorScale = m.ebitenColorScale
+	drawOptions.Filter = ebiten.FilterLinear
+	drawOptions.GeoM.Scale(scale, scale)
+	drawOptions.GeoM.Translate(rect.Min.X+opts.Offset.X, rect.Min.Y-bounce+opts.Offset.Y)
+	drawImage(dst, m.config.Icon, &drawOptions)
+}
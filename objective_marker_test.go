@@ -0,0 +1,74 @@
+package graphics_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	graphics "github.com/quasilyte/ebitengine-graphics"
+	"github.com/quasilyte/gmath"
+)
+
+func TestObjectiveMarkerScale(t *testing.T) {
+	c := graphics.NewCamera()
+	c.SetViewportRect(gmath.Rect{Max: gmath.Vec{X: 200, Y: 100}})
+
+	m := graphics.NewObjectiveMarker(graphics.ObjectiveMarkerConfig{
+		Camera:       c,
+		Icon:         ebiten.NewImage(8, 8),
+		NearDistance: 100,
+		FarDistance:  300,
+	})
+	center := c.GetWorldRect().Center()
+
+	tests := []struct {
+		dist     float64
+		scale    float64
+		onScreen bool
+	}{
+		{0, 1, true},
+		{50, 1, true},
+		{200, 0.75, false},
+		{300, 0.5, false},
+		{1000, 0.5, false},
+	}
+	for _, test := range tests {
+		m.Pos.Offset = center.Add(gmath.Vec{X: test.dist})
+		if have := m.GetScale(); !gmath.EqualApprox(have, test.scale) {
+			t.Fatalf("scale at %.1f:\nhave: %f\nwant: %f", test.dist, have, test.scale)
+		}
+		if have := m.IsOnScreen(); have != test.onScreen {
+			t.Fatalf("on-screen at %.1f:\nhave: %v\nwant: %v", test.dist, have, test.onScreen)
+		}
+	}
+}
+
+func TestObjectiveMarkerOnScreenRotated(t *testing.T) {
+	c := graphics.NewCamera()
+	c.SetViewportRect(gmath.Rect{Max: gmath.Vec{X: 200, Y: 100}})
+	c.SetRotation(math.Pi / 4)
+
+	m := graphics.NewObjectiveMarker(graphics.ObjectiveMarkerConfig{
+		Camera: c,
+		Icon:   ebiten.NewImage(8, 8),
+	})
+	center := c.GetWorldRect().Center()
+
+	tests := []struct {
+		offset   gmath.Vec
+		onScreen bool
+	}{
+		{gmath.Vec{X: 30}, true},
+		{gmath.Vec{X: 60, Y: 60}, true},
+		// These points are inside the rotated view bounding rect,
+		// but they're outside of the viewport.
+		{gmath.Vec{X: 100, Y: 100}, false},
+		{gmath.Vec{X: 100, Y: -100}, false},
+	}
+	for _, test := range tests {
+		m.Pos.Offset = center.Add(test.offset)
+		if have := m.IsOnScreen(); have != test.onScreen {
+			t.Fatalf("on-screen at %v:\nhave: %v\nwant: %v", test.offset, have, test.onScreen)
+		}
+	}
+}
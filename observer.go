@@ -0,0 +1,87 @@
+package graphics
+
+// Observer watches the graphical object state and runs
+// the associated callbacks when that state changes.
+//
+// The object state is polled during the Observer.Update call,
+// so the observed objects don't have to pay for the hooks support.
+// As a consequence, the callbacks are called at most once per Update
+// and several state changes between two updates can be collapsed
+// (a hide+show sequence doesn't trigger any hooks).
+//
+// Every callback is optional, nil callbacks are ignored.
+//
+// The observer disposes itself after the OnDispose is executed.
+type Observer struct {
+	// OnShow is called when the object becomes visible.
+	OnShow func()
+
+	// OnHide is called when the object becomes invisible.
+	OnHide func()
+
+	// OnDispose is called once the object is disposed.
+	OnDispose func()
+
+	target gsceneGraphics
+
+	visible  bool
+	disposed bool
+}
+
+// NewObserver returns an observer attached to the target object.
+//
+// The object visibility is tracked only if it implements IsVisible method.
+// Objects without this method are considered to be always visible.
+func NewObserver(target gsceneGraphics) *Observer {
+	o := &Observer{target: target}
+	o.visible = o.isTargetVisible()
+	return o
+}
+
+// Dispose stops the observation.
+// It doesn't call any of the callbacks.
+func (o *Observer) Dispose() {
+	o.disposed = true
+}
+
+// IsDisposed reports whether this observer is stopped.
+func (o *Observer) IsDisposed() bool {
+	return o.disposed
+}
+
+// Update checks the object state and runs the relevant callbacks.
+func (o *Observer) Update(_ float64) {
+	if o.disposed {
+		return
+	}
+
+	if o.target.IsDisposed() {
+		o.disposed = true
+		if o.OnDispose != nil {
+			o.OnDispose()
+		}
+		return
+	}
+
+	visible := o.isTargetVisible()
+	if visible == o.visible {
+		return
+	}
+	o.visible = visible
+	if visible {
+		if o.OnShow != nil {
+			o.OnShow()
+		}
+	} else {
+		if o.OnHide != nil {
+			o.OnHide()
+		}
+	}
+}
+
+func (o *Observer) isTargetVisible() bool {
+	if v, ok := o.target.(visibleObject); ok {
+		return v.IsVisible()
+	}
+	return true
+}
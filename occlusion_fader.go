@@ -0,0 +1,129 @@
+package graphics
+
+import (
+	"github.com/quasilyte/gmath"
+)
+
+// OcclusionFadeConfig describes the [OcclusionFader] behavior.
+type OcclusionFadeConfig struct {
+	// Alpha is an occluder alpha multiplier while the target is behind it.
+	// A zero value means 0.4.
+	Alpha float32
+
+	// FadeSpeed is an alpha change rate per second.
+	// A zero value means 4 (the fade takes ~0.15 seconds).
+	FadeSpeed float64
+}
+
+// OcclusionFader fades the taller sprites (trees, buildings)
+// when the tracked target (usually, a player) is behind them.
+//
+// An occluder is considered to be in front of the target if their bounds
+// intersect and the occluder's bottom edge is lower than the target's one.
+// This matches the Y-sorted rendering order: the objects that are
+// closer to the screen bottom are drawn on top.
+//
+// The fader modifies the occluders alpha; the original alpha value
+// is captured when the occluder is added.
+// Changing the occluder alpha while it's registered will lead to unexpected results.
+//
+// Its Update method should be called every frame.
+type OcclusionFader struct {
+	config OcclusionFadeConfig
+
+	target BoundedObject
+
+	occluders []occluderState
+}
+
+type occluderState struct {
+	sprite    *Sprite
+	origAlpha float32
+	alpha     float32
+}
+
+// NewOcclusionFader creates a fader with the specified config.
+// Use SetTarget to assign the tracked object.
+func NewOcclusionFader(config OcclusionFadeConfig) *OcclusionFader {
+	if config.Alpha == 0 {
+		config.Alpha = 0.4
+	}
+	if config.FadeSpeed == 0 {
+		config.FadeSpeed = 4
+	}
+	return &OcclusionFader{
+		config:    config,
+		occluders: make([]occluderState, 0, 8),
+	}
+}
+
+// SetTarget assigns the object that should stay visible.
+// A nil target makes all occluders fade back in.
+func (f *OcclusionFader) SetTarget(target BoundedObject) {
+	f.target = target
+}
+
+// AddOccluder registers a sprite that can hide the target.
+// Disposed occluders are removed automatically.
+func (f *OcclusionFader) AddOccluder(s *Sprite) {
+	a := s.GetAlpha()
+	f.occluders = append(f.occluders, occluderState{
+		sprite:    s,
+		origAlpha: a,
+		alpha:     a,
+	})
+}
+
+// RemoveOccluder unregisters the sprite and restores its original alpha.
+func (f *OcclusionFader) RemoveOccluder(s *Sprite) {
+	for i := range f.occluders {
+		o := &f.occluders[i]
+		if o.sprite != s {
+			continue
+		}
+		s.SetAlpha(o.origAlpha)
+		f.occluders = append(f.occluders[:i], f.occluders[i+1:]...)
+		return
+	}
+}
+
+// Update advances the fading effects.
+// delta is a time passed since the last Update call, in seconds.
+func (f *OcclusionFader) Update(delta float64) {
+	var targetRect gmath.Rect
+	hasTarget := f.target != nil
+	if hasTarget {
+		if d, ok := f.target.(gsceneGraphics); ok && d.IsDisposed() {
+			hasTarget = false
+		} else {
+			targetRect = f.target.BoundsRect()
+		}
+	}
+
+	step := float32(f.config.FadeSpeed * delta)
+	live := f.occluders[:0]
+	for _, o := range f.occluders {
+		s := o.sprite
+		if s.IsDisposed() {
+			continue
+		}
+
+		wantAlpha := o.origAlpha
+		if hasTarget && s.IsVisible() {
+			bounds := s.BoundsRect()
+			if bounds.Intersects(targetRect) && bounds.Max.Y > targetRect.Max.Y {
+				wantAlpha = o.origAlpha * f.config.Alpha
+			}
+		}
+
+		if o.alpha < wantAlpha {
+			o.alpha = min(o.alpha+step, wantAlpha)
+		} else if o.alpha > wantAlpha {
+			o.alpha = max(o.alpha-step, wantAlpha)
+		}
+		s.SetAlpha(o.alpha)
+
+		live = append(live, o)
+	}
+	f.occluders = live
+}
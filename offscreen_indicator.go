@@ -0,0 +1,257 @@
+package graphics
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// OffscreenIndicatorsConfig describes the [OffscreenIndicators] appearance.
+type OffscreenIndicatorsConfig struct {
+	// Camera is used to determine whether the target is off-screen.
+	// This field is required.
+	Camera *Camera
+
+	// Icon is an optional image drawn next to the arrow.
+	Icon *ebiten.Image
+
+	// FontFace is an optional font for the distance labels.
+	// The distance labels are not rendered if it's nil.
+	FontFace text.Face
+
+	// FormatDistance converts a world distance into the label text.
+	// By default, the distance is rounded to an integer.
+	FormatDistance func(dist float64) string
+
+	// ArrowSize is an arrow triangle height.
+	// A zero value means 8.
+	ArrowSize float64
+
+	// Margin is a distance between the viewport edges and the arrows.
+	// A zero value means 12.
+	Margin float64
+
+	// ColorScale is used for the arrows, icons and labels.
+	// A zero value means {1, 1, 1, 1}.
+	ColorScale ColorScale
+}
+
+// OffscreenIndicators draws the edge markers pointing towards
+// the registered world objects that are outside of the camera view.
+//
+// Every marker is an arrow clamped to the viewport edges,
+// optionally accompanied by an icon and a distance label.
+//
+// The indicators should be added to a [StaticLayer]
+// as they're rendered in the camera viewport coordinates.
+// Disposed targets are removed automatically.
+type OffscreenIndicators struct {
+	config OffscreenIndicatorsConfig
+
+	ebitenColorScale ebiten.ColorScale
+
+	targets []indicatorTarget
+
+	visible  bool
+	disposed bool
+}
+
+type indicatorTarget struct {
+	object     BoundedObject
+	colorScale *ebiten.ColorScale
+}
+
+// NewOffscreenIndicators creates an indicators set with the specified config.
+func NewOffscreenIndicators(config OffscreenIndicatorsConfig) *OffscreenIndicators {
+	if config.Camera == nil {
+		panic("OffscreenIndicatorsConfig.Camera can't be nil")
+	}
+	if config.ArrowSize == 0 {
+		config.ArrowSize = 8
+	}
+	if config.Margin == 0 {
+		config.Margin = 12
+	}
+	if config.ColorScale == (ColorScale{}) {
+		config.ColorScale = defaultColorScale
+	}
+	if config.FormatDistance == nil {
+		config.FormatDistance = func(dist float64) string {
+			return strconv.Itoa(int(math.Round(dist)))
+		}
+	}
+	return &OffscreenIndicators{
+		config:           config,
+		ebitenColorScale: config.ColorScale.ToEbitenColorScale(),
+		visible:          true,
+	}
+}
+
+// AddTarget registers the object to be tracked.
+// Use AddColoredTarget to override the indicator color for this target.
+func (ind *OffscreenIndicators) AddTarget(o BoundedObject) {
+	ind.targets = append(ind.targets, indicatorTarget{object: o})
+}
+
+// AddColoredTarget is like AddTarget, but the target
+// indicator uses the specified color scale.
+func (ind *OffscreenIndicators) AddColoredTarget(o BoundedObject, cs ColorScale) {
+	ecs := cs.ToEbitenColorScale()
+	ind.targets = append(ind.targets, indicatorTarget{object: o, colorScale: &ecs})
+}
+
+// RemoveTarget stops tracking the object.
+func (ind *OffscreenIndicators) RemoveTarget(o BoundedObject) {
+	for i := range ind.targets {
+		if ind.targets[i].object == o {
+			ind.targets = append(ind.targets[:i], ind.targets[i+1:]...)
+			return
+		}
+	}
+}
+
+func (ind *OffscreenIndicators) IsDisposed() bool { return ind.disposed }
+
+func (ind *OffscreenIndicators) Dispose() { ind.disposed = true }
+
+// IsVisible reports whether the indicators are visible.
+// Use SetVisibility to change this flag value.
+func (ind *OffscreenIndicators) IsVisible() bool { return ind.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (ind *OffscreenIndicators) SetVisibility(visible bool) { ind.visible = visible }
+
+func (ind *OffscreenIndicators) Draw(dst *ebiten.Image) {
+	ind.DrawWithOptions(dst, DrawOptions{})
+}
+
+func (ind *OffscreenIndicators) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !ind.visible {
+		return
+	}
+
+	camera := ind.config.Camera
+	worldRect := camera.GetWorldRect()
+	viewCenter := worldRect.Center()
+	// The indicators are rendered in the viewport coordinates,
+	// so the targets are projected to the screen first.
+	// This way the camera zoom and rotation are taken into account.
+	viewportRect := camera.GetViewportRect()
+	halfSize := viewportRect.Size().Mulf(0.5)
+
+	// The arrow tips are clamped to this area.
+	limit := gmath.Vec{
+		X: max(0, halfSize.X-ind.config.Margin),
+		Y: max(0, halfSize.Y-ind.config.Margin),
+	}
+
+	liveTargets := ind.targets[:0]
+	for _, t := range ind.targets {
+		if d, ok := t.object.(gsceneGraphics); ok && d.IsDisposed() {
+			continue
+		}
+		liveTargets = append(liveTargets, t)
+
+		bounds := t.object.BoundsRect()
+		if ind.isOnScreen(bounds) {
+			continue
+		}
+		if v, ok := t.object.(visibleObject); ok && !v.IsVisible() {
+			continue
+		}
+
+		delta := camera.WorldToScreen(bounds.Center()).Sub(viewportRect.Min).Sub(halfSize)
+		if delta.IsZero() {
+			continue
+		}
+
+		// Scale the direction vector so it touches the limit rect.
+		scale := math.Inf(1)
+		if delta.X != 0 {
+			scale = min(scale, limit.X/math.Abs(delta.X))
+		}
+		if delta.Y != 0 {
+			scale = min(scale, limit.Y/math.Abs(delta.Y))
+		}
+		dir := delta.Normalized()
+		tip := halfSize.Add(delta.Mulf(scale)).Add(opts.Offset)
+		dist := bounds.Center().DistanceTo(viewCenter)
+
+		clr := ind.ebitenColorScale
+		if t.colorScale != nil {
+			clr = *t.colorScale
+		}
+		ind.drawIndicator(dst, opts.Blend, tip, dir, dist, clr)
+	}
+	ind.targets = liveTargets
+}
+
+// isOnScreen reports whether the world rect r is visible inside the camera viewport.
+func (ind *OffscreenIndicators) isOnScreen(r gmath.Rect) bool {
+	camera := ind.config.Camera
+	// The world rect covers the entire visible area,
+	// so the projection is only needed for the rects inside of it.
+	if !r.Intersects(camera.GetWorldRect()) {
+		return false
+	}
+	corners := [4]gmath.Vec{
+		r.Min,
+		{X: r.Max.X, Y: r.Min.Y},
+		r.Max,
+		{X: r.Min.X, Y: r.Max.Y},
+	}
+	var screenBounds gmath.Rect
+	for i, corner := range corners {
+		p := camera.WorldToScreen(corner)
+		if i == 0 {
+			screenBounds = gmath.Rect{Min: p, Max: p}
+			continue
+		}
+		screenBounds.Min = gmath.Vec{X: min(screenBounds.Min.X, p.X), Y: min(screenBounds.Min.Y, p.Y)}
+		screenBounds.Max = gmath.Vec{X: max(screenBounds.Max.X, p.X), Y: max(screenBounds.Max.Y, p.Y)}
+	}
+	return screenBounds.Intersects(camera.GetViewportRect())
+}
+
+func (ind *OffscreenIndicators) drawIndicator(dst *ebiten.Image, blend *ebiten.Blend, tip, dir gmath.Vec, dist float64, clr ebiten.ColorScale) {
+	size := ind.config.ArrowSize
+	base := tip.Sub(dir.Mulf(size))
+	side := gmath.Vec{X: -dir.Y, Y: dir.X}.Mulf(size * 0.6)
+	drawTriangle(dst, blend, tip, base.Add(side), base.Sub(side), clr)
+
+	// Everything else is placed closer to the screen center.
+	pos := base.Sub(dir.Mulf(size * 0.5))
+
+	if icon := ind.config.Icon; icon != nil {
+		iconSize := icon.Bounds().Size()
+		iconRadius := 0.5 * math.Hypot(float64(iconSize.X), float64(iconSize.Y))
+		center := pos.Sub(dir.Mulf(iconRadius))
+		var drawOptions ebiten.DrawImageOptions
+		if blend != nil {
+			drawOptions.Blend = *blend
+		}
+		drawOptions.ColorScale = clr
+		drawOptions.GeoM.Translate(math.Round(center.X-float64(iconSize.X)/2), math.Round(center.Y-float64(iconSize.Y)/2))
+		drawImage(dst, icon, &drawOptions)
+		pos = center.Sub(dir.Mulf(iconRadius))
+	}
+
+	if ff := ind.config.FontFace; ff != nil {
+		s := ind.config.FormatDistance(dist)
+		w, h := text.Measure(s, ff, 0)
+		labelRadius := 0.5 * math.Hypot(w, h)
+		center := pos.Sub(dir.Mulf(labelRadius))
+		var drawOptions text.DrawOptions
+		if blend != nil {
+			drawOptions.Blend = *blend
+		}
+		drawOptions.ColorScale = clr
+		drawOptions.Filter = ebiten.FilterLinear
+		drawOptions.GeoM.Translate(math.Round(center.X-w/2), math.Round(center.Y-h/2))
+		drawText(dst, s, ff, &drawOptions)
+	}
+}
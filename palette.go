@@ -0,0 +1,170 @@
+package graphics
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+	"github.com/quasilyte/gmath"
+)
+
+// MaxPaletteColors is a max number of colors a [Palette] can remap.
+const MaxPaletteColors = 32
+
+// Palette is a color remap table used by the [PaletteSprite].
+//
+// The palette is immutable, so a single palette object
+// can be shared by any number of sprites.
+// A typical setup is a base palette of colors used in the original
+// textures and one target palette per team or character skin.
+type Palette struct {
+	uniforms map[string]any
+}
+
+// NewPalette creates a palette that replaces the from[i] colors with to[i].
+// The alpha values are ignored: the pixel transparency is preserved.
+//
+// It panics if the slices have different lengths
+// or if there are more than [MaxPaletteColors] colors.
+func NewPalette(from, to []ColorScale) *Palette {
+	if len(from) != len(to) {
+		panic("palette from and to lengths mismatch")
+	}
+	if len(from) > MaxPaletteColors {
+		panic("too many palette colors")
+	}
+
+	// The uniform arrays should always have the max length.
+	fromValues := make([]float32, MaxPaletteColors*4)
+	toValues := make([]float32, MaxPaletteColors*4)
+	for i := range from {
+		fromValues[i*4+0] = from[i].R
+		fromValues[i*4+1] = from[i].G
+		fromValues[i*4+2] = from[i].B
+		fromValues[i*4+3] = 1
+		toValues[i*4+0] = to[i].R
+		toValues[i*4+1] = to[i].G
+		toValues[i*4+2] = to[i].B
+		toValues[i*4+3] = 1
+	}
+
+	return &Palette{
+		uniforms: map[string]any{
+			"NumColors": int32(len(from)),
+			"From":      fromValues,
+			"To":        toValues,
+		},
+	}
+}
+
+// NumColors reports the number of the remapped colors.
+func (p *Palette) NumColors() int {
+	return int(p.uniforms["NumColors"].(int32))
+}
+
+// PaletteSprite renders a sprite with its colors remapped by a [Palette].
+//
+// Every source pixel that matches one of the palette's from colors
+// is replaced with the corresponding to color; other pixels keep
+// their original colors. This way, the team colors and the character
+// skins don't require duplicating every texture.
+// The colors are compared exactly (with a tiny tolerance),
+// so the textures should use a fixed palette and a nearest filter.
+//
+// All palette sprites share the same bundled shader,
+// call [CompileShaders] before creating them.
+//
+// The wrapped sprite should not be added to any layer,
+// the PaletteSprite object should be added instead.
+// The sprite transformation and color scale are respected,
+// but the sprite shader is ignored.
+//
+// PaletteSprite implements gscene Graphics interface.
+type PaletteSprite struct {
+	sprite *Sprite
+
+	palette *Palette
+
+	visible  bool
+	disposed bool
+}
+
+// NewPaletteSprite wraps a sprite into a palette renderer.
+// A nil palette makes it render the sprite as is.
+//
+// This function panics if the shaders are not compiled.
+func NewPaletteSprite(s *Sprite, p *Palette) *PaletteSprite {
+	requireShaders()
+
+	return &PaletteSprite{
+		sprite:  s,
+		palette: p,
+		visible: true,
+	}
+}
+
+// GetSprite returns the wrapped sprite.
+func (ps *PaletteSprite) GetSprite() *Sprite { return ps.sprite }
+
+// GetPalette returns the current palette.
+// Use SetPalette to change it.
+func (ps *PaletteSprite) GetPalette() *Palette { return ps.palette }
+
+// SetPalette changes the color remap table.
+// A nil palette disables the remapping.
+func (ps *PaletteSprite) SetPalette(p *Palette) { ps.palette = p }
+
+// BoundsRect returns the wrapped sprite bounds.
+func (ps *PaletteSprite) BoundsRect() gmath.Rect { return ps.sprite.BoundsRect() }
+
+// Dispose marks this object for deletion.
+// After calling this method, IsDisposed will report true.
+//
+// The wrapped sprite is not disposed.
+func (ps *PaletteSprite) Dispose() { ps.disposed = true }
+
+// IsDisposed reports whether this object is marked for deletion.
+//
+// The object is also considered to be disposed if its sprite is disposed.
+func (ps *PaletteSprite) IsDisposed() bool { return ps.disposed || ps.sprite.IsDisposed() }
+
+// IsVisible reports whether this object is visible.
+// Use SetVisibility to change this flag value.
+func (ps *PaletteSprite) IsVisible() bool { return ps.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (ps *PaletteSprite) SetVisibility(visible bool) { ps.visible = visible }
+
+// Draw renders the remapped sprite onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (ps *PaletteSprite) Draw(dst *ebiten.Image) {
+	ps.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the remapped sprite onto the provided dst image
+// while also using the extra provided offset.
+func (ps *PaletteSprite) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	s := ps.sprite
+	if !ps.visible || !s.IsVisible() || s.image == nil || s.colorScale.A == 0 {
+		return
+	}
+	if ps.palette == nil {
+		s.DrawWithOptions(dst, opts)
+		return
+	}
+
+	opts.Blend = resolveBlend(s.blendID, opts.Blend)
+
+	var options ebiten.DrawRectShaderOptions
+	if opts.Blend != nil {
+		options.Blend = *opts.Blend
+	}
+	options.GeoM = s.calculateGeoM(opts)
+	options.ColorScale = s.ebitenColorScale
+	options.Images[0] = s.frameImage()
+	options.Uniforms = ps.palette.uniforms
+	drawRectShader(dst, int(s.frameWidth), int(s.frameHeight), cache.Global.PaletteShader, &options)
+}
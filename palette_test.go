@@ -0,0 +1,59 @@
+package graphics
+
+import (
+	"testing"
+)
+
+func TestNewPalette(t *testing.T) {
+	from := []ColorScale{
+		{R: 1, G: 0, B: 0, A: 1},
+		{R: 0.5, G: 0, B: 0, A: 0.25},
+	}
+	to := []ColorScale{
+		{R: 0, G: 0, B: 1, A: 1},
+		{R: 0, G: 0, B: 0.5, A: 1},
+	}
+	p := NewPalette(from, to)
+	if have := p.NumColors(); have != 2 {
+		t.Fatalf("num colors: have %d, want 2", have)
+	}
+
+	fromValues := p.uniforms["From"].([]float32)
+	toValues := p.uniforms["To"].([]float32)
+	if len(fromValues) != MaxPaletteColors*4 || len(toValues) != MaxPaletteColors*4 {
+		t.Fatalf("unexpected uniform lengths: %d and %d", len(fromValues), len(toValues))
+	}
+	wantFrom := []float32{1, 0, 0, 1, 0.5, 0, 0, 1, 0, 0, 0, 0}
+	for i, want := range wantFrom {
+		if fromValues[i] != want {
+			t.Fatalf("From[%d]: have %v, want %v", i, fromValues[i], want)
+		}
+	}
+	wantTo := []float32{0, 0, 1, 1, 0, 0, 0.5, 1, 0, 0, 0, 0}
+	for i, want := range wantTo {
+		if toValues[i] != want {
+			t.Fatalf("To[%d]: have %v, want %v", i, toValues[i], want)
+		}
+	}
+}
+
+func TestNewPalettePanics(t *testing.T) {
+	tests := []struct {
+		name string
+		from []ColorScale
+		to   []ColorScale
+	}{
+		{"mismatch", make([]ColorScale, 2), make([]ColorScale, 3)},
+		{"overflow", make([]ColorScale, MaxPaletteColors+1), make([]ColorScale, MaxPaletteColors+1)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Fatal("expected a panic")
+				}
+			}()
+			NewPalette(test.from, test.to)
+		})
+	}
+}
@@ -3,10 +3,22 @@ package particle
 import (
 	"math"
 
+	"github.com/hajimehoshi/ebiten/v2"
+	graphics "github.com/quasilyte/ebitengine-graphics"
 	"github.com/quasilyte/ebitengine-graphics/internal/cache"
 	"github.com/quasilyte/gmath"
 )
 
+// Emitter spawns the particles described by its [Template].
+//
+// An emitter can be rendered in two ways:
+//   - added to a [Renderer] that batches all emitters with the same image
+//   - added to a graphics layer directly, like any other graphics object;
+//     all particles of the emitter are drawn with a single DrawTriangles call
+//
+// An emitter should not be added to both at the same time.
+//
+// Emitter implements gscene Graphics interface.
 type Emitter struct {
 	tmpl *Template
 
@@ -47,7 +59,8 @@ type particle struct {
 	origAngle    uint8
 	paletteIndex uint8
 	userData     uint8
-	_            [2]uint8 // unused (reserved for future use)
+	spinSeed     uint8
+	_            [1]uint8 // unused (reserved for future use)
 
 	// Would use {uint16, uint16} here to save 4 bytes,
 	// but it can be desirable to support negative coords
@@ -76,6 +89,8 @@ func (e *Emitter) Dispose() {
 	e.disposed = true
 }
 
+func (e *Emitter) IsVisible() bool { return e.visible }
+
 func (e *Emitter) SetVisibility(visible bool) { e.visible = visible }
 
 func (e *Emitter) SetLifetimeMultiplier(multiplier float64) {
@@ -95,6 +110,28 @@ func (e *Emitter) NumParticles() int {
 	return len(e.particles)
 }
 
+// Draw renders the emitter particles onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+func (e *Emitter) Draw(dst *ebiten.Image) {
+	e.DrawWithOptions(dst, graphics.DrawOptions{})
+}
+
+// DrawWithOptions renders the emitter particles onto the provided dst image
+// while also using the extra provided offset and blend mode.
+//
+// There is no need to call this method for the emitters
+// that are added to a [Renderer].
+func (e *Emitter) DrawWithOptions(dst *ebiten.Image, opts graphics.DrawOptions) {
+	if !e.visible || e.disposed || len(e.particles) == 0 {
+		return
+	}
+	batch := append(sharedResources.batchSlice[:0], e)
+	drawParticles(dst, e.tmpl.img, opts, batch)
+	sharedResources.batchSlice = batch[:0]
+}
+
 func (e *Emitter) Update() {
 	e.UpdateWithDelta(1.0 / 60.0)
 }
@@ -231,6 +268,13 @@ func (e *Emitter) emit(t float32) {
 			p.angleSeed = x
 		}
 
+		if e.tmpl.needsRandBits&(spinRandBit|flutterRandBit) != 0 {
+			// The flutter phase re-uses the spin seed.
+			x := uint8(fastrand(randBits, randSeq))
+			randSeq++
+			p.spinSeed = x
+		}
+
 		e.particles = append(e.particles, p)
 	}
 }
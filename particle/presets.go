@@ -0,0 +1,165 @@
+package particle
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	graphics "github.com/quasilyte/ebitengine-graphics"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+	"github.com/quasilyte/gmath"
+)
+
+// CelebrationConfig describes the celebration effect presets,
+// see [NewConfettiTemplate] and [NewFireworksTemplate].
+type CelebrationConfig struct {
+	// Palette is a list of the particle colors.
+	// Every particle (or a fireworks burst) gets a random palette color.
+	// A nil value means a default festive palette.
+	Palette []graphics.ColorScale
+
+	// Intensity is a particles amount multiplier.
+	// A zero value means 1.
+	Intensity float64
+
+	// Image is an optional particle image.
+	// A nil value means a white rectangle (for confetti) or a dot (for fireworks).
+	Image *ebiten.Image
+
+	// Area is a size of the area around the emitter Pos where the particles are spawned.
+	// For the confetti, it's usually a screen-wide strip above the screen.
+	// For the fireworks, it's the area of the burst centers.
+	// A zero value means a single point.
+	Area gmath.Vec
+}
+
+var defaultCelebrationPalette = []graphics.ColorScale{
+	{R: 1, G: 0.3, B: 0.3, A: 1},
+	{R: 1, G: 0.8, B: 0.2, A: 1},
+	{R: 0.3, G: 1, B: 0.4, A: 1},
+	{R: 0.3, G: 0.6, B: 1, A: 1},
+	{R: 0.9, G: 0.4, B: 1, A: 1},
+}
+
+func (config *CelebrationConfig) fillDefaults() {
+	if len(config.Palette) == 0 {
+		config.Palette = defaultCelebrationPalette
+	}
+	if len(config.Palette) > math.MaxUint8+1 {
+		panic("the palette can't have more than 256 colors")
+	}
+	if config.Intensity == 0 {
+		config.Intensity = 1
+	}
+}
+
+func (config *CelebrationConfig) burst(n float64) int {
+	return gmath.Clamp(int(math.Round(n*config.Intensity)), 1, math.MaxUint8)
+}
+
+// NewConfettiTemplate creates a confetti particles template.
+//
+// The confetti pieces are thrown up, then they fall down
+// while fluttering and spinning. The pieces fade out at the
+// end of their lifetime.
+//
+// The emitter is a continuous source: use SetEmitting to start
+// and stop it.
+func NewConfettiTemplate(config CelebrationConfig) *Template {
+	config.fillDefaults()
+
+	tmpl := NewTemplate()
+	if config.Image != nil {
+		tmpl.SetImage(config.Image)
+	} else {
+		tmpl.SetImage(cache.Global.WhitePixel)
+		tmpl.SetParticleScalingRange(gmath.Vec{X: 3, Y: 2}, gmath.Vec{X: 5, Y: 3})
+	}
+	tmpl.SetPalette(config.Palette)
+	tmpl.SetEmitInterval(0.05)
+	tmpl.SetEmitBurst(config.burst(2), config.burst(4))
+	tmpl.SetParticleLifetimeRange(2, 4)
+	tmpl.SetParticleDirection(-math.Pi/2, math.Pi/2)
+	tmpl.SetParticleSpeedRange(60, 160)
+	tmpl.SetParticleGravity(gmath.Vec{Y: 90})
+	tmpl.SetParticleSpinRange(-8, 8)
+	tmpl.SetParticleFlutter(6, 1.5)
+
+	numColors := uint64(len(config.Palette))
+	tmpl.SetSpawnColorFunc(func(ctx SpawnContext) uint {
+		return uint(ctx.RandUint() % numColors)
+	})
+	if !config.Area.IsZero() {
+		area := config.Area
+		tmpl.SetSpawnOffsetFunc(func(ctx SpawnContext) gmath.Vec {
+			x := fastrandFloat(randseed1, uint64(ctx.id)*2)
+			y := fastrandFloat(randseed1, uint64(ctx.id)*2+1)
+			return gmath.Vec{X: (x - 0.5) * area.X, Y: (y - 0.5) * area.Y}
+		})
+	}
+	tmpl.SetUpdateColorScaleFunc(func(ctx UpdateContext) graphics.ColorScale {
+		return graphics.ColorScale{R: 1, G: 1, B: 1, A: celebrationFade(ctx.Time(), 0.8)}
+	})
+
+	return tmpl
+}
+
+// NewFireworksTemplate creates a fireworks particles template.
+//
+// Every emission is a radial burst of the same color sparks
+// that slowly fall down while shrinking and fading out.
+// If the config Area is set, every burst has its own random center inside of it.
+//
+// The emitter is a continuous source: use SetEmitting to start
+// and stop it.
+func NewFireworksTemplate(config CelebrationConfig) *Template {
+	config.fillDefaults()
+
+	tmpl := NewTemplate()
+	if config.Image != nil {
+		tmpl.SetImage(config.Image)
+	} else {
+		tmpl.SetImage(cache.Global.WhitePixel)
+		tmpl.SetParticleScaling(gmath.Vec{X: 2, Y: 2})
+	}
+	tmpl.SetPalette(config.Palette)
+	tmpl.SetEmitInterval(0.8 / config.Intensity)
+	tmpl.SetEmitBurst(config.burst(30), config.burst(50))
+	tmpl.SetParticleLifetimeRange(1, 1.6)
+	tmpl.SetParticleDirection(0, 2*math.Pi)
+	tmpl.SetParticleSpeedRange(40, 120)
+	tmpl.SetParticleGravity(gmath.Vec{Y: 40})
+
+	// The burst-level values are derived from the emission generation,
+	// so all sparks of a single burst share them.
+	numColors := uint64(len(config.Palette))
+	tmpl.SetSpawnColorFunc(func(ctx SpawnContext) uint {
+		return uint(fastrand(randseed1, uint64(ctx.Generation())) % numColors)
+	})
+	if !config.Area.IsZero() {
+		area := config.Area
+		tmpl.SetSpawnOffsetFunc(func(ctx SpawnContext) gmath.Vec {
+			gen := uint64(ctx.Generation())
+			x := fastrandFloat(randseed1, gen*2)
+			y := fastrandFloat(randseed1, gen*2+1)
+			return gmath.Vec{X: (x - 0.5) * area.X, Y: (y - 0.5) * area.Y}
+		})
+	}
+	tmpl.SetUpdateColorScaleFunc(func(ctx UpdateContext) graphics.ColorScale {
+		return graphics.ColorScale{R: 1, G: 1, B: 1, A: celebrationFade(ctx.Time(), 0.5)}
+	})
+	tmpl.SetUpdateScalingFunc(func(ctx UpdateContext) gmath.Vec32 {
+		s := 1 - 0.5*ctx.Time()
+		return gmath.Vec32{X: s, Y: s}
+	})
+
+	return tmpl
+}
+
+// celebrationFade returns an alpha multiplier that is 1 until
+// the fadeStart lifetime fraction and then linearly goes to 0.
+func celebrationFade(t, fadeStart float32) float32 {
+	if t <= fadeStart {
+		return 1
+	}
+	return max(0, 1-(t-fadeStart)/(1-fadeStart))
+}
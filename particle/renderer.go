@@ -130,24 +130,26 @@ func (r *Renderer) drawBucket(dst *ebiten.Image, img *ebiten.Image, opts graphic
 			continue
 		}
 
-		if batchParticles+n > batchThreshold {
-			r.drawBatch(dst, img, opts, batch)
+		if batchParticles+n > batchThreshold && len(batch) != 0 {
+			drawParticles(dst, img, opts, batch)
 			batch = batch[:0]
 			batchParticles = 0
-		} else {
-			batch = append(batch, e)
-			batchParticles += n
 		}
+		batch = append(batch, e)
+		batchParticles += n
 	}
 
 	if len(batch) != 0 {
-		r.drawBatch(dst, img, opts, batch)
+		drawParticles(dst, img, opts, batch)
 	}
 
 	return activeEmitters
 }
 
-func (r *Renderer) drawBatch(dst, img *ebiten.Image, opts graphics.DrawOptions, emitters []*Emitter) {
+// drawParticles renders the particles of all emitters using the img texture.
+// The particles are drawn with a single DrawTriangles call unless
+// their number exceeds the uint16 vertex indices limit.
+func drawParticles(dst, img *ebiten.Image, opts graphics.DrawOptions, emitters []*Emitter) {
 	// Use pre-allocated slices.
 	vertices := cache.Global.ScratchVertices[:0]
 	indices := cache.Global.ScratchIndices[:0]
@@ -156,13 +158,21 @@ func (r *Renderer) drawBatch(dst, img *ebiten.Image, opts graphics.DrawOptions,
 		cache.Global.ScratchIndices = indices[:0]
 	}()
 
+	var drawOptions ebiten.DrawTrianglesOptions
+	if opts.Blend != nil {
+		drawOptions.Blend = *opts.Blend
+	}
+
 	idx := uint16(0)
 	offset32 := opts.Offset.AsVec32()
 
 	for _, e := range emitters {
 		tmpl := e.tmpl
 
-		w, h := float32(tmpl.img.Bounds().Dx()), float32(tmpl.img.Bounds().Dy())
+		bounds := tmpl.img.Bounds()
+		w, h := float32(bounds.Dx()), float32(bounds.Dy())
+		// The texture can be a sub-image (like an atlas frame).
+		sx, sy := float32(bounds.Min.X), float32(bounds.Min.Y)
 		halfWidth := w * 0.5
 		halfHeight := h * 0.5
 		palette := tmpl.palette
@@ -174,6 +184,14 @@ func (r *Renderer) drawBatch(dst, img *ebiten.Image, opts graphics.DrawOptions,
 			tmpl.particleMaxAngle != 0 ||
 			e.Rotation != nil
 
+		gravity := tmpl.particleGravity
+		hasGravity := !gravity.IsZero()
+		minSpin := tmpl.particleMinSpin
+		spinStep := tmpl.particleSpinStep
+		hasSpin := minSpin != 0 || spinStep != 0
+		flutterAmplitude := tmpl.flutterAmplitude
+		flutterFrequency := tmpl.flutterFrequency
+
 		updateColorScaleFunc := tmpl.updateColorScaleFunc
 		updateScalingFunc := tmpl.updateScalingFunc
 
@@ -183,6 +201,13 @@ func (r *Renderer) drawBatch(dst, img *ebiten.Image, opts graphics.DrawOptions,
 		minScaling := tmpl.particleMinScaling
 		scalingStep := tmpl.particleScalingStep
 		for _, p := range e.particles {
+			if len(vertices)+4 > math.MaxUint16 {
+				dst.DrawTriangles(vertices, indices, img, &drawOptions)
+				vertices = vertices[:0]
+				indices = indices[:0]
+				idx = 0
+			}
+
 			var pos xmath.Geom32
 			var angle float64
 			{
@@ -200,7 +225,20 @@ func (r *Renderer) drawBatch(dst, img *ebiten.Image, opts graphics.DrawOptions,
 				}
 
 				speed := minSpeed + (speedStep * float32(p.speedSeed))
-				currentPos := origPos.Add(dir.Mulf(speed).Mulf(fcounter * 0.001))
+				seconds := fcounter * 0.001
+				currentPos := origPos.Add(dir.Mulf(speed).Mulf(seconds))
+				if hasGravity {
+					currentPos = currentPos.Add(gravity.Mulf(0.5 * seconds * seconds))
+				}
+				if flutterAmplitude != 0 {
+					phase := float64(p.spinSeed) * ((2 * math.Pi) / 255)
+					currentPos.X += flutterAmplitude * float32(math.Sin(2*math.Pi*float64(flutterFrequency*seconds)+phase))
+				}
+				if hasSpin {
+					// The spin is applied after the direction is computed,
+					// so it only rotates the particle image.
+					angle += float64((minSpin + spinStep*float32(p.spinSeed)) * seconds)
+				}
 
 				scaling := gmath.Vec32{X: 1, Y: 1}
 				if needScaling {
@@ -230,17 +268,17 @@ func (r *Renderer) drawBatch(dst, img *ebiten.Image, opts graphics.DrawOptions,
 			y := pos.Ty
 			if angle == 0 {
 				vertices = append(vertices,
-					ebiten.Vertex{DstX: x, DstY: y, SrcX: 0, SrcY: 0, ColorR: clr.R, ColorG: clr.G, ColorB: clr.B, ColorA: clr.A},
-					ebiten.Vertex{DstX: x + w, DstY: y, SrcX: w, SrcY: 0, ColorR: clr.R, ColorG: clr.G, ColorB: clr.B, ColorA: clr.A},
-					ebiten.Vertex{DstX: x, DstY: y + h, SrcX: 0, SrcY: h, ColorR: clr.R, ColorG: clr.G, ColorB: clr.B, ColorA: clr.A},
-					ebiten.Vertex{DstX: x + w, DstY: y + h, SrcX: w, SrcY: h, ColorR: clr.R, ColorG: clr.G, ColorB: clr.B, ColorA: clr.A},
+					ebiten.Vertex{DstX: x, DstY: y, SrcX: sx, SrcY: sy, ColorR: clr.R, ColorG: clr.G, ColorB: clr.B, ColorA: clr.A},
+					ebiten.Vertex{DstX: x + w, DstY: y, SrcX: sx + w, SrcY: sy, ColorR: clr.R, ColorG: clr.G, ColorB: clr.B, ColorA: clr.A},
+					ebiten.Vertex{DstX: x, DstY: y + h, SrcX: sx, SrcY: sy + h, ColorR: clr.R, ColorG: clr.G, ColorB: clr.B, ColorA: clr.A},
+					ebiten.Vertex{DstX: x + w, DstY: y + h, SrcX: sx + w, SrcY: sy + h, ColorR: clr.R, ColorG: clr.G, ColorB: clr.B, ColorA: clr.A},
 				)
 			} else {
 				vertices = append(vertices,
-					ebiten.Vertex{DstX: x, DstY: y, SrcX: 0, SrcY: 0, ColorR: clr.R, ColorG: clr.G, ColorB: clr.B, ColorA: clr.A},
-					ebiten.Vertex{DstX: (pos.A1+1)*w + x, DstY: pos.C*w + y, SrcX: w, SrcY: 0, ColorR: clr.R, ColorG: clr.G, ColorB: clr.B, ColorA: clr.A},
-					ebiten.Vertex{DstX: pos.B*h + x, DstY: (pos.D1+1)*h + y, SrcX: 0, SrcY: h, ColorR: clr.R, ColorG: clr.G, ColorB: clr.B, ColorA: clr.A},
-					ebiten.Vertex{DstX: pos.ApplyX(w, h), DstY: pos.ApplyY(w, h), SrcX: w, SrcY: h, ColorR: clr.R, ColorG: clr.G, ColorB: clr.B, ColorA: clr.A},
+					ebiten.Vertex{DstX: x, DstY: y, SrcX: sx, SrcY: sy, ColorR: clr.R, ColorG: clr.G, ColorB: clr.B, ColorA: clr.A},
+					ebiten.Vertex{DstX: (pos.A1+1)*w + x, DstY: pos.C*w + y, SrcX: sx + w, SrcY: sy, ColorR: clr.R, ColorG: clr.G, ColorB: clr.B, ColorA: clr.A},
+					ebiten.Vertex{DstX: pos.B*h + x, DstY: (pos.D1+1)*h + y, SrcX: sx, SrcY: sy + h, ColorR: clr.R, ColorG: clr.G, ColorB: clr.B, ColorA: clr.A},
+					ebiten.Vertex{DstX: pos.ApplyX(w, h), DstY: pos.ApplyY(w, h), SrcX: sx + w, SrcY: sy + h, ColorR: clr.R, ColorG: clr.G, ColorB: clr.B, ColorA: clr.A},
 				)
 			}
 
@@ -249,6 +287,7 @@ func (r *Renderer) drawBatch(dst, img *ebiten.Image, opts graphics.DrawOptions,
 		}
 	}
 
-	var drawOptions ebiten.DrawTrianglesOptions
-	dst.DrawTriangles(vertices, indices, img, &drawOptions)
+	if len(indices) != 0 {
+		dst.DrawTriangles(vertices, indices, img, &drawOptions)
+	}
 }
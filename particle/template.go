@@ -15,6 +15,8 @@ const (
 	angleRandBit
 	lifetimeRandBit
 	scalingRandBit
+	spinRandBit
+	flutterRandBit
 )
 
 var defaultPalette = []graphics.ColorScale{
@@ -81,6 +83,14 @@ type Template struct {
 	particleMaxSpeed  float32
 	particleSpeedStep float32
 
+	particleMinSpin  float32
+	particleSpinStep float32
+
+	particleGravity gmath.Vec32
+
+	flutterAmplitude float32
+	flutterFrequency float32
+
 	emitInterval        float32
 	particleMinLifetime float32
 	particleMaxLifetime float32
@@ -262,6 +272,47 @@ func (tmpl *Template) adjustParticleSpeed(minSpeed, maxSpeed float32) {
 	}
 }
 
+// SetParticleGravity assigns a constant acceleration (in pixels per second squared)
+// that is applied to the particles.
+// A positive Y value makes the particles fall down.
+func (tmpl *Template) SetParticleGravity(g gmath.Vec) {
+	tmpl.particleGravity = g.AsVec32()
+}
+
+// SetParticleSpinRange makes every particle rotate with a random
+// angular speed from the [minSpin, maxSpin] range (radians per second).
+// The spin doesn't affect the particle movement direction.
+func (tmpl *Template) SetParticleSpinRange(minSpin, maxSpin float64) {
+	spin := gmath.MakeRange(minSpin, maxSpin)
+	if !spin.IsValid() {
+		panic("invalid spin range")
+	}
+
+	if spin.Min != spin.Max {
+		tmpl.needsRandBits |= spinRandBit
+	} else {
+		tmpl.needsRandBits &^= spinRandBit
+	}
+
+	tmpl.particleMinSpin = float32(spin.Min)
+	tmpl.particleSpinStep = float32(spin.Max-spin.Min) / 255
+}
+
+// SetParticleFlutter makes the particles sway horizontally.
+// The amplitude is in pixels and the frequency is in oscillations per second.
+// Every particle gets its own oscillation phase.
+//
+// A zero amplitude disables the flutter.
+func (tmpl *Template) SetParticleFlutter(amplitude, frequency float64) {
+	if amplitude != 0 {
+		tmpl.needsRandBits |= flutterRandBit
+	} else {
+		tmpl.needsRandBits &^= flutterRandBit
+	}
+	tmpl.flutterAmplitude = float32(amplitude)
+	tmpl.flutterFrequency = float32(frequency)
+}
+
 func (tmpl *Template) SetEmitInterval(t float64) {
 	tmpl.emitInterval = float32(t)
 }
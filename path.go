@@ -0,0 +1,366 @@
+package graphics
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// Path is a stroked vector path primitive.
+// It consists of the straight lines and the quadratic or cubic Bezier curves.
+//
+// The path is built by the MoveTo, LineTo, QuadTo, CubicTo and Close calls,
+// just like the path APIs of the most vector graphics libraries.
+// The path points are relative to its Pos.
+//
+// The stroke is tessellated lazily: the vertex buffer is re-used
+// until the path or its stroke properties are changed,
+// so rendering a static path (like a projectile trajectory preview) is cheap.
+type Path struct {
+	Pos gmath.Pos
+
+	// subpaths are the flattened polylines.
+	subpaths []pathSubpath
+	points   []gmath.Vec
+	bounds   gmath.Rect
+
+	strokeWidth float64
+
+	colorScale       ColorScale
+	ebitenColorScale ebiten.ColorScale
+
+	// localVertices is a tessellated stroke in the path coordinates.
+	// vertices is the same stroke translated to the last rendering origin.
+	localVertices []ebiten.Vertex
+	vertices      []ebiten.Vertex
+	indices       []uint16
+	origin        gmath.Vec
+	dirty         bool
+
+	visible  bool
+	disposed bool
+	blendID  uint8
+}
+
+type pathSubpath struct {
+	// from and to are the subpath points range.
+	from   int
+	to     int
+	closed bool
+}
+
+// NewPath returns an empty path.
+//
+// By default, a path has these properties:
+// * Visible=true
+// * The ColorScale is {1, 1, 1, 1}
+// * StrokeWidth is 1
+func NewPath() *Path {
+	return &Path{
+		strokeWidth:      1,
+		colorScale:       defaultColorScale,
+		ebitenColorScale: defaultColorScale.ToEbitenColorScale(),
+		visible:          true,
+	}
+}
+
+// Clear removes all path segments.
+// The allocated buffers are kept for the re-use.
+func (p *Path) Clear() {
+	p.subpaths = p.subpaths[:0]
+	p.points = p.points[:0]
+	p.bounds = gmath.Rect{}
+	p.dirty = true
+}
+
+// MoveTo starts a new subpath at the specified point.
+func (p *Path) MoveTo(pos gmath.Vec) {
+	p.subpaths = append(p.subpaths, pathSubpath{from: len(p.points), to: len(p.points)})
+	p.addPoint(pos)
+}
+
+// LineTo adds a straight line to the specified point.
+// If there is no current subpath, it acts like MoveTo.
+func (p *Path) LineTo(pos gmath.Vec) {
+	if !p.hasCurrentPoint() {
+		p.MoveTo(pos)
+		return
+	}
+	p.addPoint(pos)
+}
+
+// QuadTo adds a quadratic Bezier curve to the specified point.
+// If there is no current subpath, it starts at the control point.
+func (p *Path) QuadTo(ctrl, pos gmath.Vec) {
+	if !p.hasCurrentPoint() {
+		p.MoveTo(ctrl)
+	}
+	start := p.points[len(p.points)-1]
+	n := pathNumCurveSegments(start.DistanceTo(ctrl) + ctrl.DistanceTo(pos))
+	for i := 1; i <= n; i++ {
+		t := float64(i) / float64(n)
+		u := 1 - t
+		p.addPoint(gmath.Vec{
+			X: u*u*start.X + 2*u*t*ctrl.X + t*t*pos.X,
+			Y: u*u*start.Y + 2*u*t*ctrl.Y + t*t*pos.Y,
+		})
+	}
+}
+
+// CubicTo adds a cubic Bezier curve to the specified point.
+// If there is no current subpath, it starts at the first control point.
+func (p *Path) CubicTo(ctrl1, ctrl2, pos gmath.Vec) {
+	if !p.hasCurrentPoint() {
+		p.MoveTo(ctrl1)
+	}
+	start := p.points[len(p.points)-1]
+	n := pathNumCurveSegments(start.DistanceTo(ctrl1) + ctrl1.DistanceTo(ctrl2) + ctrl2.DistanceTo(pos))
+	for i := 1; i <= n; i++ {
+		t := float64(i) / float64(n)
+		u := 1 - t
+		a := u * u * u
+		b := 3 * u * u * t
+		c := 3 * u * t * t
+		d := t * t * t
+		p.addPoint(gmath.Vec{
+			X: a*start.X + b*ctrl1.X + c*ctrl2.X + d*pos.X,
+			Y: a*start.Y + b*ctrl1.Y + c*ctrl2.Y + d*pos.Y,
+		})
+	}
+}
+
+// Close connects the current subpath end with its start.
+// The next segment will start a new subpath.
+func (p *Path) Close() {
+	if !p.hasCurrentPoint() {
+		return
+	}
+	sp := &p.subpaths[len(p.subpaths)-1]
+	sp.closed = true
+	p.dirty = true
+}
+
+func (p *Path) hasCurrentPoint() bool {
+	return len(p.subpaths) != 0 && !p.subpaths[len(p.subpaths)-1].closed
+}
+
+func (p *Path) addPoint(pos gmath.Vec) {
+	sp := &p.subpaths[len(p.subpaths)-1]
+	if sp.to > sp.from && p.points[sp.to-1] == pos {
+		// Skip the zero-length segments.
+		return
+	}
+	if len(p.points) == 0 {
+		p.bounds = gmath.Rect{Min: pos, Max: pos}
+	} else {
+		p.bounds.Min.X = min(p.bounds.Min.X, pos.X)
+		p.bounds.Min.Y = min(p.bounds.Min.Y, pos.Y)
+		p.bounds.Max.X = max(p.bounds.Max.X, pos.X)
+		p.bounds.Max.Y = max(p.bounds.Max.Y, pos.Y)
+	}
+	p.points = append(p.points, pos)
+	sp.to++
+	p.dirty = true
+}
+
+// pathNumCurveSegments returns a number of line segments
+// used to approximate a curve of the specified approximate length.
+func pathNumCurveSegments(length float64) int {
+	return gmath.Clamp(int(math.Ceil(length/4)), 2, 64)
+}
+
+// BoundsRect returns the path bounding rectangle.
+// The stroke width is not taken into account.
+//
+// This is useful when trying to calculate whether this object is contained
+// inside some area or not (like a camera view area).
+func (p *Path) BoundsRect() gmath.Rect {
+	return p.bounds.Add(p.Pos.Resolve())
+}
+
+// Dispose marks this path for deletion.
+// After calling this method, IsDisposed will report true.
+func (p *Path) Dispose() {
+	p.disposed = true
+}
+
+// IsDisposed reports whether this path is marked for deletion.
+// IsDisposed returns true only after Disposed was called on this path.
+func (p *Path) IsDisposed() bool {
+	return p.disposed
+}
+
+// IsVisible reports whether this path is visible.
+// Use SetVisibility to change this flag value.
+//
+// When path is invisible (visible=false), it will not be rendered at all.
+// This is an efficient way to temporarily hide a path.
+func (p *Path) IsVisible() bool { return p.visible }
+
+// SetVisibility changes the Visible flag value.
+// It can be used to show or hide the path.
+// Use IsVisible to get the current flag value.
+func (p *Path) SetVisibility(visible bool) { p.visible = visible }
+
+// GetStrokeWidth reports the current stroke width.
+// Use SetStrokeWidth to change it.
+func (p *Path) GetStrokeWidth() float64 {
+	return p.strokeWidth
+}
+
+// SetStrokeWidth changes the stroke width.
+// The stroke is centered around the path.
+func (p *Path) SetStrokeWidth(w float64) {
+	if p.strokeWidth == w {
+		return
+	}
+	p.strokeWidth = w
+	p.dirty = true
+}
+
+// GetColorScale is used to retrieve the current color scale value of the path.
+// Use SetColorScale to change it.
+func (p *Path) GetColorScale() ColorScale {
+	return p.colorScale
+}
+
+// SetColorScale assigns a new ColorScale to this path.
+// Use GetColorScale to retrieve the current color scale.
+func (p *Path) SetColorScale(cs ColorScale) {
+	if p.colorScale == cs {
+		return
+	}
+	p.colorScale = cs
+	p.ebitenColorScale = cs.ToEbitenColorScale()
+	p.dirty = true
+}
+
+// Draw renders the path onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (p *Path) Draw(dst *ebiten.Image) {
+	p.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the path onto the provided dst image
+// while also using the extra provided offset and other options.
+func (p *Path) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !p.visible || p.colorScale.A == 0 || p.strokeWidth <= 0 {
+		return
+	}
+
+	opts.Blend = resolveBlend(p.blendID, opts.Blend)
+
+	origin := p.Pos.Resolve().Add(opts.Offset)
+	if p.dirty {
+		p.tessellate()
+		p.dirty = false
+		p.translate(origin)
+	} else if origin != p.origin {
+		p.translate(origin)
+	}
+	if len(p.indices) == 0 {
+		return
+	}
+
+	var drawOptions ebiten.DrawTrianglesOptions
+	if opts.Blend != nil {
+		drawOptions.Blend = *opts.Blend
+	}
+	drawVertexColorTriangles(dst, p.vertices, p.indices, emptyImage, &drawOptions)
+}
+
+func (p *Path) translate(origin gmath.Vec) {
+	p.origin = origin
+	p.vertices = append(p.vertices[:0], p.localVertices...)
+	x := float32(origin.X)
+	y := float32(origin.Y)
+	for i := range p.vertices {
+		p.vertices[i].DstX += x
+		p.vertices[i].DstY += y
+	}
+}
+
+func (p *Path) tessellate() {
+	p.localVertices = p.localVertices[:0]
+	p.indices = p.indices[:0]
+
+	cs := p.ebitenColorScale
+	vertex := func(pos gmath.Vec) ebiten.Vertex {
+		return ebiten.Vertex{
+			DstX:   float32(pos.X),
+			DstY:   float32(pos.Y),
+			SrcX:   1.5,
+			SrcY:   1.5,
+			ColorR: cs.R(),
+			ColorG: cs.G(),
+			ColorB: cs.B(),
+			ColorA: cs.A(),
+		}
+	}
+
+	halfWidth := p.strokeWidth * 0.5
+	for _, sp := range p.subpaths {
+		points := p.points[sp.from:sp.to]
+		n := len(points)
+		if n < 2 {
+			continue
+		}
+		closed := sp.closed && n > 2
+
+		base := uint16(len(p.localVertices))
+		for i, pt := range points {
+			var offset gmath.Vec
+			switch {
+			case closed:
+				offset = polygonMiterOffset(points[(i+n-1)%n], pt, points[(i+1)%n], halfWidth)
+			case i == 0:
+				offset = edgeNormal(pt, points[1]).Mulf(halfWidth)
+			case i == n-1:
+				offset = edgeNormal(points[i-1], pt).Mulf(halfWidth)
+			default:
+				offset = polygonMiterOffset(points[i-1], pt, points[i+1], halfWidth)
+			}
+			p.localVertices = append(p.localVertices,
+				vertex(pt.Add(offset)),
+				vertex(pt.Sub(offset)),
+			)
+		}
+
+		numSegments := n - 1
+		if closed {
+			numSegments = n
+		}
+		for i := 0; i < numSegments; i++ {
+			j := base + uint16(i)*2
+			k := base + uint16((i+1)%n)*2
+			p.indices = append(p.indices,
+				j, j+1, k,
+				j+1, k, k+1,
+			)
+		}
+	}
+}
+
+// GetBlend returns the blend mode assigned by SetBlend.
+// The second result value is false if there is no blend override.
+func (p *Path) GetBlend() (ebiten.Blend, bool) {
+	return getBlend(p.blendID)
+}
+
+// SetBlend assigns a blend mode that is used to render this path.
+// It takes priority over the DrawOptions.Blend value.
+// Use ResetBlend to remove the override.
+func (p *Path) SetBlend(b ebiten.Blend) {
+	p.blendID = internBlend(b)
+}
+
+// ResetBlend removes the blend mode override.
+// The DrawOptions.Blend value (if any) will be used again.
+func (p *Path) ResetBlend() {
+	p.blendID = 0
+}
@@ -0,0 +1,49 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/quasilyte/gmath"
+)
+
+func TestPathTessellate(t *testing.T) {
+	p := NewPath()
+	p.MoveTo(gmath.Vec{X: 0, Y: 0})
+	p.LineTo(gmath.Vec{X: 10, Y: 0})
+	p.LineTo(gmath.Vec{X: 10, Y: 0}) // A zero-length segment is ignored
+	p.QuadTo(gmath.Vec{X: 20, Y: 0}, gmath.Vec{X: 20, Y: 10})
+
+	if have := len(p.subpaths); have != 1 {
+		t.Fatalf("subpaths:\nhave: %d\nwant: 1", have)
+	}
+	last := p.points[len(p.points)-1]
+	if !last.EqualApprox(gmath.Vec{X: 20, Y: 10}) {
+		t.Fatalf("curve end:\nhave: %v\nwant: [20, 10]", last)
+	}
+	wantBounds := gmath.Rect{Max: gmath.Vec{X: 20, Y: 10}}
+	if p.bounds != wantBounds {
+		t.Fatalf("bounds:\nhave: %v\nwant: %v", p.bounds, wantBounds)
+	}
+
+	n := len(p.points)
+	p.tessellate()
+	if have, want := len(p.localVertices), n*2; have != want {
+		t.Fatalf("open path vertices:\nhave: %d\nwant: %d", have, want)
+	}
+	if have, want := len(p.indices), (n-1)*6; have != want {
+		t.Fatalf("open path indices:\nhave: %d\nwant: %d", have, want)
+	}
+
+	p.Close()
+	p.tessellate()
+	if have, want := len(p.indices), n*6; have != want {
+		t.Fatalf("closed path indices:\nhave: %d\nwant: %d", have, want)
+	}
+
+	// A new subpath starts after Close.
+	p.LineTo(gmath.Vec{X: 50, Y: 50})
+	p.LineTo(gmath.Vec{X: 60, Y: 50})
+	if have := len(p.subpaths); have != 2 {
+		t.Fatalf("subpaths after close:\nhave: %d\nwant: 2", have)
+	}
+}
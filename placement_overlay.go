@@ -0,0 +1,205 @@
+package graphics
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+	"github.com/quasilyte/gmath"
+)
+
+// PlacementOverlayConfig describes the [PlacementOverlay] grid and colors.
+type PlacementOverlayConfig struct {
+	// Origin is a grid top-left corner position in world coordinates.
+	Origin gmath.Vec
+
+	// CellSize is a grid cell size in pixels.
+	// A zero value means 32.
+	CellSize float64
+
+	// CheckCell reports whether a grid cell can be occupied.
+	// A nil function means that all cells are valid.
+	CheckCell func(col, row int) bool
+
+	// ValidColorScale is a fill color of the valid cells.
+	// A zero value means a half-transparent green color.
+	ValidColorScale ColorScale
+
+	// InvalidColorScale is a fill color of the invalid cells.
+	// A zero value means a half-transparent red color.
+	InvalidColorScale ColorScale
+}
+
+// PlacementOverlay highlights the grid cells under a building footprint.
+//
+// The footprint follows the cursor position provided by the caller
+// and snaps to the grid. Every cell under the footprint is colored
+// depending on the CheckCell result, the footprint outline is colored
+// depending on whether the entire footprint is valid.
+//
+// The overlay works in world coordinates, so it should be added to a [Layer].
+//
+// PlacementOverlay implements gscene Graphics interface.
+type PlacementOverlay struct {
+	config PlacementOverlayConfig
+
+	validColor   ebiten.ColorScale
+	invalidColor ebiten.ColorScale
+
+	col    int
+	row    int
+	width  int
+	height int
+
+	visible  bool
+	disposed bool
+}
+
+// NewPlacementOverlay creates an overlay with a 1x1 footprint.
+func NewPlacementOverlay(config PlacementOverlayConfig) *PlacementOverlay {
+	if config.CellSize == 0 {
+		config.CellSize = 32
+	}
+	if config.ValidColorScale == (ColorScale{}) {
+		config.ValidColorScale = ColorScale{R: 0.3, G: 1, B: 0.3, A: 0.4}
+	}
+	if config.InvalidColorScale == (ColorScale{}) {
+		config.InvalidColorScale = ColorScale{R: 1, G: 0.3, B: 0.3, A: 0.4}
+	}
+	return &PlacementOverlay{
+		config:       config,
+		validColor:   config.ValidColorScale.ToEbitenColorScale(),
+		invalidColor: config.InvalidColorScale.ToEbitenColorScale(),
+		width:        1,
+		height:       1,
+		visible:      true,
+	}
+}
+
+// SetFootprint changes the footprint size (in cells).
+func (o *PlacementOverlay) SetFootprint(width, height int) {
+	o.width = width
+	o.height = height
+}
+
+// SetCursorPos moves the footprint, so its center is as close
+// to the pos as possible while being aligned to the grid.
+//
+// The pos parameter should be in world coordinates.
+func (o *PlacementOverlay) SetCursorPos(pos gmath.Vec) {
+	local := pos.Sub(o.config.Origin).Mulf(1 / o.config.CellSize)
+	o.col = int(math.Floor(local.X - float64(o.width)*0.5 + 0.5))
+	o.row = int(math.Floor(local.Y - float64(o.height)*0.5 + 0.5))
+}
+
+// GetCell returns the footprint's top-left cell coordinates.
+func (o *PlacementOverlay) GetCell() (col, row int) {
+	return o.col, o.row
+}
+
+// IsValid reports whether all footprint cells are valid.
+func (o *PlacementOverlay) IsValid() bool {
+	if o.config.CheckCell == nil {
+		return true
+	}
+	for row := o.row; row < o.row+o.height; row++ {
+		for col := o.col; col < o.col+o.width; col++ {
+			if !o.config.CheckCell(col, row) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// BoundsRect returns the footprint rectangle in world coordinates.
+func (o *PlacementOverlay) BoundsRect() gmath.Rect {
+	topLeft := o.config.Origin.Add(gmath.Vec{
+		X: float64(o.col) * o.config.CellSize,
+		Y: float64(o.row) * o.config.CellSize,
+	})
+	return gmath.Rect{
+		Min: topLeft,
+		Max: topLeft.Add(gmath.Vec{
+			X: float64(o.width) * o.config.CellSize,
+			Y: float64(o.height) * o.config.CellSize,
+		}),
+	}
+}
+
+// Dispose marks this overlay for deletion.
+// After calling this method, IsDisposed will report true.
+func (o *PlacementOverlay) Dispose() { o.disposed = true }
+
+// IsDisposed reports whether this overlay is marked for deletion.
+func (o *PlacementOverlay) IsDisposed() bool { return o.disposed }
+
+// IsVisible reports whether this overlay is visible.
+// Use SetVisibility to change this flag value.
+func (o *PlacementOverlay) IsVisible() bool { return o.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (o *PlacementOverlay) SetVisibility(visible bool) { o.visible = visible }
+
+// Draw renders the overlay onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (o *PlacementOverlay) Draw(dst *ebiten.Image) {
+	o.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the overlay onto the provided dst image
+// while also using the extra provided offset.
+func (o *PlacementOverlay) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !o.visible || o.width <= 0 || o.height <= 0 {
+		return
+	}
+
+	vertices := cache.Global.ScratchVertices[:0]
+	indices := cache.Global.ScratchIndices[:0]
+	defer func() {
+		cache.Global.ScratchVertices = vertices[:0]
+		cache.Global.ScratchIndices = indices[:0]
+	}()
+
+	bounds := o.BoundsRect().Add(opts.Offset)
+	cellSize := o.config.CellSize
+	allValid := true
+	for row := 0; row < o.height; row++ {
+		for col := 0; col < o.width; col++ {
+			valid := o.config.CheckCell == nil || o.config.CheckCell(o.col+col, o.row+row)
+			cs := o.validColor
+			if !valid {
+				cs = o.invalidColor
+				allValid = false
+			}
+			// A 1 pixel gap makes the individual cells distinguishable.
+			x := bounds.Min.X + float64(col)*cellSize
+			y := bounds.Min.Y + float64(row)*cellSize
+			vertices, indices = appendRectQuad(vertices, indices, x+1, y+1, cellSize-2, cellSize-2, cs, 1)
+		}
+	}
+
+	var drawOptions ebiten.DrawTrianglesOptions
+	if opts.Blend != nil {
+		drawOptions.Blend = *opts.Blend
+	}
+	drawVertexColorTriangles(dst, vertices, indices, emptyImage, &drawOptions)
+
+	outlineColor := o.config.ValidColorScale
+	if !allValid {
+		outlineColor = o.config.InvalidColorScale
+	}
+	outlineColor.A = 1
+	cs := outlineColor.ToEbitenColorScale()
+	topRight := gmath.Vec{X: bounds.Max.X, Y: bounds.Min.Y}
+	bottomLeft := gmath.Vec{X: bounds.Min.X, Y: bounds.Max.Y}
+	drawLine(dst, opts.Blend, bounds.Min, topRight, 1, cs)
+	drawLine(dst, opts.Blend, topRight, bounds.Max, 1, cs)
+	drawLine(dst, opts.Blend, bounds.Max, bottomLeft, 1, cs)
+	drawLine(dst, opts.Blend, bottomLeft, bounds.Min, 1, cs)
+}
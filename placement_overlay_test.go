@@ -0,0 +1,50 @@
+package graphics_test
+
+import (
+	"testing"
+
+	graphics "github.com/quasilyte/ebitengine-graphics"
+	"github.com/quasilyte/gmath"
+)
+
+func TestPlacementOverlaySnapping(t *testing.T) {
+	o := graphics.NewPlacementOverlay(graphics.PlacementOverlayConfig{
+		Origin:   gmath.Vec{X: 100, Y: 100},
+		CellSize: 10,
+		CheckCell: func(col, row int) bool {
+			return col != 5 || row != 5
+		},
+	})
+
+	tests := []struct {
+		width  int
+		height int
+		pos    gmath.Vec
+		col    int
+		row    int
+		valid  bool
+	}{
+		{1, 1, gmath.Vec{X: 105, Y: 105}, 0, 0, true},
+		{1, 1, gmath.Vec{X: 109, Y: 119}, 0, 1, true},
+		{1, 1, gmath.Vec{X: 99, Y: 100}, -1, 0, true},
+		{2, 2, gmath.Vec{X: 121, Y: 121}, 1, 1, true},
+		{2, 2, gmath.Vec{X: 126, Y: 126}, 2, 2, true},
+		{3, 1, gmath.Vec{X: 155, Y: 155}, 4, 5, false},
+		{1, 1, gmath.Vec{X: 155, Y: 155}, 5, 5, false},
+		{1, 1, gmath.Vec{X: 165, Y: 155}, 6, 5, true},
+	}
+
+	for _, test := range tests {
+		o.SetFootprint(test.width, test.height)
+		o.SetCursorPos(test.pos)
+		col, row := o.GetCell()
+		if col != test.col || row != test.row {
+			t.Fatalf("%dx%d at %v:\nhave: (%d, %d)\nwant: (%d, %d)",
+				test.width, test.height, test.pos, col, row, test.col, test.row)
+		}
+		if have := o.IsValid(); have != test.valid {
+			t.Fatalf("%dx%d at %v: valid:\nhave: %v\nwant: %v",
+				test.width, test.height, test.pos, have, test.valid)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package graphics
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+	"github.com/quasilyte/gmath"
+)
+
+// Polygon is a filled simple polygon primitive with an optional outline.
+// The polygon can be either convex or concave (but not self-intersecting).
+//
+// The polygon is triangulated only when its points are changed,
+// so rendering a static shape is cheap.
+// The fill and outline are rendered in a single draw call.
+//
+// The polygon points are relative to its Pos.
+type Polygon struct {
+	Pos gmath.Pos
+
+	points  []gmath.Vec
+	indices []uint16
+	bounds  gmath.Rect
+
+	outlineWidth float64
+
+	fillColorScale          ColorScale
+	outlineColorScale       ColorScale
+	ebitenFillColorScale    ebiten.ColorScale
+	ebitenOutlineColorScale ebiten.ColorScale
+
+	visible  bool
+	disposed bool
+	blendID  uint8
+}
+
+// NewPolygon returns a polygon with the specified points.
+// See SetPoints for more info.
+//
+// By default, a polygon has these properties:
+// * Visible=true
+// * The FillColorScale is {1, 1, 1, 1}
+// * The OutlineColorScale is {0, 0, 0, 0} (invisible)
+// * OutlineWidth is 0
+func NewPolygon(points []gmath.Vec) *Polygon {
+	p := &Polygon{
+		fillColorScale:          defaultColorScale,
+		ebitenFillColorScale:    defaultColorScale.ToEbitenColorScale(),
+		outlineColorScale:       transparentColor,
+		ebitenOutlineColorScale: transparentColor.ToEbitenColorScale(),
+		visible:                 true,
+	}
+	p.SetPoints(points)
+	return p
+}
+
+// GetPoints returns the polygon points.
+// The returned slice should not be modified; use SetPoints instead.
+func (p *Polygon) GetPoints() []gmath.Vec {
+	return p.points
+}
+
+// SetPoints assigns a new polygon shape.
+// The points can be either in clockwise or counter-clockwise order.
+// The points slice is copied, so it can be re-used by the caller.
+//
+// This method triangulates the polygon, so it's more expensive than drawing.
+func (p *Polygon) SetPoints(points []gmath.Vec) {
+	p.points = append(p.points[:0], points...)
+	p.indices = triangulatePolygon(p.indices[:0], p.points)
+
+	p.bounds = gmath.Rect{}
+	for i, pt := range p.points {
+		if i == 0 {
+			p.bounds = gmath.Rect{Min: pt, Max: pt}
+			continue
+		}
+		p.bounds.Min.X = min(p.bounds.Min.X, pt.X)
+		p.bounds.Min.Y = min(p.bounds.Min.Y, pt.Y)
+		p.bounds.Max.X = max(p.bounds.Max.X, pt.X)
+		p.bounds.Max.Y = max(p.bounds.Max.Y, pt.Y)
+	}
+}
+
+// BoundsRect returns the polygon bounding rectangle.
+// The outline width is not taken into account.
+//
+// This is useful when trying to calculate whether this object is contained
+// inside some area or not (like a camera view area).
+func (p *Polygon) BoundsRect() gmath.Rect {
+	return p.bounds.Add(p.Pos.Resolve())
+}
+
+// Dispose marks this polygon for deletion.
+// After calling this method, IsDisposed will report true.
+func (p *Polygon) Dispose() {
+	p.disposed = true
+}
+
+// IsDisposed reports whether this polygon is marked for deletion.
+// IsDisposed returns true only after Disposed was called on this polygon.
+func (p *Polygon) IsDisposed() bool {
+	return p.disposed
+}
+
+// IsVisible reports whether this polygon is visible.
+// Use SetVisibility to change this flag value.
+//
+// When polygon is invisible (visible=false), it will not be rendered at all.
+// This is an efficient way to temporarily hide a polygon.
+func (p *Polygon) IsVisible() bool { return p.visible }
+
+// SetVisibility changes the Visible flag value.
+// It can be used to show or hide the polygon.
+// Use IsVisible to get the current flag value.
+func (p *Polygon) SetVisibility(visible bool) { p.visible = visible }
+
+// GetOutlineWidth reports the current outline width.
+// Use SetOutlineWidth to change it.
+func (p *Polygon) GetOutlineWidth() float64 {
+	return p.outlineWidth
+}
+
+// SetOutlineWidth changes the outline width.
+// The outline is centered around the polygon edges.
+func (p *Polygon) SetOutlineWidth(w float64) {
+	p.outlineWidth = w
+}
+
+// GetFillColorScale is used to retrieve the current fill color scale value of the polygon.
+// Use SetFillColorScale to change it.
+func (p *Polygon) GetFillColorScale() ColorScale {
+	return p.fillColorScale
+}
+
+// SetFillColorScale assigns a new fill ColorScale to this polygon.
+// Use GetFillColorScale to retrieve the current color scale.
+func (p *Polygon) SetFillColorScale(cs ColorScale) {
+	if p.fillColorScale == cs {
+		return
+	}
+	p.fillColorScale = cs
+	p.ebitenFillColorScale = cs.ToEbitenColorScale()
+}
+
+// GetOutlineColorScale is used to retrieve the current outline color scale value of the polygon.
+// Use SetOutlineColorScale to change it.
+func (p *Polygon) GetOutlineColorScale() ColorScale {
+	return p.outlineColorScale
+}
+
+// SetOutlineColorScale assigns a new outline ColorScale to this polygon.
+// Use GetOutlineColorScale to retrieve the current color scale.
+func (p *Polygon) SetOutlineColorScale(cs ColorScale) {
+	if p.outlineColorScale == cs {
+		return
+	}
+	p.outlineColorScale = cs
+	p.ebitenOutlineColorScale = cs.ToEbitenColorScale()
+}
+
+// Draw renders the polygon onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (p *Polygon) Draw(dst *ebiten.Image) {
+	p.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the polygon onto the provided dst image
+// while also using the extra provided offset and other options.
+func (p *Polygon) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !p.visible || len(p.points) < 3 {
+		return
+	}
+	hasOutline := p.outlineColorScale.A != 0 && p.outlineWidth > 0
+	if p.fillColorScale.A == 0 && !hasOutline {
+		return
+	}
+
+	opts.Blend = resolveBlend(p.blendID, opts.Blend)
+
+	vertices := cache.Global.ScratchVertices[:0]
+	indices := cache.Global.ScratchIndices[:0]
+	defer func() {
+		cache.Global.ScratchVertices = vertices[:0]
+		cache.Global.ScratchIndices = indices[:0]
+	}()
+
+	origin := p.Pos.Resolve().Add(opts.Offset)
+	vertex := func(pt gmath.Vec, cs ebiten.ColorScale) ebiten.Vertex {
+		return ebiten.Vertex{
+			DstX:   float32(origin.X + pt.X),
+			DstY:   float32(origin.Y + pt.Y),
+			SrcX:   1.5,
+			SrcY:   1.5,
+			ColorR: cs.R(),
+			ColorG: cs.G(),
+			ColorB: cs.B(),
+			ColorA: cs.A(),
+		}
+	}
+
+	if p.fillColorScale.A != 0 {
+		cs := p.ebitenFillColorScale
+		for _, pt := range p.points {
+			vertices = append(vertices, vertex(pt, cs))
+		}
+		indices = append(indices, p.indices...)
+	}
+
+	if hasOutline {
+		cs := p.ebitenOutlineColorScale
+		base := uint16(len(vertices))
+		n := len(p.points)
+		for i, pt := range p.points {
+			offset := polygonMiterOffset(p.points[(i+n-1)%n], pt, p.points[(i+1)%n], p.outlineWidth*0.5)
+			vertices = append(vertices,
+				vertex(pt.Add(offset), cs),
+				vertex(pt.Sub(offset), cs),
+			)
+		}
+		for i := uint16(0); i < uint16(n); i++ {
+			j := base + i*2
+			k := base + ((i+1)%uint16(n))*2
+			indices = append(indices,
+				j, j+1, k,
+				j+1, k, k+1,
+			)
+		}
+	}
+
+	var drawOptions ebiten.DrawTrianglesOptions
+	if opts.Blend != nil {
+		drawOptions.Blend = *opts.Blend
+	}
+	drawVertexColorTriangles(dst, vertices, indices, emptyImage, &drawOptions)
+}
+
+// polygonMiterOffset returns the vertex b outline offset
+// for the a->b and b->c edges joined with a miter.
+//
+// The miter length is limited, so the sharp corners are not
+// turned into the long spikes.
+func polygonMiterOffset(a, b, c gmath.Vec, halfWidth float64) gmath.Vec {
+	n1 := edgeNormal(a, b)
+	n2 := edgeNormal(b, c)
+	miter := n1.Add(n2)
+	if miter.IsZero() {
+		// A 180 degrees turn.
+		return n1.Mulf(halfWidth)
+	}
+	miter = miter.Normalized()
+	const maxMiterScale = 2.0
+	scale := min(1/miter.Dot(n1), maxMiterScale)
+	return miter.Mulf(halfWidth * scale)
+}
+
+func edgeNormal(a, b gmath.Vec) gmath.Vec {
+	d := b.Sub(a)
+	if d.IsZero() {
+		return gmath.Vec{}
+	}
+	return gmath.Vec{X: -d.Y, Y: d.X}.Normalized()
+}
+
+// GetBlend returns the blend mode assigned by SetBlend.
+// The second result value is false if there is no blend override.
+func (p *Polygon) GetBlend() (ebiten.Blend, bool) {
+	return getBlend(p.blendID)
+}
+
+// SetBlend assigns a blend mode that is used to render this polygon.
+// It takes priority over the DrawOptions.Blend value.
+// Use ResetBlend to remove the override.
+func (p *Polygon) SetBlend(b ebiten.Blend) {
+	p.blendID = internBlend(b)
+}
+
+// ResetBlend removes the blend mode override.
+// The DrawOptions.Blend value (if any) will be used again.
+func (p *Polygon) ResetBlend() {
+	p.blendID = 0
+}
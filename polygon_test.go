@@ -0,0 +1,33 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/quasilyte/gmath"
+)
+
+func TestPolygonMiterOffset(t *testing.T) {
+	tests := []struct {
+		a, b, c gmath.Vec
+		want    gmath.Vec
+	}{
+		// A straight line.
+		{gmath.Vec{X: 0}, gmath.Vec{X: 10}, gmath.Vec{X: 20}, gmath.Vec{Y: 1}},
+		// A right angle corner.
+		{gmath.Vec{X: 0}, gmath.Vec{X: 10}, gmath.Vec{X: 10, Y: 10}, gmath.Vec{X: -1, Y: 1}},
+		// A 180 degrees turn.
+		{gmath.Vec{X: 0}, gmath.Vec{X: 10}, gmath.Vec{X: 0}, gmath.Vec{Y: 1}},
+	}
+	for _, test := range tests {
+		have := polygonMiterOffset(test.a, test.b, test.c, 1)
+		if !have.EqualApprox(test.want) {
+			t.Fatalf("miter(%v, %v, %v):\nhave: %v\nwant: %v", test.a, test.b, test.c, have, test.want)
+		}
+	}
+
+	// A very sharp corner miter is limited.
+	have := polygonMiterOffset(gmath.Vec{X: 0}, gmath.Vec{X: 10}, gmath.Vec{X: 0, Y: 1}, 1)
+	if have.Len() > 2.0001 {
+		t.Fatalf("sharp corner miter is too long: %v", have.Len())
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"math"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
 	"github.com/quasilyte/gmath"
 )
 
@@ -17,7 +18,24 @@ func init() {
 	emptyImage.Fill(color.White)
 }
 
+// drawVertexColorTriangles renders the triangles that carry their colors in the vertices.
+//
+// The vertex colors are expected to be premultiplied by alpha
+// (like the ToEbitenColorScale results), so the premultiplied color scale
+// mode is used; with the default straight alpha mode the translucent
+// colors would get their alpha applied twice.
+// All vertex-colored DrawTriangles calls should go through this function.
+func drawVertexColorTriangles(dst *ebiten.Image, vertices []ebiten.Vertex, indices []uint16, src *ebiten.Image, drawOptions *ebiten.DrawTrianglesOptions) {
+	drawOptions.ColorScaleMode = ebiten.ColorScaleModePremultipliedAlpha
+	drawTriangles(dst, vertices, indices, src, drawOptions)
+}
+
 func drawLine(dst *ebiten.Image, blend *ebiten.Blend, pos1, pos2 gmath.Vec, width float64, cs ebiten.ColorScale) {
+	drawLineWithGeoM(dst, blend, lineGeoM(pos1, pos2, width), cs)
+}
+
+// lineGeoM returns a transformation that turns a white pixel into a line.
+func lineGeoM(pos1, pos2 gmath.Vec, width float64) ebiten.GeoM {
 	// TODO: compare with vector API.
 
 	x1 := pos1.X
@@ -27,15 +45,218 @@ func drawLine(dst *ebiten.Image, blend *ebiten.Blend, pos1, pos2 gmath.Vec, widt
 
 	length := math.Hypot(x2-x1, y2-y1)
 
+	var m ebiten.GeoM
+	m.Scale(length, width)
+	m.Rotate(math.Atan2(y2-y1, x2-x1))
+	m.Translate(x1, y1)
+	return m
+}
+
+func drawLineWithGeoM(dst *ebiten.Image, blend *ebiten.Blend, m ebiten.GeoM, cs ebiten.ColorScale) {
 	var drawOptions ebiten.DrawImageOptions
 	if blend != nil {
 		drawOptions.Blend = *blend
 	}
-	drawOptions.GeoM.Scale(length, width)
-	drawOptions.GeoM.Rotate(math.Atan2(y2-y1, x2-x1))
-	drawOptions.GeoM.Translate(x1, y1)
-
+	drawOptions.GeoM = m
 	drawOptions.ColorScale = cs
 
-	dst.DrawImage(whitePixel, &drawOptions)
+	drawImage(dst, whitePixel, &drawOptions)
+}
+
+// drawPill draws a filled rect with fully rounded left and right sides.
+func drawPill(dst *ebiten.Image, blend *ebiten.Blend, rect gmath.Rect, cs ebiten.ColorScale) {
+	const capSegments = 8
+
+	vertices := cache.Global.ScratchVertices[:0]
+	indices := cache.Global.ScratchIndices[:0]
+	defer func() {
+		cache.Global.ScratchVertices = vertices[:0]
+		cache.Global.ScratchIndices = indices[:0]
+	}()
+
+	r := min(rect.Width(), rect.Height()) * 0.5
+	center := rect.Center()
+	rightCap := gmath.Vec{X: rect.Max.X - r, Y: center.Y}
+	leftCap := gmath.Vec{X: rect.Min.X + r, Y: center.Y}
+
+	vertex := func(pos gmath.Vec) ebiten.Vertex {
+		return ebiten.Vertex{
+			DstX:   float32(pos.X),
+			DstY:   float32(pos.Y),
+			SrcX:   1.5,
+			SrcY:   1.5,
+			ColorR: cs.R(),
+			ColorG: cs.G(),
+			ColorB: cs.B(),
+			ColorA: cs.A(),
+		}
+	}
+
+	// A triangle fan: the center vertex and the outline vertices.
+	vertices = append(vertices, vertex(center))
+	for i := 0; i <= capSegments; i++ {
+		angle := gmath.Rad(-math.Pi/2 + math.Pi*float64(i)/capSegments)
+		vertices = append(vertices, vertex(rightCap.Add(gmath.RadToVec(angle).Mulf(r))))
+	}
+	for i := 0; i <= capSegments; i++ {
+		angle := gmath.Rad(math.Pi/2 + math.Pi*float64(i)/capSegments)
+		vertices = append(vertices, vertex(leftCap.Add(gmath.RadToVec(angle).Mulf(r))))
+	}
+	numOutline := uint16(len(vertices) - 1)
+	for i := uint16(0); i < numOutline; i++ {
+		indices = append(indices, 0, 1+i, 1+(i+1)%numOutline)
+	}
+
+	var drawOptions ebiten.DrawTrianglesOptions
+	if blend != nil {
+		drawOptions.Blend = *blend
+	}
+	drawVertexColorTriangles(dst, vertices, indices, emptyImage, &drawOptions)
+}
+
+// drawEllipse draws a filled axis-aligned ellipse.
+func drawEllipse(dst *ebiten.Image, blend *ebiten.Blend, center gmath.Vec, rx, ry float64, cs ebiten.ColorScale) {
+	const numSegments = 24
+
+	vertices := cache.Global.ScratchVertices[:0]
+	indices := cache.Global.ScratchIndices[:0]
+	defer func() {
+		cache.Global.ScratchVertices = vertices[:0]
+		cache.Global.ScratchIndices = indices[:0]
+	}()
+
+	vertex := func(x, y float64) ebiten.Vertex {
+		return ebiten.Vertex{
+			DstX:   float32(x),
+			DstY:   float32(y),
+			SrcX:   1.5,
+			SrcY:   1.5,
+			ColorR: cs.R(),
+			ColorG: cs.G(),
+			ColorB: cs.B(),
+			ColorA: cs.A(),
+		}
+	}
+
+	vertices = append(vertices, vertex(center.X, center.Y))
+	for i := 0; i < numSegments; i++ {
+		sin, cos := math.Sincos(2 * math.Pi * float64(i) / numSegments)
+		vertices = append(vertices, vertex(center.X+cos*rx, center.Y+sin*ry))
+	}
+	for i := uint16(0); i < numSegments; i++ {
+		indices = append(indices, 0, 1+i, 1+(i+1)%numSegments)
+	}
+
+	var drawOptions ebiten.DrawTrianglesOptions
+	if blend != nil {
+		drawOptions.Blend = *blend
+	}
+	drawVertexColorTriangles(dst, vertices, indices, emptyImage, &drawOptions)
+}
+
+// drawTriangle draws a filled triangle.
+func drawTriangle(dst *ebiten.Image, blend *ebiten.Blend, a, b, c gmath.Vec, cs ebiten.ColorScale) {
+	vertex := func(pos gmath.Vec) ebiten.Vertex {
+		return ebiten.Vertex{
+			DstX:   float32(pos.X),
+			DstY:   float32(pos.Y),
+			SrcX:   1.5,
+			SrcY:   1.5,
+			ColorR: cs.R(),
+			ColorG: cs.G(),
+			ColorB: cs.B(),
+			ColorA: cs.A(),
+		}
+	}
+	vertices := [3]ebiten.Vertex{vertex(a), vertex(b), vertex(c)}
+	indices := [3]uint16{0, 1, 2}
+
+	var drawOptions ebiten.DrawTrianglesOptions
+	if blend != nil {
+		drawOptions.Blend = *blend
+	}
+	drawVertexColorTriangles(dst, vertices[:], indices[:], emptyImage, &drawOptions)
+}
+
+// drawDashedLine is like drawLine, but it renders only the dash segments.
+// See forEachDash for the dash pattern description.
+func drawDashedLine(dst *ebiten.Image, blend *ebiten.Blend, pos1, pos2 gmath.Vec, width, dashLength, gap, phase float64, cs ebiten.ColorScale) {
+	vertices := cache.Global.ScratchVertices[:0]
+	indices := cache.Global.ScratchIndices[:0]
+	defer func() {
+		cache.Global.ScratchVertices = vertices[:0]
+		cache.Global.ScratchIndices = indices[:0]
+	}()
+
+	var drawOptions ebiten.DrawTrianglesOptions
+	if blend != nil {
+		drawOptions.Blend = *blend
+	}
+
+	vertex := func(pos gmath.Vec) ebiten.Vertex {
+		return ebiten.Vertex{
+			DstX:   float32(pos.X),
+			DstY:   float32(pos.Y),
+			SrcX:   1.5,
+			SrcY:   1.5,
+			ColorR: cs.R(),
+			ColorG: cs.G(),
+			ColorB: cs.B(),
+			ColorA: cs.A(),
+		}
+	}
+
+	// Just like with drawLine, the line width is
+	// applied to the one side of the pos1->pos2 segment.
+	length := pos1.DistanceTo(pos2)
+	dir := pos2.Sub(pos1).Mulf(1 / length)
+	normal := gmath.Vec{X: -dir.Y, Y: dir.X}.Mulf(width)
+	forEachDash(length, dashLength, gap, phase, func(from, to float64) {
+		if len(vertices)+4 > 0xffff {
+			drawVertexColorTriangles(dst, vertices, indices, emptyImage, &drawOptions)
+			vertices = vertices[:0]
+			indices = indices[:0]
+		}
+		a := pos1.Add(dir.Mulf(from))
+		b := pos1.Add(dir.Mulf(to))
+		idx := uint16(len(vertices))
+		vertices = append(vertices,
+			vertex(a),
+			vertex(b),
+			vertex(a.Add(normal)),
+			vertex(b.Add(normal)),
+		)
+		indices = append(indices,
+			idx+0, idx+1, idx+2,
+			idx+1, idx+2, idx+3,
+		)
+	})
+
+	if len(indices) != 0 {
+		drawVertexColorTriangles(dst, vertices, indices, emptyImage, &drawOptions)
+	}
+}
+
+// forEachDash calls f for every dash segment of a [0, length] line.
+//
+// The pattern is a dashLength segment followed by a gap.
+// The phase shifts the pattern along the line (a positive phase moves
+// the dashes towards the line end), so changing it over time
+// makes the dashes move ("marching ants").
+func forEachDash(length, dashLength, gap, phase float64, f func(from, to float64)) {
+	period := dashLength + gap
+	if period <= 0 || length <= 0 {
+		return
+	}
+	start := math.Mod(phase, period)
+	if start > 0 {
+		start -= period
+	}
+	for t := start; t < length; t += period {
+		from := max(t, 0)
+		to := min(t+dashLength, length)
+		if to > from {
+			f(from, to)
+		}
+	}
 }
@@ -0,0 +1,228 @@
+package graphics
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/quasilyte/gmath"
+)
+
+// FindObject returns the scene object identified by the path.
+//
+// The path syntax is "layer/object[/child...]":
+//   - layer is a layer index
+//   - object is either an object Z index inside the layer or its name
+//   - child is a Z index of a container child (can be repeated)
+//
+// Examples: "2/0", "2/player", "0/hud_panel/3/1".
+//
+// The object indexing is consistent with [SceneDrawer.Inspect] results.
+func (d *SceneDrawer) FindObject(path string) (gsceneGraphics, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid path %q: expected at least 2 parts", path)
+	}
+
+	layerIndex, err := strconv.Atoi(parts[0])
+	if err != nil || layerIndex < 0 || layerIndex >= len(d.layers) {
+		return nil, fmt.Errorf("invalid path %q: bad layer index %q", path, parts[0])
+	}
+
+	var o gsceneGraphics
+	switch l := d.layers[layerIndex].(type) {
+	case *Layer:
+		o = findLayerObject(l.objects, l.FindByName, parts[1])
+	case *StaticLayer:
+		o = findLayerObject(l.objects, l.FindByName, parts[1])
+	default:
+		return nil, fmt.Errorf("invalid path %q: %s layer can't be inspected", path, inspectTypeName(l))
+	}
+	if o == nil {
+		return nil, fmt.Errorf("invalid path %q: object %q not found", path, parts[1])
+	}
+
+	for _, part := range parts[2:] {
+		p, ok := o.(inspectableParent)
+		if !ok {
+			return nil, fmt.Errorf("invalid path %q: %s has no children", path, inspectTypeName(o))
+		}
+		child := findLiveObject(p.inspectChildren(), part)
+		if child == nil {
+			return nil, fmt.Errorf("invalid path %q: child %q not found", path, part)
+		}
+		o = child
+	}
+
+	return o, nil
+}
+
+// SetProperty finds the object by its path and updates its property.
+//
+// See [FindObject] for the path syntax and [SetObjectProperty]
+// for the supported properties.
+//
+// This is a building block for in-game consoles and remote debuggers.
+func (d *SceneDrawer) SetProperty(path, field, value string) error {
+	o, err := d.FindObject(path)
+	if err != nil {
+		return err
+	}
+	return SetObjectProperty(o, field, value)
+}
+
+// SetObjectProperty changes the object property using its text representation.
+//
+// Supported properties:
+//   - "visible": "true" or "false"
+//   - "pos": "x,y" (the resolved position, Pos.Offset is adjusted)
+//   - "alpha": a float value
+//   - "color", "fill_color", "outline_color": "RRGGBB" or "RRGGBBAA" hex color
+//
+// An error is returned if the object doesn't have the requested property
+// or if the value can't be parsed.
+func SetObjectProperty(o gsceneGraphics, field, value string) error {
+	switch field {
+	case "visible":
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("parse %s value: %w", field, err)
+		}
+		obj, ok := o.(interface{ SetVisibility(bool) })
+		if !ok {
+			return errUnsupportedProperty(o, field)
+		}
+		obj.SetVisibility(v)
+
+	case "pos":
+		v, err := parseVecProperty(value)
+		if err != nil {
+			return fmt.Errorf("parse %s value: %w", field, err)
+		}
+		pos := inspectPosField(o)
+		if pos == nil {
+			return errUnsupportedProperty(o, field)
+		}
+		if pos.Base != nil {
+			v = v.Sub(*pos.Base)
+		}
+		pos.Offset = v
+
+	case "alpha":
+		v, err := strconv.ParseFloat(value, 32)
+		if err != nil {
+			return fmt.Errorf("parse %s value: %w", field, err)
+		}
+		obj, ok := o.(interface{ SetAlpha(float32) })
+		if !ok {
+			return errUnsupportedProperty(o, field)
+		}
+		obj.SetAlpha(float32(v))
+
+	case "color":
+		cs, err := parseColorProperty(value)
+		if err != nil {
+			return fmt.Errorf("parse %s value: %w", field, err)
+		}
+		obj, ok := o.(interface{ SetColorScale(ColorScale) })
+		if !ok {
+			return errUnsupportedProperty(o, field)
+		}
+		obj.SetColorScale(cs)
+
+	case "fill_color":
+		cs, err := parseColorProperty(value)
+		if err != nil {
+			return fmt.Errorf("parse %s value: %w", field, err)
+		}
+		obj, ok := o.(interface{ SetFillColorScale(ColorScale) })
+		if !ok {
+			return errUnsupportedProperty(o, field)
+		}
+		obj.SetFillColorScale(cs)
+
+	case "outline_color":
+		cs, err := parseColorProperty(value)
+		if err != nil {
+			return fmt.Errorf("parse %s value: %w", field, err)
+		}
+		obj, ok := o.(interface{ SetOutlineColorScale(ColorScale) })
+		if !ok {
+			return errUnsupportedProperty(o, field)
+		}
+		obj.SetOutlineColorScale(cs)
+
+	default:
+		return fmt.Errorf("unknown property %q", field)
+	}
+
+	return nil
+}
+
+func errUnsupportedProperty(o gsceneGraphics, field string) error {
+	return fmt.Errorf("%s doesn't support %q property", inspectTypeName(o), field)
+}
+
+func findLayerObject[T gsceneGraphics](objects []T, findByName func(string) T, key string) gsceneGraphics {
+	if o := findLiveObject(objects, key); o != nil {
+		return o
+	}
+	o := findByName(key)
+	if gsceneGraphics(o) == nil {
+		return nil
+	}
+	return o
+}
+
+func findLiveObject[T gsceneGraphics](objects []T, key string) gsceneGraphics {
+	index, err := strconv.Atoi(key)
+	if err != nil {
+		return nil
+	}
+	// Disposed objects are skipped to keep the indexing
+	// consistent with the inspection results.
+	z := 0
+	for _, o := range objects {
+		if o.IsDisposed() {
+			continue
+		}
+		if z == index {
+			return o
+		}
+		z++
+	}
+	return nil
+}
+
+func parseVecProperty(s string) (gmath.Vec, error) {
+	xs, ys, ok := strings.Cut(s, ",")
+	if !ok {
+		return gmath.Vec{}, errors.New("expected x,y pair")
+	}
+	x, err := strconv.ParseFloat(strings.TrimSpace(xs), 64)
+	if err != nil {
+		return gmath.Vec{}, err
+	}
+	y, err := strconv.ParseFloat(strings.TrimSpace(ys), 64)
+	if err != nil {
+		return gmath.Vec{}, err
+	}
+	return gmath.Vec{X: x, Y: y}, nil
+}
+
+func parseColorProperty(s string) (ColorScale, error) {
+	s = strings.TrimPrefix(s, "#")
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return ColorScale{}, err
+	}
+	switch len(s) {
+	case 6:
+		return RGB(v), nil
+	case 8:
+		return RGBA(v), nil
+	default:
+		return ColorScale{}, errors.New("expected RRGGBB or RRGGBBAA color")
+	}
+}
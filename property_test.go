@@ -0,0 +1,51 @@
+package graphics_test
+
+import (
+	"testing"
+
+	graphics "github.com/quasilyte/ebitengine-graphics"
+	"github.com/quasilyte/gmath"
+)
+
+func TestSetObjectProperty(t *testing.T) {
+	base := gmath.Vec{X: 10, Y: 10}
+	r := graphics.NewRect(4, 4)
+	r.Pos.Base = &base
+
+	if err := graphics.SetObjectProperty(r, "pos", "15, 20"); err != nil {
+		t.Fatal(err)
+	}
+	if have := r.Pos.Resolve(); have != (gmath.Vec{X: 15, Y: 20}) {
+		t.Fatalf("pos:\nhave: %v\nwant: [15, 20]", have)
+	}
+
+	if err := graphics.SetObjectProperty(r, "visible", "false"); err != nil {
+		t.Fatal(err)
+	}
+	if r.IsVisible() {
+		t.Fatal("visible: rect is still visible")
+	}
+
+	if err := graphics.SetObjectProperty(r, "fill_color", "#ff000080"); err != nil {
+		t.Fatal(err)
+	}
+	if have := r.GetFillColorScale(); have != graphics.RGBA(0xff000080) {
+		t.Fatalf("fill_color:\nhave: %v\nwant: ff000080", have)
+	}
+
+	errorTests := []struct {
+		field string
+		value string
+	}{
+		{"alpha", "0.5"},
+		{"visible", "maybe"},
+		{"pos", "10"},
+		{"fill_color", "red"},
+		{"nonexisting", "1"},
+	}
+	for _, test := range errorTests {
+		if err := graphics.SetObjectProperty(r, test.field, test.value); err == nil {
+			t.Fatalf("%s=%s: expected an error", test.field, test.value)
+		}
+	}
+}
@@ -33,9 +33,13 @@ type Rect struct {
 
 	outlineVertices *[8]ebiten.Vertex
 
+	// fillGradient is nil unless SetFillGradient was called.
+	fillGradient *Gradient
+
 	centered bool
 	visible  bool
 	disposed bool
+	blendID  uint8
 }
 
 // NewRect returns a rectangle of the specified size.
@@ -152,6 +156,34 @@ func (rect *Rect) SetFillColorScale(cs ColorScale) {
 	rect.fillColorScale = cs
 }
 
+// GetFillGradient returns the current fill gradient.
+// The second result value is false if there is no gradient.
+// Use SetFillGradient to change it.
+func (rect *Rect) GetFillGradient() (Gradient, bool) {
+	if rect.fillGradient == nil {
+		return Gradient{}, false
+	}
+	return *rect.fillGradient, true
+}
+
+// SetFillGradient makes the rect use a gradient fill.
+// The gradient colors are multiplied by the fill color scale,
+// so the fill color scale alpha still affects the entire fill.
+//
+// This is useful for the vignettes, sky backgrounds and fading bars.
+// Use ResetFillGradient to return to the solid color fill.
+func (rect *Rect) SetFillGradient(g Gradient) {
+	if rect.fillGradient == nil {
+		rect.fillGradient = new(Gradient)
+	}
+	*rect.fillGradient = g
+}
+
+// ResetFillGradient removes the fill gradient assigned by SetFillGradient.
+func (rect *Rect) ResetFillGradient() {
+	rect.fillGradient = nil
+}
+
 // GetOutlineColorScale is used to retrieve the current outline color scale value of the rect.
 // Use SetOutlineColorScale to change it.
 func (rect *Rect) GetOutlineColorScale() ColorScale {
@@ -177,6 +209,8 @@ func (rect *Rect) Draw(dst *ebiten.Image) {
 // DrawWithOptions renders the rect onto the provided dst image
 // while also using the extra provided offset.
 func (rect *Rect) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	opts.Blend = resolveBlend(rect.blendID, opts.Blend)
+
 	if !rect.visible {
 		return
 	}
@@ -193,13 +227,21 @@ func (rect *Rect) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
 
 	if rect.outlineColorScale.A == 0 || rect.outlineWidth < 1 {
 		// Fill-only mode.
+		if rect.fillGradient != nil {
+			bounds := gmath.Rect{
+				Min: finalOffset,
+				Max: finalOffset.Add(gmath.Vec{X: rect.width, Y: rect.height}),
+			}
+			drawGradientRect(dst, opts.Blend, bounds, gmath.Rect{Max: gmath.Vec{X: 1, Y: 1}}, *rect.fillGradient, rect.fillColorScale)
+			return
+		}
 		var drawOptions ebiten.DrawImageOptions
 		if opts.Blend != nil {
 			drawOptions.Blend = *opts.Blend
 		}
 		drawOptions.GeoM = rect.calculateGeom(rect.width, rect.height, finalOffset)
 		drawOptions.ColorScale = rect.fillColorScale.ToEbitenColorScale()
-		dst.DrawImage(whitePixel, &drawOptions)
+		drawImage(dst, whitePixel, &drawOptions)
 		return
 	}
 
@@ -211,6 +253,23 @@ func (rect *Rect) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
 
 	rect.drawOutline(dst, opts.Blend, finalOffset)
 
+	if rect.fillGradient != nil && rect.width > 0 && rect.height > 0 {
+		// The inner rect takes a part of the gradient,
+		// so it looks like the outline is drawn over the gradient.
+		inset := gmath.Vec{X: rect.outlineWidth, Y: rect.outlineWidth}
+		bounds := gmath.Rect{
+			Min: finalOffset.Add(inset),
+			Max: finalOffset.Add(gmath.Vec{X: rect.width, Y: rect.height}).Sub(inset),
+		}
+		uvInset := gmath.Vec{X: rect.outlineWidth / rect.width, Y: rect.outlineWidth / rect.height}
+		uv := gmath.Rect{
+			Min: uvInset,
+			Max: gmath.Vec{X: 1, Y: 1}.Sub(uvInset),
+		}
+		drawGradientRect(dst, opts.Blend, bounds, uv, *rect.fillGradient, rect.fillColorScale)
+		return
+	}
+
 	var drawOptions ebiten.DrawImageOptions
 	if opts.Blend != nil {
 		drawOptions.Blend = *opts.Blend
@@ -218,7 +277,7 @@ func (rect *Rect) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
 	drawOptions.GeoM.Scale(rect.width-rect.outlineWidth*2, rect.height-rect.outlineWidth*2)
 	drawOptions.GeoM.Translate(rect.outlineWidth+finalOffset.X, rect.outlineWidth+finalOffset.Y)
 	drawOptions.ColorScale = rect.fillColorScale.ToEbitenColorScale()
-	dst.DrawImage(whitePixel, &drawOptions)
+	drawImage(dst, whitePixel, &drawOptions)
 }
 
 func (rect *Rect) drawOutline(dst *ebiten.Image, blend *ebiten.Blend, offset gmath.Vec) {
@@ -308,7 +367,7 @@ func (rect *Rect) drawOutline(dst *ebiten.Image, blend *ebiten.Blend, offset gma
 	if blend != nil {
 		options.Blend = *blend
 	}
-	dst.DrawTriangles(rect.outlineVertices[:], borderBoxIndices, whitePixel, &options)
+	drawTriangles(dst, rect.outlineVertices[:], borderBoxIndices, whitePixel, &options)
 }
 
 func (rect *Rect) calculateFinalOffset(offset gmath.Vec) gmath.Vec {
@@ -333,3 +392,22 @@ func (rect *Rect) calculateGeom(w, h float64, pos gmath.Vec) ebiten.GeoM {
 	geom.Translate(pos.X, pos.Y)
 	return geom
 }
+
+// GetBlend returns the blend mode assigned by SetBlend.
+// The second result value is false if there is no blend override.
+func (rect *Rect) GetBlend() (ebiten.Blend, bool) {
+	return getBlend(rect.blendID)
+}
+
+// SetBlend assigns a blend mode that is used to render this rect.
+// It takes priority over the DrawOptions.Blend value.
+// Use ResetBlend to remove the override.
+func (rect *Rect) SetBlend(b ebiten.Blend) {
+	rect.blendID = internBlend(b)
+}
+
+// ResetBlend removes the blend mode override.
+// The DrawOptions.Blend value (if any) will be used again.
+func (rect *Rect) ResetBlend() {
+	rect.blendID = 0
+}
@@ -0,0 +1,528 @@
+package graphics
+
+import (
+	"errors"
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+	"github.com/quasilyte/gmath"
+)
+
+// Recorder captures the scene rendering state every frame
+// and encodes it into a compact log that can be played back with [Replay].
+//
+// The log is decoupled from the game logic: it contains
+// the objects states (the same data as [SceneDrawer.Inspect] reports,
+// except for the names and tags), so a replay doesn't need
+// the game to be running. It can be attached to a bug report
+// or used for the visual replays.
+// Only the changed object fields are stored for every frame,
+// so the static objects cost only a few bytes per frame.
+//
+// Install the recorder with [SceneDrawer.SetRecorder] to record
+// a frame during every Draw call; RecordFrame can be used directly too.
+//
+// The recorded objects are the same objects that Inspect reports for
+// every built-in layer type: the shadow layers contribute their
+// selection markers only (the shadows are derived from the sprites
+// of other layers) and the light layers contribute their lights.
+// The custom layer types are recorded without their objects.
+//
+// The log contains the object descriptors, not the draw calls.
+// Only the sprites are replayed with their actual textures,
+// all other objects are replayed as their bounds outlines (see [Replay]).
+//
+// The recording is relatively expensive: it uses the same
+// reflection-based walk as Inspect does, so it's intended
+// to be used for debugging (like attaching a log to a bug report).
+type Recorder struct {
+	enc replayEncoder
+
+	ids    map[gsceneGraphics]uint32
+	nextID uint32
+
+	// imageKeys caches the image registry keys of the recorded textures,
+	// so the registry is not queried for every sprite every frame.
+	// The images that are not used by the last frame are removed.
+	imageKeys map[*ebiten.Image]replayImageKey
+
+	// prev is the last recorded frame state.
+	prev map[uint32]replayObject
+
+	objects []replayObject
+
+	cameraOffset gmath.Vec
+	numFrames    int
+}
+
+type replayImageKey struct {
+	key   string
+	frame int
+}
+
+// replayableObject is implemented by the objects that
+// can be replayed using their actual textures.
+type replayableObject interface {
+	// replayTexture returns the object texture and its
+	// transformation in the world coordinates.
+	replayTexture() (*ebiten.Image, ebiten.GeoM)
+}
+
+// NewRecorder creates an empty recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{
+		ids:       make(map[gsceneGraphics]uint32),
+		imageKeys: make(map[*ebiten.Image]replayImageKey),
+		prev:      make(map[uint32]replayObject),
+	}
+}
+
+// NumFrames reports the number of the recorded frames.
+func (r *Recorder) NumFrames() int { return r.numFrames }
+
+// Bytes returns the encoded log.
+// It can be loaded with [LoadReplay].
+//
+// The returned slice is valid until the next RecordFrame or Reset call.
+// It's nil if no frames were recorded.
+func (r *Recorder) Bytes() []byte {
+	if r.numFrames == 0 {
+		return nil
+	}
+	return r.enc.buf
+}
+
+// Reset discards all recorded frames.
+func (r *Recorder) Reset() {
+	r.enc.reset()
+	clear(r.ids)
+	clear(r.imageKeys)
+	clear(r.prev)
+	r.nextID = 0
+	r.numFrames = 0
+}
+
+// RecordFrame appends the current scene state to the log.
+//
+// Only the first scene camera position is recorded;
+// the camera zoom and rotation are not replayed.
+func (r *Recorder) RecordFrame(d *SceneDrawer) {
+	if r.numFrames == 0 {
+		layerTypes := make([]string, len(d.layers))
+		for i, l := range d.layers {
+			layerTypes[i] = inspectTypeName(l)
+		}
+		r.enc.writeHeader(layerTypes)
+	}
+
+	r.objects = r.objects[:0]
+	for i, l := range d.layers {
+		switch l := l.(type) {
+		case *Layer:
+			for _, o := range l.objects {
+				r.recordObject(o, i, 0)
+			}
+		case *StaticLayer:
+			for _, o := range l.objects {
+				r.recordObject(o, i, 0)
+			}
+		case inspectableLayer:
+			for _, o := range l.inspectObjects() {
+				r.recordObject(o, i, 0)
+			}
+		}
+	}
+
+	cameras := d.cameras
+	if len(cameras) == 0 {
+		cameras = d.defaultCamera
+	}
+	var cameraOffset *gmath.Vec
+	if offset := cameras[0].c.getDrawOffset(); r.numFrames == 0 || offset != r.cameraOffset {
+		r.cameraOffset = offset
+		cameraOffset = &offset
+	}
+
+	r.enc.writeFrameHeader(cameraOffset, len(r.objects))
+	for i := range r.objects {
+		o := &r.objects[i]
+		if prev, ok := r.prev[o.id]; ok {
+			r.enc.writeObject(&prev, o)
+		} else {
+			r.enc.writeObject(nil, o)
+		}
+	}
+
+	// Forget the objects that are gone, so the disposed
+	// objects can be garbage collected.
+	clear(r.prev)
+	for i := range r.objects {
+		r.prev[r.objects[i].id] = r.objects[i]
+	}
+	for o, id := range r.ids {
+		if _, ok := r.prev[id]; !ok {
+			delete(r.ids, o)
+		}
+	}
+	for img, k := range r.imageKeys {
+		if k.frame != r.numFrames {
+			delete(r.imageKeys, img)
+		}
+	}
+
+	r.numFrames++
+}
+
+func (r *Recorder) recordObject(o gsceneGraphics, layer int, parent uint32) {
+	if o.IsDisposed() {
+		return
+	}
+
+	id, ok := r.ids[o]
+	if !ok {
+		r.nextID++
+		id = r.nextID
+		r.ids[o] = id
+	}
+
+	info := inspectObjectState(o)
+	obj := replayObject{
+		id:       id,
+		parent:   parent,
+		layer:    layer,
+		typ:      info.Type,
+		visible:  info.Visible,
+		pos:      info.Pos,
+		rotation: info.Rotation,
+		text:     info.Text,
+	}
+	if info.Bounds != nil {
+		obj.hasBounds = true
+		obj.bounds = *info.Bounds
+	}
+	if info.ColorScale != nil {
+		obj.hasColorScale = true
+		obj.colorScale = *info.ColorScale
+	}
+	if info.Texture != nil {
+		obj.hasTexture = true
+		obj.texture = *info.Texture
+	}
+	if info.Scale != nil {
+		obj.hasScale = true
+		obj.scale = *info.Scale
+	}
+	if info.Blend != nil {
+		obj.hasBlend = true
+		obj.blend = *info.Blend
+	}
+	if ro, ok := o.(replayableObject); ok {
+		if img, geom := ro.replayTexture(); img != nil {
+			obj.textureKey = r.imageKey(img)
+			obj.hasGeoM = true
+			for i := range obj.geom {
+				obj.geom[i] = geom.Element(i/3, i%3)
+			}
+		}
+	}
+	r.objects = append(r.objects, obj)
+
+	if p, ok := o.(inspectableParent); ok {
+		for _, child := range p.inspectChildren() {
+			r.recordObject(child, layer, id)
+		}
+	}
+}
+
+// imageKey returns the image registry key.
+// The keys are resolved once per image.
+func (r *Recorder) imageKey(img *ebiten.Image) string {
+	k, ok := r.imageKeys[img]
+	if !ok {
+		k.key = cache.Global.FindImageKey(img)
+	}
+	k.frame = r.numFrames
+	r.imageKeys[img] = k
+	return k.key
+}
+
+// Replay plays back a log recorded by [Recorder].
+//
+// The replay is not a full scene re-creation.
+// Only the sprites that use the registered images (see [RegisterImage])
+// are rendered with their actual textures; the image registry
+// should have the same keys during the playback.
+// All other objects (labels, shapes, lines, particles and so on)
+// are not replayed visually: they're rendered as their bounds outlines
+// (using their color scale if they have one).
+// Their recorded state is still available via Inspect.
+//
+// The recorded camera offset is applied to the camera-dependent layers,
+// so the object should be added to a [StaticLayer].
+//
+// Replay implements gscene Graphics interface.
+type Replay struct {
+	layerTypes []string
+
+	// frames are the frames data offsets.
+	frames []int
+
+	dec   replayDecoder
+	state replayState
+	frame int
+
+	// textures caches the frame sub-images of the registered images.
+	textures map[replayTexture]*ebiten.Image
+
+	// hidden and isParent are the DrawWithOptions scratch maps.
+	hidden   map[uint32]bool
+	isParent map[uint32]bool
+
+	visible  bool
+	disposed bool
+}
+
+// LoadReplay decodes the [Recorder] log.
+// The replay is set to its first frame.
+func LoadReplay(data []byte) (*Replay, error) {
+	r := &Replay{
+		visible:  true,
+		textures: make(map[replayTexture]*ebiten.Image),
+		hidden:   make(map[uint32]bool),
+		isParent: make(map[uint32]bool),
+	}
+	r.dec.data = data
+	r.layerTypes = r.dec.readHeader()
+	// Decode the entire log once to validate it
+	// and to find the frames boundaries.
+	for r.dec.err == nil && r.dec.offset < len(data) {
+		r.frames = append(r.frames, r.dec.offset)
+		r.dec.readFrame(&r.state, len(r.layerTypes))
+	}
+	if r.dec.err != nil {
+		return nil, r.dec.err
+	}
+	if len(r.frames) == 0 {
+		return nil, errors.New("replay log has no frames")
+	}
+	r.rewind()
+	return r, nil
+}
+
+// NumFrames reports the number of the recorded frames.
+func (r *Replay) NumFrames() int { return len(r.frames) }
+
+// GetFrame returns the current frame index.
+func (r *Replay) GetFrame() int { return r.frame }
+
+// SetFrame changes the current frame.
+// The index is clamped to the [0, NumFrames-1] range.
+//
+// Moving forward is cheap, while moving backwards
+// decodes the log from its beginning.
+func (r *Replay) SetFrame(i int) {
+	i = gmath.Clamp(i, 0, len(r.frames)-1)
+	if i < r.frame {
+		r.rewind()
+	}
+	for r.frame < i {
+		r.frame++
+		r.dec.readFrame(&r.state, len(r.layerTypes))
+	}
+}
+
+// NextFrame is a shorthand for SetFrame(GetFrame()+1).
+// It reports false if the current frame is the last one.
+func (r *Replay) NextFrame() bool {
+	if r.frame+1 >= len(r.frames) {
+		return false
+	}
+	r.SetFrame(r.frame + 1)
+	return true
+}
+
+func (r *Replay) rewind() {
+	r.dec.offset = r.frames[0]
+	r.dec.strings = r.dec.strings[:0]
+	r.state = replayState{}
+	r.frame = 0
+	r.dec.readFrame(&r.state, len(r.layerTypes))
+}
+
+// Inspect returns the current frame scene descriptors.
+// The results are compatible with [SceneDrawer.Inspect]
+// (except for the names and tags), so [HashSnapshot] and [DiffSnapshots]
+// can be used to compare the replay with the live scene.
+func (r *Replay) Inspect() []LayerInfo {
+	children := make(map[uint32][]*replayObject)
+	for _, id := range r.state.order {
+		o := r.state.objects[id]
+		if o.parent != 0 {
+			children[o.parent] = append(children[o.parent], o)
+		}
+	}
+
+	layers := make([]LayerInfo, len(r.layerTypes))
+	for i, typ := range r.layerTypes {
+		layers[i] = LayerInfo{Index: i, Type: typ}
+	}
+	for _, id := range r.state.order {
+		o := r.state.objects[id]
+		if o.parent != 0 {
+			continue
+		}
+		l := &layers[o.layer]
+		l.Objects = append(l.Objects, o.inspect(children, len(l.Objects)))
+	}
+	return layers
+}
+
+func (o *replayObject) inspect(children map[uint32][]*replayObject, z int) ObjectInfo {
+	info := ObjectInfo{
+		Type:     o.typ,
+		Pos:      o.pos,
+		Rotation: o.rotation,
+		Text:     o.text,
+		Visible:  o.visible,
+		Z:        z,
+	}
+	if o.hasBounds {
+		bounds := o.bounds
+		info.Bounds = &bounds
+	}
+	if o.hasColorScale {
+		cs := o.colorScale
+		info.ColorScale = &cs
+	}
+	if o.hasTexture {
+		texture := o.texture
+		info.Texture = &texture
+	}
+	if o.hasScale {
+		scale := o.scale
+		info.Scale = &scale
+	}
+	if o.hasBlend {
+		blend := o.blend
+		info.Blend = &blend
+	}
+	for _, child := range children[o.id] {
+		info.Children = append(info.Children, child.inspect(children, len(info.Children)))
+	}
+	return info
+}
+
+// Dispose marks this object for deletion.
+// After calling this method, IsDisposed will report true.
+func (r *Replay) Dispose() { r.disposed = true }
+
+// IsDisposed reports whether this object is marked for deletion.
+func (r *Replay) IsDisposed() bool { return r.disposed }
+
+// IsVisible reports whether this object is visible.
+// Use SetVisibility to change this flag value.
+func (r *Replay) IsVisible() bool { return r.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (r *Replay) SetVisibility(visible bool) { r.visible = visible }
+
+// Draw renders the current replay frame onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (r *Replay) Draw(dst *ebiten.Image) {
+	r.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the current replay frame onto the provided dst image
+// while also using the extra provided offset.
+func (r *Replay) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !r.visible {
+		return
+	}
+
+	// The hidden parents hide their children too.
+	// The parents are always listed before their children.
+	hidden := r.hidden
+	isParent := r.isParent
+	clear(hidden)
+	clear(isParent)
+	for _, id := range r.state.order {
+		if parent := r.state.objects[id].parent; parent != 0 {
+			isParent[parent] = true
+		}
+	}
+
+	var drawOptions ebiten.DrawImageOptions
+	if opts.Blend != nil {
+		drawOptions.Blend = *opts.Blend
+	}
+	for _, id := range r.state.order {
+		o := r.state.objects[id]
+		if !o.visible || hidden[o.parent] {
+			hidden[o.id] = true
+			continue
+		}
+
+		offset := opts.Offset
+		if r.layerTypes[o.layer] != "graphics.StaticLayer" {
+			offset = offset.Add(r.state.cameraOffset)
+		}
+
+		if img := r.objectTexture(o); img != nil {
+			drawOptions.GeoM.Reset()
+			for i, v := range o.geom {
+				drawOptions.GeoM.SetElement(i/3, i%3, v)
+			}
+			drawOptions.GeoM.Translate(offset.X, offset.Y)
+			drawOptions.ColorScale.Reset()
+			if o.hasColorScale {
+				drawOptions.ColorScale = o.colorScale.ToEbitenColorScale()
+			}
+			drawImage(dst, img, &drawOptions)
+			continue
+		}
+
+		if o.hasBounds && !isParent[o.id] {
+			clr := debugVisibleColor
+			if o.hasColorScale {
+				clr = o.colorScale
+			}
+			drawDebugRect(dst, o.bounds.Add(offset), clr)
+		}
+	}
+}
+
+type replayTexture struct {
+	key   string
+	frame image.Rectangle
+}
+
+func (r *Replay) objectTexture(o *replayObject) *ebiten.Image {
+	if !o.hasGeoM || o.textureKey == "" {
+		return nil
+	}
+	k := replayTexture{key: o.textureKey, frame: o.texture.Frame}
+	if img, ok := r.textures[k]; ok {
+		return img
+	}
+	// The missing images are cached too, so the registry
+	// is queried only once per texture.
+	var frame *ebiten.Image
+	if img := cache.Global.GetImage(o.textureKey); img != nil {
+		frame = subImage(img, o.texture.Frame)
+	}
+	r.textures[k] = frame
+	return frame
+}
+
+// SetRecorder installs a recorder that captures a frame during every Draw call.
+// A nil recorder disables the recording.
+func (d *SceneDrawer) SetRecorder(r *Recorder) { d.recorder = r }
+
+// GetRecorder returns the recorder installed by SetRecorder.
+func (d *SceneDrawer) GetRecorder() *Recorder { return d.recorder }
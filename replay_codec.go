@@ -0,0 +1,557 @@
+package graphics
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// The replay log layout:
+//
+//	header: magic, version, layers count, layer type names
+//	frames: flags, [camera offset], objects count, objects
+//
+// Every object is encoded as its ID followed by a fields mask
+// and the changed fields values (relative to the previous frame state).
+// The strings (type names, texture keys and texts) are stored only once:
+// the first occurrence defines a new table entry, the subsequent ones refer to it.
+
+const (
+	replayMagic   = "GRPL"
+	replayVersion = 1
+)
+
+const replayFrameCameraChanged = 1 << 0
+
+type replayField uint16
+
+const (
+	// replayFieldHeader covers the layer, parent and type.
+	replayFieldHeader replayField = 1 << iota
+	// replayFieldVisible is not a change bit, but a visibility flag value.
+	replayFieldVisible
+	replayFieldPos
+	replayFieldRotation
+	replayFieldBounds
+	replayFieldColorScale
+	replayFieldTexture
+	replayFieldGeoM
+	replayFieldText
+	replayFieldScale
+	replayFieldBlend
+)
+
+// replayObject is a recorded object state.
+type replayObject struct {
+	id uint32
+
+	// parent is zero for the layer objects.
+	parent uint32
+	layer  int
+
+	typ      string
+	visible  bool
+	pos      gmath.Vec
+	rotation gmath.Rad
+
+	hasBounds bool
+	bounds    gmath.Rect
+
+	hasColorScale bool
+	colorScale    ColorScale
+
+	hasTexture bool
+	texture    TextureInfo
+	// textureKey is an image registry key of the texture.
+	// It's empty for the unregistered images.
+	textureKey string
+
+	// geom is a texture transformation matrix (a, b, c, d, tx, ty).
+	// It's only recorded for the objects that can be replayed
+	// with their actual textures (like sprites).
+	hasGeoM bool
+	geom    [6]float64
+
+	text string
+
+	hasScale bool
+	scale    gmath.Vec
+
+	hasBlend bool
+	blend    ebiten.Blend
+}
+
+func (o *replayObject) diff(prev *replayObject) replayField {
+	var mask replayField
+	if o.visible {
+		mask |= replayFieldVisible
+	}
+	if o.layer != prev.layer || o.parent != prev.parent || o.typ != prev.typ {
+		mask |= replayFieldHeader
+	}
+	if o.pos != prev.pos {
+		mask |= replayFieldPos
+	}
+	if o.rotation != prev.rotation {
+		mask |= replayFieldRotation
+	}
+	if o.hasBounds != prev.hasBounds || o.bounds != prev.bounds {
+		mask |= replayFieldBounds
+	}
+	if o.hasColorScale != prev.hasColorScale || o.colorScale != prev.colorScale {
+		mask |= replayFieldColorScale
+	}
+	if o.hasTexture != prev.hasTexture || o.texture != prev.texture || o.textureKey != prev.textureKey {
+		mask |= replayFieldTexture
+	}
+	if o.hasGeoM != prev.hasGeoM || o.geom != prev.geom {
+		mask |= replayFieldGeoM
+	}
+	if o.text != prev.text {
+		mask |= replayFieldText
+	}
+	if o.hasScale != prev.hasScale || o.scale != prev.scale {
+		mask |= replayFieldScale
+	}
+	if o.hasBlend != prev.hasBlend || o.blend != prev.blend {
+		mask |= replayFieldBlend
+	}
+	return mask
+}
+
+type replayEncoder struct {
+	buf     []byte
+	strings map[string]uint32
+}
+
+func (e *replayEncoder) reset() {
+	e.buf = e.buf[:0]
+	clear(e.strings)
+}
+
+func (e *replayEncoder) writeHeader(layerTypes []string) {
+	e.buf = append(e.buf, replayMagic...)
+	e.writeUvarint(replayVersion)
+	e.writeUvarint(uint64(len(layerTypes)))
+	for _, typ := range layerTypes {
+		e.writeRawString(typ)
+	}
+}
+
+func (e *replayEncoder) writeFrameHeader(cameraOffset *gmath.Vec, numObjects int) {
+	if cameraOffset == nil {
+		e.writeUvarint(0)
+	} else {
+		e.writeUvarint(replayFrameCameraChanged)
+		e.writeFloat(cameraOffset.X)
+		e.writeFloat(cameraOffset.Y)
+	}
+	e.writeUvarint(uint64(numObjects))
+}
+
+// writeObject encodes the object fields that differ from prev.
+// A nil prev means that the object is new.
+func (e *replayEncoder) writeObject(prev, o *replayObject) {
+	var mask replayField
+	if prev == nil {
+		mask = o.diff(&replayObject{}) | replayFieldHeader
+	} else {
+		mask = o.diff(prev)
+	}
+
+	e.writeUvarint(uint64(o.id))
+	e.writeUvarint(uint64(mask))
+	if mask&replayFieldHeader != 0 {
+		e.writeUvarint(uint64(o.layer))
+		e.writeUvarint(uint64(o.parent))
+		e.writeString(o.typ)
+	}
+	if mask&replayFieldPos != 0 {
+		e.writeFloat(o.pos.X)
+		e.writeFloat(o.pos.Y)
+	}
+	if mask&replayFieldRotation != 0 {
+		e.writeFloat(float64(o.rotation))
+	}
+	if mask&replayFieldBounds != 0 {
+		e.writeBool(o.hasBounds)
+		if o.hasBounds {
+			e.writeFloat(o.bounds.Min.X)
+			e.writeFloat(o.bounds.Min.Y)
+			e.writeFloat(o.bounds.Max.X)
+			e.writeFloat(o.bounds.Max.Y)
+		}
+	}
+	if mask&replayFieldColorScale != 0 {
+		e.writeBool(o.hasColorScale)
+		if o.hasColorScale {
+			e.writeFloat32(o.colorScale.R)
+			e.writeFloat32(o.colorScale.G)
+			e.writeFloat32(o.colorScale.B)
+			e.writeFloat32(o.colorScale.A)
+		}
+	}
+	if mask&replayFieldTexture != 0 {
+		e.writeBool(o.hasTexture)
+		if o.hasTexture {
+			e.writeString(o.textureKey)
+			e.writeUvarint(uint64(o.texture.Width))
+			e.writeUvarint(uint64(o.texture.Height))
+			e.writeVarint(int64(o.texture.Frame.Min.X))
+			e.writeVarint(int64(o.texture.Frame.Min.Y))
+			e.writeVarint(int64(o.texture.Frame.Max.X))
+			e.writeVarint(int64(o.texture.Frame.Max.Y))
+		}
+	}
+	if mask&replayFieldGeoM != 0 {
+		e.writeBool(o.hasGeoM)
+		if o.hasGeoM {
+			for _, v := range o.geom {
+				e.writeFloat(v)
+			}
+		}
+	}
+	if mask&replayFieldText != 0 {
+		e.writeString(o.text)
+	}
+	if mask&replayFieldScale != 0 {
+		e.writeBool(o.hasScale)
+		if o.hasScale {
+			e.writeFloat(o.scale.X)
+			e.writeFloat(o.scale.Y)
+		}
+	}
+	if mask&replayFieldBlend != 0 {
+		e.writeBool(o.hasBlend)
+		if o.hasBlend {
+			e.writeUvarint(uint64(o.blend.BlendFactorSourceRGB))
+			e.writeUvarint(uint64(o.blend.BlendFactorSourceAlpha))
+			e.writeUvarint(uint64(o.blend.BlendFactorDestinationRGB))
+			e.writeUvarint(uint64(o.blend.BlendFactorDestinationAlpha))
+			e.writeUvarint(uint64(o.blend.BlendOperationRGB))
+			e.writeUvarint(uint64(o.blend.BlendOperationAlpha))
+		}
+	}
+}
+
+func (e *replayEncoder) writeUvarint(v uint64) { e.buf = binary.AppendUvarint(e.buf, v) }
+
+func (e *replayEncoder) writeVarint(v int64) { e.buf = binary.AppendVarint(e.buf, v) }
+
+func (e *replayEncoder) writeFloat(v float64) {
+	e.buf = binary.LittleEndian.AppendUint64(e.buf, math.Float64bits(v))
+}
+
+func (e *replayEncoder) writeFloat32(v float32) {
+	e.buf = binary.LittleEndian.AppendUint32(e.buf, math.Float32bits(v))
+}
+
+func (e *replayEncoder) writeBool(v bool) {
+	if v {
+		e.buf = append(e.buf, 1)
+	} else {
+		e.buf = append(e.buf, 0)
+	}
+}
+
+func (e *replayEncoder) writeRawString(s string) {
+	e.writeUvarint(uint64(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+func (e *replayEncoder) writeString(s string) {
+	if index, ok := e.strings[s]; ok {
+		e.writeUvarint(uint64(index))
+		return
+	}
+	if e.strings == nil {
+		e.strings = make(map[string]uint32)
+	}
+	index := uint32(len(e.strings))
+	e.strings[s] = index
+	e.writeUvarint(uint64(index))
+	e.writeRawString(s)
+}
+
+// replayState is a decoded frame state.
+type replayState struct {
+	objects map[uint32]*replayObject
+
+	// order lists the frame objects IDs in their rendering order.
+	order []uint32
+
+	// spare is the previous frame objects map, it's reused by readFrame.
+	spare map[uint32]*replayObject
+
+	cameraOffset gmath.Vec
+}
+
+type replayDecoder struct {
+	data    []byte
+	offset  int
+	strings []string
+	err     error
+}
+
+func (d *replayDecoder) readHeader() []string {
+	if len(d.data) < len(replayMagic) || string(d.data[:len(replayMagic)]) != replayMagic {
+		d.err = errors.New("not a replay log")
+		return nil
+	}
+	d.offset = len(replayMagic)
+	if version := d.readUvarint(); d.err == nil && version != replayVersion {
+		d.err = fmt.Errorf("unsupported replay log version %d", version)
+		return nil
+	}
+	numLayers := d.readUvarint()
+	if d.err != nil || numLayers > uint64(len(d.data)) {
+		d.fail()
+		return nil
+	}
+	layerTypes := make([]string, numLayers)
+	for i := range layerTypes {
+		layerTypes[i] = d.readRawString()
+	}
+	return layerTypes
+}
+
+// readFrame applies the next frame changes to the state.
+func (d *replayDecoder) readFrame(state *replayState, numLayers int) {
+	flags := d.readUvarint()
+	if flags&replayFrameCameraChanged != 0 {
+		state.cameraOffset.X = d.readFloat()
+		state.cameraOffset.Y = d.readFloat()
+	}
+	numObjects := d.readUvarint()
+	if d.err != nil || numObjects > uint64(len(d.data)) {
+		d.fail()
+		return
+	}
+
+	state.order = state.order[:0]
+	live := state.spare
+	if live == nil {
+		live = make(map[uint32]*replayObject, numObjects)
+	}
+	clear(live)
+	for i := uint64(0); i < numObjects; i++ {
+		o := d.readObject(state, live)
+		if d.err != nil {
+			return
+		}
+		if o.layer < 0 || o.layer >= numLayers {
+			d.err = fmt.Errorf("object %d: layer %d is out of range", o.id, o.layer)
+			return
+		}
+		if o.parent != 0 && live[o.parent] == nil {
+			d.err = fmt.Errorf("object %d: parent %d is not rendered before it", o.id, o.parent)
+			return
+		}
+		live[o.id] = o
+		state.order = append(state.order, o.id)
+	}
+	// The objects that are not listed in the frame are removed.
+	state.spare = state.objects
+	state.objects = live
+}
+
+func (d *replayDecoder) readObject(state *replayState, live map[uint32]*replayObject) *replayObject {
+	id := uint32(d.readUvarint())
+	mask := replayField(d.readUvarint())
+	if d.err != nil {
+		return nil
+	}
+	if live[id] != nil {
+		d.err = fmt.Errorf("object %d: duplicated", id)
+		return nil
+	}
+
+	o := state.objects[id]
+	if o == nil {
+		if mask&replayFieldHeader == 0 {
+			d.err = fmt.Errorf("object %d: missing header", id)
+			return nil
+		}
+		o = &replayObject{id: id}
+	}
+	o.visible = mask&replayFieldVisible != 0
+	if mask&replayFieldHeader != 0 {
+		o.layer = int(d.readUvarint())
+		o.parent = uint32(d.readUvarint())
+		o.typ = d.readString()
+	}
+	if mask&replayFieldPos != 0 {
+		o.pos.X = d.readFloat()
+		o.pos.Y = d.readFloat()
+	}
+	if mask&replayFieldRotation != 0 {
+		o.rotation = gmath.Rad(d.readFloat())
+	}
+	if mask&replayFieldBounds != 0 {
+		o.hasBounds = d.readBool()
+		o.bounds = gmath.Rect{}
+		if o.hasBounds {
+			o.bounds.Min.X = d.readFloat()
+			o.bounds.Min.Y = d.readFloat()
+			o.bounds.Max.X = d.readFloat()
+			o.bounds.Max.Y = d.readFloat()
+		}
+	}
+	if mask&replayFieldColorScale != 0 {
+		o.hasColorScale = d.readBool()
+		o.colorScale = ColorScale{}
+		if o.hasColorScale {
+			o.colorScale.R = d.readFloat32()
+			o.colorScale.G = d.readFloat32()
+			o.colorScale.B = d.readFloat32()
+			o.colorScale.A = d.readFloat32()
+		}
+	}
+	if mask&replayFieldTexture != 0 {
+		o.hasTexture = d.readBool()
+		o.texture = TextureInfo{}
+		o.textureKey = ""
+		if o.hasTexture {
+			o.textureKey = d.readString()
+			o.texture.Width = int(d.readUvarint())
+			o.texture.Height = int(d.readUvarint())
+			o.texture.Frame = image.Rect(
+				int(d.readVarint()),
+				int(d.readVarint()),
+				int(d.readVarint()),
+				int(d.readVarint()),
+			)
+		}
+	}
+	if mask&replayFieldGeoM != 0 {
+		o.hasGeoM = d.readBool()
+		o.geom = [6]float64{}
+		if o.hasGeoM {
+			for i := range o.geom {
+				o.geom[i] = d.readFloat()
+			}
+		}
+	}
+	if mask&replayFieldText != 0 {
+		o.text = d.readString()
+	}
+	if mask&replayFieldScale != 0 {
+		o.hasScale = d.readBool()
+		o.scale = gmath.Vec{}
+		if o.hasScale {
+			o.scale.X = d.readFloat()
+			o.scale.Y = d.readFloat()
+		}
+	}
+	if mask&replayFieldBlend != 0 {
+		o.hasBlend = d.readBool()
+		o.blend = ebiten.Blend{}
+		if o.hasBlend {
+			o.blend.BlendFactorSourceRGB = ebiten.BlendFactor(d.readUvarint())
+			o.blend.BlendFactorSourceAlpha = ebiten.BlendFactor(d.readUvarint())
+			o.blend.BlendFactorDestinationRGB = ebiten.BlendFactor(d.readUvarint())
+			o.blend.BlendFactorDestinationAlpha = ebiten.BlendFactor(d.readUvarint())
+			o.blend.BlendOperationRGB = ebiten.BlendOperation(d.readUvarint())
+			o.blend.BlendOperationAlpha = ebiten.BlendOperation(d.readUvarint())
+		}
+	}
+	return o
+}
+
+func (d *replayDecoder) fail() {
+	if d.err == nil {
+		d.err = errors.New("truncated replay log")
+	}
+}
+
+func (d *replayDecoder) readUvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.data[d.offset:])
+	if n <= 0 {
+		d.fail()
+		return 0
+	}
+	d.offset += n
+	return v
+}
+
+func (d *replayDecoder) readVarint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.data[d.offset:])
+	if n <= 0 {
+		d.fail()
+		return 0
+	}
+	d.offset += n
+	return v
+}
+
+func (d *replayDecoder) readBytes(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if len(d.data)-d.offset < n {
+		d.fail()
+		return nil
+	}
+	b := d.data[d.offset : d.offset+n]
+	d.offset += n
+	return b
+}
+
+func (d *replayDecoder) readFloat() float64 {
+	b := d.readBytes(8)
+	if b == nil {
+		return 0
+	}
+	return math.Float64frombits(binary.LittleEndian.Uint64(b))
+}
+
+func (d *replayDecoder) readFloat32() float32 {
+	b := d.readBytes(4)
+	if b == nil {
+		return 0
+	}
+	return math.Float32frombits(binary.LittleEndian.Uint32(b))
+}
+
+func (d *replayDecoder) readBool() bool {
+	b := d.readBytes(1)
+	return b != nil && b[0] != 0
+}
+
+func (d *replayDecoder) readRawString() string {
+	n := d.readUvarint()
+	if n > uint64(len(d.data)) {
+		d.fail()
+		return ""
+	}
+	return string(d.readBytes(int(n)))
+}
+
+func (d *replayDecoder) readString() string {
+	index := d.readUvarint()
+	if d.err != nil {
+		return ""
+	}
+	switch {
+	case index < uint64(len(d.strings)):
+		return d.strings[index]
+	case index == uint64(len(d.strings)):
+		s := d.readRawString()
+		d.strings = append(d.strings, s)
+		return s
+	default:
+		d.err = fmt.Errorf("invalid string index %d", index)
+		return ""
+	}
+}
@@ -0,0 +1,158 @@
+package graphics
+
+import (
+	"image"
+	"strings"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+func TestReplay(t *testing.T) {
+	defer FreeImage("test/replay.png")
+	img := ebiten.NewImage(32, 16)
+	RegisterImage("test/replay.png", img)
+
+	world := NewLayer()
+	ui := NewStaticLayer()
+	d := &SceneDrawer{
+		layers:        []SceneLayerDrawer{world, ui},
+		defaultCamera: []installedCamera{{c: NewCamera()}},
+	}
+
+	s := NewSprite()
+	s.SetImage(img)
+	s.SetFrameRect(image.Rect(16, 0, 32, 16))
+	world.AddChild(s)
+	r := NewRect(8, 8)
+	world.AddChild(r)
+	c := NewContainer()
+	c.AddChild(NewRect(4, 4))
+	ui.AddChild(c)
+
+	rec := NewRecorder()
+	var hashes []uint64
+	record := func() {
+		rec.RecordFrame(d)
+		hashes = append(hashes, d.SnapshotHash())
+	}
+
+	record()
+	r.Pos.Offset = gmath.Vec{X: 10, Y: 20}
+	s.SetAlpha(0.5)
+	record()
+	s.SetVisibility(false)
+	r.Dispose()
+	world.AddChild(NewRect(2, 2))
+	c.AddChild(NewRect(6, 6))
+	record()
+	sizeBefore := len(rec.Bytes())
+	record()
+	// A frame without changes only lists the objects IDs and the field masks.
+	if have := len(rec.Bytes()) - sizeBefore; have > 16 {
+		t.Fatalf("unchanged frame is encoded into %d bytes", have)
+	}
+
+	replay, err := LoadReplay(rec.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replay.NumFrames() != len(hashes) {
+		t.Fatalf("frames: have %d, want %d", replay.NumFrames(), len(hashes))
+	}
+	for i, want := range hashes {
+		replay.SetFrame(i)
+		if have := HashSnapshot(replay.Inspect()); have != want {
+			t.Fatalf("frame %d: replay snapshot doesn't match the scene", i)
+		}
+		replay.Draw(ebiten.NewImage(64, 64))
+	}
+	replay.SetFrame(1)
+	if have := HashSnapshot(replay.Inspect()); have != hashes[1] {
+		t.Fatal("frame 1 snapshot doesn't match after the rewinding")
+	}
+	if o := replay.state.objects[1]; o.textureKey != "test/replay.png" || !o.hasGeoM {
+		t.Fatalf("sprite texture is not recorded: %q", o.textureKey)
+	}
+}
+
+type replayTestLayer struct{ objects []gsceneGraphics }
+
+func (l *replayTestLayer) Update(delta float64)                                {}
+func (l *replayTestLayer) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {}
+func (l *replayTestLayer) AddChild(o gsceneGraphics)                           { l.objects = append(l.objects, o) }
+
+func TestReplayLayers(t *testing.T) {
+	world := NewYSortLayer()
+	shadows := NewShadowLayer(ShadowLayerConfig{})
+	lights := NewLightLayer(LightLayerConfig{})
+	custom := &replayTestLayer{}
+	d := &SceneDrawer{
+		layers:        []SceneLayerDrawer{shadows, world, lights, custom},
+		defaultCamera: []installedCamera{{c: NewCamera()}},
+	}
+
+	s := NewSprite()
+	world.AddChild(s)
+	shadows.AddChild(s)
+	shadows.AddChild(NewSelectionMarker(SelectionMarkerConfig{}))
+	lights.AddChild(NewLight(32))
+	custom.AddChild(NewRect(4, 4))
+
+	rec := NewRecorder()
+	rec.RecordFrame(d)
+	replay, err := LoadReplay(rec.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	layers := replay.Inspect()
+	if have, want := HashSnapshot(layers), d.SnapshotHash(); have != want {
+		t.Fatal("replay snapshot doesn't match the scene")
+	}
+
+	// The shadow layer sprites belong to the world layer,
+	// the custom layer objects are not recorded.
+	wantTypes := [][]string{
+		{"graphics.SelectionMarker"},
+		{"graphics.Sprite"},
+		{"graphics.Light"},
+		nil,
+	}
+	for i, want := range wantTypes {
+		var have []string
+		for _, o := range layers[i].Objects {
+			have = append(have, o.Type)
+		}
+		if strings.Join(have, ",") != strings.Join(want, ",") {
+			t.Fatalf("layer %d objects:\nhave: %q\nwant: %q", i, have, want)
+		}
+	}
+}
+
+func TestLoadReplayErrors(t *testing.T) {
+	d := &SceneDrawer{
+		layers:        []SceneLayerDrawer{NewLayer()},
+		defaultCamera: []installedCamera{{c: NewCamera()}},
+	}
+	d.layers[0].AddChild(NewRect(8, 8))
+	rec := NewRecorder()
+	rec.RecordFrame(d)
+	data := rec.Bytes()
+
+	tests := []struct {
+		data []byte
+		err  string
+	}{
+		{nil, "not a replay log"},
+		{[]byte("GRPL\x02"), "unsupported replay log version 2"},
+		{data[:len(replayMagic)+3+len("graphics.Layer")], "replay log has no frames"},
+		{data[:len(data)-3], "truncated replay log"},
+	}
+	for _, test := range tests {
+		_, err := LoadReplay(test.data)
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Fatalf("load %q:\nhave error: %v\nwant error: %s", test.data, err, test.err)
+		}
+	}
+}
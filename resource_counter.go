@@ -0,0 +1,258 @@
+package graphics
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// ResourceCounterConfig describes the [ResourceCounter] appearance.
+type ResourceCounterConfig struct {
+	// Icon is an optional resource icon rendered to the left of the value.
+	Icon *ebiten.Image
+
+	// Face is used to render the value and the deltas.
+	// This field is required.
+	Face text.Face
+
+	// Spacing is a distance between the icon and the value.
+	// A zero value means 4.
+	Spacing float64
+
+	// ColorScale is a value text color.
+	// A zero value means {1, 1, 1, 1}.
+	ColorScale ColorScale
+
+	// GainColorScale and LossColorScale are the delta text colors.
+	// The LossColorScale is also used for the failed spend flashes.
+	// Zero values mean a green and a red colors.
+	GainColorScale ColorScale
+	LossColorScale ColorScale
+
+	// DeltaDuration is a floating delta lifetime in seconds.
+	// A zero value means 1.
+	DeltaDuration float64
+
+	// DeltaDistance is how far the deltas float up during their lifetime.
+	// A zero value means 16.
+	DeltaDistance float64
+
+	// FlashDuration is a failed spend flash duration in seconds.
+	// A zero value means 0.4.
+	FlashDuration float64
+}
+
+// ResourceCounter is an economy HUD widget: a resource icon followed by its value.
+//
+// Every value change spawns a floating "+N" or "-N" delta text that
+// fades out while moving up. A failed spend (like trying to buy something
+// without enough gold) can be reported via NotifySpendFailed,
+// which makes the value flash with the loss color.
+//
+// The animations are driven by the Update method.
+//
+// The counter is a screen-space widget: its Pos field is a top-left corner
+// of its bounds rect, so it should be added to a [StaticLayer].
+//
+// ResourceCounter implements gscene Graphics interface.
+type ResourceCounter struct {
+	// Pos is a counter top-left corner location binder.
+	// See Pos documentation to learn how it works.
+	Pos gmath.Pos
+
+	config ResourceCounterConfig
+
+	ebitenColorScale ebiten.ColorScale
+
+	value     int
+	valueText string
+
+	deltas []resourceDelta
+
+	// flash is a remaining failed spend flash time.
+	flash float64
+
+	visible  bool
+	disposed bool
+}
+
+type resourceDelta struct {
+	text string
+	gain bool
+	t    float64
+}
+
+// NewResourceCounter creates a counter with the specified config.
+// The initial value is 0.
+func NewResourceCounter(config ResourceCounterConfig) *ResourceCounter {
+	if config.Face == nil {
+		panic("ResourceCounterConfig.Face is required")
+	}
+	if config.Spacing == 0 {
+		config.Spacing = 4
+	}
+	if config.ColorScale == (ColorScale{}) {
+		config.ColorScale = defaultColorScale
+	}
+	if config.GainColorScale == (ColorScale{}) {
+		config.GainColorScale = ColorScale{R: 0.4, G: 1, B: 0.4, A: 1}
+	}
+	if config.LossColorScale == (ColorScale{}) {
+		config.LossColorScale = ColorScale{R: 1, G: 0.3, B: 0.3, A: 1}
+	}
+	if config.DeltaDuration == 0 {
+		config.DeltaDuration = 1
+	}
+	if config.DeltaDistance == 0 {
+		config.DeltaDistance = 16
+	}
+	if config.FlashDuration == 0 {
+		config.FlashDuration = 0.4
+	}
+	return &ResourceCounter{
+		config:           config,
+		ebitenColorScale: config.ColorScale.ToEbitenColorScale(),
+		valueText:        "0",
+		visible:          true,
+	}
+}
+
+// GetValue returns the current counter value.
+func (c *ResourceCounter) GetValue() int { return c.value }
+
+// SetValue changes the counter value.
+// A floating delta text is displayed if the value is changed.
+//
+// Use SetValueSilently to avoid the delta text.
+func (c *ResourceCounter) SetValue(v int) {
+	if v == c.value {
+		return
+	}
+	d := v - c.value
+	s := strconv.Itoa(d)
+	if d > 0 {
+		s = "+" + s
+	}
+	c.deltas = append(c.deltas, resourceDelta{text: s, gain: d > 0})
+	c.SetValueSilently(v)
+}
+
+// SetValueSilently changes the counter value without any animations.
+// It's useful for the initial value assignment.
+func (c *ResourceCounter) SetValueSilently(v int) {
+	c.value = v
+	c.valueText = strconv.Itoa(v)
+}
+
+// NotifySpendFailed starts a failed spend flash animation.
+func (c *ResourceCounter) NotifySpendFailed() {
+	c.flash = c.config.FlashDuration
+}
+
+// Update advances the counter animations.
+func (c *ResourceCounter) Update(delta float64) {
+	c.flash = max(0, c.flash-delta)
+
+	liveDeltas := c.deltas[:0]
+	for _, d := range c.deltas {
+		d.t += delta / c.config.DeltaDuration
+		if d.t >= 1 {
+			continue
+		}
+		liveDeltas = append(liveDeltas, d)
+	}
+	c.deltas = liveDeltas
+}
+
+// BoundsRect returns the icon and the value text bounding rectangle.
+// The floating deltas are not included.
+func (c *ResourceCounter) BoundsRect() gmath.Rect {
+	pos := c.Pos.Resolve()
+	textWidth, textHeight := text.Measure(c.valueText, c.config.Face, 0)
+	size := gmath.Vec{X: textWidth, Y: textHeight}
+	if c.config.Icon != nil {
+		bounds := c.config.Icon.Bounds()
+		size.X += float64(bounds.Dx()) + c.config.Spacing
+		size.Y = max(size.Y, float64(bounds.Dy()))
+	}
+	return gmath.Rect{Min: pos, Max: pos.Add(size)}
+}
+
+// Dispose marks this counter for deletion.
+// After calling this method, IsDisposed will report true.
+func (c *ResourceCounter) Dispose() { c.disposed = true }
+
+// IsDisposed reports whether this counter is marked for deletion.
+func (c *ResourceCounter) IsDisposed() bool { return c.disposed }
+
+// IsVisible reports whether this counter is visible.
+// Use SetVisibility to change this flag value.
+func (c *ResourceCounter) IsVisible() bool { return c.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (c *ResourceCounter) SetVisibility(visible bool) { c.visible = visible }
+
+// Draw renders the counter onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (c *ResourceCounter) Draw(dst *ebiten.Image) {
+	c.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the counter onto the provided dst image
+// while also using the extra provided offset.
+func (c *ResourceCounter) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !c.visible {
+		return
+	}
+
+	bounds := c.BoundsRect().Add(opts.Offset)
+	centerY := bounds.Center().Y
+	textX := bounds.Min.X
+
+	if c.config.Icon != nil {
+		iconBounds := c.config.Icon.Bounds()
+		var drawOptions ebiten.DrawImageOptions
+		if opts.Blend != nil {
+			drawOptions.Blend = *opts.Blend
+		}
+		drawOptions.GeoM.Translate(math.Round(bounds.Min.X), math.Round(centerY-float64(iconBounds.Dy())*0.5))
+		drawImage(dst, c.config.Icon, &drawOptions)
+		textX += float64(iconBounds.Dx()) + c.config.Spacing
+	}
+
+	var textOptions text.DrawOptions
+	if opts.Blend != nil {
+		textOptions.Blend = *opts.Blend
+	}
+	textOptions.SecondaryAlign = text.AlignCenter
+
+	textOptions.ColorScale = c.ebitenColorScale
+	if c.flash > 0 {
+		// The flash starts with a loss color and fades back to normal.
+		t := float32(c.flash / c.config.FlashDuration)
+		cs := c.config.ColorScale.Lerp(c.config.LossColorScale, t)
+		textOptions.ColorScale = cs.ToEbitenColorScale()
+	}
+	textOptions.GeoM.Translate(math.Round(textX), math.Round(centerY))
+	drawText(dst, c.valueText, c.config.Face, &textOptions)
+
+	for _, d := range c.deltas {
+		cs := c.config.LossColorScale
+		if d.gain {
+			cs = c.config.GainColorScale
+		}
+		cs.A *= float32(1 - d.t)
+		textOptions.ColorScale = cs.ToEbitenColorScale()
+		textOptions.GeoM.Reset()
+		textOptions.GeoM.Translate(math.Round(textX), math.Round(centerY-bounds.Height()-d.t*c.config.DeltaDistance))
+		drawText(dst, d.text, c.config.Face, &textOptions)
+	}
+}
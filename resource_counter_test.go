@@ -0,0 +1,33 @@
+package graphics_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	graphics "github.com/quasilyte/ebitengine-graphics"
+	"golang.org/x/image/font/basicfont"
+)
+
+func TestResourceCounterBounds(t *testing.T) {
+	c := graphics.NewResourceCounter(graphics.ResourceCounterConfig{
+		Face: text.NewGoXFace(basicfont.Face7x13),
+	})
+
+	c.SetValue(150)
+	c.SetValue(25)
+	if c.GetValue() != 25 {
+		t.Fatalf("GetValue:\nhave: %d\nwant: %d", c.GetValue(), 25)
+	}
+
+	// Every basicfont glyph is 7 pixels wide.
+	// The floating deltas are not included into the bounds.
+	if w := c.BoundsRect().Width(); w != 14 {
+		t.Fatalf("bounds width:\nhave: %v\nwant: %v", w, 14)
+	}
+
+	c.SetValueSilently(1000)
+	c.Update(10)
+	if w := c.BoundsRect().Width(); w != 28 {
+		t.Fatalf("bounds width:\nhave: %v\nwant: %v", w, 28)
+	}
+}
@@ -2,9 +2,11 @@ package graphics
 
 import (
 	"image"
+	"math"
 	"slices"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
 	"github.com/quasilyte/gmath"
 )
 
@@ -19,6 +21,14 @@ type SceneDrawer struct {
 
 	layers []SceneLayerDrawer
 	buf    *ebiten.Image
+
+	debugCullingRect gmath.Rect
+
+	recorder *Recorder
+
+	// lastDrawHash is a draw commands hash of the last Draw call.
+	// See SetDrawHashing.
+	lastDrawHash uint64
 }
 
 type installedCamera struct {
@@ -26,6 +36,10 @@ type installedCamera struct {
 
 	buf *ebiten.Image
 
+	// transformBuf is used to render the world area
+	// of a zoomed or rotated camera.
+	transformBuf *ebiten.Image
+
 	cachedRect gmath.Rect
 }
 
@@ -96,6 +110,20 @@ func (d *SceneDrawer) Update(delta float64) {
 }
 
 func (d *SceneDrawer) Draw(dst *ebiten.Image) {
+	if cache.Global.StrictMode != 0 {
+		strictBeginDraw()
+		defer strictEndDraw()
+	}
+	if d.recorder != nil {
+		d.recorder.RecordFrame(d)
+	}
+	if h := drawHashState; h != nil {
+		h.beginFrame(dst)
+		defer func() { d.lastDrawHash = h.endFrame() }()
+	} else {
+		d.lastDrawHash = 0
+	}
+
 	cameras := d.cameras
 	if len(cameras) == 0 {
 		cameras = d.defaultCamera // Contains a single full-display camera
@@ -107,19 +135,21 @@ func (d *SceneDrawer) Draw(dst *ebiten.Image) {
 		cameraDst := dst
 		if d.cameraNeedsTmpBuf(camera) {
 			cameraDst = d.cameraAdjustedBuf(camera, d.getBuf())
-			cameraDst.Clear()
+			clearImage(cameraDst)
 		}
 
-		options := DrawOptions{
-			Offset: camera.c.getDrawOffset(),
-		}
-		for i, l := range d.layers {
-			if i < 64 {
-				if uint64(1<<i)&camera.c.layerMask == 0 {
+		if camera.c.isTransformed() {
+			d.drawTransformedLayers(camera, cameraDst)
+		} else {
+			options := DrawOptions{
+				Offset: camera.c.getDrawOffset(),
+			}
+			for i, l := range d.layers {
+				if !camera.c.layerEnabled(i) {
 					continue
 				}
+				l.DrawWithOptions(cameraDst, options)
 			}
-			l.DrawWithOptions(cameraDst, options)
 		}
 
 		if cameraDst != dst {
@@ -128,7 +158,7 @@ func (d *SceneDrawer) Draw(dst *ebiten.Image) {
 				// A simple drawing without post-processing.
 				var options ebiten.DrawImageOptions
 				options.GeoM.Translate(camera.c.areaRect.Min.X, camera.c.areaRect.Min.Y)
-				dst.DrawImage(cameraDst, &options)
+				drawImage(dst, cameraDst, &options)
 			} else {
 				camera.c.pp.PostProcess(dst, cameraDst, DrawOptions{
 					Offset: gmath.Vec{
@@ -139,6 +169,78 @@ func (d *SceneDrawer) Draw(dst *ebiten.Image) {
 			}
 		}
 	}
+
+	if !d.debugCullingRect.IsZero() {
+		d.drawCullingOverlay(dst, cameras)
+	}
+}
+
+// drawTransformedLayers renders the layers through a zoomed or rotated camera.
+//
+// The world layers are rendered into an offscreen image first,
+// then this image is drawn using the camera transformation
+// (with a linear filter to make the rotation look smooth).
+// Static layers are not transformed: they're drawn directly into dst
+// and the world layers that follow them are composed in a new pass.
+func (d *SceneDrawer) drawTransformedLayers(camera *installedCamera, dst *ebiten.Image) {
+	worldRect := camera.c.GetWorldRect()
+	origin := gmath.Vec{X: math.Floor(worldRect.Min.X), Y: math.Floor(worldRect.Min.Y)}
+	width := int(math.Ceil(worldRect.Max.X - origin.X))
+	height := int(math.Ceil(worldRect.Max.Y - origin.Y))
+	buf := d.cameraTransformBuf(camera, width, height)
+
+	var drawOptions ebiten.DrawImageOptions
+	drawOptions.GeoM.Translate(origin.X, origin.Y)
+	drawOptions.GeoM.Concat(camera.c.GetGeoM())
+	// The camera geom is in screen coordinates while dst
+	// has its origin at the viewport rect's top-left corner.
+	drawOptions.GeoM.Translate(-camera.c.areaRect.Min.X, -camera.c.areaRect.Min.Y)
+	shakeOffset := camera.c.getShakeOffset()
+	drawOptions.GeoM.Translate(shakeOffset.X, shakeOffset.Y)
+	drawOptions.Filter = ebiten.FilterLinear
+
+	options := DrawOptions{
+		Offset: gmath.Vec{X: -origin.X, Y: -origin.Y},
+	}
+	pending := false
+	for i, l := range d.layers {
+		if !camera.c.layerEnabled(i) {
+			continue
+		}
+		if _, ok := l.(*StaticLayer); ok {
+			if pending {
+				drawImage(dst, buf, &drawOptions)
+				clearImage(buf)
+				pending = false
+			}
+			l.DrawWithOptions(dst, DrawOptions{})
+			continue
+		}
+		l.DrawWithOptions(buf, options)
+		pending = true
+	}
+	if pending {
+		drawImage(dst, buf, &drawOptions)
+	}
+}
+
+func (d *SceneDrawer) cameraTransformBuf(camera *installedCamera, width, height int) *ebiten.Image {
+	if camera.transformBuf != nil {
+		size := camera.transformBuf.Bounds().Size()
+		if size.X < width || size.Y < height {
+			camera.transformBuf.Deallocate()
+			camera.transformBuf = nil
+		}
+	}
+	if camera.transformBuf == nil {
+		camera.transformBuf = ebiten.NewImage(width, height)
+	}
+
+	buf := subImage(camera.transformBuf, image.Rectangle{
+		Max: image.Point{X: width, Y: height},
+	})
+	clearImage(buf)
+	return buf
 }
 
 func (d *SceneDrawer) cameraAdjustedBuf(camera *installedCamera, buf *ebiten.Image) *ebiten.Image {
@@ -152,9 +254,9 @@ func (d *SceneDrawer) cameraAdjustedBuf(camera *installedCamera, buf *ebiten.Ima
 	}
 
 	// Calculate a subimage and cache it.
-	camera.buf = buf.SubImage(image.Rectangle{
+	camera.buf = subImage(buf, image.Rectangle{
 		Max: camera.c.areaSize.ToStd(),
-	}).(*ebiten.Image)
+	})
 	camera.cachedRect = camera.c.areaRect
 
 	return camera.buf
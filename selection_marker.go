@@ -0,0 +1,248 @@
+package graphics
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+	"github.com/quasilyte/gmath"
+)
+
+// SelectionMarkerConfig describes the [SelectionMarker] appearance.
+type SelectionMarkerConfig struct {
+	// Radius is a horizontal marker radius.
+	// A zero value means 16.
+	Radius float64
+
+	// Flatten is a height-to-width ratio of the marker.
+	// Values below 1 make the marker look like it lies on the ground.
+	// A zero value means 0.5.
+	Flatten float64
+
+	// Width is a ring thickness.
+	// A zero value means 2.
+	Width float64
+
+	// NumDashes splits the ring into several arcs.
+	// A zero value means a solid ring.
+	NumDashes int
+
+	// RotationSpeed is a ring (or decal) rotation speed in radians per second.
+	// Rotating a solid ring has no visual effect.
+	RotationSpeed float64
+
+	// PulseSpeed is a number of alpha pulses per second.
+	// A zero value disables the pulsing animation.
+	PulseSpeed float64
+
+	// Decal is an optional image that is rendered instead of the ring.
+	// It's centered around the marker position and flattened
+	// according to the Flatten value.
+	Decal *ebiten.Image
+}
+
+// SelectionMarker is an animated ring (or decal) rendered under a selected unit.
+//
+// It's positioned using its Pos field, so it can be attached to
+// any transform by binding the Pos.Base pointer.
+//
+// The markers are usually added to the [ShadowLayer] that is
+// rendered below the units layer; that layer also calls
+// the marker's Update method automatically.
+// Use [ShadowLayer.AttachSelectionMarker] to make the marker
+// follow a sprite and get disposed along with it.
+// When a marker is added to some other layer,
+// its Update method should be called every frame.
+//
+// The color scale is usually used to express the unit's team color.
+//
+// SelectionMarker implements gscene Graphics interface.
+type SelectionMarker struct {
+	// Pos is a marker center location binder.
+	// See Pos documentation to learn how it works.
+	Pos gmath.Pos
+
+	config SelectionMarkerConfig
+
+	colorScale       ColorScale
+	ebitenColorScale ebiten.ColorScale
+
+	t float64
+
+	visible  bool
+	disposed bool
+}
+
+// NewSelectionMarker creates a marker with the specified config.
+//
+// By default, a marker has these properties:
+// * Visible=true
+// * The ColorScale is {1, 1, 1, 1}
+func NewSelectionMarker(config SelectionMarkerConfig) *SelectionMarker {
+	if config.Radius == 0 {
+		config.Radius = 16
+	}
+	if config.Flatten == 0 {
+		config.Flatten = 0.5
+	}
+	if config.Width == 0 {
+		config.Width = 2
+	}
+	return &SelectionMarker{
+		config:           config,
+		colorScale:       defaultColorScale,
+		ebitenColorScale: defaultColorScale.ToEbitenColorScale(),
+		visible:          true,
+	}
+}
+
+// BoundsRect returns the marker's ellipse bounding rectangle.
+func (m *SelectionMarker) BoundsRect() gmath.Rect {
+	pos := m.Pos.Resolve()
+	offset := gmath.Vec{X: m.config.Radius, Y: m.config.Radius * m.config.Flatten}
+	return gmath.Rect{Min: pos.Sub(offset), Max: pos.Add(offset)}
+}
+
+// Dispose marks this marker for deletion.
+// After calling this method, IsDisposed will report true.
+func (m *SelectionMarker) Dispose() { m.disposed = true }
+
+// IsDisposed reports whether this marker is marked for deletion.
+func (m *SelectionMarker) IsDisposed() bool { return m.disposed }
+
+// IsVisible reports whether this marker is visible.
+// Use SetVisibility to change this flag value.
+func (m *SelectionMarker) IsVisible() bool { return m.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (m *SelectionMarker) SetVisibility(visible bool) { m.visible = visible }
+
+// GetColorScale is used to retrieve the current color scale value of the marker.
+// Use SetColorScale to change it.
+func (m *SelectionMarker) GetColorScale() ColorScale { return m.colorScale }
+
+// SetColorScale assigns a new ColorScale to this marker.
+// Use GetColorScale to retrieve the current color scale.
+func (m *SelectionMarker) SetColorScale(cs ColorScale) {
+	m.colorScale = cs
+	m.ebitenColorScale = cs.ToEbitenColorScale()
+}
+
+// Update advances the marker animation.
+// delta is a time passed since the last Update call, in seconds.
+func (m *SelectionMarker) Update(delta float64) {
+	m.t += delta
+}
+
+// Draw renders the marker onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (m *SelectionMarker) Draw(dst *ebiten.Image) {
+	m.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the marker onto the provided dst image
+// while also using the extra provided offset.
+func (m *SelectionMarker) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !m.visible || m.colorScale.A == 0 {
+		return
+	}
+
+	cs := m.ebitenColorScale
+	if m.config.PulseSpeed != 0 {
+		// The color scale is premultiplied, so the alpha
+		// scaling is applied to all color components.
+		cs.ScaleAlpha(0.75 + 0.25*float32(math.Cos(m.t*m.config.PulseSpeed*2*math.Pi)))
+	}
+	center := m.Pos.Resolve().Add(opts.Offset)
+	angle := m.t * m.config.RotationSpeed
+
+	if m.config.Decal != nil {
+		m.drawDecal(dst, opts.Blend, center, angle, cs)
+		return
+	}
+
+	if m.config.NumDashes == 0 {
+		drawEllipseArc(dst, opts.Blend, center, m.config.Radius, m.config.Flatten, m.config.Width, 0, 2*math.Pi, cs)
+		return
+	}
+	step := 2 * math.Pi / float64(m.config.NumDashes)
+	// Every dash covers 60% of its sector, the rest is a gap.
+	dashLength := step * 0.6
+	for i := 0; i < m.config.NumDashes; i++ {
+		from := angle + float64(i)*step
+		drawEllipseArc(dst, opts.Blend, center, m.config.Radius, m.config.Flatten, m.config.Width, from, from+dashLength, cs)
+	}
+}
+
+func (m *SelectionMarker) drawDecal(dst *ebiten.Image, blend *ebiten.Blend, center gmath.Vec, angle float64, cs ebiten.ColorScale) {
+	var drawOptions ebiten.DrawImageOptions
+	if blend != nil {
+		drawOptions.Blend = *blend
+	}
+	drawOptions.ColorScale = cs
+
+	bounds := m.config.Decal.Bounds()
+	w := float64(bounds.Dx())
+	h := float64(bounds.Dy())
+
+	// The decal is rotated first, so the flattening
+	// makes it look like it's lying on the ground.
+	drawOptions.GeoM.Translate(-w/2, -h/2)
+	drawOptions.GeoM.Rotate(angle)
+	drawOptions.GeoM.Scale(2*m.config.Radius/w, 2*m.config.Radius*m.config.Flatten/h)
+	drawOptions.GeoM.Translate(center.X, center.Y)
+
+	drawImage(dst, m.config.Decal, &drawOptions)
+}
+
+// drawEllipseArc draws an elliptic ring segment between the from and to angles.
+// The vertical radius is rx*flatten; the ring width is scaled in the same way.
+func drawEllipseArc(dst *ebiten.Image, blend *ebiten.Blend, center gmath.Vec, rx, flatten, width, from, to float64, cs ebiten.ColorScale) {
+	// A full circle uses 32 segments; shorter arcs use less of them.
+	numSegments := max(2, int(math.Ceil(32*(to-from)/(2*math.Pi))))
+
+	vertices := cache.Global.ScratchVertices[:0]
+	indices := cache.Global.ScratchIndices[:0]
+	defer func() {
+		cache.Global.ScratchVertices = vertices[:0]
+		cache.Global.ScratchIndices = indices[:0]
+	}()
+
+	vertex := func(x, y float64) ebiten.Vertex {
+		return ebiten.Vertex{
+			DstX:   float32(x),
+			DstY:   float32(y),
+			SrcX:   1.5,
+			SrcY:   1.5,
+			ColorR: cs.R(),
+			ColorG: cs.G(),
+			ColorB: cs.B(),
+			ColorA: cs.A(),
+		}
+	}
+
+	innerRadius := max(0, rx-width)
+	for i := 0; i <= numSegments; i++ {
+		angle := from + (to-from)*float64(i)/float64(numSegments)
+		sin, cos := math.Sincos(angle)
+		vertices = append(vertices,
+			vertex(center.X+cos*rx, center.Y+sin*rx*flatten),
+			vertex(center.X+cos*innerRadius, center.Y+sin*innerRadius*flatten),
+		)
+	}
+	for i := uint16(0); i < uint16(numSegments); i++ {
+		j := i * 2
+		indices = append(indices, j, j+1, j+2, j+1, j+2, j+3)
+	}
+
+	var drawOptions ebiten.DrawTrianglesOptions
+	if blend != nil {
+		drawOptions.Blend = *blend
+	}
+	drawVertexColorTriangles(dst, vertices, indices, emptyImage, &drawOptions)
+}
@@ -16,6 +16,12 @@ var (
 
 	//go:embed _shaders/dotted_line.go
 	shaderDottedLine []byte
+
+	//go:embed _shaders/team_color.go
+	shaderTeamColor []byte
+
+	//go:embed _shaders/palette.go
+	shaderPalette []byte
 )
 
 // CompileShaders prepares shaders bundled with this package.
@@ -25,23 +31,52 @@ var (
 // Objects that require shaders so far:
 // * Circle
 // * DottedLine
+// * TeamColorSprite
+// * PaletteSprite
+//
+// It panics if any of the shaders can't be compiled.
+// Use TryCompileShaders to handle the error instead.
 func CompileShaders() {
+	if err := TryCompileShaders(); err != nil {
+		panic(err)
+	}
+}
+
+// TryCompileShaders is like CompileShaders, but it returns an error
+// instead of panicking.
+//
+// In case of an error, the shaders are not marked as compiled,
+// so the shader-dependent objects can't be used.
+// The boot rendering functions (like [DrawBootText]) can be used
+// to report the problem as they don't need any shaders.
+func TryCompileShaders() error {
 	if cache.Global.ShadersCompiled {
-		return
+		return nil
 	}
-	cache.Global.ShadersCompiled = true
 
-	mustCompileShader := func(src []byte) *ebiten.Shader {
-		s, err := ebiten.NewShader(src)
+	sources := [...]struct {
+		dst **ebiten.Shader
+		src []byte
+	}{
+		{&cache.Global.CircleOutlineShader, shaderCircleOutline},
+		{&cache.Global.DashedCircleOutlineShader, shaderDashedCircleOutline},
+		{&cache.Global.DottedLineShader, shaderDottedLine},
+		{&cache.Global.TeamColorShader, shaderTeamColor},
+		{&cache.Global.PaletteShader, shaderPalette},
+	}
+	for _, s := range sources {
+		if *s.dst != nil {
+			continue // Compiled during the previous attempt
+		}
+		compiled, err := ebiten.NewShader(s.src)
 		if err != nil {
-			panic(err)
+			return err
 		}
-		return s
+		*s.dst = compiled
 	}
 
-	cache.Global.CircleOutlineShader = mustCompileShader(shaderCircleOutline)
-	cache.Global.DashedCircleOutlineShader = mustCompileShader(shaderDashedCircleOutline)
-	cache.Global.DottedLineShader = mustCompileShader(shaderDottedLine)
+	cache.Global.ShadersCompiled = true
+	return nil
 }
 
 func requireShaders() {
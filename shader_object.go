@@ -25,6 +25,7 @@ type ShaderObject struct {
 
 	visible  bool
 	disposed bool
+	blendID  uint8
 
 	width  uint16
 	height uint16
@@ -75,6 +76,8 @@ func (o *ShaderObject) Draw(dst *ebiten.Image) {
 }
 
 func (o *ShaderObject) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	opts.Blend = resolveBlend(o.blendID, opts.Blend)
+
 	if !o.IsVisible() || o.Shader == nil || !o.Shader.Enabled {
 		return
 	}
@@ -123,5 +126,24 @@ func (o *ShaderObject) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
 	drawOptions.Images[1] = o.Shader.Texture1
 	drawOptions.Images[2] = o.Shader.Texture2
 	drawOptions.Images[3] = o.Shader.Texture3
-	dst.DrawTrianglesShader(vertices, indices, o.Shader.compiled, &drawOptions)
+	drawTrianglesShader(dst, vertices, indices, o.Shader.compiled, &drawOptions)
+}
+
+// GetBlend returns the blend mode assigned by SetBlend.
+// The second result value is false if there is no blend override.
+func (o *ShaderObject) GetBlend() (ebiten.Blend, bool) {
+	return getBlend(o.blendID)
+}
+
+// SetBlend assigns a blend mode that is used to render this object.
+// It takes priority over the DrawOptions.Blend value.
+// Use ResetBlend to remove the override.
+func (o *ShaderObject) SetBlend(b ebiten.Blend) {
+	o.blendID = internBlend(b)
+}
+
+// ResetBlend removes the blend mode override.
+// The DrawOptions.Blend value (if any) will be used again.
+func (o *ShaderObject) ResetBlend() {
+	o.blendID = 0
 }
@@ -0,0 +1,254 @@
+package graphics
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// ShadowStyle describes how the [ShadowLayer] renders the shadows.
+type ShadowStyle uint8
+
+const (
+	// ShadowEllipse is a flattened dark ellipse under the sprite.
+	ShadowEllipse ShadowStyle = iota
+
+	// ShadowSilhouette is a flattened dark copy of the sprite's current frame.
+	ShadowSilhouette
+)
+
+// ShadowLayerConfig describes the [ShadowLayer] shadows.
+type ShadowLayerConfig struct {
+	Style ShadowStyle
+
+	// Offset is applied to every shadow position.
+	// The shadow is anchored to the sprite's bottom center point.
+	Offset gmath.Vec
+
+	// Alpha is a shadow opacity.
+	// A zero value means 0.4.
+	Alpha float32
+
+	// Flatten is a vertical scaling factor of the shadow.
+	// For ellipses, it's a height-to-width ratio.
+	// A zero value means 0.35.
+	Flatten float64
+}
+
+// ShadowLayer renders the shadows for the registered sprites.
+//
+// The shadows follow the sprites automatically (including their frame
+// changes for the silhouette shadows). Put this layer below the
+// layer that renders the sprites themselves, so the shadows
+// are always drawn under everything.
+//
+// Only [Sprite] and [SelectionMarker] objects can be added to this layer,
+// AddChild panics for any other object type.
+// The selection markers are drawn on top of the shadows
+// and they're animated during the layer Update.
+// A marker can be attached to a sprite (see AttachSelectionMarker),
+// so it follows the sprite and gets disposed along with it.
+// The disposed objects are removed automatically.
+// Invisible sprites don't cast shadows.
+//
+// Like [Layer], the shadows are rendered with respect to the camera transformation.
+type ShadowLayer struct {
+	config ShadowLayerConfig
+
+	ebitenColorScale ebiten.ColorScale
+
+	sprites []*Sprite
+	markers []*SelectionMarker
+
+	// markerTargets is a parallel slice for markers:
+	// markerTargets[i] is a sprite markers[i] is attached to (or nil).
+	markerTargets []*Sprite
+
+	needFilter bool
+}
+
+// NewShadowLayer creates a shadows layer with the specified config.
+func NewShadowLayer(config ShadowLayerConfig) *ShadowLayer {
+	if config.Alpha == 0 {
+		config.Alpha = 0.4
+	}
+	if config.Flatten == 0 {
+		config.Flatten = 0.35
+	}
+	l := &ShadowLayer{
+		config:  config,
+		sprites: make([]*Sprite, 0, 16),
+	}
+	// A black color with the specified alpha.
+	l.ebitenColorScale.Scale(0, 0, 0, config.Alpha)
+	return l
+}
+
+// AddChild adds a sprite shadow or a selection marker to this layer.
+// It panics if g is neither a [Sprite] nor a [SelectionMarker].
+func (l *ShadowLayer) AddChild(g gsceneGraphics) {
+	switch g := g.(type) {
+	case *Sprite:
+		l.AddSprite(g)
+	case *SelectionMarker:
+		l.AddSelectionMarker(g)
+	default:
+		panic("unexpected shadow layer child type")
+	}
+}
+
+// AddSprite adds a sprite shadow to this layer.
+func (l *ShadowLayer) AddSprite(s *Sprite) {
+	l.sprites = append(l.sprites, s)
+	l.needFilter = true
+}
+
+// AddSelectionMarker adds a selection marker to this layer.
+func (l *ShadowLayer) AddSelectionMarker(m *SelectionMarker) {
+	l.AttachSelectionMarker(m, nil)
+}
+
+// AttachSelectionMarker adds a selection marker that follows the sprite.
+//
+// The marker is rendered around the sprite's bottom center point
+// (the same point its shadow is anchored to, but without the shadow offset);
+// the marker's Pos is used as an extra offset from that point.
+// The marker is hidden while the sprite is invisible
+// and it's disposed automatically after the sprite is disposed.
+//
+// A nil sprite makes it identical to AddSelectionMarker.
+func (l *ShadowLayer) AttachSelectionMarker(m *SelectionMarker, s *Sprite) {
+	l.markers = append(l.markers, m)
+	l.markerTargets = append(l.markerTargets, s)
+	l.needFilter = true
+}
+
+func (l *ShadowLayer) Update(delta float64) {
+	for _, m := range l.markers {
+		m.Update(delta)
+	}
+	l.needFilter = true
+}
+
+func (l *ShadowLayer) filter() {
+	liveSprites := l.sprites[:0]
+	for _, s := range l.sprites {
+		if s.IsDisposed() {
+			continue
+		}
+		liveSprites = append(liveSprites, s)
+	}
+	l.sprites = liveSprites
+
+	liveMarkers := l.markers[:0]
+	for i, m := range l.markers {
+		target := l.markerTargets[i]
+		if target != nil && target.IsDisposed() {
+			m.Dispose()
+		}
+		if m.IsDisposed() {
+			continue
+		}
+		l.markerTargets[len(liveMarkers)] = target
+		liveMarkers = append(liveMarkers, m)
+	}
+	clear(l.markerTargets[len(liveMarkers):])
+	l.markerTargets = l.markerTargets[:len(liveMarkers)]
+	l.markers = liveMarkers
+}
+
+func (l *ShadowLayer) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if l.needFilter {
+		l.filter()
+	}
+	l.needFilter = false
+
+	for _, s := range l.sprites {
+		if !s.IsVisible() || s.image == nil || s.colorScale.A == 0 {
+			continue
+		}
+		anchor := l.shadowAnchor(s).Add(opts.Offset)
+
+		switch l.config.Style {
+		case ShadowEllipse:
+			rx := s.BoundsRect().Width() * s.scaleX * 0.5
+			drawEllipse(dst, opts.Blend, anchor, rx, rx*l.config.Flatten, l.ebitenColorScale)
+		case ShadowSilhouette:
+			l.drawSilhouette(dst, opts.Blend, s, anchor)
+		}
+	}
+
+	for i, m := range l.markers {
+		target := l.markerTargets[i]
+		if target == nil {
+			m.DrawWithOptions(dst, opts)
+			continue
+		}
+		if !target.IsVisible() || target.IsDisposed() {
+			continue
+		}
+		markerOpts := opts
+		markerOpts.Offset = opts.Offset.Add(l.spriteFootPoint(target))
+		m.DrawWithOptions(dst, markerOpts)
+	}
+}
+
+func (l *ShadowLayer) inspectObjects() []Object {
+	objects := make([]Object, 0, len(l.markers))
+	for _, m := range l.markers {
+		objects = append(objects, m)
+	}
+	return objects
+}
+
+// shadowAnchor returns the sprite's bottom center point
+// (in the world coordinates) with the shadow offset applied.
+func (l *ShadowLayer) shadowAnchor(s *Sprite) gmath.Vec {
+	return l.spriteFootPoint(s).Add(l.config.Offset)
+}
+
+// spriteFootPoint returns the sprite's bottom center point in the world coordinates.
+func (l *ShadowLayer) spriteFootPoint(s *Sprite) gmath.Vec {
+	// The bounds rect ignores the sprite scaling,
+	// so the point offset from the sprite position is scaled here.
+	bounds := s.BoundsRect()
+	pos := s.calculatePos()
+	return pos.Add(gmath.Vec{
+		X: (bounds.Center().X - pos.X) * s.scaleX,
+		Y: (bounds.Max.Y - pos.Y) * s.scaleY,
+	})
+}
+
+// silhouetteGeoM returns the sprite frame transformation
+// that turns it into a shadow silhouette at the anchor point.
+func (l *ShadowLayer) silhouetteGeoM(s *Sprite, anchor gmath.Vec) ebiten.GeoM {
+	var m ebiten.GeoM
+
+	w := float64(s.frameWidth)
+	h := float64(s.frameHeight)
+	if s.IsHorizontallyFlipped() {
+		m.Scale(-1, 1)
+		m.Translate(w, 0)
+	}
+	if s.IsVerticallyFlipped() {
+		m.Scale(1, -1)
+		m.Translate(0, h)
+	}
+
+	// The silhouette is flattened around its bottom center point.
+	m.Translate(-w/2, -h)
+	m.Scale(s.scaleX, s.scaleY*l.config.Flatten)
+	m.Translate(anchor.X, anchor.Y)
+
+	return m
+}
+
+func (l *ShadowLayer) drawSilhouette(dst *ebiten.Image, blend *ebiten.Blend, s *Sprite, anchor gmath.Vec) {
+	var drawOptions ebiten.DrawImageOptions
+	if blend != nil {
+		drawOptions.Blend = *blend
+	}
+	drawOptions.ColorScale = l.ebitenColorScale
+	drawOptions.GeoM = l.silhouetteGeoM(s, anchor)
+
+	drawImage(dst, s.frameImage(), &drawOptions)
+}
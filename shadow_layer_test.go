@@ -0,0 +1,128 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+func TestShadowLayerAnchor(t *testing.T) {
+	l := NewShadowLayer(ShadowLayerConfig{Offset: gmath.Vec{X: 1, Y: 2}})
+	s := NewSprite()
+	s.SetImage(ebiten.NewImage(16, 32))
+	s.Pos.Offset = gmath.Vec{X: 100, Y: 100}
+
+	tests := []struct {
+		centered bool
+		scaleX   float64
+		scaleY   float64
+		want     gmath.Vec
+	}{
+		{true, 1, 1, gmath.Vec{X: 101, Y: 118}},
+		{true, 1, 2, gmath.Vec{X: 101, Y: 134}},
+		{false, 1, 1, gmath.Vec{X: 109, Y: 134}},
+		{false, 2, 2, gmath.Vec{X: 117, Y: 166}},
+	}
+	for _, test := range tests {
+		s.SetCentered(test.centered)
+		s.SetScaleX(test.scaleX)
+		s.SetScaleY(test.scaleY)
+		if have := l.shadowAnchor(s); have != test.want {
+			t.Fatalf("anchor(centered=%v, scale=%v,%v):\nhave: %v\nwant: %v",
+				test.centered, test.scaleX, test.scaleY, have, test.want)
+		}
+	}
+}
+
+func TestShadowLayerSilhouette(t *testing.T) {
+	l := NewShadowLayer(ShadowLayerConfig{Style: ShadowSilhouette, Flatten: 0.5})
+	s := NewSprite()
+	s.SetImage(ebiten.NewImage(16, 32))
+	anchor := gmath.Vec{X: 100, Y: 116}
+
+	apply := func(x, y float64) gmath.Vec {
+		m := l.silhouetteGeoM(s, anchor)
+		x, y = m.Apply(x, y)
+		return gmath.Vec{X: x, Y: y}
+	}
+
+	// The frame bottom center is placed at the anchor,
+	// the frame height is flattened.
+	if have, want := apply(8, 32), anchor; have != want {
+		t.Fatalf("bottom center:\nhave: %v\nwant: %v", have, want)
+	}
+	if have, want := apply(0, 0), (gmath.Vec{X: 92, Y: 100}); have != want {
+		t.Fatalf("top left:\nhave: %v\nwant: %v", have, want)
+	}
+
+	// The flipped frames cast the flipped shadows.
+	s.SetHorizontalFlip(true)
+	if have, want := apply(0, 0), (gmath.Vec{X: 108, Y: 100}); have != want {
+		t.Fatalf("top left (hflip):\nhave: %v\nwant: %v", have, want)
+	}
+	s.SetHorizontalFlip(false)
+	s.SetVerticalFlip(true)
+	if have, want := apply(0, 0), (gmath.Vec{X: 92, Y: 116}); have != want {
+		t.Fatalf("top left (vflip):\nhave: %v\nwant: %v", have, want)
+	}
+}
+
+func TestShadowLayerFilter(t *testing.T) {
+	l := NewShadowLayer(ShadowLayerConfig{})
+	s1 := NewSprite()
+	s1.SetImage(ebiten.NewImage(8, 8))
+	s2 := NewSprite()
+	s2.SetImage(ebiten.NewImage(8, 8))
+	l.AddChild(s1)
+	l.AddChild(s2)
+	m := NewSelectionMarker(SelectionMarkerConfig{})
+	l.AddChild(m)
+
+	s1.Dispose()
+	m.Dispose()
+	l.DrawWithOptions(ebiten.NewImage(32, 32), DrawOptions{})
+	if len(l.sprites) != 1 || l.sprites[0] != s2 || len(l.markers) != 0 {
+		t.Fatalf("unexpected live objects: %d sprites, %d markers", len(l.sprites), len(l.markers))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("adding a rect to the shadow layer should panic")
+		}
+	}()
+	l.AddChild(NewRect(4, 4))
+}
+
+func TestShadowLayerAttachedMarker(t *testing.T) {
+	l := NewShadowLayer(ShadowLayerConfig{Offset: gmath.Vec{X: 1, Y: 2}})
+	s := NewSprite()
+	s.SetImage(ebiten.NewImage(16, 32))
+	s.Pos.Offset = gmath.Vec{X: 100, Y: 100}
+	m1 := NewSelectionMarker(SelectionMarkerConfig{})
+	m2 := NewSelectionMarker(SelectionMarkerConfig{})
+	l.AttachSelectionMarker(m1, s)
+	l.AddSelectionMarker(m2)
+
+	// The marker is centered at the sprite foot point,
+	// the shadow offset is not applied.
+	if have, want := l.spriteFootPoint(s), (gmath.Vec{X: 100, Y: 116}); have != want {
+		t.Fatalf("foot point:\nhave: %v\nwant: %v", have, want)
+	}
+
+	dst := ebiten.NewImage(32, 32)
+	l.DrawWithOptions(dst, DrawOptions{})
+	if len(l.markers) != 2 {
+		t.Fatalf("unexpected live markers: %d", len(l.markers))
+	}
+
+	s.Dispose()
+	l.Update(1.0 / 60)
+	l.DrawWithOptions(dst, DrawOptions{})
+	if !m1.IsDisposed() {
+		t.Fatal("the marker of a disposed sprite is not disposed")
+	}
+	if len(l.markers) != 1 || l.markers[0] != m2 || len(l.markerTargets) != 1 || l.markerTargets[0] != nil {
+		t.Fatalf("unexpected live markers: %d", len(l.markers))
+	}
+}
@@ -0,0 +1,134 @@
+package graphics
+
+import (
+	"math"
+
+	"github.com/quasilyte/gmath"
+)
+
+// ShakeConfig describes the [Shake] effect parameters.
+type ShakeConfig struct {
+	// Amplitude is a maximum shake offset in pixels.
+	// It's reached when the trauma is 1.
+	// A zero value means 8.
+	Amplitude float64
+
+	// Frequency is a number of the shake direction changes per second.
+	// A zero value means 25.
+	Frequency float64
+
+	// Decay is a trauma amount that is removed every second.
+	// A zero value means 1 (a full trauma decays during 1 second).
+	Decay float64
+}
+
+// Shake is a trauma-based shake effect.
+//
+// The trauma is a value in [0, 1] range that is increased
+// by AddTrauma (e.g. when something explodes) and then linearly decays
+// over time. The shake offset is proportional to the squared trauma,
+// so the small hits produce a barely noticeable shake while
+// the big ones shake hard.
+//
+// The offset is a smooth noise, not a random jitter,
+// so the shake doesn't look chaotic at the high frame rates.
+//
+// Assign it to a [Camera] or a [Container] using their SetShake methods;
+// they add the shake offset to the draw offset, so the objects Pos
+// values are not affected.
+// A single shake can be shared by several cameras and containers.
+//
+// Its Update method should be called every frame.
+type Shake struct {
+	config ShakeConfig
+
+	trauma float64
+	t      float64
+
+	offset gmath.Vec
+}
+
+// NewShake creates an inactive shake effect with the specified config.
+func NewShake(config ShakeConfig) *Shake {
+	if config.Amplitude == 0 {
+		config.Amplitude = 8
+	}
+	if config.Frequency == 0 {
+		config.Frequency = 25
+	}
+	if config.Decay == 0 {
+		config.Decay = 1
+	}
+	return &Shake{config: config}
+}
+
+// AddTrauma increases the shake trauma level.
+// The result is clamped to [0, 1] range.
+func (s *Shake) AddTrauma(amount float64) {
+	s.trauma = gmath.Clamp(s.trauma+amount, 0, 1)
+}
+
+// ShakeFor starts a full-trauma shake that lasts for the specified
+// number of seconds. It changes the config Decay value.
+func (s *Shake) ShakeFor(duration float64) {
+	s.config.Decay = 1 / duration
+	s.trauma = 1
+}
+
+// GetTrauma returns the current trauma level.
+func (s *Shake) GetTrauma() float64 { return s.trauma }
+
+// IsActive reports whether the shake has some trauma left.
+func (s *Shake) IsActive() bool { return s.trauma > 0 }
+
+// Stop resets the trauma level to zero.
+func (s *Shake) Stop() {
+	s.trauma = 0
+	s.offset = gmath.Vec{}
+}
+
+// Offset returns the current shake offset.
+// It's updated during the Update calls.
+func (s *Shake) Offset() gmath.Vec { return s.offset }
+
+// Update advances the shake effect.
+// delta is a time passed since the last Update call, in seconds.
+func (s *Shake) Update(delta float64) {
+	if s.trauma == 0 {
+		return
+	}
+
+	s.trauma = max(0, s.trauma-s.config.Decay*delta)
+	if s.trauma == 0 {
+		s.offset = gmath.Vec{}
+		return
+	}
+
+	s.t += delta
+	k := s.config.Amplitude * s.trauma * s.trauma
+	x := s.t * s.config.Frequency
+	s.offset = gmath.Vec{
+		X: k * shakeNoise(x, 0),
+		Y: k * shakeNoise(x, 1),
+	}
+}
+
+// shakeNoise is a 1D value noise in [-1, 1] range.
+// Different seeds produce uncorrelated noise curves.
+func shakeNoise(x float64, seed uint32) float64 {
+	i := math.Floor(x)
+	t := x - i
+	// A smoothstep makes the curve continuous at the integer points.
+	t = t * t * (3 - 2*t)
+	a := shakeHash(uint32(int64(i)), seed)
+	b := shakeHash(uint32(int64(i)+1), seed)
+	return a + (b-a)*t
+}
+
+func shakeHash(x, seed uint32) float64 {
+	h := x*0x9e3779b1 ^ seed*0x85ebca77
+	h ^= h >> 15
+	h *= 0x2c1b3c6d
+	h ^= h >> 12
+	return float64(h)/math.MaxUint32*2 - 1
+}
@@ -0,0 +1,60 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/quasilyte/gmath"
+)
+
+func TestShakeNoiseBounds(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		x := float64(i) * 0.37
+		for seed := uint32(0); seed < 2; seed++ {
+			v := shakeNoise(x, seed)
+			if v < -1 || v > 1 {
+				t.Fatalf("noise(%v, %d)=%v is out of range", x, seed, v)
+			}
+		}
+	}
+}
+
+func TestShake(t *testing.T) {
+	s := NewShake(ShakeConfig{Amplitude: 10, Decay: 2})
+	if s.IsActive() {
+		t.Fatal("a new shake is active")
+	}
+
+	s.AddTrauma(0.7)
+	s.AddTrauma(0.7)
+	if have := s.GetTrauma(); have != 1 {
+		t.Fatalf("trauma: have %v, want 1", have)
+	}
+
+	s.Update(0.25)
+	if have := s.GetTrauma(); !gmath.EqualApprox(have, 0.5) {
+		t.Fatalf("trauma: have %v, want 0.5", have)
+	}
+	// The offset is limited by amplitude*trauma^2.
+	offset := s.Offset()
+	if offset.X < -2.5 || offset.X > 2.5 || offset.Y < -2.5 || offset.Y > 2.5 {
+		t.Fatalf("offset %v is out of range", offset)
+	}
+
+	s.Update(0.5)
+	if s.IsActive() {
+		t.Fatal("the shake is still active after the trauma decay")
+	}
+	if !s.Offset().IsZero() {
+		t.Fatalf("offset is not reset: %v", s.Offset())
+	}
+
+	s.ShakeFor(4)
+	s.Update(1)
+	if have := s.GetTrauma(); !gmath.EqualApprox(have, 0.75) {
+		t.Fatalf("trauma: have %v, want 0.75", have)
+	}
+	s.Stop()
+	if s.IsActive() {
+		t.Fatal("the shake is still active after Stop")
+	}
+}
@@ -0,0 +1,199 @@
+package graphics
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"math"
+	"strconv"
+)
+
+// SnapshotHash returns a deterministic hash of the current scene rendering state.
+//
+// It's a shorthand for HashSnapshot(d.Inspect()).
+// See [HashSnapshot] for more info.
+func (d *SceneDrawer) SnapshotHash() uint64 {
+	return HashSnapshot(d.Inspect())
+}
+
+// HashSnapshot computes a deterministic hash of the scene snapshot.
+//
+// The hash covers the [ObjectInfo] fields: objects order,
+// their types, positions, bounds, rotations, colors, texture sizes,
+// texts, scales, blend modes and visibility.
+// Names and tags are ignored.
+//
+// The object states that are not reported by the introspection API
+// (like a gauge value) and the texture identities are not hashed.
+// Use [SceneDrawer.DrawHash] to detect unintended rendering changes in CI:
+// it hashes the actual draw calls.
+// When the draw hashes differ, the snapshots and [DiffSnapshots]
+// can help to find out what has changed.
+func HashSnapshot(layers []LayerInfo) uint64 {
+	h := fnv.New64a()
+	var buf [8]byte
+	for i := range layers {
+		l := &layers[i]
+		hashSnapshotString(h, &buf, l.Type)
+		hashSnapshotInt(h, &buf, int64(len(l.Objects)))
+		for j := range l.Objects {
+			hashSnapshotObject(h, &buf, &l.Objects[j])
+		}
+	}
+	return h.Sum64()
+}
+
+// DiffSnapshots returns a list of human-readable differences between two snapshots.
+// An empty result means that snapshots are identical (in terms of [HashSnapshot]).
+//
+// The object paths in the messages use the [SceneDrawer.FindObject] syntax.
+func DiffSnapshots(before, after []LayerInfo) []string {
+	var diff []string
+	if len(before) != len(after) {
+		diff = append(diff, fmt.Sprintf("layers count: %d -> %d", len(before), len(after)))
+	}
+	for i := 0; i < min(len(before), len(after)); i++ {
+		path := strconv.Itoa(i)
+		if before[i].Type != after[i].Type {
+			diff = append(diff, fmt.Sprintf("%s: layer type: %s -> %s", path, before[i].Type, after[i].Type))
+			continue
+		}
+		diff = diffSnapshotObjects(diff, path, before[i].Objects, after[i].Objects)
+	}
+	return diff
+}
+
+func diffSnapshotObjects(diff []string, path string, before, after []ObjectInfo) []string {
+	if len(before) != len(after) {
+		diff = append(diff, fmt.Sprintf("%s: objects count: %d -> %d", path, len(before), len(after)))
+	}
+	for i := 0; i < min(len(before), len(after)); i++ {
+		a := &before[i]
+		b := &after[i]
+		objectPath := path + "/" + strconv.Itoa(i)
+		if a.Type != b.Type {
+			diff = append(diff, fmt.Sprintf("%s: type: %s -> %s", objectPath, a.Type, b.Type))
+			continue
+		}
+		if a.Visible != b.Visible {
+			diff = append(diff, fmt.Sprintf("%s: visible: %v -> %v", objectPath, a.Visible, b.Visible))
+		}
+		if a.Pos != b.Pos {
+			diff = append(diff, fmt.Sprintf("%s: pos: %v -> %v", objectPath, a.Pos, b.Pos))
+		}
+		if a.Rotation != b.Rotation {
+			diff = append(diff, fmt.Sprintf("%s: rotation: %v -> %v", objectPath, a.Rotation, b.Rotation))
+		}
+		if !equalPtrValues(a.Bounds, b.Bounds) {
+			diff = append(diff, fmt.Sprintf("%s: bounds: %v -> %v", objectPath, formatPtrValue(a.Bounds), formatPtrValue(b.Bounds)))
+		}
+		if !equalPtrValues(a.ColorScale, b.ColorScale) {
+			diff = append(diff, fmt.Sprintf("%s: color scale: %v -> %v", objectPath, formatPtrValue(a.ColorScale), formatPtrValue(b.ColorScale)))
+		}
+		if !equalPtrValues(a.Texture, b.Texture) {
+			diff = append(diff, fmt.Sprintf("%s: texture: %v -> %v", objectPath, formatPtrValue(a.Texture), formatPtrValue(b.Texture)))
+		}
+		if a.Text != b.Text {
+			diff = append(diff, fmt.Sprintf("%s: text: %q -> %q", objectPath, a.Text, b.Text))
+		}
+		if !equalPtrValues(a.Scale, b.Scale) {
+			diff = append(diff, fmt.Sprintf("%s: scale: %v -> %v", objectPath, formatPtrValue(a.Scale), formatPtrValue(b.Scale)))
+		}
+		if !equalPtrValues(a.Blend, b.Blend) {
+			diff = append(diff, fmt.Sprintf("%s: blend: %v -> %v", objectPath, formatPtrValue(a.Blend), formatPtrValue(b.Blend)))
+		}
+		diff = diffSnapshotObjects(diff, objectPath, a.Children, b.Children)
+	}
+	return diff
+}
+
+func equalPtrValues[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func formatPtrValue[T any](v *T) string {
+	if v == nil {
+		return "<nil>"
+	}
+	return fmt.Sprint(*v)
+}
+
+func hashSnapshotObject(h hash.Hash64, buf *[8]byte, o *ObjectInfo) {
+	// The variable-length fields are length-prefixed and
+	// the optional fields are prefixed by their presence flags,
+	// so the different objects can't produce the same byte stream.
+	hashSnapshotString(h, buf, o.Type)
+	hashSnapshotBool(h, buf, o.Visible)
+	hashSnapshotFloat(h, buf, o.Pos.X)
+	hashSnapshotFloat(h, buf, o.Pos.Y)
+	hashSnapshotFloat(h, buf, float64(o.Rotation))
+	hashSnapshotBool(h, buf, o.Bounds != nil)
+	if o.Bounds != nil {
+		hashSnapshotFloat(h, buf, o.Bounds.Min.X)
+		hashSnapshotFloat(h, buf, o.Bounds.Min.Y)
+		hashSnapshotFloat(h, buf, o.Bounds.Max.X)
+		hashSnapshotFloat(h, buf, o.Bounds.Max.Y)
+	}
+	hashSnapshotBool(h, buf, o.ColorScale != nil)
+	if o.ColorScale != nil {
+		hashSnapshotFloat(h, buf, float64(o.ColorScale.R))
+		hashSnapshotFloat(h, buf, float64(o.ColorScale.G))
+		hashSnapshotFloat(h, buf, float64(o.ColorScale.B))
+		hashSnapshotFloat(h, buf, float64(o.ColorScale.A))
+	}
+	hashSnapshotBool(h, buf, o.Texture != nil)
+	if o.Texture != nil {
+		hashSnapshotInt(h, buf, int64(o.Texture.Width))
+		hashSnapshotInt(h, buf, int64(o.Texture.Height))
+		hashSnapshotInt(h, buf, int64(o.Texture.Frame.Min.X))
+		hashSnapshotInt(h, buf, int64(o.Texture.Frame.Min.Y))
+		hashSnapshotInt(h, buf, int64(o.Texture.Frame.Max.X))
+		hashSnapshotInt(h, buf, int64(o.Texture.Frame.Max.Y))
+	}
+	hashSnapshotString(h, buf, o.Text)
+	hashSnapshotBool(h, buf, o.Scale != nil)
+	if o.Scale != nil {
+		hashSnapshotFloat(h, buf, o.Scale.X)
+		hashSnapshotFloat(h, buf, o.Scale.Y)
+	}
+	hashSnapshotBool(h, buf, o.Blend != nil)
+	if o.Blend != nil {
+		hashSnapshotInt(h, buf, int64(o.Blend.BlendFactorSourceRGB))
+		hashSnapshotInt(h, buf, int64(o.Blend.BlendFactorSourceAlpha))
+		hashSnapshotInt(h, buf, int64(o.Blend.BlendFactorDestinationRGB))
+		hashSnapshotInt(h, buf, int64(o.Blend.BlendFactorDestinationAlpha))
+		hashSnapshotInt(h, buf, int64(o.Blend.BlendOperationRGB))
+		hashSnapshotInt(h, buf, int64(o.Blend.BlendOperationAlpha))
+	}
+	hashSnapshotInt(h, buf, int64(len(o.Children)))
+	for i := range o.Children {
+		hashSnapshotObject(h, buf, &o.Children[i])
+	}
+}
+
+func hashSnapshotString(h hash.Hash64, buf *[8]byte, s string) {
+	hashSnapshotInt(h, buf, int64(len(s)))
+	h.Write([]byte(s))
+}
+
+func hashSnapshotBool(h hash.Hash64, buf *[8]byte, v bool) {
+	if v {
+		hashSnapshotInt(h, buf, 1)
+	} else {
+		hashSnapshotInt(h, buf, 0)
+	}
+}
+
+func hashSnapshotInt(h hash.Hash64, buf *[8]byte, v int64) {
+	binary.LittleEndian.PutUint64(buf[:], uint64(v))
+	h.Write(buf[:])
+}
+
+func hashSnapshotFloat(h hash.Hash64, buf *[8]byte, v float64) {
+	binary.LittleEndian.PutUint64(buf[:], math.Float64bits(v))
+	h.Write(buf[:])
+}
@@ -0,0 +1,80 @@
+package graphics_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	graphics "github.com/quasilyte/ebitengine-graphics"
+	"github.com/quasilyte/gmath"
+)
+
+func TestSnapshotDiff(t *testing.T) {
+	l := graphics.NewLayer()
+	r := graphics.NewRect(8, 8)
+	l.AddChild(r)
+	l.AddChild(graphics.NewLine(gmath.Pos{}, gmath.Pos{Offset: gmath.Vec{X: 10}}))
+
+	before := []graphics.LayerInfo{graphics.InspectLayer(l)}
+	same := []graphics.LayerInfo{graphics.InspectLayer(l)}
+	if graphics.HashSnapshot(before) != graphics.HashSnapshot(same) {
+		t.Fatal("identical snapshots have different hashes")
+	}
+	if diff := graphics.DiffSnapshots(before, same); len(diff) != 0 {
+		t.Fatalf("identical snapshots have diff: %v", diff)
+	}
+
+	r.Pos.Offset.X = 4
+	after := []graphics.LayerInfo{graphics.InspectLayer(l)}
+	if graphics.HashSnapshot(before) == graphics.HashSnapshot(after) {
+		t.Fatal("different snapshots have identical hashes")
+	}
+	diff := graphics.DiffSnapshots(before, after)
+	wantPrefixes := []string{
+		"0/0: pos: ",
+		"0/0: bounds: ",
+	}
+	if len(diff) != len(wantPrefixes) {
+		t.Fatalf("diff:\nhave: %q\nwant %d entries", diff, len(wantPrefixes))
+	}
+	for i := range diff {
+		if !strings.HasPrefix(diff[i], wantPrefixes[i]) {
+			t.Fatalf("diff[%d]:\nhave: %q\nwant: %q prefix", i, diff[i], wantPrefixes[i])
+		}
+	}
+}
+
+func TestSnapshotHashFields(t *testing.T) {
+	hash := func(o graphics.ObjectInfo) uint64 {
+		return graphics.HashSnapshot([]graphics.LayerInfo{{Objects: []graphics.ObjectInfo{o}}})
+	}
+
+	// Without the presence flags, these two objects
+	// would be encoded into the same byte stream.
+	bounds := gmath.Rect{Min: gmath.Vec{X: 1, Y: 1}, Max: gmath.Vec{X: 1, Y: 1}}
+	cs := graphics.ColorScale{R: 1, G: 1, B: 1, A: 1}
+	if hash(graphics.ObjectInfo{Bounds: &bounds}) == hash(graphics.ObjectInfo{ColorScale: &cs}) {
+		t.Fatal("bounds and color scale objects have identical hashes")
+	}
+
+	base := graphics.ObjectInfo{Type: "graphics.Label", Text: "a"}
+	blend := ebiten.BlendLighter
+	changed := []graphics.ObjectInfo{
+		{Type: "graphics.Label", Text: "b"},
+		{Type: "graphics.Labela"},
+		{Type: "graphics.Label", Text: "a", Scale: &gmath.Vec{X: 2, Y: 2}},
+		{Type: "graphics.Label", Text: "a", Blend: &blend},
+	}
+	for i, o := range changed {
+		if hash(o) == hash(base) {
+			t.Fatalf("changed[%d] has the same hash as the original object", i)
+		}
+		diff := graphics.DiffSnapshots(
+			[]graphics.LayerInfo{{Objects: []graphics.ObjectInfo{base}}},
+			[]graphics.LayerInfo{{Objects: []graphics.ObjectInfo{o}}},
+		)
+		if len(diff) == 0 {
+			t.Fatalf("changed[%d] has no diff", i)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package graphics
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// SpinnerStyle selects the [Spinner] animation preset.
+type SpinnerStyle uint8
+
+const (
+	// SpinnerRing is a rotating partial ring.
+	SpinnerRing SpinnerStyle = iota
+
+	// SpinnerDots is a row of the dots that bounce one after another.
+	SpinnerDots
+
+	// SpinnerProgressRing is a ring that is filled clockwise from the top
+	// according to the current progress value.
+	// If the config Face is set, the percentage is rendered inside the ring.
+	SpinnerProgressRing
+)
+
+// SpinnerConfig describes the [Spinner] appearance.
+type SpinnerConfig struct {
+	Style SpinnerStyle
+
+	// Radius is a ring radius.
+	// For the dots style, it's a half of the dots row width.
+	// A zero value means 12.
+	Radius float64
+
+	// Thickness is a ring width.
+	// For the dots style, it's a dot diameter.
+	// A zero value means 3.
+	Thickness float64
+
+	// NumDots is a number of dots for the dots style.
+	// A zero value means 3.
+	NumDots int
+
+	// Speed is a number of full animation cycles per second.
+	// A zero value means 1.
+	Speed float64
+
+	// Face is an optional progress ring percentage label font.
+	Face text.Face
+
+	// ColorScale is a spinner color.
+	// A zero value means {1, 1, 1, 1}.
+	ColorScale ColorScale
+
+	// TrackColorScale is a progress ring background color.
+	// A zero value means {1, 1, 1, 0.25}.
+	TrackColorScale ColorScale
+}
+
+// Spinner is an animated loading indicator.
+// See [SpinnerStyle] for the available presets.
+//
+// It's a good fit for the loading screens while
+// the resources are being loaded in the background.
+// For the progress ring, report the loading progress via SetProgress.
+//
+// The animation is driven by the Update method.
+// The spinner is always centered around its Pos.
+//
+// Spinner implements gscene Graphics interface.
+type Spinner struct {
+	// Pos is a spinner center location binder.
+	// See Pos documentation to learn how it works.
+	Pos gmath.Pos
+
+	config SpinnerConfig
+
+	ebitenColorScale      ebiten.ColorScale
+	ebitenTrackColorScale ebiten.ColorScale
+
+	// t is an animation cycle progress in [0, 1) range.
+	t float64
+
+	progress      float64
+	progressLabel string
+
+	visible  bool
+	disposed bool
+}
+
+// NewSpinner creates a spinner with the specified config.
+func NewSpinner(config SpinnerConfig) *Spinner {
+	if config.Radius == 0 {
+		config.Radius = 12
+	}
+	if config.Thickness == 0 {
+		config.Thickness = 3
+	}
+	if config.NumDots == 0 {
+		config.NumDots = 3
+	}
+	if config.Speed == 0 {
+		config.Speed = 1
+	}
+	if config.ColorScale == (ColorScale{}) {
+		config.ColorScale = defaultColorScale
+	}
+	if config.TrackColorScale == (ColorScale{}) {
+		config.TrackColorScale = ColorScale{R: 1, G: 1, B: 1, A: 0.25}
+	}
+	return &Spinner{
+		config:                config,
+		ebitenColorScale:      config.ColorScale.ToEbitenColorScale(),
+		ebitenTrackColorScale: config.TrackColorScale.ToEbitenColorScale(),
+		progressLabel:         "0%",
+		visible:               true,
+	}
+}
+
+// Update advances the spinner animation.
+func (s *Spinner) Update(delta float64) {
+	s.t = wrapTimeOfDay(s.t + delta*s.config.Speed)
+}
+
+// GetProgress returns the current progress value.
+// Use SetProgress to change it.
+func (s *Spinner) GetProgress() float64 { return s.progress }
+
+// SetProgress changes the progress value.
+// The value is clamped to [0, 1].
+//
+// Only the progress ring style uses this value.
+func (s *Spinner) SetProgress(v float64) {
+	v = gmath.Clamp(v, 0, 1)
+	if s.progress == v {
+		return
+	}
+	s.progress = v
+	s.progressLabel = strconv.Itoa(int(v*100)) + "%"
+}
+
+// BoundsRect returns the spinner bounding rectangle.
+func (s *Spinner) BoundsRect() gmath.Rect {
+	pos := s.Pos.Resolve()
+	half := gmath.Vec{X: s.config.Radius, Y: s.config.Radius}
+	if s.config.Style == SpinnerDots {
+		// The dots bounce up to their diameter above the center line.
+		r := s.config.Thickness * 0.5
+		half = gmath.Vec{X: s.config.Radius + r, Y: r + s.config.Thickness}
+	}
+	return gmath.Rect{
+		Min: pos.Sub(half),
+		Max: pos.Add(half),
+	}
+}
+
+// Dispose marks this spinner for deletion.
+// After calling this method, IsDisposed will report true.
+func (s *Spinner) Dispose() { s.disposed = true }
+
+// IsDisposed reports whether this spinner is marked for deletion.
+func (s *Spinner) IsDisposed() bool { return s.disposed }
+
+// IsVisible reports whether this spinner is visible.
+// Use SetVisibility to change this flag value.
+func (s *Spinner) IsVisible() bool { return s.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (s *Spinner) SetVisibility(visible bool) { s.visible = visible }
+
+// Draw renders the spinner onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (s *Spinner) Draw(dst *ebiten.Image) {
+	s.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the spinner onto the provided dst image
+// while also using the extra provided offset.
+func (s *Spinner) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !s.visible {
+		return
+	}
+
+	center := s.Pos.Resolve().Add(opts.Offset)
+	r := s.config.Radius
+	w := s.config.Thickness
+
+	switch s.config.Style {
+	case SpinnerRing:
+		// A 3/4 ring that makes a full turn per cycle.
+		from := 2 * math.Pi * s.t
+		drawEllipseArc(dst, opts.Blend, center, r, 1, w, from, from+1.5*math.Pi, s.ebitenColorScale)
+
+	case SpinnerDots:
+		n := s.config.NumDots
+		dotRadius := w * 0.5
+		for i := 0; i < n; i++ {
+			x := 0.0
+			if n > 1 {
+				x = -r + 2*r*float64(i)/float64(n-1)
+			}
+			// Every dot bounces during its own part of the first half of the cycle,
+			// the second half is a pause.
+			phase := s.t*2 - float64(i)/float64(n)
+			y := 0.0
+			if phase >= 0 && phase < 1 {
+				y = -math.Sin(math.Pi*phase) * w
+			}
+			drawEllipse(dst, opts.Blend, center.Add(gmath.Vec{X: x, Y: y}), dotRadius, dotRadius, s.ebitenColorScale)
+		}
+
+	case SpinnerProgressRing:
+		drawEllipseArc(dst, opts.Blend, center, r, 1, w, 0, 2*math.Pi, s.ebitenTrackColorScale)
+		if s.progress > 0 {
+			from := -math.Pi / 2
+			drawEllipseArc(dst, opts.Blend, center, r, 1, w, from, from+2*math.Pi*s.progress, s.ebitenColorScale)
+		}
+		if s.config.Face != nil {
+			var textOptions text.DrawOptions
+			if opts.Blend != nil {
+				textOptions.Blend = *opts.Blend
+			}
+			textOptions.PrimaryAlign = text.AlignCenter
+			textOptions.SecondaryAlign = text.AlignCenter
+			textOptions.ColorScale = s.ebitenColorScale
+			textOptions.GeoM.Translate(math.Round(center.X), math.Round(center.Y))
+			drawText(dst, s.progressLabel, s.config.Face, &textOptions)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package graphics
+
+import "testing"
+
+func TestSpinnerProgress(t *testing.T) {
+	s := NewSpinner(SpinnerConfig{Style: SpinnerProgressRing})
+
+	tests := []struct {
+		value float64
+		want  float64
+		label string
+	}{
+		{0.5, 0.5, "50%"},
+		{0.999, 0.999, "99%"},
+		{1.5, 1, "100%"},
+		{-1, 0, "0%"},
+	}
+	for _, test := range tests {
+		s.SetProgress(test.value)
+		if have := s.GetProgress(); have != test.want {
+			t.Fatalf("SetProgress(%v):\nhave: %v\nwant: %v", test.value, have, test.want)
+		}
+		if s.progressLabel != test.label {
+			t.Fatalf("SetProgress(%v) label:\nhave: %q\nwant: %q", test.value, s.progressLabel, test.label)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"image"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
 	"github.com/quasilyte/gmath"
 )
 
@@ -51,6 +52,8 @@ type Sprite struct {
 	frameHeight uint16
 
 	flags spriteFlag
+
+	blendID uint8
 }
 
 type spriteFlag uint8
@@ -66,6 +69,8 @@ const (
 
 // NewSprite returns an empty sprite.
 // Use SetImage method to assign a texture to it.
+// An empty sprite renders nothing; a nil image passed to SetImage
+// is replaced by a [MissingImage] placeholder.
 //
 // By default, a sprite has these properties:
 // * Centered=true
@@ -140,6 +145,10 @@ func (s *Sprite) GetFrameHeight() int {
 // A frame size defines an image rectangle sizes to be used.
 // A frame offset defines the rectangle Min value.
 func (s *Sprite) SetFrameWidth(w int) {
+	if cache.Global.StrictMode != 0 {
+		strictCheckMutation(s, s.IsDisposed())
+		strictCheckSize(s, "frame width", w)
+	}
 	uw := uint16(w)
 	if s.frameWidth == uw {
 		return
@@ -157,6 +166,10 @@ func (s *Sprite) SetFrameWidth(w int) {
 // A frame size defines an image rectangle sizes to be used.
 // A frame offset defines the rectangle Min value.
 func (s *Sprite) SetFrameHeight(h int) {
+	if cache.Global.StrictMode != 0 {
+		strictCheckMutation(s, s.IsDisposed())
+		strictCheckSize(s, "frame height", h)
+	}
 	uh := uint16(h)
 	if s.frameHeight == uh {
 		return
@@ -195,6 +208,9 @@ func (s *Sprite) GetColorScale() ColorScale {
 // SetColorScale assigns a new ColorScale to this sprite.
 // Use GetColorScale to retrieve the current color scale.
 func (s *Sprite) SetColorScale(cs ColorScale) {
+	if cache.Global.StrictMode != 0 {
+		strictCheckMutation(s, s.IsDisposed())
+	}
 	if s.colorScale == cs {
 		return
 	}
@@ -208,6 +224,9 @@ func (s *Sprite) GetAlpha() float32 { return s.colorScale.A }
 
 // SetAlpha is a convenient way to change the alpha value of the ColorScale.
 func (s *Sprite) SetAlpha(a float32) {
+	if cache.Global.StrictMode != 0 {
+		strictCheckMutation(s, s.IsDisposed())
+	}
 	if s.colorScale.A == a {
 		return
 	}
@@ -289,6 +308,10 @@ func (s *Sprite) GetFrameOffsetY() int {
 // A frame offset defines the rectangle Min value.
 // A frame size defines an image rectangle sizes to be used.
 func (s *Sprite) SetFrameOffsetX(x int) {
+	if cache.Global.StrictMode != 0 {
+		strictCheckMutation(s, s.IsDisposed())
+		strictCheckSize(s, "frame offset", x)
+	}
 	ux := uint16(x)
 	if s.frameOffsetX == ux {
 		return
@@ -306,6 +329,10 @@ func (s *Sprite) SetFrameOffsetX(x int) {
 // A frame offset defines the rectangle Min value.
 // A frame size defines an image rectangle sizes to be used.
 func (s *Sprite) SetFrameOffsetY(y int) {
+	if cache.Global.StrictMode != 0 {
+		strictCheckMutation(s, s.IsDisposed())
+		strictCheckSize(s, "frame offset", y)
+	}
 	uy := uint16(y)
 	if s.frameOffsetY == uy {
 		return
@@ -315,14 +342,49 @@ func (s *Sprite) SetFrameOffsetY(y int) {
 	s.flags |= spriteFlagSubImageChanged
 }
 
+// GetFrameRect returns the current frame rectangle inside the sprite's image.
+// It's a combination of the frame offset and the frame size.
+func (s *Sprite) GetFrameRect() image.Rectangle {
+	return s.frameRect()
+}
+
+// SetFrameRect assigns the frame offset and size at once.
+// Use GetFrameRect to retrieve the current frame rectangle.
+//
+// This is the most convenient way to select a spritesheet frame:
+// many sprites can share one atlas image and select their
+// own frames without allocating the sub-images every frame.
+// The sub-image is re-created only when the frame actually changes.
+func (s *Sprite) SetFrameRect(r image.Rectangle) {
+	s.SetFrameOffsetX(r.Min.X)
+	s.SetFrameOffsetY(r.Min.Y)
+	s.SetFrameWidth(r.Dx())
+	s.SetFrameHeight(r.Dy())
+}
+
 // SetImage changes the image associated with a sprite.
 //
 // Assigning an image sets the frame offsets to {0, 0}.
 // The default frame width/height are image sizes.
+//
+// A nil image is replaced by a [MissingImage] placeholder
+// of the current frame size (or 16x16 if the frame is empty).
 func (s *Sprite) SetImage(img *ebiten.Image) {
+	if img == nil {
+		w, h := int(s.frameWidth), int(s.frameHeight)
+		if w == 0 || h == 0 {
+			w, h = missingImageSize, missingImageSize
+		}
+		img = MissingImage("", w, h)
+	}
 	s.image = img
 
 	imageBounds := img.Bounds()
+	if cache.Global.StrictMode != 0 {
+		strictCheckMutation(s, s.IsDisposed())
+		strictCheckSize(s, "image width", imageBounds.Dx())
+		strictCheckSize(s, "image height", imageBounds.Dy())
+	}
 	s.frameWidth = uint16(imageBounds.Dx())
 	s.frameHeight = uint16(imageBounds.Dy())
 	s.frameOffsetX = 0
@@ -365,24 +427,57 @@ func (s *Sprite) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
 	//
 	// The order of operations in this function matters.
 
+	if cache.Global.StrictMode != 0 {
+		strictCheckDraw(s, s.IsDisposed())
+	}
+
 	// Try to save some processing time if this sprite should not be rendered.
 	if !s.IsVisible() || s.image == nil || s.colorScale.A == 0 {
 		return
 	}
 
+	opts.Blend = resolveBlend(s.blendID, opts.Blend)
+
 	var drawOptions ebiten.DrawImageOptions
 	if opts.Blend != nil {
 		drawOptions.Blend = *opts.Blend
 	}
 	drawOptions.ColorScale = s.ebitenColorScale
+	drawOptions.GeoM = s.calculateGeoM(opts)
+
+	srcImage := s.frameImage()
+
+	if s.Shader == nil || !s.Shader.Enabled {
+		drawImage(dst, srcImage, &drawOptions)
+		return
+	}
+
+	srcImageBounds := srcImage.Bounds()
+	var options ebiten.DrawRectShaderOptions
+	if opts.Blend != nil {
+		options.Blend = *opts.Blend
+	}
+	options.GeoM = drawOptions.GeoM
+	options.ColorScale = drawOptions.ColorScale
+	options.Images[0] = srcImage
+	options.Images[1] = s.Shader.Texture1
+	options.Images[2] = s.Shader.Texture2
+	options.Images[3] = s.Shader.Texture3
+	options.Uniforms = s.Shader.shaderData
+	drawRectShader(dst, srcImageBounds.Dx(), srcImageBounds.Dy(), s.Shader.compiled, &options)
+}
+
+// calculateGeoM returns the frame image transformation matrix.
+func (s *Sprite) calculateGeoM(opts DrawOptions) ebiten.GeoM {
+	var m ebiten.GeoM
 
 	if s.IsHorizontallyFlipped() {
-		drawOptions.GeoM.Scale(-1, 1)
-		drawOptions.GeoM.Translate(float64(s.frameWidth), 0)
+		m.Scale(-1, 1)
+		m.Translate(float64(s.frameWidth), 0)
 	}
 	if s.IsVerticallyFlipped() {
-		drawOptions.GeoM.Scale(1, -1)
-		drawOptions.GeoM.Translate(0, float64(s.frameHeight))
+		m.Scale(1, -1)
+		m.Translate(0, float64(s.frameHeight))
 	}
 
 	origin := gmath.Vec{}
@@ -396,17 +491,22 @@ func (s *Sprite) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
 	}
 
 	// The rotation and scaling should be done around the origin point.
-	drawOptions.GeoM.Translate(-origin.X, -origin.Y)
+	m.Translate(-origin.X, -origin.Y)
 	if targetRotation != 0 {
-		drawOptions.GeoM.Rotate(float64(targetRotation))
+		m.Rotate(float64(targetRotation))
 	}
 	if s.scaleX != 1 || s.scaleY != 1 {
-		drawOptions.GeoM.Scale(s.scaleX, s.scaleY)
+		m.Scale(s.scaleX, s.scaleY)
 	}
 
 	pos := s.calculatePos().Add(opts.Offset)
-	drawOptions.GeoM.Translate(pos.X, pos.Y)
+	m.Translate(pos.X, pos.Y)
+
+	return m
+}
 
+// frameImage returns the current frame image (a sub-image or the entire image).
+func (s *Sprite) frameImage() *ebiten.Image {
 	// Making a sub-image can be more expensive than we would like it
 	// to be, therefore we cache the subimage result and update it
 	// only when subimage reslicing might be needed.
@@ -416,29 +516,10 @@ func (s *Sprite) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
 		s.updateSubImage()
 	}
 
-	srcImage := s.subImage
-	if srcImage == nil {
-		srcImage = s.image
-	}
-
-	if s.Shader == nil || !s.Shader.Enabled {
-		dst.DrawImage(srcImage, &drawOptions)
-		return
+	if s.subImage != nil {
+		return s.subImage
 	}
-
-	srcImageBounds := srcImage.Bounds()
-	var options ebiten.DrawRectShaderOptions
-	if opts.Blend != nil {
-		options.Blend = *opts.Blend
-	}
-	options.GeoM = drawOptions.GeoM
-	options.ColorScale = drawOptions.ColorScale
-	options.Images[0] = srcImage
-	options.Images[1] = s.Shader.Texture1
-	options.Images[2] = s.Shader.Texture2
-	options.Images[3] = s.Shader.Texture3
-	options.Uniforms = s.Shader.shaderData
-	dst.DrawRectShader(srcImageBounds.Dx(), srcImageBounds.Dy(), s.Shader.compiled, &options)
+	return s.image
 }
 
 func (s *Sprite) calculatePos() gmath.Vec {
@@ -464,7 +545,11 @@ func (s *Sprite) updateSubImage() {
 		return
 	}
 
-	subImageBounds := image.Rectangle{
+	s.subImage = subImage(s.image, s.frameRect())
+}
+
+func (s *Sprite) frameRect() image.Rectangle {
+	return image.Rectangle{
 		Min: image.Point{
 			X: int(s.frameOffsetX),
 			Y: int(s.frameOffsetY),
@@ -474,7 +559,6 @@ func (s *Sprite) updateSubImage() {
 			Y: int(s.frameOffsetY) + int(s.frameHeight),
 		},
 	}
-	s.subImage = s.image.SubImage(subImageBounds).(*ebiten.Image)
 }
 
 func (s *Sprite) getFlag(f spriteFlag) bool {
@@ -482,5 +566,43 @@ func (s *Sprite) getFlag(f spriteFlag) bool {
 }
 
 func (s *Sprite) setFlag(f spriteFlag, v bool) {
+	if cache.Global.StrictMode != 0 {
+		strictCheckMutation(s, s.IsDisposed())
+	}
 	setFlag(&s.flags, f, v)
 }
+
+func (s *Sprite) inspectTexture() *TextureInfo {
+	if s.image == nil {
+		return nil
+	}
+	bounds := s.image.Bounds()
+	return &TextureInfo{
+		Width:  bounds.Dx(),
+		Height: bounds.Dy(),
+		Frame:  s.frameRect(),
+	}
+}
+
+func (s *Sprite) replayTexture() (*ebiten.Image, ebiten.GeoM) {
+	return s.image, s.calculateGeoM(DrawOptions{})
+}
+
+// GetBlend returns the blend mode assigned by SetBlend.
+// The second result value is false if there is no blend override.
+func (s *Sprite) GetBlend() (ebiten.Blend, bool) {
+	return getBlend(s.blendID)
+}
+
+// SetBlend assigns a blend mode that is used to render this sprite.
+// It takes priority over the DrawOptions.Blend value.
+// Use ResetBlend to remove the override.
+func (s *Sprite) SetBlend(b ebiten.Blend) {
+	s.blendID = internBlend(b)
+}
+
+// ResetBlend removes the blend mode override.
+// The DrawOptions.Blend value (if any) will be used again.
+func (s *Sprite) ResetBlend() {
+	s.blendID = 0
+}
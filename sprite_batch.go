@@ -0,0 +1,171 @@
+package graphics
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// SpriteBatch renders many sprites using as few draw calls as possible.
+//
+// The consecutive sprites that share the same image are rendered
+// using a single DrawTriangles call. To benefit from the batching,
+// the sprites should use the same atlas image and select their
+// regions via the frame offsets (see [Sprite.SetFrameRect]).
+// The sprite draw order is preserved.
+//
+// Sprites with an enabled shader are rendered individually.
+// The sprite blend modes are respected.
+// Disposed sprites are removed from the batch automatically.
+//
+// SpriteBatch implements gscene Graphics interface.
+type SpriteBatch struct {
+	sprites []*Sprite
+
+	vertices []ebiten.Vertex
+	indices  []uint16
+
+	visible  bool
+	disposed bool
+}
+
+// NewSpriteBatch returns an empty sprite batch.
+func NewSpriteBatch() *SpriteBatch {
+	return &SpriteBatch{
+		sprites: make([]*Sprite, 0, 16),
+		visible: true,
+	}
+}
+
+// AddSprite adds the sprite to the batch.
+// The sprite should not be added to any layer or container.
+func (b *SpriteBatch) AddSprite(s *Sprite) {
+	b.sprites = append(b.sprites, s)
+}
+
+// Len reports the number of sprites in the batch.
+// The disposed sprites are counted until the next Draw call.
+func (b *SpriteBatch) Len() int { return len(b.sprites) }
+
+// Dispose marks this batch for deletion.
+// After calling this method, IsDisposed will report true.
+func (b *SpriteBatch) Dispose() { b.disposed = true }
+
+// IsDisposed reports whether this batch is marked for deletion.
+func (b *SpriteBatch) IsDisposed() bool { return b.disposed }
+
+// IsVisible reports whether this batch is visible.
+// Use SetVisibility to change this flag value.
+func (b *SpriteBatch) IsVisible() bool { return b.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (b *SpriteBatch) SetVisibility(visible bool) { b.visible = visible }
+
+// Draw renders the batch onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (b *SpriteBatch) Draw(dst *ebiten.Image) {
+	b.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the batch onto the provided dst image
+// while also using the extra provided offset.
+func (b *SpriteBatch) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !b.visible {
+		return
+	}
+
+	// The batch is also split when the sprites have different blend modes.
+	var src *ebiten.Image
+	blendID := uint8(0)
+	blendOpts := opts
+	liveSprites := b.sprites[:0]
+	for _, s := range b.sprites {
+		if s.IsDisposed() {
+			continue
+		}
+		liveSprites = append(liveSprites, s)
+
+		if !s.IsVisible() || s.image == nil || s.colorScale.A == 0 {
+			continue
+		}
+		if s.Shader != nil && s.Shader.Enabled {
+			b.flush(dst, src, blendOpts)
+			s.DrawWithOptions(dst, opts)
+			continue
+		}
+		if s.image != src || s.blendID != blendID || len(b.vertices)+4 > 0xffff {
+			b.flush(dst, src, blendOpts)
+			src = s.image
+			blendID = s.blendID
+			blendOpts = opts
+			blendOpts.Blend = resolveBlend(blendID, opts.Blend)
+		}
+		b.appendSprite(s, opts)
+	}
+	clear(b.sprites[len(liveSprites):])
+	b.sprites = liveSprites
+
+	b.flush(dst, src, blendOpts)
+}
+
+func (b *SpriteBatch) appendSprite(s *Sprite, opts DrawOptions) {
+	geom := s.calculateGeoM(opts)
+	frame := s.frameRect()
+
+	w := float64(s.frameWidth)
+	h := float64(s.frameHeight)
+	cs := s.ebitenColorScale
+	vertex := func(x, y float64, srcX, srcY int) ebiten.Vertex {
+		dstX, dstY := geom.Apply(x, y)
+		return ebiten.Vertex{
+			DstX:   float32(dstX),
+			DstY:   float32(dstY),
+			SrcX:   float32(srcX),
+			SrcY:   float32(srcY),
+			ColorR: cs.R(),
+			ColorG: cs.G(),
+			ColorB: cs.B(),
+			ColorA: cs.A(),
+		}
+	}
+
+	i := uint16(len(b.vertices))
+	b.vertices = append(b.vertices,
+		vertex(0, 0, frame.Min.X, frame.Min.Y),
+		vertex(w, 0, frame.Max.X, frame.Min.Y),
+		vertex(0, h, frame.Min.X, frame.Max.Y),
+		vertex(w, h, frame.Max.X, frame.Max.Y),
+	)
+	b.indices = append(b.indices,
+		i+0, i+1, i+2,
+		i+1, i+2, i+3,
+	)
+}
+
+func (b *SpriteBatch) flush(dst, src *ebiten.Image, opts DrawOptions) {
+	if len(b.indices) == 0 {
+		return
+	}
+
+	var drawOptions ebiten.DrawTrianglesOptions
+	if opts.Blend != nil {
+		drawOptions.Blend = *opts.Blend
+	}
+	// Sprite color scales are alpha-premultiplied.
+	drawOptions.ColorScaleMode = ebiten.ColorScaleModePremultipliedAlpha
+	drawTriangles(dst, b.vertices, b.indices, src, &drawOptions)
+
+	b.vertices = b.vertices[:0]
+	b.indices = b.indices[:0]
+}
+
+func (b *SpriteBatch) inspectChildren() []DisposableObject {
+	children := make([]DisposableObject, len(b.sprites))
+	for i, s := range b.sprites {
+		children[i] = s
+	}
+	return children
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"unsafe"
 
+	"github.com/hajimehoshi/ebiten/v2"
 	graphics "github.com/quasilyte/ebitengine-graphics"
 )
 
@@ -21,3 +22,32 @@ func TestSpriteSize(t *testing.T) {
 		t.Fatalf("sizeof(Sprite):\nhave: %d\nwant: %d", haveSize, wantSize)
 	}
 }
+
+func TestSpriteBlend(t *testing.T) {
+	s := graphics.NewSprite()
+	if _, ok := s.GetBlend(); ok {
+		t.Fatalf("a new sprite should have no blend override")
+	}
+
+	s.SetBlend(ebiten.BlendLighter)
+	b, ok := s.GetBlend()
+	if !ok || b != ebiten.BlendLighter {
+		t.Fatalf("GetBlend after SetBlend(BlendLighter):\nhave: %v %v", b, ok)
+	}
+
+	s2 := graphics.NewSprite()
+	s2.SetBlend(graphics.BlendMultiply)
+	s.SetBlend(graphics.BlendMultiply)
+	b, ok = s.GetBlend()
+	if !ok || b != graphics.BlendMultiply {
+		t.Fatalf("GetBlend after SetBlend(BlendMultiply):\nhave: %v %v", b, ok)
+	}
+
+	s.ResetBlend()
+	if _, ok := s.GetBlend(); ok {
+		t.Fatalf("ResetBlend should remove the blend override")
+	}
+	if b, _ := s2.GetBlend(); b != graphics.BlendMultiply {
+		t.Fatalf("ResetBlend affected another sprite")
+	}
+}
@@ -1,7 +1,11 @@
 package graphics
 
 import (
+	"slices"
+
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+	"github.com/quasilyte/gmath"
 )
 
 // StaticLayer is like [Layer], but objects are rendered in a camera-independent way.
@@ -12,7 +16,8 @@ import (
 //
 // This layer is well-suited for HUDs and overlays.
 type StaticLayer struct {
-	objects []gsceneGraphics
+	objects  []gsceneGraphics
+	registry objectRegistry
 }
 
 func NewStaticLayer() *StaticLayer {
@@ -20,6 +25,9 @@ func NewStaticLayer() *StaticLayer {
 }
 
 func (l *StaticLayer) AddChild(g gsceneGraphics) {
+	if cache.Global.StrictMode != 0 {
+		strictCheckAddChild(g)
+	}
 	l.objects = append(l.objects, g)
 }
 
@@ -30,6 +38,7 @@ func (l *StaticLayer) filter() {
 	liveObjects := l.objects[:0]
 	for _, o := range l.objects {
 		if o.IsDisposed() {
+			l.registry.Forget(o)
 			continue
 		}
 		liveObjects = append(liveObjects, o)
@@ -41,6 +50,87 @@ func (l *StaticLayer) DrawWithOptions(dst *ebiten.Image, _ DrawOptions) {
 	l.filter()
 
 	for _, o := range l.objects {
+		if cache.Global.DebugWireframe {
+			if o, ok := o.(Object); ok {
+				drawObject(dst, o, DrawOptions{})
+				continue
+			}
+		}
 		o.Draw(dst)
 	}
 }
+
+// ObjectsAt is like [Layer.ObjectsAt], but pos is in screen coordinates.
+//
+// The results have the same type as the [Layer.ObjectsAt] results,
+// so only the objects that implement [Object] participate in the hit-testing.
+func (l *StaticLayer) ObjectsAt(dst []Object, pos gmath.Vec) []Object {
+	for i := len(l.objects) - 1; i >= 0; i-- {
+		o, ok := l.objects[i].(Object)
+		if ok && objectContains(o, pos) {
+			dst = append(dst, o)
+		}
+	}
+	return dst
+}
+
+// TopObjectAt is like [Layer.TopObjectAt], but pos is in screen coordinates.
+// See [StaticLayer.ObjectsAt] to learn which objects participate in the hit-testing.
+func (l *StaticLayer) TopObjectAt(pos gmath.Vec) Object {
+	for i := len(l.objects) - 1; i >= 0; i-- {
+		o, ok := l.objects[i].(Object)
+		if ok && objectContains(o, pos) {
+			return o
+		}
+	}
+	return nil
+}
+
+// SetObjectName is like [Layer.SetObjectName].
+func (l *StaticLayer) SetObjectName(o gsceneGraphics, name string) {
+	if slices.Contains(l.objects, o) {
+		l.registry.SetName(o, name)
+	}
+}
+
+// GetObjectName is like [Layer.GetObjectName].
+func (l *StaticLayer) GetObjectName(o gsceneGraphics) string { return l.registry.GetName(o) }
+
+// AddObjectTag is like [Layer.AddObjectTag].
+func (l *StaticLayer) AddObjectTag(o gsceneGraphics, tag string) {
+	if slices.Contains(l.objects, o) {
+		l.registry.AddTag(o, tag)
+	}
+}
+
+// RemoveObjectTag is like [Layer.RemoveObjectTag].
+func (l *StaticLayer) RemoveObjectTag(o gsceneGraphics, tag string) { l.registry.RemoveTag(o, tag) }
+
+// GetObjectTags is like [Layer.GetObjectTags].
+func (l *StaticLayer) GetObjectTags(o gsceneGraphics) []string { return l.registry.GetTags(o) }
+
+// FindByName is like [Layer.FindByName].
+func (l *StaticLayer) FindByName(name string) gsceneGraphics {
+	if name == "" || l.registry.IsEmpty() {
+		return nil
+	}
+	for _, o := range l.objects {
+		if !o.IsDisposed() && l.registry.GetName(o) == name {
+			return o
+		}
+	}
+	return nil
+}
+
+// FindByTag is like [Layer.FindByTag].
+func (l *StaticLayer) FindByTag(dst []gsceneGraphics, tag string) []gsceneGraphics {
+	if l.registry.IsEmpty() {
+		return dst
+	}
+	for _, o := range l.objects {
+		if !o.IsDisposed() && l.registry.HasTag(o, tag) {
+			dst = append(dst, o)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,196 @@
+package graphics
+
+import (
+	"bytes"
+	"log"
+	"math"
+	"reflect"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+)
+
+// StrictMode describes how the misuse diagnostics are reported.
+// See [SetStrictMode].
+type StrictMode uint8
+
+const (
+	// StrictModeOff disables the diagnostics (the default).
+	StrictModeOff StrictMode = iota
+
+	// StrictModeLog reports every problem to the error handler (see [SetErrorHandler]).
+	// If there is no error handler, the standard logger is used.
+	StrictModeLog
+
+	// StrictModePanic panics with a [MisuseError] value.
+	StrictModePanic
+)
+
+// MisuseError describes an incorrect object usage detected in the strict mode.
+type MisuseError struct {
+	// Object is a type name of the misused object, like "graphics.Sprite".
+	Object string
+
+	Message string
+}
+
+func (e *MisuseError) Error() string {
+	return e.Object + ": " + e.Message
+}
+
+// SetStrictMode enables or disables the misuse diagnostics.
+//
+// The objects like [Sprite] and [Label] are kept as small as possible:
+// their sizes are stored as uint16 values and their states are bit-packed.
+// Using them incorrectly doesn't fail loudly, it just leads to
+// the weird rendering results. The strict mode detects:
+//
+//   - objects that are drawn, mutated or added to a layer after Dispose
+//   - objects that are drawn without being added to a layer
+//     (or any other object of this package that manages the children)
+//   - frame and text sizes that don't fit into uint16
+//   - objects that are mutated from a goroutine other than the game goroutine
+//     (the goroutine that draws the scene)
+//
+// Every problem is reported only once per object.
+// The built-in layers and scene drawer are aware of the strict mode, so
+// objects that are drawn by them are never reported as drawn outside of a layer.
+//
+// The checks are relatively expensive (especially the goroutine check),
+// so this mode is intended to be used during the development only.
+func SetStrictMode(mode StrictMode) {
+	cache.Global.StrictMode = uint8(mode)
+	strict.drawDepth.Store(0)
+	strict.gameGoroutine.Store(0)
+	strict.reportedMu.Lock()
+	strict.reported = nil
+	strict.reportedMu.Unlock()
+}
+
+// GetStrictMode returns the current strict mode.
+// Use SetStrictMode to change it.
+func GetStrictMode() StrictMode {
+	return StrictMode(cache.Global.StrictMode)
+}
+
+var strict strictState
+
+// strictState fields are atomic: the mutation checks
+// can be executed from any goroutine (detecting that is
+// one of the strict mode purposes).
+type strictState struct {
+	// drawDepth is greater than zero while the layers (or scene) are drawn.
+	drawDepth atomic.Int32
+
+	// gameGoroutine is recorded during the first strict scene draw.
+	// A zero value means "not known yet".
+	gameGoroutine atomic.Uint64
+
+	// reportedMu protects the reported map, since the problems
+	// can be reported from any goroutine.
+	reportedMu sync.Mutex
+	reported   map[strictReportKey]struct{}
+}
+
+type strictReportKey struct {
+	// o is an object address: the reported objects
+	// should not be kept alive by the reported map.
+	o   uintptr
+	msg string
+}
+
+func strictBeginDraw() {
+	strict.drawDepth.Add(1)
+	if strict.gameGoroutine.Load() == 0 {
+		strict.gameGoroutine.CompareAndSwap(0, currentGoroutineID())
+	}
+}
+
+func strictEndDraw() {
+	strict.drawDepth.Add(-1)
+}
+
+// strictCheckDraw should be called by the strict-aware objects
+// at the beginning of their DrawWithOptions.
+func strictCheckDraw(o any, disposed bool) {
+	if disposed {
+		strictReport(o, "drawn after Dispose")
+	}
+	if strict.drawDepth.Load() == 0 {
+		strictReport(o, "drawn without being added to a layer")
+	}
+}
+
+// strictCheckMutation should be called by the strict-aware objects setters.
+func strictCheckMutation(o any, disposed bool) {
+	if disposed {
+		strictReport(o, "mutated after Dispose")
+	}
+	if g := strict.gameGoroutine.Load(); g != 0 && currentGoroutineID() != g {
+		strictReport(o, "mutated from a non-game goroutine")
+	}
+}
+
+// strictCheckSize reports the size values that are truncated by a uint16 field.
+func strictCheckSize(o any, what string, v int) {
+	if v < 0 || v > math.MaxUint16 {
+		strictReport(o, what+" "+strconv.Itoa(v)+" doesn't fit into uint16")
+	}
+}
+
+func strictCheckAddChild(o gsceneGraphics) {
+	if o.IsDisposed() {
+		strictReport(o, "added to a layer after Dispose")
+	}
+}
+
+func strictReport(o any, msg string) {
+	key := strictReportKey{o: strictObjectAddr(o), msg: msg}
+	strict.reportedMu.Lock()
+	_, seen := strict.reported[key]
+	if !seen {
+		if strict.reported == nil {
+			strict.reported = make(map[strictReportKey]struct{}, 4)
+		}
+		strict.reported[key] = struct{}{}
+	}
+	strict.reportedMu.Unlock()
+	if seen {
+		return
+	}
+
+	err := &MisuseError{Object: inspectTypeName(o), Message: msg}
+	if StrictMode(cache.Global.StrictMode) == StrictModePanic {
+		panic(err)
+	}
+	if h := getErrorHandler(); h != nil {
+		h(err)
+		return
+	}
+	log.Print(err)
+}
+
+// strictObjectAddr returns the object pointer value.
+// All graphical objects are pointers, so this address identifies the object.
+func strictObjectAddr(o any) uintptr {
+	if v := reflect.ValueOf(o); v.Kind() == reflect.Pointer {
+		return v.Pointer()
+	}
+	return 0
+}
+
+// currentGoroutineID parses the goroutine ID from the stack trace header.
+// The format is "goroutine 1 [running]:".
+func currentGoroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i != -1 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
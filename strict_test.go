@@ -0,0 +1,121 @@
+package graphics
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestStrictModeLog(t *testing.T) {
+	var reported []string
+	SetErrorHandler(func(err error) {
+		var misuse *MisuseError
+		if !errors.As(err, &misuse) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		reported = append(reported, err.Error())
+	})
+	SetStrictMode(StrictModeLog)
+	defer func() {
+		SetStrictMode(StrictModeOff)
+		SetErrorHandler(nil)
+	}()
+
+	s := NewSprite()
+	s.SetFrameWidth(70000)
+	s.Dispose()
+	s.SetAlpha(0.5)
+	s.SetAlpha(0.2) // Reported only once
+	layer := NewLayer()
+	layer.AddChild(s)
+
+	want := []string{
+		"graphics.Sprite: frame width 70000 doesn't fit into uint16",
+		"graphics.Sprite: mutated after Dispose",
+		"graphics.Sprite: added to a layer after Dispose",
+	}
+	if len(reported) != len(want) {
+		t.Fatalf("reported:\nhave: %q\nwant: %q", reported, want)
+	}
+	for i := range want {
+		if reported[i] != want[i] {
+			t.Fatalf("reported[%d]:\nhave: %q\nwant: %q", i, reported[i], want[i])
+		}
+	}
+}
+
+func TestStrictModeGoroutine(t *testing.T) {
+	var reported []string
+	SetErrorHandler(func(err error) {
+		reported = append(reported, err.Error())
+	})
+	SetStrictMode(StrictModeLog)
+	defer func() {
+		SetStrictMode(StrictModeOff)
+		SetErrorHandler(nil)
+	}()
+
+	// The first strict draw records the game goroutine.
+	strictBeginDraw()
+	strictEndDraw()
+
+	s := NewSprite()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.SetAlpha(0.5)
+		s.SetAlpha(0.2) // Reported only once
+	}()
+	wg.Wait()
+	s.SetAlpha(1)
+
+	want := "graphics.Sprite: mutated from a non-game goroutine"
+	if len(reported) != 1 || reported[0] != want {
+		t.Fatalf("reported:\nhave: %q\nwant: [%q]", reported, want)
+	}
+}
+
+func TestStrictModePanic(t *testing.T) {
+	SetStrictMode(StrictModePanic)
+	defer SetStrictMode(StrictModeOff)
+
+	defer func() {
+		r := recover()
+		if _, ok := r.(*MisuseError); !ok {
+			t.Fatalf("expected a MisuseError panic, got %v", r)
+		}
+	}()
+
+	l := NewLabel(nil)
+	l.SetSize(-1, 10)
+}
+
+func TestStrictModeOff(t *testing.T) {
+	SetErrorHandler(func(err error) {
+		t.Fatalf("unexpected error: %v", err)
+	})
+	defer SetErrorHandler(nil)
+
+	s := NewSprite()
+	s.Dispose()
+	s.SetFrameWidth(70000)
+	s.SetAlpha(0.5)
+}
+
+func TestCurrentGoroutineID(t *testing.T) {
+	id := currentGoroutineID()
+	if id == 0 {
+		t.Fatal("can't parse the goroutine ID")
+	}
+	if id != currentGoroutineID() {
+		t.Fatal("the goroutine ID is not stable")
+	}
+	ch := make(chan uint64)
+	go func() {
+		ch <- currentGoroutineID()
+	}()
+	if other := <-ch; other == id || other == 0 {
+		t.Fatalf("unexpected other goroutine ID: %d", other)
+	}
+}
@@ -0,0 +1,156 @@
+package graphics
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+	"github.com/quasilyte/gmath"
+)
+
+// TeamColorSprite renders a sprite with its masked regions recolored by a team color.
+//
+// The mask is a grayscale image that has the same layout as the sprite image,
+// so it's always aligned with the current sprite frame.
+// White mask pixels are fully recolored, black (or transparent) pixels
+// keep their original colors; the values in between are blended.
+// The recoloring multiplies the original pixel color by the team color,
+// so the masked regions should be drawn in grayscale (classic RTS unit coloring).
+//
+// All team color sprites share the same bundled shader,
+// call [CompileShaders] before creating them.
+//
+// The wrapped sprite should not be added to any layer,
+// the TeamColorSprite object should be added instead.
+// The sprite transformation and color scale are respected,
+// but the sprite shader is ignored.
+//
+// TeamColorSprite implements gscene Graphics interface.
+type TeamColorSprite struct {
+	sprite *Sprite
+
+	mask *ebiten.Image
+
+	// The sub-image is re-created only when the sprite frame changes.
+	maskSubImage  *ebiten.Image
+	maskFrameRect image.Rectangle
+
+	teamColor ColorScale
+	uniforms  map[string]any
+
+	visible  bool
+	disposed bool
+}
+
+// NewTeamColorSprite wraps a sprite into a team color renderer.
+// The initial team color is {1, 1, 1, 1}, which keeps the sprite colors intact.
+//
+// This function panics if the shaders are not compiled.
+func NewTeamColorSprite(s *Sprite, mask *ebiten.Image) *TeamColorSprite {
+	requireShaders()
+
+	ts := &TeamColorSprite{
+		sprite:   s,
+		mask:     mask,
+		uniforms: make(map[string]any, 1),
+		visible:  true,
+	}
+	ts.SetTeamColorScale(defaultColorScale)
+	return ts
+}
+
+// GetSprite returns the wrapped sprite.
+func (ts *TeamColorSprite) GetSprite() *Sprite { return ts.sprite }
+
+// GetMask returns the current mask image.
+// Use SetMask to change it.
+func (ts *TeamColorSprite) GetMask() *ebiten.Image { return ts.mask }
+
+// SetMask changes the mask image.
+func (ts *TeamColorSprite) SetMask(mask *ebiten.Image) {
+	ts.mask = mask
+	ts.maskSubImage = nil
+}
+
+// GetTeamColorScale returns the current team color.
+// Use SetTeamColorScale to change it.
+func (ts *TeamColorSprite) GetTeamColorScale() ColorScale { return ts.teamColor }
+
+// SetTeamColorScale changes the color used to recolor the masked regions.
+//
+// The alpha component controls the recoloring strength:
+// a zero alpha disables the recoloring.
+func (ts *TeamColorSprite) SetTeamColorScale(cs ColorScale) {
+	if ts.teamColor == cs {
+		return
+	}
+	ts.teamColor = cs
+	ts.uniforms["TeamColor"] = []float32{cs.R, cs.G, cs.B, cs.A}
+}
+
+// BoundsRect returns the wrapped sprite bounds.
+func (ts *TeamColorSprite) BoundsRect() gmath.Rect { return ts.sprite.BoundsRect() }
+
+// Dispose marks this object for deletion.
+// After calling this method, IsDisposed will report true.
+//
+// The wrapped sprite is not disposed.
+func (ts *TeamColorSprite) Dispose() { ts.disposed = true }
+
+// IsDisposed reports whether this object is marked for deletion.
+//
+// The object is also considered to be disposed if its sprite is disposed.
+func (ts *TeamColorSprite) IsDisposed() bool { return ts.disposed || ts.sprite.IsDisposed() }
+
+// IsVisible reports whether this object is visible.
+// Use SetVisibility to change this flag value.
+func (ts *TeamColorSprite) IsVisible() bool { return ts.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (ts *TeamColorSprite) SetVisibility(visible bool) { ts.visible = visible }
+
+// Draw renders the recolored sprite onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (ts *TeamColorSprite) Draw(dst *ebiten.Image) {
+	ts.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the recolored sprite onto the provided dst image
+// while also using the extra provided offset.
+//
+// A nil mask makes it render the sprite as is.
+func (ts *TeamColorSprite) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	s := ts.sprite
+	if !ts.visible || !s.IsVisible() || s.image == nil || s.colorScale.A == 0 {
+		return
+	}
+	if ts.mask == nil {
+		s.DrawWithOptions(dst, opts)
+		return
+	}
+
+	opts.Blend = resolveBlend(s.blendID, opts.Blend)
+
+	srcImage := s.frameImage()
+	frameRect := s.frameRect()
+	if ts.maskSubImage == nil || ts.maskFrameRect != frameRect {
+		ts.maskSubImage = subImage(ts.mask, frameRect)
+		ts.maskFrameRect = frameRect
+	}
+
+	var options ebiten.DrawRectShaderOptions
+	if opts.Blend != nil {
+		options.Blend = *opts.Blend
+	}
+	options.GeoM = s.calculateGeoM(opts)
+	options.ColorScale = s.ebitenColorScale
+	options.Images[0] = srcImage
+	options.Images[1] = ts.maskSubImage
+	options.Uniforms = ts.uniforms
+	drawRectShader(dst, int(s.frameWidth), int(s.frameHeight), cache.Global.TeamColorShader, &options)
+}
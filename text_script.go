@@ -0,0 +1,117 @@
+package graphics
+
+import (
+	"strings"
+
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+)
+
+// TextScript is a rich text segment vertical position kind.
+// See [TextSegment].
+type TextScript uint8
+
+const (
+	TextScriptNormal TextScript = iota
+
+	// TextScriptSuper renders a smaller text above the baseline (like x²).
+	TextScriptSuper
+
+	// TextScriptSub renders a smaller text below the baseline (like H₂O).
+	TextScriptSub
+)
+
+const (
+	// scriptScale is a super/subscript text scaling factor.
+	scriptScale = 0.6
+
+	// The baseline shifts are measured in the font ascent units.
+	superscriptShift = 0.4
+	subscriptShift   = 0.2
+)
+
+func (script TextScript) scale() float64 {
+	if script == TextScriptNormal {
+		return 1
+	}
+	return scriptScale
+}
+
+// baselineShift returns the segment baseline offset relative to the line baseline.
+// A negative value means "above the baseline".
+func (script TextScript) baselineShift(font *cache.FontInfo) float64 {
+	switch script {
+	case TextScriptSuper:
+		return -superscriptShift * font.Ascent
+	case TextScriptSub:
+		return subscriptShift * font.Ascent
+	default:
+		return 0
+	}
+}
+
+// metrics returns the font metrics adjusted for the script kind.
+// The ascent and descent are measured from the line baseline.
+func (script TextScript) metrics(font *cache.FontInfo) (ascent, descent, lineHeight float64) {
+	if script == TextScriptNormal {
+		return font.Ascent, font.Descent, font.LineHeight
+	}
+	shift := script.baselineShift(font)
+	ascent = max(0, scriptScale*font.Ascent-shift)
+	descent = max(0, scriptScale*font.Descent+shift)
+	lineGap := font.LineHeight - font.Ascent - font.Descent
+	return ascent, descent, ascent + descent + lineGap
+}
+
+// ParseScriptMarkup splits s into the rich text segments
+// using the ^{...} and _{...} markup for superscripts and subscripts.
+//
+// For example, "E=mc^{2}" produces three segments:
+// "E=mc" (normal), "2" (superscript).
+// An unterminated group is treated as a normal text.
+// The segments have zero color scale and a nil face,
+// so they inherit these from the label.
+//
+// The result can be passed to [Label.SetTextSegments].
+func ParseScriptMarkup(s string) []TextSegment {
+	var segments []TextSegment
+	for {
+		i := strings.IndexAny(s, "^_")
+		if i == -1 || i+1 >= len(s) || s[i+1] != '{' {
+			if i != -1 && i+1 < len(s) {
+				// Not a markup group: keep the char and continue.
+				segments = appendNormalSegment(segments, s[:i+1])
+				s = s[i+1:]
+				continue
+			}
+			break
+		}
+		end := strings.IndexByte(s[i:], '}')
+		if end == -1 {
+			break
+		}
+		segments = appendNormalSegment(segments, s[:i])
+		script := TextScriptSuper
+		if s[i] == '_' {
+			script = TextScriptSub
+		}
+		if group := s[i+len("^{") : i+end]; group != "" {
+			segments = append(segments, TextSegment{Text: group, Script: script})
+		}
+		s = s[i+end+len("}"):]
+	}
+	return appendNormalSegment(segments, s)
+}
+
+func appendNormalSegment(segments []TextSegment, s string) []TextSegment {
+	if s == "" {
+		return segments
+	}
+	if len(segments) != 0 {
+		last := &segments[len(segments)-1]
+		if last.Script == TextScriptNormal {
+			last.Text += s
+			return segments
+		}
+	}
+	return append(segments, TextSegment{Text: s})
+}
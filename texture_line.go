@@ -9,6 +9,28 @@ import (
 	"github.com/quasilyte/gmath"
 )
 
+// TextureLineMode selects how the [TextureLine] texture is mapped onto the line.
+type TextureLineMode uint8
+
+const (
+	// TextureLineRepeat tiles the texture along the line.
+	// The last tile is cropped to fit the line length.
+	//
+	// This is a default mode that works well for chains and ropes.
+	TextureLineRepeat TextureLineMode = iota
+
+	// TextureLineStretch stretches a single texture copy
+	// between the line endpoints.
+	//
+	// This mode works well for beams and tethers.
+	TextureLineStretch
+)
+
+// TextureLine draws a texture between the two points with a proper rotation.
+// The texture can be tiled or stretched, see [TextureLineMode].
+//
+// Changing the line endpoints is cheap: no images are
+// allocated, only the vertices are re-calculated during the rendering.
 type TextureLine struct {
 	BeginPos gmath.Pos
 	EndPos   gmath.Pos
@@ -26,6 +48,8 @@ type TextureLine struct {
 
 	visible  bool
 	disposed bool
+	blendID  uint8
+	mode     TextureLineMode
 }
 
 func NewTextureLine(begin, end gmath.Pos) *TextureLine {
@@ -89,10 +113,18 @@ func (l *TextureLine) IsVisible() bool { return l.visible }
 // Use IsVisible to get the current flag value.
 func (l *TextureLine) SetVisibility(visible bool) { l.visible = visible }
 
-// SetTexture assigns the repeatable line texture.
+// GetMode reports the current texture mapping mode.
+// Use SetMode to change it.
+func (l *TextureLine) GetMode() TextureLineMode { return l.mode }
+
+// SetMode changes the texture mapping mode.
+// The default mode is TextureLineRepeat.
+func (l *TextureLine) SetMode(mode TextureLineMode) { l.mode = mode }
+
+// SetTexture assigns the line texture.
 //
-// This texture should loop well if the line's length can be higher
-// than the texture's width.
+// In the TextureLineRepeat mode, this texture should loop well
+// if the line's length can be higher than the texture's width.
 func (l *TextureLine) SetTexture(texture *ebiten.Image) {
 	l.texture = texture
 
@@ -123,6 +155,8 @@ func (l *TextureLine) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
 		return
 	}
 
+	opts.Blend = resolveBlend(l.blendID, opts.Blend)
+
 	beginVec := l.BeginPos.Resolve().Add(opts.Offset).AsVec32()
 	endVec := l.EndPos.Resolve().Add(opts.Offset).AsVec32()
 
@@ -142,6 +176,9 @@ func (l *TextureLine) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
 
 	step := endVec.DirectionTo(beginVec).Mulf(textureWidth)
 	numSteps := int(beginVec.DistanceTo(endVec)/textureWidth) + 1
+	if l.mode == TextureLineStretch {
+		numSteps = 1
+	}
 	lastStep := numSteps - 1
 
 	angle := float64(step.Angle())
@@ -167,12 +204,17 @@ func (l *TextureLine) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
 		if i == lastStep {
 			w = currentPos.DistanceTo(endVec)
 		}
+		// srcW is w unless the texture is stretched.
+		srcW := w
+		if l.mode == TextureLineStretch {
+			srcW = textureWidth
+		}
 
 		vertices = append(vertices,
 			ebiten.Vertex{DstX: x, DstY: y, SrcX: 0, SrcY: 0, ColorR: clr.R, ColorG: clr.G, ColorB: clr.B, ColorA: clr.A},
-			ebiten.Vertex{DstX: (geom.A1+1)*w + x, DstY: geom.C*w + y, SrcX: w, SrcY: 0, ColorR: clr.R, ColorG: clr.G, ColorB: clr.B, ColorA: clr.A},
+			ebiten.Vertex{DstX: (geom.A1+1)*w + x, DstY: geom.C*w + y, SrcX: srcW, SrcY: 0, ColorR: clr.R, ColorG: clr.G, ColorB: clr.B, ColorA: clr.A},
 			ebiten.Vertex{DstX: geom.B*h + x, DstY: (geom.D1+1)*h + y, SrcX: 0, SrcY: h, ColorR: clr.R, ColorG: clr.G, ColorB: clr.B, ColorA: clr.A},
-			ebiten.Vertex{DstX: geom.ApplyX(w, h), DstY: geom.ApplyY(w, h), SrcX: w, SrcY: h, ColorR: clr.R, ColorG: clr.G, ColorB: clr.B, ColorA: clr.A},
+			ebiten.Vertex{DstX: geom.ApplyX(w, h), DstY: geom.ApplyY(w, h), SrcX: srcW, SrcY: h, ColorR: clr.R, ColorG: clr.G, ColorB: clr.B, ColorA: clr.A},
 		)
 		indices = append(indices,
 			idx+0, idx+1, idx+2,
@@ -188,7 +230,7 @@ func (l *TextureLine) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
 		if opts.Blend != nil {
 			drawOptions.Blend = *opts.Blend
 		}
-		dst.DrawTriangles(vertices, indices, l.texture, &drawOptions)
+		drawTriangles(dst, vertices, indices, l.texture, &drawOptions)
 		return
 	}
 
@@ -201,5 +243,36 @@ func (l *TextureLine) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
 	drawOptions.Images[2] = l.Shader.Texture2
 	drawOptions.Images[3] = l.Shader.Texture3
 	drawOptions.Uniforms = l.Shader.shaderData
-	dst.DrawTrianglesShader(vertices, indices, l.Shader.compiled, &drawOptions)
+	drawTrianglesShader(dst, vertices, indices, l.Shader.compiled, &drawOptions)
+}
+
+func (l *TextureLine) inspectTexture() *TextureInfo {
+	if l.texture == nil {
+		return nil
+	}
+	bounds := l.texture.Bounds()
+	return &TextureInfo{
+		Width:  bounds.Dx(),
+		Height: bounds.Dy(),
+		Frame:  bounds,
+	}
+}
+
+// GetBlend returns the blend mode assigned by SetBlend.
+// The second result value is false if there is no blend override.
+func (l *TextureLine) GetBlend() (ebiten.Blend, bool) {
+	return getBlend(l.blendID)
+}
+
+// SetBlend assigns a blend mode that is used to render this line.
+// It takes priority over the DrawOptions.Blend value.
+// Use ResetBlend to remove the override.
+func (l *TextureLine) SetBlend(b ebiten.Blend) {
+	l.blendID = internBlend(b)
+}
+
+// ResetBlend removes the blend mode override.
+// The DrawOptions.Blend value (if any) will be used again.
+func (l *TextureLine) ResetBlend() {
+	l.blendID = 0
 }
@@ -0,0 +1,369 @@
+package graphics
+
+import (
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+	"github.com/quasilyte/gmath"
+)
+
+// TileEmpty is a [TileMap] tile ID that is not rendered.
+const TileEmpty = -1
+
+// TileMapConfig describes the [TileMap] tileset and chunking.
+type TileMapConfig struct {
+	// Tileset is an image that contains all tiles.
+	// The tiles are enumerated from left to right, top to bottom,
+	// starting from 0.
+	// Sub-images (like an atlas frame) are supported.
+	// This field is required.
+	Tileset *ebiten.Image
+
+	// TileWidth and TileHeight are a single tile sizes.
+	// These fields are required.
+	TileWidth  int
+	TileHeight int
+
+	// ChunkSize is a chunk side length, in tiles.
+	// A zero value means 16; the maximum value is 64.
+	ChunkSize int
+
+	// Camera is an optional camera used for culling:
+	// only the chunks that are visible by the camera are rendered (and baked).
+	Camera *Camera
+}
+
+// TileMap renders a grid of tiles from a tileset image.
+//
+// The map is split into square chunks; every chunk is baked into
+// an offscreen image when it's rendered for the first time,
+// so a static map is rendered with a single image draw call per chunk.
+// Changing a tile only marks its chunk for re-baking;
+// the map dependents (like a [CacheGroup] it belongs to) are notified too.
+// The chunks without any tiles don't allocate images.
+//
+// The tile positions are in world coordinates,
+// so TileMap should be added to a [Layer].
+// The Pos is a top-left corner of the map.
+//
+// TileMap implements gscene Graphics interface.
+type TileMap struct {
+	Pos gmath.Pos
+
+	config TileMapConfig
+
+	tiles []int32
+
+	numCols int
+	numRows int
+
+	tilesetCols int
+	tilesetRows int
+
+	chunks        []tileMapChunk
+	numChunkCols  int
+	numChunkRows  int
+	chunkWidthPx  int
+	chunkHeightPx int
+
+	changed DirtySignal
+
+	visible  bool
+	disposed bool
+}
+
+type tileMapChunk struct {
+	img *ebiten.Image
+
+	// numTiles is a number of non-empty tiles inside this chunk.
+	numTiles int
+
+	dirty bool
+}
+
+// NewTileMap creates a map of the specified size (in tiles).
+// All tiles are initialized to [TileEmpty].
+func NewTileMap(config TileMapConfig, numCols, numRows int) *TileMap {
+	if config.Tileset == nil {
+		panic("TileMapConfig.Tileset can't be nil")
+	}
+	if config.TileWidth <= 0 || config.TileHeight <= 0 {
+		panic("TileMapConfig tile sizes should be positive")
+	}
+	if config.ChunkSize == 0 {
+		config.ChunkSize = 16
+	}
+	if config.ChunkSize < 0 || config.ChunkSize > 64 {
+		panic("TileMapConfig.ChunkSize should be in [1, 64] range")
+	}
+
+	bounds := config.Tileset.Bounds()
+	m := &TileMap{
+		config:        config,
+		tiles:         make([]int32, numCols*numRows),
+		numCols:       numCols,
+		numRows:       numRows,
+		tilesetCols:   bounds.Dx() / config.TileWidth,
+		tilesetRows:   bounds.Dy() / config.TileHeight,
+		numChunkCols:  (numCols + config.ChunkSize - 1) / config.ChunkSize,
+		numChunkRows:  (numRows + config.ChunkSize - 1) / config.ChunkSize,
+		chunkWidthPx:  config.ChunkSize * config.TileWidth,
+		chunkHeightPx: config.ChunkSize * config.TileHeight,
+		visible:       true,
+	}
+	for i := range m.tiles {
+		m.tiles[i] = TileEmpty
+	}
+	m.chunks = make([]tileMapChunk, m.numChunkCols*m.numChunkRows)
+	return m
+}
+
+// GetSize reports the map size, in tiles.
+func (m *TileMap) GetSize() (numCols, numRows int) {
+	return m.numCols, m.numRows
+}
+
+// GetTile returns the tile ID at the specified position.
+// It returns [TileEmpty] for the out of bounds positions.
+func (m *TileMap) GetTile(col, row int) int {
+	if !m.inBounds(col, row) {
+		return TileEmpty
+	}
+	return int(m.tiles[row*m.numCols+col])
+}
+
+// SetTile changes the tile ID at the specified position.
+// Any negative ID is treated as [TileEmpty].
+// The out of bounds positions are ignored.
+//
+// Only the affected chunk is re-baked during the next Draw call.
+func (m *TileMap) SetTile(col, row, id int) {
+	if !m.inBounds(col, row) {
+		return
+	}
+	if m.setTile(col, row, id) {
+		m.changed.Emit()
+	}
+}
+
+// setTile updates the tile and its chunk counters without notifying the dependents.
+// It reports whether the tile was changed.
+func (m *TileMap) setTile(col, row, id int) bool {
+	id = max(id, TileEmpty)
+	i := row*m.numCols + col
+	prev := m.tiles[i]
+	if int(prev) == id {
+		return false
+	}
+	m.tiles[i] = int32(id)
+
+	chunk := &m.chunks[m.chunkIndex(col, row)]
+	chunk.dirty = true
+	switch {
+	case prev == TileEmpty:
+		chunk.numTiles++
+	case id == TileEmpty:
+		chunk.numTiles--
+	}
+	return true
+}
+
+// DirtySignal returns a signal that is emitted when any tile is changed.
+// It implements the [DirtySource] interface.
+func (m *TileMap) DirtySignal() *DirtySignal {
+	return &m.changed
+}
+
+// SetTiles assigns all tiles of the map at once.
+// The ids slice is a row-major grid of the map size.
+//
+// The dirty signal is emitted once, after all tiles are assigned
+// (and only if any of them was changed).
+func (m *TileMap) SetTiles(ids []int) {
+	if len(ids) != len(m.tiles) {
+		panic("the tiles slice size doesn't match the map size")
+	}
+	changed := false
+	for i, id := range ids {
+		if m.setTile(i%m.numCols, i/m.numCols, id) {
+			changed = true
+		}
+	}
+	if changed {
+		m.changed.Emit()
+	}
+}
+
+func (m *TileMap) inBounds(col, row int) bool {
+	return col >= 0 && row >= 0 && col < m.numCols && row < m.numRows
+}
+
+func (m *TileMap) chunkIndex(col, row int) int {
+	return (row/m.config.ChunkSize)*m.numChunkCols + col/m.config.ChunkSize
+}
+
+// BoundsRect returns the map bounding rectangle.
+func (m *TileMap) BoundsRect() gmath.Rect {
+	pos := m.Pos.Resolve()
+	return gmath.Rect{
+		Min: pos,
+		Max: pos.Add(gmath.Vec{
+			X: float64(m.numCols * m.config.TileWidth),
+			Y: float64(m.numRows * m.config.TileHeight),
+		}),
+	}
+}
+
+// IsDisposed reports whether this map is marked for deletion.
+func (m *TileMap) IsDisposed() bool { return m.disposed }
+
+// Dispose marks this map for deletion and releases the chunk images.
+func (m *TileMap) Dispose() {
+	for i := range m.chunks {
+		c := &m.chunks[i]
+		if c.img != nil {
+			c.img.Deallocate()
+			c.img = nil
+		}
+	}
+	m.disposed = true
+}
+
+// IsVisible reports whether this map is visible.
+// Use SetVisibility to change this flag value.
+func (m *TileMap) IsVisible() bool { return m.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (m *TileMap) SetVisibility(visible bool) { m.visible = visible }
+
+// Draw renders the map onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (m *TileMap) Draw(dst *ebiten.Image) {
+	m.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the map onto the provided dst image
+// while also using the extra provided offset.
+func (m *TileMap) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !m.visible || m.disposed {
+		return
+	}
+
+	pos := m.Pos.Resolve()
+
+	// The range of the chunks to render.
+	fromCol, fromRow := 0, 0
+	toCol, toRow := m.numChunkCols, m.numChunkRows
+	if m.config.Camera != nil {
+		view := m.config.Camera.GetWorldRect()
+		fromCol = max(0, int(math.Floor((view.Min.X-pos.X)/float64(m.chunkWidthPx))))
+		fromRow = max(0, int(math.Floor((view.Min.Y-pos.Y)/float64(m.chunkHeightPx))))
+		toCol = min(m.numChunkCols, int(math.Floor((view.Max.X-pos.X)/float64(m.chunkWidthPx)))+1)
+		toRow = min(m.numChunkRows, int(math.Floor((view.Max.Y-pos.Y)/float64(m.chunkHeightPx)))+1)
+	}
+
+	pos = pos.Add(opts.Offset)
+	var drawOptions ebiten.DrawImageOptions
+	if opts.Blend != nil {
+		drawOptions.Blend = *opts.Blend
+	}
+	for row := fromRow; row < toRow; row++ {
+		for col := fromCol; col < toCol; col++ {
+			c := &m.chunks[row*m.numChunkCols+col]
+			if c.dirty {
+				m.bakeChunk(c, col, row)
+			}
+			if c.numTiles == 0 {
+				continue
+			}
+			drawOptions.GeoM.Reset()
+			drawOptions.GeoM.Translate(
+				pos.X+float64(col*m.chunkWidthPx),
+				pos.Y+float64(row*m.chunkHeightPx),
+			)
+			drawImage(dst, c.img, &drawOptions)
+		}
+	}
+}
+
+func (m *TileMap) bakeChunk(c *tileMapChunk, chunkCol, chunkRow int) {
+	c.dirty = false
+	if c.numTiles == 0 {
+		if c.img != nil {
+			c.img.Deallocate()
+			c.img = nil
+		}
+		return
+	}
+	if c.img == nil {
+		c.img = ebiten.NewImage(m.chunkWidthPx, m.chunkHeightPx)
+	} else {
+		clearImage(c.img)
+	}
+
+	vertices := cache.Global.ScratchVertices[:0]
+	indices := cache.Global.ScratchIndices[:0]
+	defer func() {
+		cache.Global.ScratchVertices = vertices[:0]
+		cache.Global.ScratchIndices = indices[:0]
+	}()
+
+	chunkSize := m.config.ChunkSize
+	tw := float32(m.config.TileWidth)
+	th := float32(m.config.TileHeight)
+	for y := 0; y < chunkSize; y++ {
+		row := chunkRow*chunkSize + y
+		if row >= m.numRows {
+			break
+		}
+		for x := 0; x < chunkSize; x++ {
+			col := chunkCol*chunkSize + x
+			if col >= m.numCols {
+				break
+			}
+			id := int(m.tiles[row*m.numCols+col])
+			src, ok := m.tileSrcRect(id)
+			if !ok {
+				continue
+			}
+			dstX := float32(x) * tw
+			dstY := float32(y) * th
+			srcX := float32(src.Min.X)
+			srcY := float32(src.Min.Y)
+			idx := uint16(len(vertices))
+			vertices = append(vertices,
+				ebiten.Vertex{DstX: dstX, DstY: dstY, SrcX: srcX, SrcY: srcY, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+				ebiten.Vertex{DstX: dstX + tw, DstY: dstY, SrcX: srcX + tw, SrcY: srcY, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+				ebiten.Vertex{DstX: dstX, DstY: dstY + th, SrcX: srcX, SrcY: srcY + th, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+				ebiten.Vertex{DstX: dstX + tw, DstY: dstY + th, SrcX: srcX + tw, SrcY: srcY + th, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+			)
+			indices = append(indices,
+				idx+0, idx+1, idx+2,
+				idx+1, idx+2, idx+3,
+			)
+		}
+	}
+
+	var drawOptions ebiten.DrawTrianglesOptions
+	drawTriangles(c.img, vertices, indices, m.config.Tileset, &drawOptions)
+}
+
+// tileSrcRect returns the tileset image rect of the tile.
+// The second result is false for empty tiles and for
+// the IDs that are outside of the tileset.
+func (m *TileMap) tileSrcRect(id int) (image.Rectangle, bool) {
+	if id < 0 || m.tilesetCols == 0 || id >= m.tilesetCols*m.tilesetRows {
+		return image.Rectangle{}, false
+	}
+	bounds := m.config.Tileset.Bounds()
+	x := bounds.Min.X + (id%m.tilesetCols)*m.config.TileWidth
+	y := bounds.Min.Y + (id/m.tilesetCols)*m.config.TileHeight
+	return image.Rect(x, y, x+m.config.TileWidth, y+m.config.TileHeight), true
+}
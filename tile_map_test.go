@@ -0,0 +1,105 @@
+package graphics
+
+import (
+	"image"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestTileMapChunks(t *testing.T) {
+	m := NewTileMap(TileMapConfig{
+		Tileset:    ebiten.NewImage(64, 32),
+		TileWidth:  16,
+		TileHeight: 16,
+		ChunkSize:  4,
+	}, 10, 5)
+
+	if have, want := len(m.chunks), 3*2; have != want {
+		t.Fatalf("chunks:\nhave: %d\nwant: %d", have, want)
+	}
+
+	m.SetTile(0, 0, 1)
+	m.SetTile(1, 0, 2)
+	m.SetTile(9, 4, 3)
+	m.SetTile(10, 0, 3) // Out of bounds
+	if have := m.chunks[0].numTiles; have != 2 {
+		t.Fatalf("first chunk tiles:\nhave: %d\nwant: 2", have)
+	}
+	if have := m.chunks[5].numTiles; have != 1 {
+		t.Fatalf("last chunk tiles:\nhave: %d\nwant: 1", have)
+	}
+	if !m.chunks[0].dirty || m.chunks[1].dirty {
+		t.Fatalf("unexpected dirty flags: %v %v", m.chunks[0].dirty, m.chunks[1].dirty)
+	}
+
+	m.SetTile(0, 0, TileEmpty)
+	m.SetTile(1, 0, -10)
+	if have := m.chunks[0].numTiles; have != 0 {
+		t.Fatalf("cleared chunk tiles:\nhave: %d\nwant: 0", have)
+	}
+	if have := m.GetTile(1, 0); have != TileEmpty {
+		t.Fatalf("cleared tile:\nhave: %d\nwant: %d", have, TileEmpty)
+	}
+}
+
+func TestTileMapTileSrcRect(t *testing.T) {
+	m := NewTileMap(TileMapConfig{
+		Tileset:    ebiten.NewImage(64, 32),
+		TileWidth:  16,
+		TileHeight: 16,
+	}, 1, 1)
+
+	tests := []struct {
+		id   int
+		want image.Rectangle
+		ok   bool
+	}{
+		{0, image.Rect(0, 0, 16, 16), true},
+		{5, image.Rect(16, 16, 32, 32), true},
+		{7, image.Rect(48, 16, 64, 32), true},
+		{8, image.Rectangle{}, false},
+		{TileEmpty, image.Rectangle{}, false},
+	}
+	for _, test := range tests {
+		have, ok := m.tileSrcRect(test.id)
+		if have != test.want || ok != test.ok {
+			t.Fatalf("tileSrcRect(%d):\nhave: %v, %v\nwant: %v, %v", test.id, have, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestTileMapSetTiles(t *testing.T) {
+	m := NewTileMap(TileMapConfig{
+		Tileset:    ebiten.NewImage(64, 32),
+		TileWidth:  16,
+		TileHeight: 16,
+		ChunkSize:  2,
+	}, 4, 2)
+	var dep testInvalidatable
+	m.DirtySignal().Connect(&dep)
+
+	ids := []int{
+		1, 2, TileEmpty, 3,
+		TileEmpty, -5, 4, 5,
+	}
+	m.SetTiles(ids)
+	if dep.n != 1 {
+		t.Fatalf("dirty notifications:\nhave: %d\nwant: 1", dep.n)
+	}
+	if have := m.chunks[0].numTiles; have != 2 {
+		t.Fatalf("first chunk tiles:\nhave: %d\nwant: 2", have)
+	}
+	if have := m.chunks[1].numTiles; have != 3 {
+		t.Fatalf("second chunk tiles:\nhave: %d\nwant: 3", have)
+	}
+	if have := m.GetTile(3, 1); have != 5 {
+		t.Fatalf("tile (3, 1):\nhave: %d\nwant: 5", have)
+	}
+
+	// Assigning the same tiles again is not a change.
+	m.SetTiles(ids)
+	if dep.n != 1 {
+		t.Fatalf("dirty notifications after a no-op:\nhave: %d\nwant: 1", dep.n)
+	}
+}
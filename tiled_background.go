@@ -0,0 +1,262 @@
+package graphics
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// TiledBackground repeats a texture across a rectangle.
+//
+// The texture can be scrolled by changing its offset or by assigning
+// a scroll speed; a camera-based parallax is also supported.
+// This is enough to build the infinite backgrounds and the parallax layers:
+// usually, it's a screen-sized background added to a [StaticLayer].
+//
+// The entire rectangle is rendered with a single textured quad
+// (the texture coordinates are wrapped), no matter how many
+// times the texture is repeated.
+//
+// The Pos is a top-left corner of the rectangle.
+type TiledBackground struct {
+	Pos gmath.Pos
+
+	texture *ebiten.Image
+
+	width  float64
+	height float64
+
+	offset      gmath.Vec
+	scrollSpeed gmath.Vec
+
+	camera         *Camera
+	parallaxFactor gmath.Vec
+
+	colorScale       ColorScale
+	ebitenColorScale ebiten.ColorScale
+
+	visible  bool
+	disposed bool
+	blendID  uint8
+}
+
+// NewTiledBackground returns a background of the specified size
+// that repeats the texture.
+func NewTiledBackground(texture *ebiten.Image, width, height float64) *TiledBackground {
+	return &TiledBackground{
+		texture:          texture,
+		width:            width,
+		height:           height,
+		colorScale:       defaultColorScale,
+		ebitenColorScale: defaultColorScale.ToEbitenColorScale(),
+		visible:          true,
+	}
+}
+
+// GetTexture returns the current background texture.
+func (b *TiledBackground) GetTexture() *ebiten.Image { return b.texture }
+
+// SetTexture changes the background texture.
+func (b *TiledBackground) SetTexture(texture *ebiten.Image) { b.texture = texture }
+
+// GetSize reports the current background size.
+// Use SetSize to change it.
+func (b *TiledBackground) GetSize() (width, height float64) {
+	return b.width, b.height
+}
+
+// SetSize changes the background size.
+func (b *TiledBackground) SetSize(width, height float64) {
+	b.width = width
+	b.height = height
+}
+
+// GetOffset reports the current texture scroll offset.
+// Use SetOffset to change it.
+func (b *TiledBackground) GetOffset() gmath.Vec { return b.offset }
+
+// SetOffset changes the texture scroll offset.
+// A positive offset moves the texture to the left and up.
+func (b *TiledBackground) SetOffset(offset gmath.Vec) {
+	b.offset = b.wrapOffset(offset)
+}
+
+// GetScrollSpeed reports the current automatic scroll speed.
+// Use SetScrollSpeed to change it.
+func (b *TiledBackground) GetScrollSpeed() gmath.Vec { return b.scrollSpeed }
+
+// SetScrollSpeed changes the automatic scroll speed (in pixels per second).
+// The scrolling is driven by the Update method.
+func (b *TiledBackground) SetScrollSpeed(speed gmath.Vec) { b.scrollSpeed = speed }
+
+// SetParallax binds the texture offset to the camera position.
+// The camera offset multiplied by the factor is added to the texture offset,
+// so a factor of {1, 1} makes the texture move with the world
+// and a smaller factor makes it look farther away.
+//
+// A nil camera disables the parallax.
+func (b *TiledBackground) SetParallax(camera *Camera, factor gmath.Vec) {
+	b.camera = camera
+	b.parallaxFactor = factor
+}
+
+// Update advances the automatic scrolling.
+func (b *TiledBackground) Update(delta float64) {
+	if b.scrollSpeed.IsZero() {
+		return
+	}
+	b.SetOffset(b.offset.Add(b.scrollSpeed.Mulf(delta)))
+}
+
+// wrapOffset keeps the offset inside the texture size
+// to avoid the precision loss during the long scrolling.
+func (b *TiledBackground) wrapOffset(offset gmath.Vec) gmath.Vec {
+	if b.texture == nil {
+		return offset
+	}
+	bounds := b.texture.Bounds()
+	return gmath.Vec{
+		X: wrapCoord(offset.X, float64(bounds.Dx())),
+		Y: wrapCoord(offset.Y, float64(bounds.Dy())),
+	}
+}
+
+func wrapCoord(v, size float64) float64 {
+	if size <= 0 {
+		return v
+	}
+	v = math.Mod(v, size)
+	if v < 0 {
+		v += size
+	}
+	return v
+}
+
+// GetColorScale is used to retrieve the current color scale value of the background.
+// Use SetColorScale to change it.
+func (b *TiledBackground) GetColorScale() ColorScale {
+	return b.colorScale
+}
+
+// SetColorScale assigns a new ColorScale to this background.
+// Use GetColorScale to retrieve the current color scale.
+func (b *TiledBackground) SetColorScale(cs ColorScale) {
+	if b.colorScale == cs {
+		return
+	}
+	b.colorScale = cs
+	b.ebitenColorScale = cs.ToEbitenColorScale()
+}
+
+// BoundsRect returns the background rectangle.
+//
+// This is useful when trying to calculate whether this object is contained
+// inside some area or not (like a camera view area).
+func (b *TiledBackground) BoundsRect() gmath.Rect {
+	pos := b.Pos.Resolve()
+	return gmath.Rect{
+		Min: pos,
+		Max: pos.Add(gmath.Vec{X: b.width, Y: b.height}),
+	}
+}
+
+// Dispose marks this background for deletion.
+// After calling this method, IsDisposed will report true.
+func (b *TiledBackground) Dispose() {
+	b.disposed = true
+}
+
+// IsDisposed reports whether this background is marked for deletion.
+// IsDisposed returns true only after Disposed was called on this background.
+func (b *TiledBackground) IsDisposed() bool {
+	return b.disposed
+}
+
+// IsVisible reports whether this background is visible.
+// Use SetVisibility to change this flag value.
+//
+// When background is invisible (visible=false), it will not be rendered at all.
+// This is an efficient way to temporarily hide a background.
+func (b *TiledBackground) IsVisible() bool { return b.visible }
+
+// SetVisibility changes the Visible flag value.
+// It can be used to show or hide the background.
+// Use IsVisible to get the current flag value.
+func (b *TiledBackground) SetVisibility(visible bool) { b.visible = visible }
+
+// Draw renders the background onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (b *TiledBackground) Draw(dst *ebiten.Image) {
+	b.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the background onto the provided dst image
+// while also using the extra provided offset and other options.
+func (b *TiledBackground) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !b.visible || b.texture == nil || b.colorScale.A == 0 || b.width <= 0 || b.height <= 0 {
+		return
+	}
+
+	opts.Blend = resolveBlend(b.blendID, opts.Blend)
+
+	offset := b.offset
+	if b.camera != nil {
+		parallax := b.camera.GetOffset()
+		offset = b.wrapOffset(offset.Add(gmath.Vec{
+			X: parallax.X * b.parallaxFactor.X,
+			Y: parallax.Y * b.parallaxFactor.Y,
+		}))
+	}
+
+	pos := b.Pos.Resolve().Add(opts.Offset)
+	bounds := b.texture.Bounds()
+	u0 := float32(float64(bounds.Min.X) + offset.X)
+	v0 := float32(float64(bounds.Min.Y) + offset.Y)
+	u1 := u0 + float32(b.width)
+	v1 := v0 + float32(b.height)
+	x0 := float32(pos.X)
+	y0 := float32(pos.Y)
+	x1 := x0 + float32(b.width)
+	y1 := y0 + float32(b.height)
+
+	cs := b.ebitenColorScale
+	r, g, bl, a := cs.R(), cs.G(), cs.B(), cs.A()
+	vertices := [4]ebiten.Vertex{
+		{DstX: x0, DstY: y0, SrcX: u0, SrcY: v0, ColorR: r, ColorG: g, ColorB: bl, ColorA: a},
+		{DstX: x1, DstY: y0, SrcX: u1, SrcY: v0, ColorR: r, ColorG: g, ColorB: bl, ColorA: a},
+		{DstX: x0, DstY: y1, SrcX: u0, SrcY: v1, ColorR: r, ColorG: g, ColorB: bl, ColorA: a},
+		{DstX: x1, DstY: y1, SrcX: u1, SrcY: v1, ColorR: r, ColorG: g, ColorB: bl, ColorA: a},
+	}
+	indices := [6]uint16{0, 1, 2, 1, 2, 3}
+
+	var drawOptions ebiten.DrawTrianglesOptions
+	if opts.Blend != nil {
+		drawOptions.Blend = *opts.Blend
+	}
+	drawOptions.Address = ebiten.AddressRepeat
+	drawVertexColorTriangles(dst, vertices[:], indices[:], b.texture, &drawOptions)
+}
+
+// GetBlend returns the blend mode assigned by SetBlend.
+// The second result value is false if there is no blend override.
+func (b *TiledBackground) GetBlend() (ebiten.Blend, bool) {
+	return getBlend(b.blendID)
+}
+
+// SetBlend assigns a blend mode that is used to render this background.
+// It takes priority over the DrawOptions.Blend value.
+// Use ResetBlend to remove the override.
+func (b *TiledBackground) SetBlend(blend ebiten.Blend) {
+	b.blendID = internBlend(blend)
+}
+
+// ResetBlend removes the blend mode override.
+// The DrawOptions.Blend value (if any) will be used again.
+func (b *TiledBackground) ResetBlend() {
+	b.blendID = 0
+}
@@ -0,0 +1,24 @@
+package graphics
+
+import "testing"
+
+func TestWrapCoord(t *testing.T) {
+	tests := []struct {
+		v    float64
+		size float64
+		want float64
+	}{
+		{0, 32, 0},
+		{10, 32, 10},
+		{32, 32, 0},
+		{70, 32, 6},
+		{-1, 32, 31},
+		{-64, 32, 0},
+		{5, 0, 5},
+	}
+	for _, test := range tests {
+		if have := wrapCoord(test.v, test.size); have != test.want {
+			t.Fatalf("wrapCoord(%v, %v):\nhave: %v\nwant: %v", test.v, test.size, have, test.want)
+		}
+	}
+}
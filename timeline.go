@@ -0,0 +1,208 @@
+package graphics
+
+import (
+	"sort"
+)
+
+// Timeline is a sequencer that runs the scheduled actions at their times.
+// It can be used to script the intros, boss phases and tutorials:
+// the visual effects are described declaratively instead of
+// being driven by a hand-written state machine.
+//
+// There are two kinds of actions:
+// the instant ones (see AddAction) that are executed once,
+// and the spans (see AddSpan) that receive their progress
+// during every Update call until they're finished.
+// The actions with equal start times are executed in the order they were added.
+//
+// Seeking backwards re-runs all actions from the timeline start,
+// so the instant actions should be the state changes that can be
+// repeated (like a label text change); the spawned objects
+// are not removed by the rewinding.
+//
+// The timeline is not a graphics object, its Update method
+// should be called every frame.
+// A zero value is ready to use.
+type Timeline struct {
+	actions []timelineAction
+
+	onComplete func()
+
+	elapsed  float64
+	duration float64
+
+	sorted  bool
+	playing bool
+	paused  bool
+}
+
+type timelineAction struct {
+	start    float64
+	duration float64
+
+	// apply receives the action progress in [0, 1] range.
+	apply func(t float64)
+
+	done bool
+}
+
+// AddAction schedules a function call at the specified time.
+func (tl *Timeline) AddAction(at float64, f func()) {
+	tl.AddSpan(at, 0, func(float64) { f() })
+}
+
+// AddSpan schedules an action that lasts for the specified number of seconds.
+// The apply function receives the linear progress value during every Update call;
+// the last apply call always receives a value of 1.
+//
+// Use an [Easing] inside apply to get a non-linear interpolation.
+func (tl *Timeline) AddSpan(at, duration float64, apply func(t float64)) {
+	tl.actions = append(tl.actions, timelineAction{
+		start:    at,
+		duration: duration,
+		apply:    apply,
+	})
+	tl.duration = max(tl.duration, at+duration)
+	tl.sorted = false
+}
+
+// AddTween schedules a tween start.
+// The create function is called every time the action is executed
+// (the tweens can't be rewound), its result is added to the tweener.
+//
+// A tween duration doesn't extend the timeline duration.
+func (tl *Timeline) AddTween(at float64, tw *Tweener, create func() *Tween) {
+	tl.AddAction(at, func() { tw.Add(create()) })
+}
+
+// AddText schedules a label text change.
+func (tl *Timeline) AddText(at float64, l *Label, s string) {
+	tl.AddAction(at, func() { l.SetText(s) })
+}
+
+// AddShake schedules a [Shake] trauma increase.
+func (tl *Timeline) AddShake(at float64, s *Shake, trauma float64) {
+	tl.AddAction(at, func() { s.AddTrauma(trauma) })
+}
+
+// Clear removes all scheduled actions and stops the timeline.
+// The OnComplete callback is preserved.
+func (tl *Timeline) Clear() {
+	clear(tl.actions)
+	tl.actions = tl.actions[:0]
+	tl.elapsed = 0
+	tl.duration = 0
+	tl.playing = false
+	tl.paused = false
+}
+
+// OnComplete assigns a function that is called after the timeline is finished.
+func (tl *Timeline) OnComplete(f func()) { tl.onComplete = f }
+
+// GetDuration returns the timeline length, in seconds.
+// It's the latest end time of its actions.
+func (tl *Timeline) GetDuration() float64 { return tl.duration }
+
+// GetTime returns the current timeline position, in seconds.
+func (tl *Timeline) GetTime() float64 { return tl.elapsed }
+
+// IsPlaying reports whether the timeline is started and not finished yet.
+// A paused timeline is still playing.
+func (tl *Timeline) IsPlaying() bool { return tl.playing }
+
+// IsPaused reports whether the timeline is paused.
+func (tl *Timeline) IsPaused() bool { return tl.paused }
+
+// Pause stops the time without resetting the timeline position.
+// Use Resume to continue.
+func (tl *Timeline) Pause() { tl.paused = true }
+
+// Resume continues the paused timeline.
+func (tl *Timeline) Resume() { tl.paused = false }
+
+// Stop interrupts the timeline without calling the OnComplete callback.
+// The actions keep their current states.
+func (tl *Timeline) Stop() {
+	tl.playing = false
+	tl.paused = false
+}
+
+// Play starts the timeline from the beginning.
+// The actions scheduled at zero time are executed immediately.
+func (tl *Timeline) Play() {
+	tl.rewind()
+	tl.playing = true
+	tl.paused = false
+	tl.advance(0)
+}
+
+// Seek moves the timeline position to the specified time.
+// The time is clamped to the [0, duration] range.
+//
+// Seeking forward executes all the skipped actions
+// (the spans receive their final progress values).
+// Seeking backwards re-runs the actions from the timeline start.
+//
+// Seek can be used while the timeline is paused.
+// Seeking a playing timeline to its end finishes it.
+func (tl *Timeline) Seek(t float64) {
+	t = max(0, min(t, tl.duration))
+	if t < tl.elapsed {
+		tl.rewind()
+	}
+	tl.advance(t)
+}
+
+// Update advances the timeline if it's playing and not paused.
+// delta is a time passed since the last Update call, in seconds.
+func (tl *Timeline) Update(delta float64) {
+	if !tl.playing || tl.paused {
+		return
+	}
+	tl.advance(tl.elapsed + delta)
+}
+
+func (tl *Timeline) rewind() {
+	for i := range tl.actions {
+		tl.actions[i].done = false
+	}
+	tl.elapsed = 0
+}
+
+func (tl *Timeline) advance(t float64) {
+	if !tl.sorted {
+		tl.sorted = true
+		sort.SliceStable(tl.actions, func(i, j int) bool {
+			return tl.actions[i].start < tl.actions[j].start
+		})
+	}
+
+	tl.elapsed = t
+	// The actions added by the callbacks are executed
+	// starting from the next advance call.
+	numActions := len(tl.actions)
+	for i := 0; i < numActions; i++ {
+		a := &tl.actions[i]
+		if a.done {
+			continue
+		}
+		if a.start > t {
+			// The actions are sorted by their start times.
+			break
+		}
+		progress := 1.0
+		if a.duration > 0 {
+			progress = min(1, (t-a.start)/a.duration)
+		}
+		a.done = progress == 1
+		a.apply(progress)
+	}
+
+	if tl.playing && tl.elapsed >= tl.duration {
+		tl.playing = false
+		tl.paused = false
+		if tl.onComplete != nil {
+			tl.onComplete()
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package graphics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/quasilyte/gmath"
+)
+
+func TestTimeline(t *testing.T) {
+	var tl Timeline
+	var events []string
+	progress := 0.0
+	tl.AddAction(1, func() { events = append(events, "b") })
+	tl.AddAction(0, func() { events = append(events, "a") })
+	tl.AddAction(1, func() { events = append(events, "c") })
+	tl.AddSpan(1, 2, func(t float64) { progress = t })
+	completed := 0
+	tl.OnComplete(func() { completed++ })
+
+	if have := tl.GetDuration(); have != 3 {
+		t.Fatalf("duration: have %v, want 3", have)
+	}
+
+	tl.Update(1)
+	if len(events) != 0 {
+		t.Fatal("the timeline is updated before Play")
+	}
+
+	tl.Play()
+	if have := strings.Join(events, ""); have != "a" {
+		t.Fatalf("events after Play: %q", have)
+	}
+	tl.Update(1.5)
+	if have := strings.Join(events, ""); have != "abc" {
+		t.Fatalf("events at t=1.5: %q", have)
+	}
+	if !gmath.EqualApprox(progress, 0.25) {
+		t.Fatalf("span progress: have %v, want 0.25", progress)
+	}
+
+	tl.Pause()
+	tl.Update(1)
+	if tl.GetTime() != 1.5 || !tl.IsPlaying() {
+		t.Fatal("a paused timeline should keep its position")
+	}
+
+	// A backwards seek re-runs the actions from the start.
+	tl.Seek(0.5)
+	if have := strings.Join(events, ""); have != "abca" {
+		t.Fatalf("events after Seek(0.5): %q", have)
+	}
+	tl.Seek(2)
+	if have := strings.Join(events, ""); have != "abcabc" {
+		t.Fatalf("events after Seek(2): %q", have)
+	}
+	if !gmath.EqualApprox(progress, 0.5) {
+		t.Fatalf("span progress after Seek(2): have %v, want 0.5", progress)
+	}
+
+	tl.Resume()
+	tl.Update(5)
+	if progress != 1 {
+		t.Fatalf("final span progress: have %v, want 1", progress)
+	}
+	if completed != 1 || tl.IsPlaying() {
+		t.Fatal("the timeline is not finished")
+	}
+	tl.Update(1)
+	if completed != 1 {
+		t.Fatal("OnComplete is called more than once")
+	}
+}
+
+func TestTimelineTween(t *testing.T) {
+	var tl Timeline
+	var tw Tweener
+	s := NewSprite()
+	tl.AddTween(1, &tw, func() *Tween { return FadeOut(s, 1) })
+	tl.AddAction(2, func() {})
+
+	tl.Play()
+	tl.Update(1)
+	if tw.NumActive() != 1 {
+		t.Fatal("the tween is not started")
+	}
+	tw.Update(0.5)
+	if have := s.GetAlpha(); !gmath.EqualApprox(have, 0.5) {
+		t.Fatalf("alpha: have %v, want 0.5", have)
+	}
+
+	tl.Seek(0)
+	tl.Seek(1)
+	if tw.NumActive() != 2 {
+		t.Fatalf("tweens after the rewinding: have %d, want 2", tw.NumActive())
+	}
+}
@@ -0,0 +1,283 @@
+package graphics
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+	"github.com/quasilyte/gmath"
+)
+
+// TrailConfig describes the [Trail] appearance.
+type TrailConfig struct {
+	// Length is a max number of the recorded positions.
+	// A zero value means 16; the maximum value is 4096.
+	Length int
+
+	// MinDistance is a distance the target should travel
+	// before its next position is recorded.
+	// A zero value means 4.
+	MinDistance float64
+
+	// PointLifetime is a recorded position lifetime, in seconds.
+	// It makes the trail shrink when the target stops moving.
+	// A zero value means that the positions never expire
+	// (they're only replaced by the newer ones).
+	PointLifetime float64
+
+	// Width is a trail width at its head (the target position).
+	// A zero value means 6.
+	Width float64
+
+	// TailWidth is a trail width at its end.
+	// A zero value makes the tail pointy.
+	TailWidth float64
+
+	// ColorScale is a trail color at its head.
+	// A zero value means {1, 1, 1, 1}.
+	ColorScale ColorScale
+
+	// TailColorScale is a trail color at its end.
+	// A zero value means a fully transparent ColorScale.
+	TailColorScale ColorScale
+}
+
+// Trail renders a fading ribbon behind the moving target.
+// It's useful for the swords, projectiles and dash effects.
+//
+// The Pos is a followed target position.
+// The target positions are recorded during the Update calls;
+// the ribbon is drawn from the oldest recorded position
+// to the current target position, the width and the color
+// are interpolated between the tail and the head values.
+//
+// The positions are stored inside a fixed-size ring buffer,
+// so the trail doesn't allocate after its creation.
+//
+// Trail implements gscene Graphics interface.
+type Trail struct {
+	Pos gmath.Pos
+
+	config TrailConfig
+
+	// points is a ring buffer of the recorded positions.
+	// The oldest point is located at the start index.
+	points    []trailPoint
+	start     int
+	numPoints int
+
+	visible  bool
+	disposed bool
+	blendID  uint8
+}
+
+type trailPoint struct {
+	pos gmath.Vec
+	age float64
+}
+
+// NewTrail creates a trail with the specified config.
+func NewTrail(config TrailConfig) *Trail {
+	if config.Length == 0 {
+		config.Length = 16
+	}
+	if config.Length < 0 || config.Length > 4096 {
+		panic("TrailConfig.Length should be in [1, 4096] range")
+	}
+	if config.MinDistance == 0 {
+		config.MinDistance = 4
+	}
+	if config.Width == 0 {
+		config.Width = 6
+	}
+	if config.ColorScale == (ColorScale{}) {
+		config.ColorScale = defaultColorScale
+	}
+	if config.TailColorScale == (ColorScale{}) {
+		config.TailColorScale = config.ColorScale
+		config.TailColorScale.A = 0
+	}
+
+	return &Trail{
+		config:  config,
+		points:  make([]trailPoint, config.Length),
+		visible: true,
+	}
+}
+
+// Clear removes all recorded positions.
+// It should be called after the target teleports,
+// so the trail doesn't connect the old and new locations.
+func (t *Trail) Clear() {
+	t.start = 0
+	t.numPoints = 0
+}
+
+// NumPoints reports the number of the currently recorded positions.
+func (t *Trail) NumPoints() int { return t.numPoints }
+
+// Update records the target position and expires the old positions.
+func (t *Trail) Update(delta float64) {
+	if lifetime := t.config.PointLifetime; lifetime != 0 {
+		for i := 0; i < t.numPoints; i++ {
+			t.pointAt(i).age += delta
+		}
+		for t.numPoints > 0 && t.pointAt(0).age > lifetime {
+			t.start = (t.start + 1) % len(t.points)
+			t.numPoints--
+		}
+	}
+
+	pos := t.Pos.Resolve()
+	if t.numPoints != 0 && t.pointAt(t.numPoints-1).pos.DistanceTo(pos) < t.config.MinDistance {
+		return
+	}
+	if t.numPoints < len(t.points) {
+		t.numPoints++
+	} else {
+		t.start = (t.start + 1) % len(t.points)
+	}
+	*t.pointAt(t.numPoints - 1) = trailPoint{pos: pos}
+}
+
+// pointAt returns the i-th point, starting from the oldest one.
+func (t *Trail) pointAt(i int) *trailPoint {
+	return &t.points[(t.start+i)%len(t.points)]
+}
+
+// BoundsRect returns the trail bounding rectangle.
+func (t *Trail) BoundsRect() gmath.Rect {
+	pos := t.Pos.Resolve()
+	r := gmath.Rect{Min: pos, Max: pos}
+	for i := 0; i < t.numPoints; i++ {
+		p := t.pointAt(i).pos
+		r.Min = gmath.Vec{X: min(r.Min.X, p.X), Y: min(r.Min.Y, p.Y)}
+		r.Max = gmath.Vec{X: max(r.Max.X, p.X), Y: max(r.Max.Y, p.Y)}
+	}
+	pad := max(t.config.Width, t.config.TailWidth) * 0.5
+	return gmath.Rect{
+		Min: r.Min.Sub(gmath.Vec{X: pad, Y: pad}),
+		Max: r.Max.Add(gmath.Vec{X: pad, Y: pad}),
+	}
+}
+
+// Dispose marks this trail for deletion.
+// After calling this method, IsDisposed will report true.
+func (t *Trail) Dispose() { t.disposed = true }
+
+// IsDisposed reports whether this trail is marked for deletion.
+func (t *Trail) IsDisposed() bool { return t.disposed }
+
+// IsVisible reports whether this trail is visible.
+// Use SetVisibility to change this flag value.
+func (t *Trail) IsVisible() bool { return t.visible }
+
+// SetVisibility changes the Visible flag value.
+// The positions are still recorded while the trail is hidden.
+// Use IsVisible to get the current flag value.
+func (t *Trail) SetVisibility(visible bool) { t.visible = visible }
+
+// Draw renders the trail onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (t *Trail) Draw(dst *ebiten.Image) {
+	t.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the trail onto the provided dst image
+// while also using the extra provided offset.
+func (t *Trail) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !t.visible || t.numPoints == 0 {
+		return
+	}
+
+	// The current target position is the trail head.
+	// It's not recorded yet unless the last position matches it.
+	head := t.Pos.Resolve()
+	numPoints := t.numPoints
+	if t.pointAt(numPoints-1).pos.DistanceTo(head) > gmath.Epsilon {
+		numPoints++
+	}
+	if numPoints < 2 {
+		return
+	}
+	point := func(i int) gmath.Vec {
+		if i >= t.numPoints {
+			return head
+		}
+		return t.pointAt(i).pos
+	}
+
+	opts.Blend = resolveBlend(t.blendID, opts.Blend)
+
+	vertices := cache.Global.ScratchVertices[:0]
+	indices := cache.Global.ScratchIndices[:0]
+	defer func() {
+		cache.Global.ScratchVertices = vertices[:0]
+		cache.Global.ScratchIndices = indices[:0]
+	}()
+
+	var normal gmath.Vec
+	for i := 0; i < numPoints; i++ {
+		// The direction is computed using the neighbours,
+		// so the ribbon joints look smooth enough.
+		dir := point(min(i+1, numPoints-1)).Sub(point(max(i-1, 0)))
+		if !dir.IsZero() {
+			dir = dir.Normalized()
+			normal = gmath.Vec{X: -dir.Y, Y: dir.X}
+		}
+
+		progress := float64(i) / float64(numPoints-1)
+		halfWidth := gmath.Lerp(t.config.TailWidth, t.config.Width, progress) * 0.5
+		cs := t.config.TailColorScale.Lerp(t.config.ColorScale, float32(progress))
+		ecs := cs.ToEbitenColorScale()
+
+		p := point(i).Add(opts.Offset)
+		side := normal.Mulf(halfWidth)
+		for _, v := range [2]gmath.Vec{p.Add(side), p.Sub(side)} {
+			vertices = append(vertices, ebiten.Vertex{
+				DstX:   float32(v.X),
+				DstY:   float32(v.Y),
+				SrcX:   1.5,
+				SrcY:   1.5,
+				ColorR: ecs.R(),
+				ColorG: ecs.G(),
+				ColorB: ecs.B(),
+				ColorA: ecs.A(),
+			})
+		}
+		if i != 0 {
+			idx := uint16(2 * (i - 1))
+			indices = append(indices,
+				idx+0, idx+1, idx+2,
+				idx+1, idx+2, idx+3,
+			)
+		}
+	}
+
+	var drawOptions ebiten.DrawTrianglesOptions
+	if opts.Blend != nil {
+		drawOptions.Blend = *opts.Blend
+	}
+	drawVertexColorTriangles(dst, vertices, indices, emptyImage, &drawOptions)
+}
+
+// GetBlend returns the blend mode assigned by SetBlend.
+// The second result value is false if there is no blend override.
+func (t *Trail) GetBlend() (ebiten.Blend, bool) {
+	return getBlend(t.blendID)
+}
+
+// SetBlend assigns a blend mode that is used to render this trail.
+// It takes priority over the DrawOptions.Blend value.
+// Use ResetBlend to remove the override.
+func (t *Trail) SetBlend(blend ebiten.Blend) {
+	t.blendID = internBlend(blend)
+}
+
+// ResetBlend removes the blend mode override.
+// The DrawOptions.Blend value (if any) will be used again.
+func (t *Trail) ResetBlend() {
+	t.blendID = 0
+}
@@ -0,0 +1,58 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/quasilyte/gmath"
+)
+
+func TestTrailRingBuffer(t *testing.T) {
+	trail := NewTrail(TrailConfig{Length: 3, MinDistance: 5})
+	var pos gmath.Vec
+	trail.Pos.Base = &pos
+
+	trail.Update(0.1)
+	pos.X = 2 // Too close, not recorded
+	trail.Update(0.1)
+	for _, x := range []float64{10, 20, 30} {
+		pos.X = x
+		trail.Update(0.1)
+	}
+
+	if have := trail.NumPoints(); have != 3 {
+		t.Fatalf("num points:\nhave: %d\nwant: 3", have)
+	}
+	for i, want := range []float64{10, 20, 30} {
+		if have := trail.pointAt(i).pos.X; have != want {
+			t.Fatalf("point[%d]:\nhave: %v\nwant: %v", i, have, want)
+		}
+	}
+
+	trail.Clear()
+	if have := trail.NumPoints(); have != 0 {
+		t.Fatalf("num points after clear:\nhave: %d\nwant: 0", have)
+	}
+}
+
+func TestTrailPointLifetime(t *testing.T) {
+	trail := NewTrail(TrailConfig{Length: 8, PointLifetime: 0.25})
+	var pos gmath.Vec
+	trail.Pos.Base = &pos
+
+	for i := 0; i < 4; i++ {
+		pos.X = float64(i * 10)
+		trail.Update(0.1)
+	}
+	// The first point is 0.3 seconds old now.
+	if have := trail.NumPoints(); have != 3 {
+		t.Fatalf("num points:\nhave: %d\nwant: 3", have)
+	}
+
+	// The target stops: the trail shrinks to a single point.
+	for i := 0; i < 5; i++ {
+		trail.Update(0.1)
+	}
+	if have := trail.NumPoints(); have != 1 {
+		t.Fatalf("num points after stop:\nhave: %d\nwant: 1", have)
+	}
+}
@@ -0,0 +1,145 @@
+package graphics
+
+import (
+	"github.com/quasilyte/gmath"
+)
+
+// Transform is a node of the parent-child transformation hierarchy.
+//
+// The Pos and Rotation are local: they're resolved relative to the
+// parent transform, so the local offset is rotated along with the parent.
+// A turret sprite attached to a tank transform follows the tank
+// movement and its rotation while also having its own aiming rotation.
+//
+// A root transform (the one without a parent) can bind its Pos
+// and Rotation to the game object (like a unit) fields.
+//
+// The world values are computed during the Update call.
+// The attached objects are bound to these values, so the transforms
+// should be updated after the game objects movement, but before the
+// scene is drawn. The update order is not important:
+// every transform resolves its parents chain on its own.
+type Transform struct {
+	// Pos is a local position binder.
+	// For a root transform, it's a world position.
+	Pos gmath.Pos
+
+	// Rotation is an optional local rotation binder.
+	Rotation *gmath.Rad
+
+	// Alpha is a local alpha multiplier.
+	// The world alpha is a product of the alpha values of the entire chain.
+	Alpha float32
+
+	parent *Transform
+
+	worldPos      gmath.Vec
+	worldRotation gmath.Rad
+	worldAlpha    float32
+
+	alphaTargets []transformAlphaTarget
+}
+
+type transformAlphaTarget interface {
+	SetAlpha(a float32)
+	IsDisposed() bool
+}
+
+// NewTransform creates a root transform with the Alpha of 1.
+func NewTransform() *Transform {
+	return &Transform{
+		Alpha:      1,
+		worldAlpha: 1,
+	}
+}
+
+// GetParent returns the parent transform assigned by SetParent.
+func (t *Transform) GetParent() *Transform { return t.parent }
+
+// SetParent makes this transform relative to the parent.
+// A nil parent makes this transform a root transform.
+//
+// It panics if this operation would create a cycle.
+func (t *Transform) SetParent(parent *Transform) {
+	for p := parent; p != nil; p = p.parent {
+		if p == t {
+			panic("Transform.SetParent: cyclic parent chain")
+		}
+	}
+	t.parent = parent
+}
+
+// WorldPos returns the position computed by the last Update call.
+func (t *Transform) WorldPos() gmath.Vec { return t.worldPos }
+
+// WorldRotation returns the rotation computed by the last Update call.
+func (t *Transform) WorldRotation() gmath.Rad { return t.worldRotation }
+
+// WorldAlpha returns the alpha computed by the last Update call.
+func (t *Transform) WorldAlpha() float32 { return t.worldAlpha }
+
+// AttachSprite binds the sprite position and rotation to this transform.
+// The sprite alpha is controlled by the transform too.
+//
+// The sprite Pos.Offset is preserved and applied in the world space
+// (it's not rotated), so it's usually a zero value.
+func (t *Transform) AttachSprite(s *Sprite) {
+	s.Pos.Base = &t.worldPos
+	s.Rotation = &t.worldRotation
+	t.alphaTargets = append(t.alphaTargets, s)
+}
+
+// AttachLabel binds the label position to this transform.
+// The label alpha is controlled by the transform too.
+//
+// Labels are not rotated, only their position is affected by the
+// parent rotation.
+func (t *Transform) AttachLabel(l *Label) {
+	l.Pos.Base = &t.worldPos
+	t.alphaTargets = append(t.alphaTargets, l)
+}
+
+// AttachContainer binds the container position and rotation to this transform.
+func (t *Transform) AttachContainer(c *Container) {
+	c.Pos.Base = &t.worldPos
+	c.Rotation = &t.worldRotation
+}
+
+// Update re-computes the world values and applies
+// the world alpha to the attached objects.
+// The disposed objects are detached automatically.
+func (t *Transform) Update(_ float64) {
+	t.resolve()
+
+	liveTargets := t.alphaTargets[:0]
+	for _, o := range t.alphaTargets {
+		if o.IsDisposed() {
+			continue
+		}
+		liveTargets = append(liveTargets, o)
+		o.SetAlpha(t.worldAlpha)
+	}
+	clear(t.alphaTargets[len(liveTargets):])
+	t.alphaTargets = liveTargets
+}
+
+func (t *Transform) resolve() {
+	localPos := t.Pos.Resolve()
+	var localRotation gmath.Rad
+	if t.Rotation != nil {
+		localRotation = *t.Rotation
+	}
+
+	p := t.parent
+	if p == nil {
+		t.worldPos = localPos
+		t.worldRotation = localRotation
+		t.worldAlpha = t.Alpha
+		return
+	}
+
+	p.resolve()
+	t.worldPos = p.worldPos.Add(localPos.Rotated(p.worldRotation))
+	t.worldRotation = p.worldRotation + localRotation
+	t.worldAlpha = p.worldAlpha * t.Alpha
+}
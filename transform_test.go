@@ -0,0 +1,68 @@
+package graphics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/quasilyte/gmath"
+)
+
+func TestTransformHierarchy(t *testing.T) {
+	unitPos := gmath.Vec{X: 100, Y: 50}
+	unitRotation := gmath.Rad(math.Pi / 2)
+
+	tank := NewTransform()
+	tank.Pos.Base = &unitPos
+	tank.Rotation = &unitRotation
+	tank.Alpha = 0.5
+
+	aim := gmath.Rad(math.Pi / 4)
+	turret := NewTransform()
+	turret.SetParent(tank)
+	turret.Pos.Offset = gmath.Vec{X: 10}
+	turret.Rotation = &aim
+
+	s := NewSprite()
+	turret.AttachSprite(s)
+	turret.Update(0)
+
+	// The local offset is rotated by the tank rotation.
+	if have := turret.WorldPos(); !have.EqualApprox(gmath.Vec{X: 100, Y: 60}) {
+		t.Fatalf("world pos: have %v, want {100, 60}", have)
+	}
+	if have := turret.WorldRotation(); !have.EqualApprox(unitRotation + aim) {
+		t.Fatalf("world rotation: have %v, want %v", have, unitRotation+aim)
+	}
+	if have := s.GetAlpha(); have != 0.5 {
+		t.Fatalf("sprite alpha: have %v, want 0.5", have)
+	}
+	if have := s.Pos.Resolve(); have != turret.WorldPos() {
+		t.Fatalf("sprite pos: have %v, want %v", have, turret.WorldPos())
+	}
+
+	// The parent doesn't need to be updated explicitly.
+	unitPos.X = 0
+	turret.Update(0)
+	if have := s.Pos.Resolve(); !have.EqualApprox(gmath.Vec{X: 0, Y: 60}) {
+		t.Fatalf("sprite pos after move: have %v, want {0, 60}", have)
+	}
+
+	s.Dispose()
+	turret.Update(0)
+	if len(turret.alphaTargets) != 0 {
+		t.Fatal("the disposed sprite should be detached")
+	}
+}
+
+func TestTransformCycle(t *testing.T) {
+	a := NewTransform()
+	b := NewTransform()
+	b.SetParent(a)
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic")
+		}
+	}()
+	a.SetParent(b)
+}
@@ -0,0 +1,92 @@
+package graphics
+
+import (
+	"github.com/quasilyte/gmath"
+)
+
+// triangulatePolygon appends the triangle indices of a simple polygon to dst.
+//
+// It implements an ear clipping algorithm, so it works for
+// both convex and concave polygons (without self-intersections).
+// The points can be either in clockwise or counter-clockwise order.
+func triangulatePolygon(dst []uint16, points []gmath.Vec) []uint16 {
+	n := len(points)
+	if n < 3 {
+		return dst
+	}
+
+	// Make the algorithm orientation-independent by
+	// flipping the cross product signs for the clockwise polygons.
+	orientation := 1.0
+	if polygonSignedArea(points) < 0 {
+		orientation = -1
+	}
+
+	remaining := make([]uint16, n)
+	for i := range remaining {
+		remaining[i] = uint16(i)
+	}
+
+	for len(remaining) > 3 {
+		earFound := false
+		for i := range remaining {
+			prev := remaining[(i+len(remaining)-1)%len(remaining)]
+			curr := remaining[i]
+			next := remaining[(i+1)%len(remaining)]
+			a, b, c := points[prev], points[curr], points[next]
+			if orientation*crossProduct(a, b, c) <= 0 {
+				// A reflex (or degenerate) vertex can't be an ear.
+				continue
+			}
+			if polygonPointInTriangle(points, remaining, a, b, c, orientation) {
+				continue
+			}
+			dst = append(dst, prev, curr, next)
+			remaining = append(remaining[:i], remaining[i+1:]...)
+			earFound = true
+			break
+		}
+		if !earFound {
+			// The polygon is either self-intersecting or it has
+			// collinear points that prevent the ear clipping.
+			// Use a fan for the rest of the vertices as a fallback.
+			for i := 1; i < len(remaining)-1; i++ {
+				dst = append(dst, remaining[0], remaining[i], remaining[i+1])
+			}
+			return dst
+		}
+	}
+
+	return append(dst, remaining[0], remaining[1], remaining[2])
+}
+
+func polygonPointInTriangle(points []gmath.Vec, candidates []uint16, a, b, c gmath.Vec, orientation float64) bool {
+	for _, i := range candidates {
+		p := points[i]
+		if p == a || p == b || p == c {
+			continue
+		}
+		// The points on the triangle edges are treated as inside points:
+		// otherwise a vertex that touches the diagonal would be
+		// cut off by an ear that goes outside the polygon.
+		if orientation*crossProduct(a, b, p) >= 0 &&
+			orientation*crossProduct(b, c, p) >= 0 &&
+			orientation*crossProduct(c, a, p) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func polygonSignedArea(points []gmath.Vec) float64 {
+	area := 0.0
+	for i, p := range points {
+		q := points[(i+1)%len(points)]
+		area += p.X*q.Y - q.X*p.Y
+	}
+	return area * 0.5
+}
+
+func crossProduct(a, b, c gmath.Vec) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
@@ -0,0 +1,61 @@
+package graphics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/quasilyte/gmath"
+)
+
+func TestTriangulatePolygon(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []gmath.Vec
+	}{
+		{
+			name:   "triangle",
+			points: []gmath.Vec{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 0, Y: 10}},
+		},
+		{
+			name:   "square",
+			points: []gmath.Vec{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}},
+		},
+		{
+			name:   "square_reversed",
+			points: []gmath.Vec{{X: 0, Y: 10}, {X: 10, Y: 10}, {X: 10, Y: 0}, {X: 0, Y: 0}},
+		},
+		{
+			name: "concave_l_shape",
+			points: []gmath.Vec{
+				{X: 0, Y: 0}, {X: 20, Y: 0}, {X: 20, Y: 10},
+				{X: 10, Y: 10}, {X: 10, Y: 20}, {X: 0, Y: 20},
+			},
+		},
+		{
+			name: "arrow",
+			points: []gmath.Vec{
+				{X: 0, Y: 0}, {X: 10, Y: 5}, {X: 20, Y: 0}, {X: 10, Y: 20},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			indices := triangulatePolygon(nil, test.points)
+			if have, want := len(indices), 3*(len(test.points)-2); have != want {
+				t.Fatalf("indices count:\nhave: %d\nwant: %d", have, want)
+			}
+			// The triangles should cover the polygon exactly.
+			area := 0.0
+			for i := 0; i < len(indices); i += 3 {
+				a := test.points[indices[i+0]]
+				b := test.points[indices[i+1]]
+				c := test.points[indices[i+2]]
+				area += math.Abs(crossProduct(a, b, c)) * 0.5
+			}
+			if want := math.Abs(polygonSignedArea(test.points)); math.Abs(area-want) > 0.0001 {
+				t.Fatalf("triangles area:\nhave: %f\nwant: %f", area, want)
+			}
+		})
+	}
+}
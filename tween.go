@@ -0,0 +1,260 @@
+package graphics
+
+import (
+	"math"
+
+	"github.com/quasilyte/gmath"
+)
+
+// Easing maps the linear tween progress t in [0, 1] range
+// to the interpolation factor.
+type Easing func(t float64) float64
+
+// EaseLinear is a constant speed easing.
+func EaseLinear(t float64) float64 { return t }
+
+// EaseInQuad starts slowly and accelerates.
+func EaseInQuad(t float64) float64 { return t * t }
+
+// EaseOutQuad starts quickly and decelerates.
+func EaseOutQuad(t float64) float64 { return t * (2 - t) }
+
+// EaseInOutQuad accelerates until the halfway point and then decelerates.
+func EaseInOutQuad(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return -1 + (4-2*t)*t
+}
+
+// EaseOutCubic is like EaseOutQuad, but with a sharper deceleration.
+func EaseOutCubic(t float64) float64 {
+	t--
+	return t*t*t + 1
+}
+
+// EaseOutBack overshoots the target value a bit before settling.
+// It's a good fit for the pop-up UI elements.
+func EaseOutBack(t float64) float64 {
+	const c1 = 1.70158
+	const c3 = c1 + 1
+	return 1 + c3*math.Pow(t-1, 3) + c1*math.Pow(t-1, 2)
+}
+
+// Tween interpolates some property over time.
+//
+// The tweens are not graphics objects, their Update method
+// should be called every frame; a [Tweener] can do that for a group of tweens.
+//
+// A tween created for a target object (like [TweenAlpha])
+// stops when the target is disposed.
+// A finished tween reports IsDisposed as true.
+type Tween struct {
+	apply func(t float64)
+
+	target interface{ IsDisposed() bool }
+
+	easing     Easing
+	onComplete func()
+
+	duration float64
+	elapsed  float64
+
+	disposed bool
+}
+
+// NewTween creates a tween that calls apply with the eased progress value
+// during every Update call.
+// The last apply call always receives a value of 1.
+//
+// The default easing is [EaseLinear].
+func NewTween(duration float64, apply func(t float64)) *Tween {
+	return &Tween{
+		apply:    apply,
+		easing:   EaseLinear,
+		duration: duration,
+	}
+}
+
+// TweenAlpha creates a tween that changes the object alpha
+// from its current value to the specified one.
+//
+// [Sprite] and [Label] can be used as the tween targets.
+func TweenAlpha(o tweenAlphaTarget, to float32, duration float64) *Tween {
+	from := o.GetAlpha()
+	t := NewTween(duration, func(t float64) {
+		o.SetAlpha(gmath.Lerp(from, to, float32(t)))
+	})
+	t.target = o
+	return t
+}
+
+// FadeOut is a shorthand for TweenAlpha(o, 0, duration).
+func FadeOut(o tweenAlphaTarget, duration float64) *Tween {
+	return TweenAlpha(o, 0, duration)
+}
+
+// FadeIn is a shorthand for TweenAlpha(o, 1, duration).
+func FadeIn(o tweenAlphaTarget, duration float64) *Tween {
+	return TweenAlpha(o, 1, duration)
+}
+
+// TweenColorScale creates a tween that changes the object color scale
+// from its current value to the specified one.
+//
+// [Sprite] and [Label] can be used as the tween targets.
+func TweenColorScale(o tweenColorScaleTarget, to ColorScale, duration float64) *Tween {
+	from := o.GetColorScale()
+	t := NewTween(duration, func(t float64) {
+		o.SetColorScale(from.Lerp(to, float32(t)))
+	})
+	t.target = o
+	return t
+}
+
+// TweenOffset creates a tween that changes the pos offset
+// from its current value to the specified one.
+//
+// The target is an owner of the pos, it's used to stop the tween
+// once the owner is disposed: TweenOffset(s, &s.Pos, to, duration).
+func TweenOffset(target interface{ IsDisposed() bool }, pos *gmath.Pos, to gmath.Vec, duration float64) *Tween {
+	from := pos.Offset
+	t := NewTween(duration, func(t float64) {
+		pos.Offset = from.LinearInterpolate(to, t)
+	})
+	t.target = target
+	return t
+}
+
+// TweenRotation creates a tween that changes the rotation
+// from its current value to the specified one.
+//
+// The target is an owner of the rotation (see [TweenOffset]).
+func TweenRotation(target interface{ IsDisposed() bool }, rotation *gmath.Rad, to gmath.Rad, duration float64) *Tween {
+	from := *rotation
+	t := NewTween(duration, func(t float64) {
+		*rotation = gmath.Lerp(from, to, gmath.Rad(t))
+	})
+	t.target = target
+	return t
+}
+
+// TweenScale creates a tween that changes the sprite scaling
+// from its current values to the specified one (for both axes).
+func TweenScale(s *Sprite, to float64, duration float64) *Tween {
+	fromX := s.GetScaleX()
+	fromY := s.GetScaleY()
+	t := NewTween(duration, func(t float64) {
+		s.SetScaleX(gmath.Lerp(fromX, to, t))
+		s.SetScaleY(gmath.Lerp(fromY, to, t))
+	})
+	t.target = s
+	return t
+}
+
+type tweenAlphaTarget interface {
+	GetAlpha() float32
+	SetAlpha(a float32)
+	IsDisposed() bool
+}
+
+type tweenColorScaleTarget interface {
+	GetColorScale() ColorScale
+	SetColorScale(cs ColorScale)
+	IsDisposed() bool
+}
+
+// SetEasing changes the tween easing function.
+// It returns the tween itself to allow the call chaining.
+func (t *Tween) SetEasing(e Easing) *Tween {
+	t.easing = e
+	return t
+}
+
+// OnComplete assigns a callback that is executed once the tween is finished.
+// It's not called if the tween is stopped by Dispose or
+// if its target object is disposed.
+// It returns the tween itself to allow the call chaining.
+func (t *Tween) OnComplete(f func()) *Tween {
+	t.onComplete = f
+	return t
+}
+
+// Dispose stops the tween.
+// The property keeps its current value.
+func (t *Tween) Dispose() { t.disposed = true }
+
+// IsDisposed reports whether this tween is stopped or finished.
+func (t *Tween) IsDisposed() bool { return t.disposed }
+
+// Update advances the tween.
+// delta is a time passed since the last Update call, in seconds.
+func (t *Tween) Update(delta float64) {
+	if t.disposed {
+		return
+	}
+	if t.target != nil && t.target.IsDisposed() {
+		t.disposed = true
+		return
+	}
+
+	t.elapsed += delta
+	if t.elapsed < t.duration {
+		t.apply(t.easing(t.elapsed / t.duration))
+		return
+	}
+
+	t.apply(1)
+	t.disposed = true
+	if t.onComplete != nil {
+		t.onComplete()
+	}
+}
+
+// Tweener updates a group of tweens.
+// The finished tweens are removed automatically.
+//
+// Its Update method should be called every frame.
+// A zero value is ready to use.
+type Tweener struct {
+	tweens []*Tween
+}
+
+// Add starts the tween.
+// It returns the tween itself to allow the call chaining.
+func (tw *Tweener) Add(t *Tween) *Tween {
+	tw.tweens = append(tw.tweens, t)
+	return t
+}
+
+// NumActive reports the number of the running tweens.
+func (tw *Tweener) NumActive() int { return len(tw.tweens) }
+
+// Clear stops all running tweens.
+func (tw *Tweener) Clear() {
+	for _, t := range tw.tweens {
+		t.Dispose()
+	}
+	clear(tw.tweens)
+	tw.tweens = tw.tweens[:0]
+}
+
+// Update advances all running tweens.
+// delta is a time passed since the last Update call, in seconds.
+func (tw *Tweener) Update(delta float64) {
+	// The tweens started by the OnComplete callbacks
+	// are appended to the slice; they're updated starting
+	// from the next Update call.
+	for _, t := range tw.tweens {
+		t.Update(delta)
+	}
+	live := tw.tweens[:0]
+	for _, t := range tw.tweens {
+		if t.IsDisposed() {
+			continue
+		}
+		live = append(live, t)
+	}
+	clear(tw.tweens[len(live):])
+	tw.tweens = live
+}
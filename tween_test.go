@@ -0,0 +1,74 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/quasilyte/gmath"
+)
+
+func TestEasingBounds(t *testing.T) {
+	easings := []Easing{
+		EaseLinear,
+		EaseInQuad,
+		EaseOutQuad,
+		EaseInOutQuad,
+		EaseOutCubic,
+		EaseOutBack,
+	}
+	for i, e := range easings {
+		if have := e(0); !gmath.EqualApprox(have, 0) {
+			t.Fatalf("easing%d: f(0)=%v, want 0", i, have)
+		}
+		if have := e(1); !gmath.EqualApprox(have, 1) {
+			t.Fatalf("easing%d: f(1)=%v, want 1", i, have)
+		}
+	}
+}
+
+func TestTweenAlpha(t *testing.T) {
+	var tw Tweener
+	s := NewSprite()
+	completed := false
+	tw.Add(FadeOut(s, 1)).OnComplete(func() {
+		completed = true
+	})
+
+	tw.Update(0.25)
+	if have := s.GetAlpha(); !gmath.EqualApprox(have, 0.75) {
+		t.Fatalf("alpha: have %v, want 0.75", have)
+	}
+	tw.Update(1)
+	if have := s.GetAlpha(); have != 0 {
+		t.Fatalf("final alpha: have %v, want 0", have)
+	}
+	if !completed {
+		t.Fatal("OnComplete callback is not called")
+	}
+	if tw.NumActive() != 0 {
+		t.Fatal("the finished tween should be removed")
+	}
+}
+
+func TestTweenDisposedTarget(t *testing.T) {
+	var tw Tweener
+	s := NewSprite()
+	s.Pos.Offset = gmath.Vec{X: 10}
+	tw.Add(TweenOffset(s, &s.Pos, gmath.Vec{X: 20}, 1)).
+		SetEasing(EaseInQuad).
+		OnComplete(func() {
+			t.Fatal("OnComplete should not be called")
+		})
+
+	tw.Update(0.5)
+	if have := s.Pos.Offset; have != (gmath.Vec{X: 12.5}) {
+		t.Fatalf("offset: have %v, want {12.5, 0}", have)
+	}
+	s.Dispose()
+	tw.Update(0.1)
+	if tw.NumActive() != 0 {
+		t.Fatal("the tween should be stopped after its target is disposed")
+	}
+	if have := s.Pos.Offset; have != (gmath.Vec{X: 12.5}) {
+		t.Fatalf("offset after dispose: have %v, want {12.5, 0}", have)
+	}
+}
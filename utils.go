@@ -1,6 +1,7 @@
 package graphics
 
 import (
+	"github.com/hajimehoshi/ebiten/v2"
 	"golang.org/x/exp/constraints"
 )
 
@@ -19,3 +20,15 @@ func setFlag[T constraints.Integer](flags *T, bit T, value bool) {
 		clearFlag(flags, bit)
 	}
 }
+
+// ensureImageSize returns an image of the specified size.
+// It re-uses the img if its size matches.
+func ensureImageSize(img *ebiten.Image, width, height int) *ebiten.Image {
+	if img != nil {
+		if b := img.Bounds(); b.Dx() == width && b.Dy() == height {
+			return img
+		}
+		img.Deallocate()
+	}
+	return ebiten.NewImage(width, height)
+}
@@ -0,0 +1,194 @@
+package graphics
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// VectorFieldConfig describes the [VectorField] visualization.
+type VectorFieldConfig struct {
+	// CellSize is a grid cell size in pixels.
+	// A zero value means 32.
+	CellSize float64
+
+	// Density controls how many cells are rendered.
+	// A value of N means that every Nth cell (in both directions) gets an arrow.
+	// A non-positive value means 1 (all cells are rendered).
+	Density int
+
+	// Scale is a multiplier that maps the vector length to the arrow length in pixels.
+	// A zero value means that all arrows are normalized
+	// to fit the cell, only their directions are displayed.
+	Scale float64
+
+	// LineWidth is an arrow line width.
+	// A zero value means 1.
+	LineWidth float64
+
+	// ColorScale is used to render the arrows.
+	// A zero value means {1, 1, 1, 1}.
+	ColorScale ColorScale
+}
+
+// VectorField is a debug visualization of a vector field grid.
+//
+// It renders an arrow per grid cell, which makes it useful
+// for the flow field pathfinding and wind simulations debugging.
+// The zero vectors are not rendered.
+//
+// The grid is stored in row-major order.
+// The field is positioned using its Pos field (the grid's top-left corner).
+//
+// VectorField implements gscene Graphics interface.
+type VectorField struct {
+	// Pos is a grid top-left corner location binder.
+	// See Pos documentation to learn how it works.
+	Pos gmath.Pos
+
+	config VectorFieldConfig
+
+	ebitenColorScale ebiten.ColorScale
+
+	vectors []gmath.Vec
+	width   int
+	height  int
+
+	visible  bool
+	disposed bool
+}
+
+// NewVectorField creates a width x height vector field visualization.
+// All vectors are zero initially.
+func NewVectorField(width, height int, config VectorFieldConfig) *VectorField {
+	if config.CellSize == 0 {
+		config.CellSize = 32
+	}
+	if config.Density <= 0 {
+		config.Density = 1
+	}
+	if config.LineWidth == 0 {
+		config.LineWidth = 1
+	}
+	if config.ColorScale == (ColorScale{}) {
+		config.ColorScale = defaultColorScale
+	}
+	return &VectorField{
+		config:           config,
+		ebitenColorScale: config.ColorScale.ToEbitenColorScale(),
+		vectors:          make([]gmath.Vec, width*height),
+		width:            width,
+		height:           height,
+		visible:          true,
+	}
+}
+
+// GetVector returns the vector of the specified grid cell.
+func (f *VectorField) GetVector(x, y int) gmath.Vec {
+	return f.vectors[y*f.width+x]
+}
+
+// SetVector assigns the vector of the specified grid cell.
+func (f *VectorField) SetVector(x, y int, v gmath.Vec) {
+	f.vectors[y*f.width+x] = v
+}
+
+// SetVectors copies all vectors from the row-major slice.
+// The slice length should be equal to width*height.
+func (f *VectorField) SetVectors(vectors []gmath.Vec) {
+	if len(vectors) != len(f.vectors) {
+		panic("vectors slice length doesn't match the field size")
+	}
+	copy(f.vectors, vectors)
+}
+
+// BoundsRect returns the grid bounding rectangle.
+func (f *VectorField) BoundsRect() gmath.Rect {
+	pos := f.Pos.Resolve()
+	return gmath.Rect{
+		Min: pos,
+		Max: pos.Add(gmath.Vec{
+			X: float64(f.width) * f.config.CellSize,
+			Y: float64(f.height) * f.config.CellSize,
+		}),
+	}
+}
+
+// Dispose marks this field for deletion.
+// After calling this method, IsDisposed will report true.
+func (f *VectorField) Dispose() { f.disposed = true }
+
+// IsDisposed reports whether this field is marked for deletion.
+func (f *VectorField) IsDisposed() bool { return f.disposed }
+
+// IsVisible reports whether this field is visible.
+// Use SetVisibility to change this flag value.
+func (f *VectorField) IsVisible() bool { return f.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (f *VectorField) SetVisibility(visible bool) { f.visible = visible }
+
+// Draw renders the field onto the provided dst image.
+//
+// This method is a shorthand to DrawWithOptions(dst, {})
+// which also implements the gscene.Graphics interface.
+//
+// See DrawWithOptions for more info.
+func (f *VectorField) Draw(dst *ebiten.Image) {
+	f.DrawWithOptions(dst, DrawOptions{})
+}
+
+// DrawWithOptions renders the field onto the provided dst image
+// while also using the extra provided offset.
+//
+// Only the arrows that are inside the dst bounds are rendered.
+func (f *VectorField) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !f.visible {
+		return
+	}
+
+	cellSize := f.config.CellSize
+	density := f.config.Density
+	// The arrows are centered inside the cells group.
+	spacing := cellSize * float64(density)
+	maxLength := spacing * 0.9
+	headSize := max(3, f.config.LineWidth*3)
+
+	origin := f.Pos.Resolve().Add(opts.Offset)
+	dstRect := gmath.RectFromStd(dst.Bounds())
+
+	for y := 0; y < f.height; y += density {
+		for x := 0; x < f.width; x += density {
+			v := f.vectors[y*f.width+x]
+			if v.IsZero() {
+				continue
+			}
+
+			center := origin.Add(gmath.Vec{
+				X: float64(x)*cellSize + spacing*0.5,
+				Y: float64(y)*cellSize + spacing*0.5,
+			})
+			if !dstRect.Contains(center) {
+				continue
+			}
+
+			length := maxLength
+			if f.config.Scale != 0 {
+				length = min(maxLength, v.Len()*f.config.Scale)
+			}
+			dir := v.Normalized()
+			from := center.Sub(dir.Mulf(length * 0.5))
+			to := center.Add(dir.Mulf(length * 0.5))
+			f.drawArrow(dst, opts.Blend, from, to, dir, min(headSize, length*0.5))
+		}
+	}
+}
+
+func (f *VectorField) drawArrow(dst *ebiten.Image, blend *ebiten.Blend, from, to, dir gmath.Vec, headSize float64) {
+	cs := f.ebitenColorScale
+	headBase := to.Sub(dir.Mulf(headSize))
+	drawLine(dst, blend, from, headBase, f.config.LineWidth, cs)
+
+	normal := gmath.Vec{X: -dir.Y, Y: dir.X}.Mulf(headSize * 0.5)
+	drawTriangle(dst, blend, to, headBase.Add(normal), headBase.Sub(normal), cs)
+}
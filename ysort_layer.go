@@ -0,0 +1,172 @@
+package graphics
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+)
+
+// YSortLayer is like [Layer], but it re-sorts its objects by their Y coordinate every frame.
+//
+// Objects with lower Y are rendered first, so the characters that are
+// closer to the bottom of the screen are drawn in front of the others.
+// This is how top-down and isometric scenes usually work.
+//
+// By default, the sort key is a BoundsRect().Max.Y value (the "feet" of the object).
+// Objects that don't implement [BoundedObject] have a zero key.
+// Use SetKeyFunc to override this behavior.
+//
+// Objects with equal keys are rendered in the order they were added.
+// The sorting doesn't allocate. The order from the previous frame is reused,
+// so a mostly-static scene is sorted in a linear time.
+//
+// The objects inside this layer are displayed with respect to the camera transformation.
+type YSortLayer struct {
+	objects    []ySortEntry
+	needFilter bool
+
+	nextSeq uint32
+
+	keyFunc func(o Object) float64
+
+	culler objectCuller
+}
+
+type ySortEntry struct {
+	o   Object
+	key float64
+
+	// seq is an insertion sequence number.
+	// It's used as a tie-breaker for the equal keys.
+	seq uint32
+}
+
+func compareYSortEntries(a, b ySortEntry) int {
+	if c := cmp.Compare(a.key, b.key); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.seq, b.seq)
+}
+
+func NewYSortLayer() *YSortLayer {
+	return &YSortLayer{objects: make([]ySortEntry, 0, 16)}
+}
+
+// SetKeyFunc assigns a custom sort key function.
+// The function is called once per object during every Draw call.
+//
+// A nil value restores the default BoundsRect().Max.Y key.
+func (l *YSortLayer) SetKeyFunc(f func(o Object) float64) {
+	l.keyFunc = f
+}
+
+// SetCulling enables or disables the viewport culling for this layer.
+// See [Layer.SetCulling] for more info.
+//
+// The culled objects are still sorted.
+func (l *YSortLayer) SetCulling(enabled bool, margin float64) {
+	l.culler = objectCuller{enabled: enabled, margin: margin}
+}
+
+func (l *YSortLayer) AddChild(g gsceneGraphics) {
+	if cache.Global.StrictMode != 0 {
+		strictCheckAddChild(g)
+	}
+	l.objects = append(l.objects, ySortEntry{o: g.(Object), seq: l.nextSeq})
+	l.nextSeq++
+	l.needFilter = true
+}
+
+func (l *YSortLayer) Update(_ float64) {
+	l.needFilter = true
+}
+
+func (l *YSortLayer) filter() {
+	liveObjects := l.objects[:0]
+	for _, e := range l.objects {
+		if e.o.IsDisposed() {
+			continue
+		}
+		liveObjects = append(liveObjects, e)
+	}
+	// Release the references to the disposed objects.
+	clear(l.objects[len(liveObjects):])
+	l.objects = liveObjects
+}
+
+func (l *YSortLayer) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if l.needFilter {
+		l.filter()
+	}
+	l.needFilter = false
+	l.sortObjects()
+
+	if !l.culler.enabled {
+		for _, e := range l.objects {
+			drawObject(dst, e.o, opts)
+		}
+		return
+	}
+
+	visibleRect := l.culler.visibleRect(dst, opts)
+	for _, e := range l.objects {
+		if l.culler.isCulled(e.o, visibleRect) {
+			continue
+		}
+		drawObject(dst, e.o, opts)
+	}
+}
+
+func (l *YSortLayer) sortKey(o Object) float64 {
+	if l.keyFunc != nil {
+		return l.keyFunc(o)
+	}
+	if b, ok := o.(BoundedObject); ok {
+		return b.BoundsRect().Max.Y
+	}
+	return 0
+}
+
+func (l *YSortLayer) sortObjects() {
+	objects := l.objects
+	numUnordered := 0
+	for i := range objects {
+		objects[i].key = l.sortKey(objects[i].o)
+		if i > 0 && compareYSortEntries(objects[i-1], objects[i]) > 0 {
+			numUnordered++
+		}
+	}
+	if numUnordered == 0 {
+		return
+	}
+
+	// When too many objects have moved (like after a big camera jump
+	// with a custom key function), the insertion sort becomes quadratic.
+	if numUnordered > 8 && numUnordered > len(objects)/16 {
+		slices.SortStableFunc(objects, compareYSortEntries)
+		return
+	}
+
+	// An insertion sort is very fast for almost sorted slices,
+	// which is a typical case here:
+	// only a few objects change their relative order every frame.
+	for i := 1; i < len(objects); i++ {
+		e := objects[i]
+		j := i
+		for j > 0 && compareYSortEntries(objects[j-1], e) > 0 {
+			objects[j] = objects[j-1]
+			j--
+		}
+		objects[j] = e
+	}
+}
+
+func (l *YSortLayer) inspectObjects() []Object {
+	objects := make([]Object, 0, len(l.objects))
+	for _, e := range l.objects {
+		objects = append(objects, e.o)
+	}
+	return objects
+}
@@ -0,0 +1,89 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/quasilyte/gmath"
+)
+
+func TestYSortLayerOrder(t *testing.T) {
+	newRect := func(y float64) *Rect {
+		r := NewRect(10, 10)
+		r.SetCentered(false)
+		r.Pos.Offset = gmath.Vec{Y: y}
+		return r
+	}
+
+	a := newRect(30)
+	b := newRect(10)
+	c := newRect(30)
+	d := newRect(0)
+
+	l := NewYSortLayer()
+	l.AddChild(a)
+	l.AddChild(b)
+	l.AddChild(c)
+	l.AddChild(d)
+
+	checkOrder := func(want ...*Rect) {
+		t.Helper()
+		l.sortObjects()
+		for i, o := range l.inspectObjects() {
+			if o != want[i] {
+				t.Fatalf("objects[%d]: unexpected object", i)
+			}
+		}
+	}
+
+	// a and c have equal keys, so their insertion order is preserved.
+	checkOrder(d, b, a, c)
+
+	d.Pos.Offset.Y = 50
+	checkOrder(b, a, c, d)
+
+	l.SetKeyFunc(func(o Object) float64 {
+		return -o.(*Rect).Pos.Resolve().Y
+	})
+	checkOrder(d, a, c, b)
+}
+
+func TestYSortLayerInsertionOrder(t *testing.T) {
+	l := NewYSortLayer()
+	rects := make([]*Rect, 100)
+	for i := range rects {
+		r := NewRect(10, 10)
+		r.SetCentered(false)
+		r.Pos.Offset = gmath.Vec{Y: float64(len(rects) - i)}
+		rects[i] = r
+		l.AddChild(r)
+	}
+	// Every object is out of order, so the fallback sorting is used.
+	l.sortObjects()
+	for i, o := range l.inspectObjects() {
+		if o != rects[len(rects)-i-1] {
+			t.Fatalf("objects[%d]: unexpected object", i)
+		}
+	}
+
+	// After the keys become equal, the insertion order is restored.
+	for _, r := range rects {
+		r.Pos.Offset.Y = 0
+	}
+	l.sortObjects()
+	for i, o := range l.inspectObjects() {
+		if o != rects[i] {
+			t.Fatalf("objects[%d]: the insertion order is not restored", i)
+		}
+	}
+
+	// The same goes for the insertion sort path.
+	rects[0].Pos.Offset.Y = 5
+	l.sortObjects()
+	rects[0].Pos.Offset.Y = 0
+	l.sortObjects()
+	for i, o := range l.inspectObjects() {
+		if o != rects[i] {
+			t.Fatalf("objects[%d]: the insertion order is not restored after a move", i)
+		}
+	}
+}
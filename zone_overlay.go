@@ -0,0 +1,308 @@
+package graphics
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/ebitengine-graphics/internal/cache"
+	"github.com/quasilyte/gmath"
+)
+
+// ZonePattern describes how the zone polygon is filled.
+type ZonePattern uint8
+
+const (
+	// ZonePatternSolid fills the zone with a solid color.
+	ZonePatternSolid ZonePattern = iota
+
+	// ZonePatternStripes fills the zone with diagonal stripes.
+	// It's usually used to mark the contested territories.
+	ZonePatternStripes
+)
+
+// ZoneOverlayConfig describes the [ZoneOverlay] borders.
+type ZoneOverlayConfig struct {
+	// BorderWidth is a zone border line width.
+	// A zero value means 2.
+	BorderWidth float64
+
+	// DashLength makes the borders dashed.
+	// A zero value means a solid border.
+	DashLength float64
+
+	// DashSpeed is a dash movement speed along the border, in pixels per second.
+	// It only affects the dashed borders.
+	DashSpeed float64
+}
+
+// ZoneOverlay renders filled territory polygons with borders.
+//
+// Every zone is created by [ZoneOverlay.AddZone].
+// The zone polygon is triangulated only when it's changed,
+// so the static zones are cheap to render.
+//
+// The zone polygons are in world coordinates,
+// so ZoneOverlay should be added to a [Layer].
+// Its Update method should be called every frame
+// if the borders are animated.
+type ZoneOverlay struct {
+	config ZoneOverlayConfig
+
+	zones []*Zone
+
+	t float64
+
+	visible  bool
+	disposed bool
+}
+
+// Zone is a single territory polygon of the [ZoneOverlay].
+type Zone struct {
+	points  []gmath.Vec
+	indices []uint16
+	bounds  gmath.Rect
+
+	fillColorScale   ebiten.ColorScale
+	borderColorScale ebiten.ColorScale
+
+	pattern ZonePattern
+
+	visible  bool
+	disposed bool
+}
+
+// zoneStripesImage is an 8x8 repeated texture with a diagonal stripe.
+var zoneStripesImage *ebiten.Image
+
+// NewZoneOverlay creates a zones renderer with the specified config.
+func NewZoneOverlay(config ZoneOverlayConfig) *ZoneOverlay {
+	if config.BorderWidth == 0 {
+		config.BorderWidth = 2
+	}
+	return &ZoneOverlay{
+		config:  config,
+		visible: true,
+	}
+}
+
+// AddZone creates a new zone with no polygon.
+// Use [Zone.SetPolygon] to assign its shape.
+//
+// By default, the fill is a white half-transparent color
+// and the border is white.
+func (o *ZoneOverlay) AddZone() *Zone {
+	z := &Zone{visible: true}
+	z.SetFillColorScale(ColorScale{R: 1, G: 1, B: 1, A: 0.3})
+	z.SetBorderColorScale(defaultColorScale)
+	o.zones = append(o.zones, z)
+	return z
+}
+
+// SetPolygon assigns a new zone shape.
+// The points should describe a simple polygon (without self-intersections).
+// The points slice is copied, so it can be re-used by the caller.
+//
+// This method triangulates the polygon, so it's more expensive than drawing.
+func (z *Zone) SetPolygon(points []gmath.Vec) {
+	z.points = append(z.points[:0], points...)
+	z.indices = triangulatePolygon(z.indices[:0], z.points)
+
+	z.bounds = gmath.Rect{}
+	for i, p := range z.points {
+		if i == 0 {
+			z.bounds = gmath.Rect{Min: p, Max: p}
+			continue
+		}
+		z.bounds.Min.X = min(z.bounds.Min.X, p.X)
+		z.bounds.Min.Y = min(z.bounds.Min.Y, p.Y)
+		z.bounds.Max.X = max(z.bounds.Max.X, p.X)
+		z.bounds.Max.Y = max(z.bounds.Max.Y, p.Y)
+	}
+}
+
+// BoundsRect returns the zone polygon bounding rectangle.
+func (z *Zone) BoundsRect() gmath.Rect { return z.bounds }
+
+// SetFillColorScale assigns the zone fill color.
+// For the stripes pattern, it's a color of the stripes.
+func (z *Zone) SetFillColorScale(cs ColorScale) { z.fillColorScale = cs.ToEbitenColorScale() }
+
+// SetBorderColorScale assigns the zone border color.
+// A zero alpha disables the border rendering.
+func (z *Zone) SetBorderColorScale(cs ColorScale) { z.borderColorScale = cs.ToEbitenColorScale() }
+
+// GetPattern returns the current zone fill pattern.
+// Use SetPattern to change it.
+func (z *Zone) GetPattern() ZonePattern { return z.pattern }
+
+// SetPattern changes the zone fill pattern.
+func (z *Zone) SetPattern(pattern ZonePattern) { z.pattern = pattern }
+
+// IsVisible reports whether this zone is visible.
+// Use SetVisibility to change this flag value.
+func (z *Zone) IsVisible() bool { return z.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (z *Zone) SetVisibility(visible bool) { z.visible = visible }
+
+// Dispose removes the zone from its overlay.
+func (z *Zone) Dispose() { z.disposed = true }
+
+// IsDisposed reports whether this zone is removed.
+func (z *Zone) IsDisposed() bool { return z.disposed }
+
+func (o *ZoneOverlay) IsDisposed() bool { return o.disposed }
+
+func (o *ZoneOverlay) Dispose() { o.disposed = true }
+
+// IsVisible reports whether the overlay is visible.
+// Use SetVisibility to change this flag value.
+func (o *ZoneOverlay) IsVisible() bool { return o.visible }
+
+// SetVisibility changes the Visible flag value.
+// Use IsVisible to get the current flag value.
+func (o *ZoneOverlay) SetVisibility(visible bool) { o.visible = visible }
+
+// Update advances the border animation.
+// delta is a time passed since the last Update call, in seconds.
+func (o *ZoneOverlay) Update(delta float64) {
+	o.t += delta
+}
+
+func (o *ZoneOverlay) Draw(dst *ebiten.Image) {
+	o.DrawWithOptions(dst, DrawOptions{})
+}
+
+func (o *ZoneOverlay) DrawWithOptions(dst *ebiten.Image, opts DrawOptions) {
+	if !o.visible {
+		return
+	}
+
+	liveZones := o.zones[:0]
+	for _, z := range o.zones {
+		if z.disposed {
+			continue
+		}
+		liveZones = append(liveZones, z)
+		if !z.visible || len(z.indices) == 0 {
+			continue
+		}
+		o.drawFill(dst, opts, z)
+		if z.borderColorScale.A() != 0 {
+			o.drawBorder(dst, opts, z)
+		}
+	}
+	o.zones = liveZones
+}
+
+func (o *ZoneOverlay) drawFill(dst *ebiten.Image, opts DrawOptions, z *Zone) {
+	if z.fillColorScale.A() == 0 {
+		return
+	}
+
+	vertices := cache.Global.ScratchVertices[:0]
+	defer func() {
+		cache.Global.ScratchVertices = vertices[:0]
+	}()
+
+	src := emptyImage
+	var drawOptions ebiten.DrawTrianglesOptions
+	if opts.Blend != nil {
+		drawOptions.Blend = *opts.Blend
+	}
+	if z.pattern == ZonePatternStripes {
+		src = getZoneStripesImage()
+		drawOptions.Address = ebiten.AddressRepeat
+	}
+
+	cs := z.fillColorScale
+	for _, p := range z.points {
+		v := ebiten.Vertex{
+			DstX:   float32(p.X + opts.Offset.X),
+			DstY:   float32(p.Y + opts.Offset.Y),
+			SrcX:   1.5,
+			SrcY:   1.5,
+			ColorR: cs.R(),
+			ColorG: cs.G(),
+			ColorB: cs.B(),
+			ColorA: cs.A(),
+		}
+		if z.pattern == ZonePatternStripes {
+			// The pattern is bound to the world coordinates,
+			// so it doesn't move when the camera pans.
+			v.SrcX = float32(p.X)
+			v.SrcY = float32(p.Y)
+		}
+		vertices = append(vertices, v)
+	}
+
+	drawVertexColorTriangles(dst, vertices, z.indices, src, &drawOptions)
+}
+
+func (o *ZoneOverlay) drawBorder(dst *ebiten.Image, opts DrawOptions, z *Zone) {
+	width := o.config.BorderWidth
+	cs := z.borderColorScale
+
+	if o.config.DashLength == 0 {
+		for i, p := range z.points {
+			q := z.points[(i+1)%len(z.points)]
+			drawLine(dst, opts.Blend, p.Add(opts.Offset), q.Add(opts.Offset), width, cs)
+		}
+		return
+	}
+
+	// The dash pattern is continuous along the entire perimeter.
+	// A dash is followed by a gap of the same length.
+	period := 2 * o.config.DashLength
+	dist := math.Mod(o.t*o.config.DashSpeed, period)
+	if dist < 0 {
+		dist += period
+	}
+	for i, p := range z.points {
+		q := z.points[(i+1)%len(z.points)]
+		edge := q.Sub(p)
+		edgeLength := edge.Len()
+		if edgeLength == 0 {
+			continue
+		}
+		dir := edge.Mulf(1 / edgeLength)
+		s := 0.0
+		for s < edgeLength {
+			phase := math.Mod(dist+s, period)
+			step := min(edgeLength-s, o.config.DashLength-math.Mod(phase, o.config.DashLength))
+			if phase < o.config.DashLength {
+				from := p.Add(dir.Mulf(s)).Add(opts.Offset)
+				to := p.Add(dir.Mulf(s + step)).Add(opts.Offset)
+				drawLine(dst, opts.Blend, from, to, width, cs)
+			}
+			s += step
+		}
+		dist += edgeLength
+	}
+}
+
+func getZoneStripesImage() *ebiten.Image {
+	if zoneStripesImage != nil {
+		return zoneStripesImage
+	}
+
+	const size = 8
+	pixels := make([]byte, size*size*4)
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			if (x+y)%size >= size/2 {
+				continue
+			}
+			i := (y*size + x) * 4
+			pixels[i+0] = 0xff
+			pixels[i+1] = 0xff
+			pixels[i+2] = 0xff
+			pixels[i+3] = 0xff
+		}
+	}
+	img := ebiten.NewImage(size, size)
+	img.WritePixels(pixels)
+	zoneStripesImage = img
+	return img
+}